@@ -17,92 +17,229 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// BackupConfig is the configuration of a chain backup
+type BackupConfig struct {
+	From    uint64
+	To      *uint64
+	OutPath string
+	// Incremental continues the backup at OutPath from its last block, a new backup is created
+	// from the From block if the file does not exist. An existing backup can only be continued
+	// from the block following its last one, so any other From block is rejected
+	Incremental bool
+	// Compress compresses the chunks of a new backup with zstd
+	Compress bool
+}
+
 // CreateBackup fetches blockchain data with the specific range via gRPC
-// and save this data as binary archive to given path
+// and save this data as chunked binary archive to given path
 func CreateBackup(
 	conn *grpc.ClientConn,
 	logger hclog.Logger,
-	from uint64,
-	to *uint64,
-	outPath string,
+	config BackupConfig,
 ) (uint64, uint64, error) {
-	// always create new file, throw error if the file exists
-	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	out, err := openBackupOutput(config)
 	if err != nil {
 		return 0, 0, err
 	}
 
-	closeFile := func() error {
-		if err := fs.Close(); err != nil {
-			logger.Error("an error occurred while closing file", "err", err)
-
-			return err
-		}
-
-		return nil
-	}
-	removeFile := func() {
-		if err := os.Remove(outPath); err != nil {
-			logger.Error("an error occurred while removing file", "err", err)
-		}
-	}
-	// clean up function for the file when error occurs in the middle of function
-	closeAndRemoveFile := func() {
-		if err := closeFile(); err == nil {
-			removeFile()
-		}
-	}
-
 	signalCh := common.GetTerminationSignalCh()
 	ctx, cancelFn := context.WithCancel(context.Background())
 
 	defer cancelFn()
 
 	go func() {
-		<-signalCh
-		logger.Info("Caught termination signal, shutting down...")
-		cancelFn()
+		select {
+		case <-signalCh:
+			logger.Info("Caught termination signal, shutting down...")
+			cancelFn()
+		case <-ctx.Done():
+		}
 	}()
 
 	clt := proto.NewSystemClient(conn)
 
-	reqTo, reqToHash, err := determineTo(ctx, clt, to)
+	from := config.From
+
+	if index := out.writer.index; len(index.Chunks) > 0 {
+		if err := checkBackupHead(ctx, clt, index); err != nil {
+			out.abort(logger)
+
+			return 0, 0, err
+		}
+
+		from = index.Latest + 1
+
+		logger.Info("Continuing backup", "latest", index.Latest, "hash", index.LatestHash)
+	}
+
+	reqTo, _, err := determineTo(ctx, clt, config.To)
 	if err != nil {
-		closeAndRemoveFile()
+		out.abort(logger)
 
 		return 0, 0, err
 	}
 
+	if reqTo < from {
+		out.abort(logger)
+
+		return 0, 0, fmt.Errorf("no blocks to back up, the backup ends at block %d", from-1)
+	}
+
 	stream, err := clt.Export(ctx, &proto.ExportRequest{
 		From: from,
 		To:   reqTo,
 	})
 	if err != nil {
-		closeAndRemoveFile()
+		out.abort(logger)
 
 		return 0, 0, err
 	}
 
-	if err := writeMetadata(fs, logger, reqTo, reqToHash); err != nil {
-		closeAndRemoveFile()
+	resFrom, resTo, err := processExportStream(stream, logger, out.writer, from, reqTo)
+	if err != nil {
+		out.abort(logger)
 
 		return 0, 0, err
 	}
 
-	resFrom, resTo, err := processExportStream(stream, logger, fs, from, reqTo)
-	if err != nil {
-		closeAndRemoveFile()
+	if err := out.commit(); err != nil {
+		out.abort(logger)
 
 		return 0, 0, err
 	}
 
-	if err := closeFile(); err != nil {
-		removeFile()
+	logger.Info("Wrote backup index", "latest", out.writer.index.Latest, "hash", out.writer.index.LatestHash,
+		"chunks", len(out.writer.index.Chunks), "codec", out.writer.index.Codec)
 
-		return 0, 0, err
+	return *resFrom, *resTo, nil
+}
+
+// backupOutput is the file a backup is written to
+type backupOutput struct {
+	file   *os.File
+	writer *chunkWriter
+
+	// continued is set when an existing backup of the given size is continued
+	continued bool
+	size      int64
+}
+
+// openBackupOutput creates the backup file, or opens the existing one in the incremental mode
+func openBackupOutput(config BackupConfig) (*backupOutput, error) {
+	if config.Incremental && common.FileExists(config.OutPath) {
+		file, err := os.OpenFile(config.OutPath, os.O_RDWR, 0644)
+		if err != nil {
+			return nil, err
+		}
+
+		index, err := readBackupIndex(file)
+		if err != nil {
+			file.Close()
+
+			return nil, fmt.Errorf("failed to continue backup %s: %w", config.OutPath, err)
+		}
+
+		if len(index.Chunks) > 0 && config.From != 0 && config.From != index.Latest+1 {
+			file.Close()
+
+			return nil, fmt.Errorf("%w: backup %s continues from block %d, not from block %d",
+				errIncrementalFrom, config.OutPath, index.Latest+1, config.From)
+		}
+
+		// the chunks and the new index are appended after the footer, so the backup stays
+		// readable until the new footer is written
+		size, err := file.Seek(0, io.SeekEnd)
+		if err != nil {
+			file.Close()
+
+			return nil, err
+		}
+
+		writer, err := appendChunkWriter(file, uint64(size), index)
+		if err != nil {
+			file.Close()
+
+			return nil, err
+		}
+
+		return &backupOutput{file: file, writer: writer, continued: true, size: size}, nil
 	}
 
-	return *resFrom, *resTo, nil
+	// always create new file, throw error if the file exists
+	file, err := os.OpenFile(config.OutPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	codec := CodecNone
+	if config.Compress {
+		codec = CodecZstd
+	}
+
+	out := &backupOutput{file: file}
+
+	if out.writer, err = newChunkWriter(file, codec); err != nil {
+		file.Close()
+		os.Remove(config.OutPath)
+
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// commit writes the index and the footer and closes the file
+func (o *backupOutput) commit() error {
+	if err := o.writer.close(); err != nil {
+		return err
+	}
+
+	if err := o.file.Sync(); err != nil {
+		return err
+	}
+
+	return o.file.Close()
+}
+
+// abort closes the file and removes the new backup, a continued backup is truncated
+// back to its previous size
+func (o *backupOutput) abort(logger hclog.Logger) {
+	if o.continued {
+		if err := o.file.Truncate(o.size); err != nil {
+			logger.Error("an error occurred while truncating file", "err", err)
+		}
+	}
+
+	// the file may already be closed by a failed commit
+	if err := o.file.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+		logger.Error("an error occurred while closing file", "err", err)
+	}
+
+	if !o.continued {
+		if err := os.Remove(o.file.Name()); err != nil {
+			logger.Error("an error occurred while removing file", "err", err)
+		}
+	}
+}
+
+// checkBackupHead checks that the last block of the continued backup is in the chain of the node
+func checkBackupHead(ctx context.Context, clt proto.SystemClient, index *Index) error {
+	resp, err := clt.BlockByNumber(ctx, &proto.BlockByNumberRequest{Number: index.Latest})
+	if err != nil {
+		return fmt.Errorf("failed to get block %d of the backup head: %w", index.Latest, err)
+	}
+
+	block := types.Block{}
+	if err := block.UnmarshalRLP(resp.Data); err != nil {
+		return err
+	}
+
+	if block.Hash() != index.LatestHash {
+		return fmt.Errorf("the backup ends at block %d (%s), but the node has block %s",
+			index.Latest, index.LatestHash, block.Hash())
+	}
+
+	return nil
 }
 
 func determineTo(ctx context.Context, clt proto.SystemClient, to *uint64) (uint64, types.Hash, error) {
@@ -127,23 +264,6 @@ func determineTo(ctx context.Context, clt proto.SystemClient, to *uint64) (uint6
 	return uint64(status.Current.Number), types.StringToHash(status.Current.Hash), nil
 }
 
-// writeMetadata writes the latest block height and the block hash to the writer
-func writeMetadata(writer io.Writer, logger hclog.Logger, to uint64, toHash types.Hash) error {
-	metadata := Metadata{
-		Latest:     to,
-		LatestHash: toHash,
-	}
-
-	_, err := writer.Write(metadata.MarshalRLP())
-	if err != nil {
-		return err
-	}
-
-	logger.Info("Wrote metadata to backup", "latest", to, "hash", toHash)
-
-	return err
-}
-
 func processExportStream(
 	stream proto.System_ExportClient,
 	logger hclog.Logger,
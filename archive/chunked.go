@@ -0,0 +1,432 @@
+package archive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/klauspost/compress/zstd"
+)
+
+// A chunked backup is laid out as
+//
+//	magic | chunk 0 | chunk 1 | ... | index | index offset | magic
+//
+// where every chunk holds the RLP encoded blocks of a consecutive range, compressed with the
+// codec of the backup, and the RLP encoded index locates the chunks. An incremental backup
+// appends its chunks and a new index after the previous footer, so the previous backup stays
+// intact until the new footer is written
+const (
+	// backupMagic marks the beginning and the end of a chunked backup
+	backupMagic = "BLADEBAK"
+
+	// footerSize is the size of the index offset and the trailing magic
+	footerSize = 8 + len(backupMagic)
+
+	// chunkBlocks is the number of blocks in a chunk
+	chunkBlocks = 1000
+)
+
+var (
+	errNotChunkedBackup = errors.New("file is not a chunked backup")
+	errBlockNotInBackup = errors.New("block is not in the backup")
+	errIncrementalFrom  = errors.New("the from block does not follow the incremental backup")
+
+	castagnoli = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// isChunkedBackup reports whether the input starts with the magic of a chunked backup
+func isChunkedBackup(input io.ReaderAt) (bool, error) {
+	magic := make([]byte, len(backupMagic))
+
+	if _, err := input.ReadAt(magic, 0); errors.Is(err, io.EOF) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return string(magic) == backupMagic, nil
+}
+
+// chunkWriter writes the blocks into chunks and the index of a chunked backup
+type chunkWriter struct {
+	output  io.Writer
+	offset  uint64
+	index   *Index
+	encoder *zstd.Encoder
+
+	pending     bytes.Buffer
+	pendingFrom uint64
+	pendingTo   uint64
+	parentHash  types.Hash
+	lastHash    types.Hash
+	hasPending  bool
+	hasLast     bool
+}
+
+// newChunkWriter creates the writer of a new chunked backup and writes the leading magic
+func newChunkWriter(output io.Writer, codec Codec) (*chunkWriter, error) {
+	if _, err := output.Write([]byte(backupMagic)); err != nil {
+		return nil, err
+	}
+
+	return appendChunkWriter(output, uint64(len(backupMagic)), &Index{Codec: codec})
+}
+
+// appendChunkWriter creates the writer continuing the backup with the given index,
+// the output must be positioned at the given offset
+func appendChunkWriter(output io.Writer, offset uint64, index *Index) (*chunkWriter, error) {
+	w := &chunkWriter{
+		output: output,
+		offset: offset,
+		index:  index,
+	}
+
+	if len(index.Chunks) > 0 {
+		w.lastHash = index.LatestHash
+		w.hasLast = true
+	}
+
+	switch index.Codec {
+	case CodecNone:
+	case CodecZstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		w.encoder = encoder
+	default:
+		return nil, fmt.Errorf("unsupported backup codec %s", index.Codec)
+	}
+
+	return w, nil
+}
+
+// appendBlock adds the block to the pending chunk and writes the chunk once it is full
+func (w *chunkWriter) appendBlock(block *types.Block) error {
+	if w.hasLast {
+		if latest := w.index.Latest; block.Number() != latest+1 || block.ParentHash() != w.lastHash {
+			return fmt.Errorf("block %d (%s) does not follow block %d (%s) of the backup",
+				block.Number(), block.Hash(), latest, w.lastHash)
+		}
+	}
+
+	if !w.hasPending {
+		w.pendingFrom = block.Number()
+		w.parentHash = block.ParentHash()
+		w.hasPending = true
+	}
+
+	w.pending.Write(block.MarshalRLP())
+
+	w.pendingTo = block.Number()
+	w.lastHash = block.Hash()
+	w.hasLast = true
+	w.index.Latest = block.Number()
+	w.index.LatestHash = block.Hash()
+
+	if w.pendingTo-w.pendingFrom+1 >= chunkBlocks {
+		return w.flushChunk()
+	}
+
+	return nil
+}
+
+// Write adds the RLP encoded blocks to the backup, the data must hold whole blocks
+// as sent in the export events
+func (w *chunkWriter) Write(data []byte) (int, error) {
+	stream := newBlockStream(bytes.NewReader(data))
+
+	for {
+		block, err := stream.nextBlock()
+		if err != nil {
+			return 0, err
+		}
+
+		if block == nil {
+			return len(data), nil
+		}
+
+		if err := w.appendBlock(block); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// flushChunk writes the pending chunk and adds it to the index
+func (w *chunkWriter) flushChunk() error {
+	if !w.hasPending {
+		return nil
+	}
+
+	data := w.pending.Bytes()
+	if w.encoder != nil {
+		data = w.encoder.EncodeAll(data, nil)
+	}
+
+	if _, err := w.output.Write(data); err != nil {
+		return err
+	}
+
+	w.index.Chunks = append(w.index.Chunks, ChunkInfo{
+		From:       w.pendingFrom,
+		To:         w.pendingTo,
+		Offset:     w.offset,
+		Size:       uint64(len(data)),
+		Checksum:   crc32.Checksum(data, castagnoli),
+		ParentHash: w.parentHash,
+		LastHash:   w.lastHash,
+	})
+
+	w.offset += uint64(len(data))
+	w.pending.Reset()
+	w.hasPending = false
+
+	return nil
+}
+
+// close writes the pending chunk, the index and the footer
+func (w *chunkWriter) close() error {
+	if err := w.flushChunk(); err != nil {
+		return err
+	}
+
+	if w.encoder != nil {
+		if err := w.encoder.Close(); err != nil {
+			return err
+		}
+	}
+
+	footer := make([]byte, 8, footerSize)
+	binary.BigEndian.PutUint64(footer, w.offset)
+	footer = append(footer, backupMagic...)
+
+	if _, err := w.output.Write(w.index.MarshalRLP()); err != nil {
+		return err
+	}
+
+	_, err := w.output.Write(footer)
+
+	return err
+}
+
+// BackupReader gives random access to the blocks of a chunked backup
+type BackupReader struct {
+	file    *os.File
+	index   *Index
+	decoder *zstd.Decoder
+}
+
+// OpenBackup opens the chunked backup at the given path and reads its index
+func OpenBackup(path string) (*BackupReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := newBackupReader(file)
+	if err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func newBackupReader(file *os.File) (*BackupReader, error) {
+	index, err := readBackupIndex(file)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &BackupReader{
+		file:  file,
+		index: index,
+	}
+
+	switch index.Codec {
+	case CodecNone:
+	case CodecZstd:
+		if r.decoder, err = zstd.NewReader(nil); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported backup codec %s", index.Codec)
+	}
+
+	return r, nil
+}
+
+// readBackupIndex reads the index of the chunked backup from its footer
+func readBackupIndex(file *os.File) (*Index, error) {
+	if ok, err := isChunkedBackup(file); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errNotChunkedBackup
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	size := uint64(stat.Size())
+	if size < uint64(len(backupMagic)+footerSize) {
+		return nil, fmt.Errorf("%w: the footer is missing", errNotChunkedBackup)
+	}
+
+	footer := make([]byte, footerSize)
+	if _, err := file.ReadAt(footer, int64(size)-int64(footerSize)); err != nil {
+		return nil, err
+	}
+
+	if string(footer[8:]) != backupMagic {
+		return nil, fmt.Errorf("%w: the footer is missing, the backup was not completed", errNotChunkedBackup)
+	}
+
+	indexOffset := binary.BigEndian.Uint64(footer[:8])
+	if indexOffset < uint64(len(backupMagic)) || indexOffset > size-uint64(footerSize) {
+		return nil, fmt.Errorf("invalid backup index offset %d", indexOffset)
+	}
+
+	data := make([]byte, size-uint64(footerSize)-indexOffset)
+	if _, err := file.ReadAt(data, int64(indexOffset)); err != nil {
+		return nil, err
+	}
+
+	index := &Index{}
+	if err := index.UnmarshalRLP(data); err != nil {
+		return nil, fmt.Errorf("failed to decode backup index: %w", err)
+	}
+
+	return index, nil
+}
+
+// Index returns the index of the backup
+func (r *BackupReader) Index() *Index {
+	return r.index
+}
+
+// ReadChunk returns the blocks of the chunk at the given position of the index
+func (r *BackupReader) ReadChunk(i int) ([]*types.Block, error) {
+	data, err := r.readChunkData(i)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := newBlockStream(bytes.NewReader(data))
+	blocks := make([]*types.Block, 0, r.index.Chunks[i].To-r.index.Chunks[i].From+1)
+
+	for {
+		block, err := stream.nextBlock()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode chunk %d: %w", i, err)
+		}
+
+		if block == nil {
+			return blocks, nil
+		}
+
+		blocks = append(blocks, block)
+	}
+}
+
+// ReadBlock returns the block with the given number, only its chunk is read
+func (r *BackupReader) ReadBlock(number uint64) (*types.Block, error) {
+	chunks := r.index.Chunks
+
+	i := sort.Search(len(chunks), func(i int) bool {
+		return chunks[i].To >= number
+	})
+	if i == len(chunks) || chunks[i].From > number {
+		return nil, fmt.Errorf("%w: %d", errBlockNotInBackup, number)
+	}
+
+	blocks, err := r.ReadChunk(i)
+	if err != nil {
+		return nil, err
+	}
+
+	if idx := number - chunks[i].From; idx < uint64(len(blocks)) && blocks[idx].Number() == number {
+		return blocks[idx], nil
+	}
+
+	return nil, fmt.Errorf("%w: %d", errBlockNotInBackup, number)
+}
+
+// readChunkData reads the chunk at the given position of the index, checks its checksum
+// and returns the decompressed RLP encoded blocks
+func (r *BackupReader) readChunkData(i int) ([]byte, error) {
+	if i < 0 || i >= len(r.index.Chunks) {
+		return nil, fmt.Errorf("chunk %d is out of range", i)
+	}
+
+	chunk := r.index.Chunks[i]
+	data := make([]byte, chunk.Size)
+
+	if _, err := r.file.ReadAt(data, int64(chunk.Offset)); err != nil {
+		return nil, fmt.Errorf("failed to read chunk %d: %w", i, err)
+	}
+
+	if checksum := crc32.Checksum(data, castagnoli); checksum != chunk.Checksum {
+		return nil, fmt.Errorf("checksum mismatch of chunk %d (blocks %d - %d)", i, chunk.From, chunk.To)
+	}
+
+	if r.decoder == nil {
+		return data, nil
+	}
+
+	data, err := r.decoder.DecodeAll(data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress chunk %d: %w", i, err)
+	}
+
+	return data, nil
+}
+
+// blocksReader returns the reader of the RLP encoded blocks of all the chunks in order
+func (r *BackupReader) blocksReader() io.Reader {
+	return &chunkStreamReader{backup: r}
+}
+
+// Close closes the backup file
+func (r *BackupReader) Close() error {
+	if r.decoder != nil {
+		r.decoder.Close()
+	}
+
+	return r.file.Close()
+}
+
+// chunkStreamReader reads the decompressed chunks one after another
+type chunkStreamReader struct {
+	backup *BackupReader
+	next   int
+	chunk  *bytes.Reader
+}
+
+func (c *chunkStreamReader) Read(p []byte) (int, error) {
+	for c.chunk == nil || c.chunk.Len() == 0 {
+		if c.next >= len(c.backup.index.Chunks) {
+			return 0, io.EOF
+		}
+
+		data, err := c.backup.readChunkData(c.next)
+		if err != nil {
+			return 0, err
+		}
+
+		c.chunk = bytes.NewReader(data)
+		c.next++
+	}
+
+	return c.chunk.Read(p)
+}
@@ -0,0 +1,233 @@
+package archive
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestChain creates a hash chain of blocks starting with the genesis block
+func newTestChain(n int) []*types.Block {
+	chain := make([]*types.Block, n)
+
+	for i := range chain {
+		header := &types.Header{
+			Number:     uint64(i),
+			TxRoot:     types.EmptyRootHash,
+			Sha3Uncles: types.EmptyUncleHash,
+			ExtraData:  []byte{byte(i)},
+		}
+
+		if i > 0 {
+			header.ParentHash = chain[i-1].Hash()
+		}
+
+		chain[i] = &types.Block{Header: header.ComputeHash()}
+	}
+
+	return chain
+}
+
+// writeTestBackup writes a chunked backup of the blocks to a new file
+func writeTestBackup(t *testing.T, path string, codec Codec, blocks []*types.Block) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	w, err := newChunkWriter(file, codec)
+	require.NoError(t, err)
+
+	for _, b := range blocks {
+		require.NoError(t, w.appendBlock(b))
+	}
+
+	require.NoError(t, w.close())
+}
+
+func TestBackupReader(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(2500)
+
+	for _, codec := range []Codec{CodecNone, CodecZstd} {
+		codec := codec
+
+		t.Run(codec.String(), func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "backup")
+			writeTestBackup(t, path, codec, chain)
+
+			backup, err := OpenBackup(path)
+			require.NoError(t, err)
+
+			defer backup.Close()
+
+			index := backup.Index()
+			require.Equal(t, codec, index.Codec)
+			require.Equal(t, uint64(2499), index.Latest)
+			require.Equal(t, chain[2499].Hash(), index.LatestHash)
+			require.Len(t, index.Chunks, 3)
+			require.Equal(t, uint64(1000), index.Chunks[1].From)
+			require.Equal(t, uint64(1999), index.Chunks[1].To)
+			require.Equal(t, chain[999].Hash(), index.Chunks[1].ParentHash)
+
+			block, err := backup.ReadBlock(1500)
+			require.NoError(t, err)
+			require.Equal(t, chain[1500].Hash(), block.Hash())
+
+			_, err = backup.ReadBlock(2500)
+			require.ErrorIs(t, err, errBlockNotInBackup)
+
+			result, err := VerifyBackup(path)
+			require.NoError(t, err)
+			require.Equal(t, &VerifyResult{
+				Chunked: true,
+				Codec:   codec,
+				Chunks:  3,
+				From:    0,
+				To:      2499,
+				Blocks:  2500,
+				Latest:  chain[2499].Hash(),
+			}, result)
+		})
+	}
+}
+
+func TestBackup_Incremental(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(1500)
+	path := filepath.Join(t.TempDir(), "backup")
+
+	writeTestBackup(t, path, CodecZstd, chain[:1200])
+
+	stat, err := os.Stat(path)
+	require.NoError(t, err)
+
+	// the backup can't be continued from another block than the one following it
+	_, err = openBackupOutput(BackupConfig{OutPath: path, Incremental: true, From: 1000})
+	require.ErrorIs(t, err, errIncrementalFrom)
+
+	// a continuation which does not follow the backup is aborted and the backup is left intact
+	out, err := openBackupOutput(BackupConfig{OutPath: path, Incremental: true, From: 1200})
+	require.NoError(t, err)
+	require.ErrorContains(t, out.writer.appendBlock(chain[1300]), "does not follow block 1199")
+
+	out.abort(hclog.NewNullLogger())
+
+	truncated, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, stat.Size(), truncated.Size())
+
+	out, err = openBackupOutput(BackupConfig{OutPath: path, Incremental: true})
+	require.NoError(t, err)
+
+	for _, b := range chain[1200:] {
+		require.NoError(t, out.writer.appendBlock(b))
+	}
+
+	require.NoError(t, out.commit())
+
+	result, err := VerifyBackup(path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1500), result.Blocks)
+	require.Equal(t, 3, result.Chunks)
+	require.Equal(t, chain[1499].Hash(), result.Latest)
+
+	// the chunked backup is restored
+	progression := progress.NewProgressionWrapper(progress.ChainSyncRestore)
+	mock := &mockChain{genesis: chain[0], blocks: []*types.Block{}}
+
+	require.NoError(t, RestoreChain(mock, path, progression))
+	require.Len(t, mock.blocks, 1499)
+	require.Equal(t, chain[1499].Hash(), getLatestBlockFromMockChain(mock).Hash())
+}
+
+func TestVerifyBackup_Invalid(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(20)
+	dir := t.TempDir()
+
+	t.Run("corrupted chunk", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(dir, "corrupted")
+		writeTestBackup(t, path, CodecZstd, chain)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		data[len(backupMagic)+10] ^= 0xff
+		require.NoError(t, os.WriteFile(path, data, 0600))
+
+		_, err = VerifyBackup(path)
+		require.ErrorContains(t, err, "checksum mismatch of chunk 0")
+	})
+
+	t.Run("incomplete backup", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(dir, "incomplete")
+		writeTestBackup(t, path, CodecNone, chain)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, data[:len(data)-1], 0600))
+
+		_, err = VerifyBackup(path)
+		require.ErrorIs(t, err, errNotChunkedBackup)
+	})
+
+	t.Run("legacy backup with a gap", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		metadata := &Metadata{Latest: 19, LatestHash: chain[19].Hash()}
+		buf.Write(metadata.MarshalRLP())
+
+		for i, b := range chain {
+			if i != 7 {
+				buf.Write(b.MarshalRLP())
+			}
+		}
+
+		path := filepath.Join(dir, "legacy")
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+
+		_, err := VerifyBackup(path)
+		require.ErrorContains(t, err, "block 8 follows block 6")
+	})
+
+	t.Run("legacy backup", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		metadata := &Metadata{Latest: 19, LatestHash: chain[19].Hash()}
+		buf.Write(metadata.MarshalRLP())
+
+		for _, b := range chain {
+			buf.Write(b.MarshalRLP())
+		}
+
+		path := filepath.Join(dir, "valid")
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+
+		result, err := VerifyBackup(path)
+		require.NoError(t, err)
+		require.False(t, result.Chunked)
+		require.Equal(t, uint64(20), result.Blocks)
+	})
+}
@@ -1,6 +1,7 @@
 package archive
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -27,16 +28,37 @@ type blockchainInterface interface {
 	VerifyFinalizedBlock(*types.Block) (*types.FullBlock, error)
 }
 
-// RestoreChain reads blocks from the archive and write to the chain
+// RestoreChain reads blocks from the archive and write to the chain.
+// Both the chunked backups and the legacy plain RLP streams are supported
 func RestoreChain(chain blockchainInterface, filePath string, progression *progress.ProgressionWrapper) error {
 	fp, err := os.Open(filePath)
 	if err != nil {
 		return err
 	}
 
-	blockStream := newBlockStream(fp)
+	chunked, err := isChunkedBackup(fp)
+	if err != nil {
+		fp.Close()
+
+		return err
+	}
 
-	return importBlocks(chain, blockStream, progression)
+	if !chunked {
+		defer fp.Close()
+
+		return importBlocks(chain, newBlockStream(fp), progression)
+	}
+
+	backup, err := newBackupReader(fp)
+	if err != nil {
+		fp.Close()
+
+		return err
+	}
+
+	defer backup.Close()
+
+	return importBlocks(chain, newChunkedBlockStream(backup), progression)
 }
 
 // import blocks scans all blocks from stream and write them to chain
@@ -159,6 +181,14 @@ type blockStream struct {
 	buffer []byte
 }
 
+// newChunkedBlockStream returns the stream of the metadata and the blocks of a chunked backup
+func newChunkedBlockStream(backup *BackupReader) *blockStream {
+	return newBlockStream(io.MultiReader(
+		bytes.NewReader(backup.index.Metadata.MarshalRLP()),
+		backup.blocksReader(),
+	))
+}
+
 func newBlockStream(input io.Reader) *blockStream {
 	return &blockStream{
 		input:  input,
@@ -59,3 +59,171 @@ func (m *Metadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 
 	return nil
 }
+
+// Codec is the compression codec of the chunks in a backup
+type Codec uint8
+
+const (
+	// CodecNone stores the chunks uncompressed
+	CodecNone Codec = iota
+	// CodecZstd compresses every chunk with zstd
+	CodecZstd
+)
+
+func (c Codec) String() string {
+	switch c {
+	case CodecNone:
+		return "none"
+	case CodecZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(c))
+	}
+}
+
+// ChunkInfo is the index entry of a chunk of consecutive blocks in a backup
+type ChunkInfo struct {
+	From uint64
+	To   uint64
+	// Offset and Size locate the stored, possibly compressed, chunk in the backup file
+	Offset uint64
+	Size   uint64
+	// Checksum is the CRC-32 (Castagnoli) checksum of the stored chunk
+	Checksum uint32
+	// ParentHash is the parent hash of the first block and LastHash the hash of the last block
+	ParentHash types.Hash
+	LastHash   types.Hash
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (c *ChunkInfo) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(c.From))
+	vv.Set(arena.NewUint(c.To))
+	vv.Set(arena.NewUint(c.Offset))
+	vv.Set(arena.NewUint(c.Size))
+	vv.Set(arena.NewUint(uint64(c.Checksum)))
+	vv.Set(arena.NewBytes(c.ParentHash.Bytes()))
+	vv.Set(arena.NewBytes(c.LastHash.Bytes()))
+
+	return vv
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (c *ChunkInfo) UnmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 7 {
+		return fmt.Errorf("incorrect number of elements to decode ChunkInfo, expected 7 but found %d", len(elems))
+	}
+
+	if c.From, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	if c.To, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	if c.Offset, err = elems[2].GetUint64(); err != nil {
+		return err
+	}
+
+	if c.Size, err = elems[3].GetUint64(); err != nil {
+		return err
+	}
+
+	checksum, err := elems[4].GetUint64()
+	if err != nil {
+		return err
+	}
+
+	c.Checksum = uint32(checksum)
+
+	if err = elems[5].GetHash(c.ParentHash[:]); err != nil {
+		return err
+	}
+
+	return elems[6].GetHash(c.LastHash[:])
+}
+
+// Index is the data stored at the end of a chunked backup, it locates every chunk in the file
+type Index struct {
+	Metadata
+	Codec  Codec
+	Chunks []ChunkInfo
+}
+
+// MarshalRLP returns RLP encoded bytes
+func (i *Index) MarshalRLP() []byte {
+	return i.MarshalRLPTo(nil)
+}
+
+// MarshalRLPTo sets RLP encoded bytes to given byte slice
+func (i *Index) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(i.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (i *Index) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(i.Metadata.MarshalRLPWith(arena))
+	vv.Set(arena.NewUint(uint64(i.Codec)))
+
+	chunks := arena.NewArray()
+	for idx := range i.Chunks {
+		chunks.Set(i.Chunks[idx].MarshalRLPWith(arena))
+	}
+
+	vv.Set(chunks)
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
+func (i *Index) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(i.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (i *Index) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 3 {
+		return fmt.Errorf("incorrect number of elements to decode Index, expected 3 but found %d", len(elems))
+	}
+
+	if err = i.Metadata.UnmarshalRLPFrom(p, elems[0]); err != nil {
+		return err
+	}
+
+	codec, err := elems[1].GetUint64()
+	if err != nil {
+		return err
+	}
+
+	i.Codec = Codec(codec)
+
+	chunks, err := elems[2].GetElems()
+	if err != nil {
+		return err
+	}
+
+	i.Chunks = make([]ChunkInfo, len(chunks))
+
+	for idx, chunk := range chunks {
+		if err = i.Chunks[idx].UnmarshalRLPFrom(p, chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
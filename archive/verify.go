@@ -0,0 +1,190 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+)
+
+// VerifyResult is the summary of a verified backup
+type VerifyResult struct {
+	Chunked bool
+	Codec   Codec
+	Chunks  int
+	From    uint64
+	To      uint64
+	Blocks  uint64
+	Latest  types.Hash
+}
+
+// hashChain checks that the blocks of a backup form a hash chain
+type hashChain struct {
+	result   *VerifyResult
+	lastHash types.Hash
+}
+
+// append checks the block and that it follows the previous block of the backup
+func (c *hashChain) append(block *types.Block) error {
+	if err := verifyBlockBody(block); err != nil {
+		return err
+	}
+
+	if c.result.Blocks > 0 {
+		if block.Number() != c.result.To+1 {
+			return fmt.Errorf("block %d follows block %d", block.Number(), c.result.To)
+		}
+
+		if block.ParentHash() != c.lastHash {
+			return fmt.Errorf("parent hash %s of block %d does not match the hash %s of block %d",
+				block.ParentHash(), block.Number(), c.lastHash, c.result.To)
+		}
+	} else {
+		c.result.From = block.Number()
+	}
+
+	c.result.To = block.Number()
+	c.result.Blocks++
+	c.lastHash = block.Hash()
+
+	return nil
+}
+
+// verifyBlockBody checks that the transactions and the uncles of the block match its header
+func verifyBlockBody(block *types.Block) error {
+	if root := buildroot.CalculateTransactionsRoot(block.Transactions, block.Number()); root != block.Header.TxRoot {
+		return fmt.Errorf("transactions root %s of block %d does not match the header root %s",
+			root, block.Number(), block.Header.TxRoot)
+	}
+
+	if root := buildroot.CalculateUncleRoot(block.Uncles); root != block.Header.Sha3Uncles {
+		return fmt.Errorf("uncles root %s of block %d does not match the header root %s",
+			root, block.Number(), block.Header.Sha3Uncles)
+	}
+
+	return nil
+}
+
+// VerifyBackup checks the hash chain continuity of the blocks in the backup at the given path,
+// their bodies against their headers, and that the chain ends with the latest block of the metadata.
+// The chunks of a chunked backup are also checked against their checksums and their index entries
+func VerifyBackup(path string) (*VerifyResult, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	chunked, err := isChunkedBackup(fp)
+	if err != nil {
+		fp.Close()
+
+		return nil, err
+	}
+
+	if !chunked {
+		defer fp.Close()
+
+		return verifyLegacyBackup(newBlockStream(fp))
+	}
+
+	backup, err := newBackupReader(fp)
+	if err != nil {
+		fp.Close()
+
+		return nil, err
+	}
+
+	defer backup.Close()
+
+	return verifyChunkedBackup(backup)
+}
+
+func verifyChunkedBackup(backup *BackupReader) (*VerifyResult, error) {
+	index := backup.Index()
+	result := &VerifyResult{
+		Chunked: true,
+		Codec:   index.Codec,
+		Chunks:  len(index.Chunks),
+	}
+	chain := &hashChain{result: result}
+
+	for i, chunk := range index.Chunks {
+		blocks, err := backup.ReadChunk(i)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(blocks) == 0 || blocks[0].Number() != chunk.From || blocks[len(blocks)-1].Number() != chunk.To {
+			return nil, fmt.Errorf("blocks of chunk %d do not match its range %d - %d", i, chunk.From, chunk.To)
+		}
+
+		if blocks[0].ParentHash() != chunk.ParentHash || blocks[len(blocks)-1].Hash() != chunk.LastHash {
+			return nil, fmt.Errorf("block hashes of chunk %d do not match its index entry", i)
+		}
+
+		for _, block := range blocks {
+			if err := chain.append(block); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := checkLatest(result, chain, &index.Metadata); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func verifyLegacyBackup(stream *blockStream) (*VerifyResult, error) {
+	metadata, err := stream.getMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata == nil {
+		return nil, errors.New("expected metadata in archive but doesn't exist")
+	}
+
+	result := &VerifyResult{}
+	chain := &hashChain{result: result}
+
+	for {
+		block, err := stream.nextBlock()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode block after block %d: %w", result.To, err)
+		}
+
+		if block == nil {
+			break
+		}
+
+		if err := chain.append(block); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkLatest(result, chain, metadata); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// checkLatest checks that the verified blocks end with the latest block of the metadata
+func checkLatest(result *VerifyResult, chain *hashChain, metadata *Metadata) error {
+	if result.Blocks == 0 {
+		return errors.New("backup holds no blocks")
+	}
+
+	if result.To != metadata.Latest || chain.lastHash != metadata.LatestHash {
+		return fmt.Errorf("backup ends with block %d (%s), but its metadata holds block %d (%s)",
+			result.To, chain.lastHash, metadata.Latest, metadata.LatestHash)
+	}
+
+	result.Latest = chain.lastHash
+
+	return nil
+}
@@ -38,6 +38,9 @@ var (
 	ErrInvalidStateRoot     = errors.New("invalid block state root")
 	ErrInvalidGasUsed       = errors.New("invalid block gas used")
 	ErrInvalidReceiptsRoot  = errors.New("invalid block receipts root")
+
+	// ErrInvalidSeal is wrapped by the consensus errors of the blocks whose seals fail the verification
+	ErrInvalidSeal = errors.New("invalid block seal")
 )
 
 // Blockchain is a blockchain reference
@@ -49,6 +52,8 @@ type Blockchain struct {
 	executor  Executor
 	txSigner  TxSigner
 
+	stateChecker StateChecker // Checker of the state availability, used by the head recovery
+
 	genesisConfig *chain.Chain // Config containing chain information
 	genesis       types.Hash   // The hash of the genesis block
 
@@ -257,14 +262,10 @@ func (b *Blockchain) ComputeGenesis() error {
 			return fmt.Errorf("genesis file does not match current genesis")
 		}
 
-		header, ok := b.GetHeaderByHash(head)
-		if !ok {
-			return fmt.Errorf("failed to get header with hash %s", head.String())
-		}
-
-		diff, ok := b.GetTD(head)
-		if !ok {
-			return fmt.Errorf("failed to read difficulty")
+		// make sure the head survived the last shutdown, otherwise start from the last consistent block
+		header, diff, err := b.recoverHead(head)
+		if err != nil {
+			return err
 		}
 
 		b.logger.Info(
@@ -448,6 +449,29 @@ func (b *Blockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error
 	return b.db.ReadReceipts(n, hash)
 }
 
+// HistoryTail returns the number of the first block with the body and receipts available,
+// the history of the older blocks was pruned
+func (b *Blockchain) HistoryTail() uint64 {
+	return b.db.HistoryTail()
+}
+
+// TxIndexTail returns the number of the first block with the transaction lookups available,
+// the lookups of the older blocks were pruned
+func (b *Blockchain) TxIndexTail() uint64 {
+	return b.db.TxIndexTail()
+}
+
+// StartReindex rebuilds the block and transaction lookups of the canonical blocks in the range in the background
+func (b *Blockchain) StartReindex(from, to uint64) error {
+	return b.db.StartReindex(from, to)
+}
+
+// ReindexStatus returns the progress of the last lookup reindex, whether it is still running
+// and the error it failed with
+func (b *Blockchain) ReindexStatus() (storagev2.ReindexProgress, bool, error) {
+	return b.db.ReindexStatus()
+}
+
 // GetBodyByHash returns the body by their hash
 func (b *Blockchain) GetBodyByHash(hash types.Hash) (*types.Body, bool) {
 	return b.readBody(hash)
@@ -499,7 +523,9 @@ func (b *Blockchain) readBody(hash types.Hash) (*types.Body, bool) {
 
 	bb, err := b.db.ReadBody(n, hash)
 	if err != nil {
-		b.logger.Error("failed to read body", "err", err)
+		if !errors.Is(err, storagev2.ErrHistoryPruned) {
+			b.logger.Error("failed to read body", "err", err)
+		}
 
 		return nil, false
 	}
@@ -711,6 +737,26 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 // - The receipts match up
 // - The execution result matches up
 func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, error) {
+	if err := b.verifyBlockRoots(block); err != nil {
+		return nil, err
+	}
+
+	// Execute the transactions in the block and grab the result
+	blockResult, executeErr := b.executeBlockTransactions(block)
+	if executeErr != nil {
+		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
+	}
+
+	// Verify the local execution result with the proposed block data
+	if err := blockResult.verifyBlockResult(block); err != nil {
+		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+	}
+
+	return blockResult.Receipts, nil
+}
+
+// verifyBlockRoots verifies that the uncles and the transactions of the block match its header
+func (b *Blockchain) verifyBlockRoots(block *types.Block) error {
 	// Make sure the Uncles root matches up
 	if hash := buildroot.CalculateUncleRoot(block.Uncles); hash != block.Header.Sha3Uncles {
 		b.logger.Error(fmt.Sprintf(
@@ -719,7 +765,7 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.Sha3Uncles,
 		))
 
-		return nil, ErrInvalidSha3Uncles
+		return ErrInvalidSha3Uncles
 	}
 
 	// Make sure the transactions root matches up
@@ -730,21 +776,10 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.TxRoot,
 		))
 
-		return nil, ErrInvalidTxRoot
+		return ErrInvalidTxRoot
 	}
 
-	// Execute the transactions in the block and grab the result
-	blockResult, executeErr := b.executeBlockTransactions(block)
-	if executeErr != nil {
-		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
-	}
-
-	// Verify the local execution result with the proposed block data
-	if err := blockResult.verifyBlockResult(block); err != nil {
-		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
-	}
-
-	return blockResult.Receipts, nil
+	return nil
 }
 
 // verifyBlockResult verifies that the block transaction execution result
@@ -953,6 +988,68 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 	return nil
 }
 
+// WriteSyncedBlock verifies and writes a block up to the pivot of a state sync, whose state is
+// downloaded instead of being executed. The header is verified by the consensus and the body
+// against the header, the transactions are not executed, so no receipts are written for the block
+func (b *Blockchain) WriteSyncedBlock(block *types.Block, source string) error {
+	if err := b.consensus.VerifyHeader(block.Header); err != nil {
+		return fmt.Errorf("failed to verify the header: %w", err)
+	}
+
+	if err := b.verifyBlockParent(block); err != nil {
+		return err
+	}
+
+	if err := b.verifyBlockRoots(block); err != nil {
+		return err
+	}
+
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if block.Number() <= b.Header().Number {
+		b.logger.Info("block already inserted", "block", block.Number(), "source", source)
+
+		return nil
+	}
+
+	header := block.Header
+
+	batchWriter := b.db.NewWriter()
+
+	if err := b.writeBody(batchWriter, block); err != nil {
+		return err
+	}
+
+	// Write the header to the chain
+	evnt := &Event{Source: source}
+
+	isCanonical, newTD, err := b.writeHeaderImpl(batchWriter, evnt, header)
+	if err != nil {
+		return err
+	}
+
+	// update snapshot
+	if err := b.consensus.ProcessHeaders([]*types.Header{header}); err != nil {
+		return err
+	}
+
+	// the state of the synced blocks is never computed, so the head recovery must not rewind below them
+	if isCanonical {
+		batchWriter.PutSyncPivot(header.Number)
+	}
+
+	if err := b.writeBatchAndUpdate(batchWriter, header, newTD, isCanonical); err != nil {
+		return err
+	}
+
+	b.dispatchEvent(evnt)
+
+	b.logger.Debug("synced block", "number", header.Number, "hash", header.Hash, "source", source)
+
+	return nil
+}
+
 // GetCachedReceipts retrieves cached receipts for given headerHash
 func (b *Blockchain) GetCachedReceipts(headerHash types.Hash) ([]*types.Receipt, error) {
 	receipts, found := b.receiptsCache.Get(headerHash)
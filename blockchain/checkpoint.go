@@ -0,0 +1,173 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	errChainNotEmpty      = errors.New("chain has blocks after the genesis")
+	errNoCheckpoint       = errors.New("chain was not synced from a checkpoint")
+	errCheckpointMismatch = errors.New("blocks do not link to the checkpoint")
+)
+
+// Checkpoint returns the header of the lowest block of a chain synced from a trusted checkpoint,
+// the blocks between the genesis and the checkpoint are missing until they are backfilled
+func (b *Blockchain) Checkpoint() (*types.Header, bool) {
+	number, hash, ok := b.db.ReadCheckpoint()
+	if !ok {
+		return nil, false
+	}
+
+	header, err := b.db.ReadHeader(number, hash)
+	if err != nil {
+		return nil, false
+	}
+
+	return header, true
+}
+
+// WriteCheckpointBlock makes the trusted checkpoint block the head of a chain having only the genesis block,
+// without its ancestors. The block is not executed, so its header must be verified by the caller
+// and its state must already be stored. The total difficulty of the missing blocks is taken to be
+// the difficulty of the checkpoint
+func (b *Blockchain) WriteCheckpointBlock(block *types.Block, source string) error {
+	if err := b.verifyBlockRoots(block); err != nil {
+		return err
+	}
+
+	header := block.Header
+
+	if b.stateChecker != nil && !b.stateChecker.HasState(header.StateRoot) {
+		return fmt.Errorf("%w: root %s", errStateMissing, header.StateRoot)
+	}
+
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if head := b.Header(); head.Number != 0 {
+		return fmt.Errorf("%w: head is block %d", errChainNotEmpty, head.Number)
+	}
+
+	genesisTD, ok := b.readTotalDifficulty(b.genesis)
+	if !ok {
+		return errors.New("failed to get genesis difficulty")
+	}
+
+	td := new(big.Int).SetUint64(header.Difficulty)
+	td.Mul(td, new(big.Int).SetUint64(header.Number))
+	td.Add(td, genesisTD)
+
+	batchWriter := b.db.NewWriter()
+
+	if err := b.writeBody(batchWriter, block); err != nil {
+		return err
+	}
+
+	batchWriter.PutCanonicalHeader(header, td)
+
+	lowest, err := b.lowestBlock(header)
+	if err != nil {
+		return err
+	}
+
+	if err := b.db.WriteCheckpoint(batchWriter, lowest, header.Hash); err != nil {
+		return err
+	}
+
+	b.headersCache.Add(header.Hash, header)
+	b.setCurrentHeader(header, td)
+
+	evnt := &Event{Source: source, Type: EventHead}
+	evnt.AddNewHeader(header)
+	evnt.SetDifficulty(td)
+
+	b.dispatchEvent(evnt)
+
+	b.logger.Info("wrote checkpoint block", "number", header.Number, "hash", header.Hash, "source", source)
+
+	return nil
+}
+
+// BackfillBlocks writes the consecutive blocks, in ascending order, preceding the checkpoint and makes
+// the first of them the new checkpoint. The blocks are verified by their hashes linking them to
+// the checkpoint, they are not executed so their receipts are missing
+func (b *Blockchain) BackfillBlocks(blocks []*types.Block) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	checkpoint, ok := b.Checkpoint()
+	if !ok {
+		return errNoCheckpoint
+	}
+
+	// the hashes are checked from the checkpoint down, so every block is linked to it
+	td, ok := b.readTotalDifficulty(checkpoint.Hash)
+	if !ok {
+		return errors.New("failed to get checkpoint difficulty")
+	}
+
+	td = new(big.Int).Sub(td, new(big.Int).SetUint64(checkpoint.Difficulty))
+	child := checkpoint
+	batchWriter := b.db.NewWriter()
+
+	for i := len(blocks) - 1; i >= 0; i-- {
+		block := blocks[i]
+		header := block.Header.ComputeHash()
+
+		if header.Hash != child.ParentHash || header.Number+1 != child.Number {
+			return fmt.Errorf("%w: block %d (%s) is not the parent of block %d",
+				errCheckpointMismatch, header.Number, header.Hash, child.Number)
+		}
+
+		if err := b.verifyBlockRoots(block); err != nil {
+			return err
+		}
+
+		if err := b.writeBody(batchWriter, block); err != nil {
+			return err
+		}
+
+		batchWriter.PutHeader(header)
+		batchWriter.PutBlockLookup(header.Hash, header.Number)
+		batchWriter.PutCanonicalHash(header.Number, header.Hash)
+		batchWriter.PutTotalDifficulty(header.Number, header.Hash, td)
+
+		td = new(big.Int).Sub(td, new(big.Int).SetUint64(header.Difficulty))
+		child = header
+	}
+
+	lowest, err := b.lowestBlock(child)
+	if err != nil {
+		return err
+	}
+
+	if err := b.db.WriteCheckpoint(batchWriter, lowest, child.Hash); err != nil {
+		return err
+	}
+
+	b.logger.Debug("backfilled blocks", "from", blocks[0].Number(), "to", checkpoint.Number-1)
+
+	return nil
+}
+
+// lowestBlock returns the checkpoint number of a chain whose lowest stored block after the genesis
+// is the given one. It is zero if the block follows the genesis, as no blocks are missing then
+func (b *Blockchain) lowestBlock(header *types.Header) (uint64, error) {
+	if header.Number != 1 {
+		return header.Number, nil
+	}
+
+	if header.ParentHash != b.genesis {
+		return 0, fmt.Errorf("%w: block 1 is not the child of the genesis", errCheckpointMismatch)
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,67 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockchain_CheckpointSync(t *testing.T) {
+	t.Parallel()
+
+	b := NewTestBlockchain(t, nil)
+	headers := NewTestHeadersWithSeed(b.Header(), 10, 0)
+	blocks := HeadersToBlocks(headers)
+
+	require.NoError(t, b.WriteCheckpointBlock(blocks[9], "test"))
+	require.Equal(t, uint64(9), b.Header().Number)
+	require.Equal(t, uint64(9), b.HistoryTail())
+	require.Equal(t, uint64(9), b.TxIndexTail())
+
+	checkpoint, ok := b.Checkpoint()
+	require.True(t, ok)
+	require.Equal(t, headers[9].Hash, checkpoint.Hash)
+
+	_, ok = b.GetHeaderByNumber(8)
+	require.False(t, ok)
+
+	// the checkpoint is a consistent head without its parent
+	header, _, err := b.recoverHead(headers[9].Hash)
+	require.NoError(t, err)
+	require.Equal(t, headers[9].Hash, header.Hash)
+
+	require.ErrorIs(t, b.WriteCheckpointBlock(blocks[9], "test"), errChainNotEmpty)
+
+	// the blocks must be the parents of the checkpoint
+	require.ErrorIs(t, b.BackfillBlocks(blocks[2:5]), errCheckpointMismatch)
+
+	require.NoError(t, b.BackfillBlocks(blocks[5:9]))
+	require.Equal(t, uint64(5), b.HistoryTail())
+	require.Equal(t, uint64(5), b.TxIndexTail())
+
+	checkpoint, ok = b.Checkpoint()
+	require.True(t, ok)
+	require.Equal(t, headers[5].Hash, checkpoint.Hash)
+
+	for i := 5; i < 9; i++ {
+		header, ok := b.GetHeaderByNumber(uint64(i))
+		require.True(t, ok)
+		require.Equal(t, headers[i].Hash, header.Hash)
+
+		parentTD, ok := b.GetTD(headers[i].Hash)
+		require.True(t, ok)
+
+		td, ok := b.GetTD(headers[i+1].Hash)
+		require.True(t, ok)
+		require.Equal(t, headers[i+1].Difficulty, td.Uint64()-parentTD.Uint64())
+	}
+
+	// the checkpoint is removed once the blocks following the genesis are written
+	require.NoError(t, b.BackfillBlocks(blocks[1:5]))
+	require.Zero(t, b.HistoryTail())
+
+	_, ok = b.Checkpoint()
+	require.False(t, ok)
+
+	require.ErrorIs(t, b.BackfillBlocks(blocks[1:2]), errNoCheckpoint)
+}
@@ -0,0 +1,197 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	errCanonicalHashMissing = errors.New("canonical hash missing")
+	errBlockLookupMissing   = errors.New("block lookup missing")
+	errDifficultyMissing    = errors.New("total difficulty missing")
+	errBodyMissing          = errors.New("body missing")
+	errStateMissing         = errors.New("state missing")
+	errBrokenCanonicalChain = errors.New("parent is not the canonical block")
+	errResyncRequired       = errors.New("no consistent block found to recover the head, the chain has to be resynced")
+)
+
+// maxRecoveryDepth is the maximum number of blocks the head is rewound by on startup
+const maxRecoveryDepth = uint64(1024)
+
+// StateChecker reports whether the state of the given root is available
+type StateChecker interface {
+	HasState(root types.Hash) bool
+}
+
+// SetStateChecker sets the checker used to find the blocks without state on startup
+func (b *Blockchain) SetStateChecker(c StateChecker) {
+	b.stateChecker = c
+}
+
+// recoverHead returns the head block to start from. The main and the lookup databases are
+// written in separate batches and the state is committed apart from the block, so after an
+// unclean shutdown the head pointers may reference a block which is only partially persisted.
+// In that case the head is walked back to the last fully consistent canonical block, the head
+// pointers are rewritten and the canonical entries of the blocks above it are deleted.
+// The walk stops at the history tail, the checkpoint and the state sync pivot, as the blocks below them
+// can't be checked, and at most maxRecoveryDepth blocks are rewound. If no consistent block is found
+// within that range nothing is written and the chain has to be resynced
+func (b *Blockchain) recoverHead(headHash types.Hash) (*types.Header, *big.Int, error) {
+	headNumber, ok := b.db.ReadHeadNumber()
+	if !ok {
+		n, err := b.db.ReadBlockLookup(headHash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read head number: %w", err)
+		}
+
+		headNumber = n
+	}
+
+	header, diff, err := b.checkBlock(headNumber, headHash)
+	if err == nil {
+		return header, diff, nil
+	}
+
+	b.logger.Warn("head block is inconsistent, recovering the chain head",
+		"number", headNumber, "hash", headHash, "err", err)
+
+	lowest := b.lowestRecoverableBlock(headNumber)
+
+	for n := int64(headNumber); n >= int64(lowest); n-- {
+		hash, ok := b.db.ReadCanonicalHash(uint64(n))
+		if !ok {
+			err = errCanonicalHashMissing
+		} else if header, diff, err = b.checkBlock(uint64(n), hash); err == nil {
+			break
+		}
+
+		b.logger.Debug("skipping inconsistent block", "number", n, "hash", hash, "err", err)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: checked blocks %d - %d: %w", errResyncRequired, lowest, headNumber, err)
+	}
+
+	writer := b.db.NewWriter()
+
+	writer.PutHeadHash(header.Hash)
+	writer.PutHeadNumber(header.Number)
+
+	for n := header.Number + 1; n <= headNumber; n++ {
+		b.deleteCanonicalBlock(writer, n)
+	}
+
+	if err := writer.WriteBatch(); err != nil {
+		return nil, nil, fmt.Errorf("failed to write the recovered head: %w", err)
+	}
+
+	b.logger.Warn("recovered the chain head",
+		"previous number", headNumber, "previous hash", headHash,
+		"number", header.Number, "hash", header.Hash,
+		"rewound blocks", headNumber-header.Number)
+
+	return header, diff, nil
+}
+
+// lowestRecoverableBlock returns the lowest block the head with the given number can be rewound to
+func (b *Blockchain) lowestRecoverableBlock(headNumber uint64) uint64 {
+	lowest := b.db.HistoryTail()
+
+	if n, _, ok := b.db.ReadCheckpoint(); ok && n > lowest {
+		lowest = n
+	}
+
+	if n, ok := b.db.ReadSyncPivot(); ok && n > lowest {
+		lowest = n
+	}
+
+	if headNumber > maxRecoveryDepth && headNumber-maxRecoveryDepth > lowest {
+		lowest = headNumber - maxRecoveryDepth
+	}
+
+	return lowest
+}
+
+// deleteCanonicalBlock deletes the canonical hash of the block with the given number,
+// and the block and transaction lookups pointing to it
+func (b *Blockchain) deleteCanonicalBlock(writer *storagev2.Writer, n uint64) {
+	hash, ok := b.db.ReadCanonicalHash(n)
+	if !ok {
+		return
+	}
+
+	writer.DeleteCanonicalHash(n)
+
+	if lookup, err := b.db.ReadBlockLookup(hash); err == nil && lookup == n {
+		writer.DeleteBlockLookup(hash)
+	}
+
+	body, err := b.db.ReadBody(n, hash)
+	if err != nil {
+		return
+	}
+
+	for _, tx := range body.Transactions {
+		if lookup, err := b.db.ReadTxLookup(tx.Hash()); err == nil && lookup == n {
+			writer.DeleteTxLookup(tx.Hash())
+		}
+	}
+}
+
+// checkBlock checks that the canonical block with the given number and hash and its state are
+// fully persisted and that its parent is the canonical block, and returns its header and total difficulty
+func (b *Blockchain) checkBlock(n uint64, hash types.Hash) (*types.Header, *big.Int, error) {
+	canonical, ok := b.db.ReadCanonicalHash(n)
+	if !ok {
+		return nil, nil, errCanonicalHashMissing
+	}
+
+	if canonical != hash {
+		return nil, nil, fmt.Errorf("%w: canonical hash is %s", errBrokenCanonicalChain, canonical)
+	}
+
+	if lookup, err := b.db.ReadBlockLookup(hash); err != nil || lookup != n {
+		return nil, nil, errBlockLookupMissing
+	}
+
+	header, err := b.db.ReadHeader(n, hash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	if header.ComputeHash().Hash != hash || header.Number != n {
+		return nil, nil, fmt.Errorf("header does not match block %d with hash %s", n, hash)
+	}
+
+	diff, ok := b.db.ReadTotalDifficulty(n, hash)
+	if !ok {
+		return nil, nil, errDifficultyMissing
+	}
+
+	if n > 0 {
+		// the parent of the checkpoint of a chain synced from it is not stored
+		number, checkpoint, isCheckpointed := b.db.ReadCheckpoint()
+		if !isCheckpointed || number != n || checkpoint != hash {
+			if parent, ok := b.db.ReadCanonicalHash(n - 1); !ok || parent != header.ParentHash {
+				return nil, nil, errBrokenCanonicalChain
+			}
+		}
+
+		// the body of a block without transactions carries no data, so only the others are required
+		if header.TxRoot != types.EmptyRootHash {
+			if _, err := b.db.ReadBody(n, hash); err != nil {
+				return nil, nil, fmt.Errorf("%w: %v", errBodyMissing, err)
+			}
+		}
+	}
+
+	if b.stateChecker != nil && !b.stateChecker.HasState(header.StateRoot) {
+		return nil, nil, fmt.Errorf("%w: root %s", errStateMissing, header.StateRoot)
+	}
+
+	return header, diff, nil
+}
@@ -0,0 +1,276 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStateChecker struct {
+	missing map[types.Hash]bool
+}
+
+func (m *mockStateChecker) HasState(root types.Hash) bool {
+	return !m.missing[root]
+}
+
+// newRecoveryTestHeaders creates a chain of headers with a distinct state root per block
+func newRecoveryTestHeaders(n int) []*types.Header {
+	headers := NewTestHeaders(n)
+
+	for i, header := range headers {
+		header.StateRoot = types.BytesToHash([]byte{byte(i + 1)})
+
+		if i > 0 {
+			header.ParentHash = headers[i-1].Hash
+		}
+
+		header.ComputeHash()
+	}
+
+	return headers
+}
+
+func TestBlockchain_RecoverHead(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		corrupt  func(t *testing.T, w *storagev2.Writer, headers []*types.Header, checker *mockStateChecker)
+		expected uint64
+	}{
+		{
+			name:     "consistent head",
+			corrupt:  func(*testing.T, *storagev2.Writer, []*types.Header, *mockStateChecker) {},
+			expected: 9,
+		},
+		{
+			name: "head and its parent without state",
+			corrupt: func(_ *testing.T, _ *storagev2.Writer, headers []*types.Header, checker *mockStateChecker) {
+				checker.missing[headers[9].StateRoot] = true
+				checker.missing[headers[8].StateRoot] = true
+			},
+			expected: 7,
+		},
+		{
+			name: "head number ahead of the canonical chain",
+			corrupt: func(_ *testing.T, w *storagev2.Writer, _ []*types.Header, _ *mockStateChecker) {
+				w.PutHeadNumber(12)
+			},
+			expected: 9,
+		},
+		{
+			name: "broken canonical chain",
+			corrupt: func(_ *testing.T, w *storagev2.Writer, headers []*types.Header, _ *mockStateChecker) {
+				w.PutCanonicalHash(8, headers[2].Hash)
+			},
+			expected: 7,
+		},
+		{
+			name: "head without body",
+			corrupt: func(_ *testing.T, w *storagev2.Writer, headers []*types.Header, _ *mockStateChecker) {
+				// the head holds transactions, but its body was never written
+				head := headers[9].Copy()
+				head.TxRoot = types.StringToHash("1")
+				head.ComputeHash()
+
+				w.PutCanonicalHeader(head, new(big.Int).SetUint64(head.Number+1))
+			},
+			expected: 8,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			headers := newRecoveryTestHeaders(10)
+			b := NewTestBlockchain(t, headers)
+
+			checker := &mockStateChecker{missing: map[types.Hash]bool{}}
+			b.SetStateChecker(checker)
+
+			w := b.db.NewWriter()
+			c.corrupt(t, w, headers, checker)
+			require.NoError(t, w.WriteBatch())
+
+			head, ok := b.db.ReadHeadHash()
+			require.True(t, ok)
+
+			header, diff, err := b.recoverHead(head)
+			require.NoError(t, err)
+			require.Equal(t, headers[c.expected].Hash, header.Hash)
+			require.NotNil(t, diff)
+
+			// the head pointers are repaired
+			headHash, ok := b.db.ReadHeadHash()
+			require.True(t, ok)
+			require.Equal(t, headers[c.expected].Hash, headHash)
+
+			headNumber, ok := b.db.ReadHeadNumber()
+			require.True(t, ok)
+			require.Equal(t, c.expected, headNumber)
+
+			// the blocks above the head are no longer canonical
+			for n := c.expected + 1; n < uint64(len(headers)); n++ {
+				_, ok := b.db.ReadCanonicalHash(n)
+				require.False(t, ok, n)
+			}
+		})
+	}
+}
+
+func TestBlockchain_RecoverHead_NoConsistentBlock(t *testing.T) {
+	t.Parallel()
+
+	headers := newRecoveryTestHeaders(3)
+	b := NewTestBlockchain(t, headers)
+
+	checker := &mockStateChecker{missing: map[types.Hash]bool{}}
+	for _, header := range headers {
+		checker.missing[header.StateRoot] = true
+	}
+
+	b.SetStateChecker(checker)
+
+	_, _, err := b.recoverHead(headers[2].Hash)
+	require.ErrorIs(t, err, errResyncRequired)
+	require.ErrorIs(t, err, errStateMissing)
+}
+
+// requireChainUnchanged checks that the head pointers and the canonical hashes still reference the given headers
+func requireChainUnchanged(t *testing.T, b *Blockchain, headers []*types.Header) {
+	t.Helper()
+
+	head := headers[len(headers)-1]
+
+	headHash, ok := b.db.ReadHeadHash()
+	require.True(t, ok)
+	require.Equal(t, head.Hash, headHash)
+
+	headNumber, ok := b.db.ReadHeadNumber()
+	require.True(t, ok)
+	require.Equal(t, head.Number, headNumber)
+
+	for _, header := range headers {
+		hash, ok := b.db.ReadCanonicalHash(header.Number)
+		require.True(t, ok, header.Number)
+		require.Equal(t, header.Hash, hash)
+	}
+}
+
+func TestBlockchain_RecoverHead_MaxDepth(t *testing.T) {
+	t.Parallel()
+
+	headers := newRecoveryTestHeaders(int(maxRecoveryDepth) + 10)
+	b := NewTestBlockchain(t, headers)
+
+	// only the genesis has its state, which is deeper than the head can be rewound
+	checker := &mockStateChecker{missing: map[types.Hash]bool{}}
+	for _, header := range headers[1:] {
+		checker.missing[header.StateRoot] = true
+	}
+
+	b.SetStateChecker(checker)
+
+	_, _, err := b.recoverHead(headers[len(headers)-1].Hash)
+	require.ErrorIs(t, err, errResyncRequired)
+	require.ErrorIs(t, err, errStateMissing)
+
+	requireChainUnchanged(t, b, headers)
+}
+
+func TestBlockchain_RecoverHead_SyncPivot(t *testing.T) {
+	t.Parallel()
+
+	headers := newRecoveryTestHeaders(10)
+	b := NewTestBlockchain(t, headers)
+
+	// the blocks up to the pivot were synced without their state
+	w := b.db.NewWriter()
+	w.PutSyncPivot(5)
+	require.NoError(t, w.WriteBatch())
+
+	checker := &mockStateChecker{missing: map[types.Hash]bool{}}
+	for _, header := range headers[1:] {
+		checker.missing[header.StateRoot] = true
+	}
+
+	b.SetStateChecker(checker)
+
+	_, _, err := b.recoverHead(headers[9].Hash)
+	require.ErrorIs(t, err, errResyncRequired)
+
+	requireChainUnchanged(t, b, headers)
+
+	// the pivot block is a valid head once its state is synced
+	delete(checker.missing, headers[5].StateRoot)
+
+	header, _, err := b.recoverHead(headers[9].Hash)
+	require.NoError(t, err)
+	require.Equal(t, headers[5].Hash, header.Hash)
+}
+
+func TestBlockchain_RecoverHead_DeletesLookups(t *testing.T) {
+	t.Parallel()
+
+	headers := newRecoveryTestHeaders(10)
+	b := NewTestBlockchain(t, headers)
+
+	checker := &mockStateChecker{missing: map[types.Hash]bool{headers[9].StateRoot: true}}
+	b.SetStateChecker(checker)
+
+	// the transaction of the head is indexed
+	tx := types.NewTx(types.NewLegacyTx(types.WithNonce(1), types.WithGasPrice(big.NewInt(1))))
+	tx.ComputeHash()
+
+	w := b.db.NewWriter()
+	w.PutBody(9, headers[9].Hash, &types.Body{Transactions: []*types.Transaction{tx}})
+	w.PutTxLookup(tx.Hash(), 9)
+	require.NoError(t, w.WriteBatch())
+
+	header, _, err := b.recoverHead(headers[9].Hash)
+	require.NoError(t, err)
+	require.Equal(t, headers[8].Hash, header.Hash)
+
+	_, ok := b.db.ReadCanonicalHash(9)
+	require.False(t, ok)
+
+	_, err = b.db.ReadBlockLookup(headers[9].Hash)
+	require.ErrorIs(t, err, storagev2.ErrNotFound)
+
+	_, err = b.db.ReadTxLookup(tx.Hash())
+	require.ErrorIs(t, err, storagev2.ErrNotFound)
+
+	// the lookups of the new head are kept
+	n, err := b.db.ReadBlockLookup(headers[8].Hash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), n)
+}
+
+func TestBlockchain_RecoverHead_HistoryTail(t *testing.T) {
+	t.Parallel()
+
+	b := NewTestBlockchain(t, nil)
+	headers := NewTestHeadersWithSeed(b.Header(), 10, 0)
+
+	require.NoError(t, b.WriteCheckpointBlock(HeadersToBlocks(headers)[9], "test"))
+	require.Equal(t, uint64(9), b.HistoryTail())
+
+	// the blocks below the tail can't be checked, so the walk stops at the checkpoint
+	b.SetStateChecker(&mockStateChecker{missing: map[types.Hash]bool{headers[9].StateRoot: true}})
+
+	_, _, err := b.recoverHead(headers[9].Hash)
+	require.ErrorIs(t, err, errResyncRequired)
+	require.ErrorIs(t, err, errStateMissing)
+
+	// nothing is deleted
+	hash, ok := b.db.ReadCanonicalHash(9)
+	require.True(t, ok)
+	require.Equal(t, headers[9].Hash, hash)
+}
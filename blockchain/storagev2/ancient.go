@@ -0,0 +1,172 @@
+package storagev2
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// ancientTables are the tables moved to the ancient store, the items are numbered by the block number
+var ancientTables = map[uint8]string{
+	CANONICAL:  "hashes",
+	HEADER:     "headers",
+	BODY:       "bodies",
+	RECEIPTS:   "receipts",
+	DIFFICULTY: "diffs",
+}
+
+// AncientStore is an append-only store of the finalized canonical blocks,
+// which keeps them out of the main database
+type AncientStore struct {
+	lock   sync.Mutex // serializes the appends
+	tables map[uint8]*ancientTable
+}
+
+// OpenAncientStore opens the ancient store in the given directory, creating it if needed
+func OpenAncientStore(path string) (*AncientStore, error) {
+	return openAncientStore(path, ancientSegmentSize)
+}
+
+func openAncientStore(path string, maxSegmentSize uint32) (*AncientStore, error) {
+	if err := common.CreateDirSafe(path, 0700); err != nil {
+		return nil, err
+	}
+
+	a := &AncientStore{tables: map[uint8]*ancientTable{}}
+
+	for t, name := range ancientTables {
+		table, err := openAncientTable(path, name, maxSegmentSize)
+		if err != nil {
+			a.Close()
+
+			return nil, fmt.Errorf("failed to open ancient table %s: %w", name, err)
+		}
+
+		a.tables[t] = table
+	}
+
+	// a crash may leave the tables with different lengths, the extra items are appended again
+	if err := a.truncate(a.Blocks()); err != nil {
+		a.Close()
+
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Blocks returns the number of blocks in the ancient store
+func (a *AncientStore) Blocks() uint64 {
+	var blocks uint64 = ^uint64(0)
+
+	for _, table := range a.tables {
+		if items := table.Items(); items < blocks {
+			blocks = items
+		}
+	}
+
+	return blocks
+}
+
+// truncate drops the items following the given block number from all tables
+func (a *AncientStore) truncate(blocks uint64) error {
+	for _, table := range a.tables {
+		if err := table.truncate(blocks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Retrieve returns the raw data of the given table for the block number
+func (a *AncientStore) Retrieve(t uint8, bn uint64) ([]byte, bool, error) {
+	table, ok := a.tables[t]
+	if !ok {
+		return nil, false, nil
+	}
+
+	data, err := table.Retrieve(bn)
+	if errors.Is(err, errAncientOutOfBounds) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return data, true, nil
+}
+
+// get returns the data stored in the ancient store under the main database key
+func (a *AncientStore) get(t uint8, k []byte) ([]byte, bool, error) {
+	if _, ok := a.tables[t]; !ok || len(k) < 8 {
+		return nil, false, nil
+	}
+
+	bn := common.EncodeBytesToUint64(k[:8])
+
+	if t != CANONICAL {
+		// keys of the block data contain the block hash, which has to be the canonical one
+		hash, ok, err := a.Retrieve(CANONICAL, bn)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+
+		if types.BytesToHash(k[8:]) != types.BytesToHash(hash) {
+			return nil, false, nil
+		}
+	}
+
+	data, ok, err := a.Retrieve(t, bn)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	// an empty item is stored for missing data (e.g. genesis receipts),
+	// only the total difficulty of zero is legitimately empty
+	if len(data) == 0 && t != DIFFICULTY {
+		return nil, false, nil
+	}
+
+	return data, true, nil
+}
+
+// append appends the raw data of the block to all tables, blocks have to be appended in order
+func (a *AncientStore) append(bn uint64, data map[uint8][]byte) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for t, table := range a.tables {
+		if err := table.Append(bn, data[t]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Sync flushes the appended blocks to the disk
+func (a *AncientStore) Sync() error {
+	for _, table := range a.tables {
+		if err := table.Sync(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the ancient store
+func (a *AncientStore) Close() error {
+	var errs []error
+
+	for _, table := range a.tables {
+		errs = append(errs, table.Close())
+	}
+
+	return errors.Join(errs...)
+}
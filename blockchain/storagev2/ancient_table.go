@@ -0,0 +1,354 @@
+package storagev2
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// ancientSegmentSize is the maximum size of a single data segment file
+	ancientSegmentSize = 1 << 31
+
+	// indexEntrySize is the size of an index entry, segment number (u32) + end offset in the segment (u32)
+	indexEntrySize = 8
+)
+
+var errAncientOutOfBounds = errors.New("ancient item out of bounds")
+
+// indexEntry locates the end of an item, the item starts at the end of the previous one
+// if they are in the same segment, otherwise at the beginning of the segment
+type indexEntry struct {
+	segment uint32
+	offset  uint32
+}
+
+func (e indexEntry) marshal() []byte {
+	b := make([]byte, indexEntrySize)
+	binary.BigEndian.PutUint32(b[:4], e.segment)
+	binary.BigEndian.PutUint32(b[4:], e.offset)
+
+	return b
+}
+
+func (e *indexEntry) unmarshal(b []byte) {
+	e.segment = binary.BigEndian.Uint32(b[:4])
+	e.offset = binary.BigEndian.Uint32(b[4:])
+}
+
+// ancientTable is an append-only table of items numbered from zero.
+// The items are stored back to back in segment data files and
+// located through a fixed size entry per item in the index file
+type ancientTable struct {
+	path string
+	name string
+
+	lock sync.RWMutex
+
+	index *os.File
+	head  *os.File // the segment the items are appended to
+
+	items       uint64
+	headSegment uint32
+	headSize    uint32
+	segments    map[uint32]*os.File // open segments used for reads
+
+	maxSegmentSize uint32
+}
+
+func openAncientTable(path, name string, maxSegmentSize uint32) (*ancientTable, error) {
+	index, err := os.OpenFile(filepath.Join(path, name+".idx"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &ancientTable{
+		path:           path,
+		name:           name,
+		index:          index,
+		segments:       map[uint32]*os.File{},
+		maxSegmentSize: maxSegmentSize,
+	}
+
+	if err := t.repair(); err != nil {
+		t.Close()
+
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *ancientTable) segmentPath(segment uint32) string {
+	return filepath.Join(t.path, fmt.Sprintf("%s.%04d.dat", t.name, segment))
+}
+
+// repair drops the partially written tail of the table left by a crash, so the index
+// only refers to data present in the segments and no data follows the last item
+func (t *ancientTable) repair() error {
+	stat, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+
+	items := uint64(stat.Size()) / indexEntrySize
+
+	var last indexEntry
+
+	for ; items > 0; items-- {
+		if last, err = t.readIndex(items - 1); err != nil {
+			return err
+		}
+
+		segmentStat, statErr := os.Stat(t.segmentPath(last.segment))
+		if statErr != nil && !os.IsNotExist(statErr) {
+			return statErr
+		}
+
+		if statErr == nil && segmentStat.Size() >= int64(last.offset) {
+			break
+		}
+	}
+
+	if items == 0 {
+		last = indexEntry{}
+	}
+
+	if err := t.index.Truncate(int64(items * indexEntrySize)); err != nil {
+		return err
+	}
+
+	// remove the segments following the last item
+	for segment := last.segment + 1; ; segment++ {
+		if err := os.Remove(t.segmentPath(segment)); err != nil {
+			if os.IsNotExist(err) {
+				break
+			}
+
+			return err
+		}
+	}
+
+	head, err := os.OpenFile(t.segmentPath(last.segment), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+
+	if err := head.Truncate(int64(last.offset)); err != nil {
+		head.Close()
+
+		return err
+	}
+
+	t.head = head
+	t.headSegment = last.segment
+	t.headSize = last.offset
+	t.items = items
+	t.segments[last.segment] = head
+
+	return nil
+}
+
+func (t *ancientTable) readIndex(item uint64) (indexEntry, error) {
+	var (
+		buf   = make([]byte, indexEntrySize)
+		entry indexEntry
+	)
+
+	if _, err := t.index.ReadAt(buf, int64(item*indexEntrySize)); err != nil {
+		return entry, err
+	}
+
+	entry.unmarshal(buf)
+
+	return entry, nil
+}
+
+// Items returns the number of items in the table
+func (t *ancientTable) Items() uint64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.items
+}
+
+// Append appends the item with the given number, which has to be the next one
+func (t *ancientTable) Append(item uint64, data []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if item != t.items {
+		return fmt.Errorf("%s: appending item %d, expected %d", t.name, item, t.items)
+	}
+
+	if uint64(len(data)) > uint64(t.maxSegmentSize) {
+		return fmt.Errorf("%s: item %d of %d bytes exceeds the segment size", t.name, item, len(data))
+	}
+
+	if uint64(t.headSize)+uint64(len(data)) > uint64(t.maxSegmentSize) {
+		if err := t.nextSegment(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := t.head.WriteAt(data, int64(t.headSize)); err != nil {
+		return err
+	}
+
+	entry := indexEntry{segment: t.headSegment, offset: t.headSize + uint32(len(data))}
+	if _, err := t.index.WriteAt(entry.marshal(), int64(t.items*indexEntrySize)); err != nil {
+		return err
+	}
+
+	t.headSize = entry.offset
+	t.items++
+
+	return nil
+}
+
+// nextSegment starts appending to a new segment, it must be called with the lock held
+func (t *ancientTable) nextSegment() error {
+	if err := t.head.Sync(); err != nil {
+		return err
+	}
+
+	head, err := os.OpenFile(t.segmentPath(t.headSegment+1), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	t.headSegment++
+	t.headSize = 0
+	t.head = head
+	t.segments[t.headSegment] = head
+
+	return nil
+}
+
+// Retrieve returns the item with the given number
+func (t *ancientTable) Retrieve(item uint64) ([]byte, error) {
+	t.lock.RLock()
+
+	if item >= t.items {
+		t.lock.RUnlock()
+
+		return nil, errAncientOutOfBounds
+	}
+
+	t.lock.RUnlock()
+
+	// items are never modified once appended, so they are read without the write lock
+	end, err := t.readIndex(item)
+	if err != nil {
+		return nil, err
+	}
+
+	start := indexEntry{segment: end.segment}
+
+	if item > 0 {
+		prev, err := t.readIndex(item - 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if prev.segment == end.segment {
+			start.offset = prev.offset
+		}
+	}
+
+	segment, err := t.segment(end.segment)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, end.offset-start.offset)
+	if _, err := segment.ReadAt(data, int64(start.offset)); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (t *ancientTable) segment(segment uint32) (*os.File, error) {
+	t.lock.RLock()
+	f, ok := t.segments[segment]
+	t.lock.RUnlock()
+
+	if ok {
+		return f, nil
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if f, ok := t.segments[segment]; ok {
+		return f, nil
+	}
+
+	f, err := os.Open(t.segmentPath(segment))
+	if err != nil {
+		return nil, err
+	}
+
+	t.segments[segment] = f
+
+	return f, nil
+}
+
+// truncate drops the items following the given number
+func (t *ancientTable) truncate(items uint64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if items >= t.items {
+		return nil
+	}
+
+	for segment, f := range t.segments {
+		if err := f.Close(); err != nil {
+			return err
+		}
+
+		delete(t.segments, segment)
+	}
+
+	if err := t.index.Truncate(int64(items * indexEntrySize)); err != nil {
+		return err
+	}
+
+	// the data of the dropped items is removed the same way as after a crash
+	return t.repair()
+}
+
+// Sync flushes the appended items to the disk, the data before the index
+func (t *ancientTable) Sync() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.head.Sync(); err != nil {
+		return err
+	}
+
+	return t.index.Sync()
+}
+
+// Close closes the files of the table
+func (t *ancientTable) Close() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var errs []error
+
+	for _, f := range t.segments {
+		errs = append(errs, f.Close())
+	}
+
+	t.segments = map[uint32]*os.File{}
+
+	errs = append(errs, t.index.Close())
+
+	return errors.Join(errs...)
+}
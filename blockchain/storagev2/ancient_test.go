@@ -0,0 +1,326 @@
+package storagev2
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+// lockedMemoryDB is a minimal database safe for the concurrent use by the freezer
+type lockedMemoryDB struct {
+	lock sync.RWMutex
+	kv   [MAX_TABLES]map[string][]byte
+}
+
+func newLockedMemoryDB() *lockedMemoryDB {
+	db := &lockedMemoryDB{}
+	for i := range db.kv {
+		db.kv[i] = map[string][]byte{}
+	}
+
+	return db
+}
+
+func (db *lockedMemoryDB) Get(t uint8, k []byte) ([]byte, bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	v, ok := db.kv[t][hex.EncodeToHex(k)]
+
+	return v, ok, nil
+}
+
+func (db *lockedMemoryDB) Close() error {
+	return nil
+}
+
+func (db *lockedMemoryDB) NewBatch() Batch {
+	return &lockedMemoryBatch{db: db}
+}
+
+type lockedMemoryBatch struct {
+	db  *lockedMemoryDB
+	ops []func()
+}
+
+func (b *lockedMemoryBatch) Put(t uint8, k []byte, v []byte) {
+	b.ops = append(b.ops, func() { b.db.kv[t][hex.EncodeToHex(k)] = v })
+}
+
+func (b *lockedMemoryBatch) Delete(t uint8, k []byte) {
+	b.ops = append(b.ops, func() { delete(b.db.kv[t], hex.EncodeToHex(k)) })
+}
+
+func (b *lockedMemoryBatch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	for _, op := range b.ops {
+		op()
+	}
+
+	return nil
+}
+
+// writeAncientTestChain extends the chain with the given number of blocks
+func writeAncientTestChain(t *testing.T, s *Storage, headers []*types.Header, count uint64) []*types.Header {
+	t.Helper()
+
+	parent := types.ZeroHash
+	if len(headers) > 0 {
+		parent = headers[len(headers)-1].Hash
+	}
+
+	from := uint64(len(headers))
+
+	for i := from; i < from+count; i++ {
+		header := &types.Header{Number: i, ParentHash: parent, ExtraData: []byte{byte(i)}}
+		header.ComputeHash()
+
+		txs := generateTxs(t, int(i)*2, 2, addr1, &addr2)
+
+		w := s.NewWriter()
+		w.PutCanonicalHeader(header, big.NewInt(int64(i)))
+		w.PutBody(i, header.Hash, &types.Body{Transactions: txs})
+
+		// genesis has no receipts
+		if i > 0 {
+			w.PutReceipts(i, header.Hash, []*types.Receipt{{TxHash: txs[0].Hash(), GasUsed: i}})
+		}
+
+		require.NoError(t, w.WriteBatch())
+
+		headers = append(headers, header)
+		parent = header.Hash
+	}
+
+	return headers
+}
+
+func requireAncientTestChain(t *testing.T, s *Storage, headers []*types.Header) {
+	t.Helper()
+
+	for i, expected := range headers {
+		bn := uint64(i)
+
+		hash, ok := s.ReadCanonicalHash(bn)
+		require.True(t, ok)
+		require.Equal(t, expected.Hash, hash)
+
+		header, err := s.ReadHeader(bn, hash)
+		require.NoError(t, err)
+		require.Equal(t, expected.Hash, header.ComputeHash().Hash)
+
+		body, err := s.ReadBody(bn, hash)
+		require.NoError(t, err)
+		require.Len(t, body.Transactions, 2)
+
+		receipts, err := s.ReadReceipts(bn, hash)
+		if bn == 0 {
+			require.ErrorIs(t, err, ErrNotFound)
+		} else {
+			require.NoError(t, err)
+			require.Len(t, receipts, 1)
+			require.Equal(t, bn, receipts[0].GasUsed)
+		}
+
+		td, ok := s.ReadTotalDifficulty(bn, hash)
+		require.True(t, ok)
+		require.Equal(t, int64(i), td.Int64())
+	}
+}
+
+func TestAncientTable_AppendRetrieve(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+
+	table, err := openAncientTable(path, "test", 16)
+	require.NoError(t, err)
+
+	items := [][]byte{{1, 2, 3}, {}, {4, 5, 6, 7, 8, 9, 10}, {11, 12, 13, 14, 15, 16, 17, 18, 19}, {20}}
+	for i, item := range items {
+		require.NoError(t, table.Append(uint64(i), item))
+	}
+
+	require.Error(t, table.Append(2, []byte{1}))
+	require.Error(t, table.Append(uint64(len(items)), make([]byte, 17)))
+
+	requireItems := func(table *ancientTable) {
+		t.Helper()
+
+		require.Equal(t, uint64(len(items)), table.Items())
+
+		for i, item := range items {
+			data, err := table.Retrieve(uint64(i))
+			require.NoError(t, err)
+			require.Equal(t, item, data)
+		}
+
+		_, err := table.Retrieve(uint64(len(items)))
+		require.ErrorIs(t, err, errAncientOutOfBounds)
+	}
+
+	requireItems(table)
+
+	// the items span multiple segments
+	require.FileExists(t, filepath.Join(path, "test.0001.dat"))
+
+	require.NoError(t, table.Sync())
+	require.NoError(t, table.Close())
+
+	table, err = openAncientTable(path, "test", 16)
+	require.NoError(t, err)
+
+	requireItems(table)
+	require.NoError(t, table.Close())
+}
+
+func TestAncientTable_Repair(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+
+	table, err := openAncientTable(path, "test", 8)
+	require.NoError(t, err)
+
+	for i := uint64(0); i < 6; i++ {
+		require.NoError(t, table.Append(i, []byte{byte(i), byte(i), byte(i)}))
+	}
+
+	require.NoError(t, table.Close())
+
+	// the data of the last item and a part of the following index entry are lost
+	require.NoError(t, os.Truncate(filepath.Join(path, "test.0002.dat"), 4))
+
+	index, err := os.OpenFile(filepath.Join(path, "test.idx"), os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+
+	_, err = index.Write([]byte{0, 0, 0})
+	require.NoError(t, err)
+	require.NoError(t, index.Close())
+
+	table, err = openAncientTable(path, "test", 8)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), table.Items())
+
+	// appending continues after the last valid item
+	require.NoError(t, table.Append(5, []byte{9}))
+
+	data, err := table.Retrieve(4)
+	require.NoError(t, err)
+	require.Equal(t, []byte{4, 4, 4}, data)
+
+	data, err = table.Retrieve(5)
+	require.NoError(t, err)
+	require.Equal(t, []byte{9}, data)
+
+	require.NoError(t, table.truncate(1))
+	require.Equal(t, uint64(1), table.Items())
+	require.NoFileExists(t, filepath.Join(path, "test.0001.dat"))
+
+	require.NoError(t, table.Close())
+}
+
+func TestStorage_Freeze(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+	db := newLockedMemoryDB()
+
+	s, err := Open(hclog.NewNullLogger(), [2]Database{db, nil})
+	require.NoError(t, err)
+
+	headers := writeAncientTestChain(t, s, nil, 10)
+
+	ancient, err := openAncientStore(path, 1024)
+	require.NoError(t, err)
+
+	s.EnableAncient(ancient, 3)
+
+	_, err = s.Freeze()
+	require.NoError(t, err)
+
+	// blocks 0 - 6 are moved, the last three are kept in the database
+	require.Equal(t, uint64(7), s.AncientBlocks())
+
+	for bn := uint64(0); bn < 10; bn++ {
+		_, ok, err := db.Get(HEADER, getKey(bn, headers[bn].Hash))
+		require.NoError(t, err)
+		require.Equal(t, bn >= 7, ok, bn)
+
+		_, ok, err = db.Get(CANONICAL, common.EncodeUint64ToBytes(bn))
+		require.NoError(t, err)
+		require.Equal(t, bn >= 7, ok, bn)
+	}
+
+	requireAncientTestChain(t, s, headers)
+
+	// only the canonical block is served from the ancient store
+	_, err = s.ReadHeader(3, types.StringToHash("fork"))
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// the chain grows, the new blocks are frozen on the next pass
+	headers = writeAncientTestChain(t, s, headers, 2)
+
+	_, err = s.Freeze()
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), s.AncientBlocks())
+
+	requireAncientTestChain(t, s, headers)
+	require.NoError(t, s.Close())
+
+	// the ancient blocks are read again after a restart
+	ancient, err = openAncientStore(path, 1024)
+	require.NoError(t, err)
+
+	s, err = Open(hclog.NewNullLogger(), [2]Database{db, nil})
+	require.NoError(t, err)
+
+	s.EnableAncient(ancient, 3)
+
+	requireAncientTestChain(t, s, headers)
+	require.NoError(t, s.Close())
+}
+
+func TestStorage_FreezeDeletesLeftovers(t *testing.T) {
+	t.Parallel()
+
+	db := newLockedMemoryDB()
+
+	s, err := Open(hclog.NewNullLogger(), [2]Database{db, nil})
+	require.NoError(t, err)
+
+	headers := writeAncientTestChain(t, s, nil, 6)
+
+	ancient, err := openAncientStore(t.TempDir(), 1024)
+	require.NoError(t, err)
+
+	// the blocks are appended to the ancient store, but the node stops before they are deleted
+	hashes, err := (&Storage{db: s.db, ancient: ancient}).freezeRange(0, 3)
+	require.NoError(t, err)
+	require.Len(t, hashes, 3)
+
+	s.EnableAncient(ancient, 10)
+
+	moved, err := s.Freeze()
+	require.NoError(t, err)
+	require.Zero(t, moved)
+
+	for bn := uint64(0); bn < 6; bn++ {
+		_, ok, err := db.Get(BODY, getKey(bn, headers[bn].Hash))
+		require.NoError(t, err)
+		require.Equal(t, bn >= 3, ok, bn)
+	}
+
+	requireAncientTestChain(t, s, headers)
+	require.NoError(t, s.Close())
+}
@@ -0,0 +1,43 @@
+package storagev2
+
+import (
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// ReadCheckpoint returns the number and the hash of the lowest block of a chain synced from a trusted
+// checkpoint. The blocks before it, except the genesis, are not stored until they are backfilled
+func (s *Storage) ReadCheckpoint() (uint64, types.Hash, bool) {
+	data, ok := s.get(CHECKPOINT, CHECKPOINT_KEY)
+	if !ok || len(data) != 8+types.HashLength {
+		return 0, types.Hash{}, false
+	}
+
+	return common.EncodeBytesToUint64(data[:8]), types.BytesToHash(data[8:]), true
+}
+
+// WriteCheckpoint writes the batch together with the new lowest block of the chain synced from a checkpoint,
+// which is also the first block with its body and transaction lookups kept. A zero number removes the checkpoint once the blocks
+// following the genesis are stored
+func (s *Storage) WriteCheckpoint(w *Writer, bn uint64, hash types.Hash) error {
+	s.history.lock.Lock()
+	defer s.history.lock.Unlock()
+
+	if bn == 0 {
+		w.deleteFromTable(CHECKPOINT, CHECKPOINT_KEY)
+	} else {
+		w.putIntoTable(CHECKPOINT, CHECKPOINT_KEY, getKey(bn, hash))
+	}
+
+	w.putHistoryTail(bn)
+	w.putTxIndexTail(bn)
+
+	if err := w.WriteBatch(); err != nil {
+		return err
+	}
+
+	s.history.tail.Store(bn)
+	s.history.txTail.Store(bn)
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package storagev2
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// freezeInterval is the interval the blocks are moved to the ancient store at
+	freezeInterval = 30 * time.Second
+
+	// freezeBatchSize is the number of blocks moved to the ancient store before they are deleted from the database
+	freezeBatchSize = 10000
+)
+
+// freezer moves the canonical blocks further than the distance from the head to the ancient store
+type freezer struct {
+	lock     sync.Mutex // serializes the freeze passes
+	distance uint64
+
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+func (f *freezer) stop() {
+	if f.closeCh == nil {
+		return
+	}
+
+	close(f.closeCh)
+	<-f.doneCh
+
+	f.closeCh = nil
+}
+
+// EnableAncient makes the storage read the frozen blocks from the ancient store and periodically
+// move the canonical blocks further than the distance from the head there.
+// The ancient store is closed together with the storage
+func (s *Storage) EnableAncient(ancient *AncientStore, distance uint64) {
+	s.ancient = ancient
+	s.freezing = freezer{
+		distance: distance,
+		closeCh:  make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+
+	go s.runFreezer()
+}
+
+// ReadAncient makes the storage read the frozen blocks from the ancient store without
+// moving any new blocks there, it is used by the offline tools.
+// The ancient store is closed together with the storage
+func (s *Storage) ReadAncient(ancient *AncientStore) {
+	s.ancient = ancient
+}
+
+func (s *Storage) runFreezer() {
+	defer close(s.freezing.doneCh)
+
+	ticker := time.NewTicker(freezeInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Freeze(); err != nil {
+			s.logger.Error("failed to move blocks to the ancient store", "err", err)
+		}
+
+		select {
+		case <-s.freezing.closeCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// AncientBlocks returns the number of blocks in the ancient store
+func (s *Storage) AncientBlocks() uint64 {
+	if s.ancient == nil {
+		return 0
+	}
+
+	return s.ancient.Blocks()
+}
+
+// Freeze moves the canonical blocks further than the distance from the head to the ancient store
+// and returns the number of the moved blocks
+func (s *Storage) Freeze() (uint64, error) {
+	s.freezing.lock.Lock()
+	defer s.freezing.lock.Unlock()
+
+	if s.ancient == nil {
+		return 0, nil
+	}
+
+	if err := s.deleteFrozen(); err != nil {
+		return 0, err
+	}
+
+	// the ancient store holds the blocks without gaps, so nothing is moved before the checkpoint is backfilled
+	if _, _, ok := s.ReadCheckpoint(); ok {
+		return 0, nil
+	}
+
+	head, ok := s.ReadHeadNumber()
+	if !ok || head < s.freezing.distance {
+		return 0, nil
+	}
+
+	var (
+		start = s.ancient.Blocks()
+		limit = head - s.freezing.distance + 1
+	)
+
+	for from := start; from < limit; {
+		to := min(from+freezeBatchSize, limit)
+
+		hashes, err := s.freezeRange(from, to)
+		if err != nil {
+			return s.ancient.Blocks() - start, err
+		}
+
+		if err := s.deleteBlocks(from, hashes); err != nil {
+			return s.ancient.Blocks() - start, err
+		}
+
+		s.logger.Debug("moved blocks to the ancient store", "from", from, "to", to-1)
+
+		from = to
+	}
+
+	return s.ancient.Blocks() - start, nil
+}
+
+// freezeRange appends the canonical blocks in the range to the ancient store and returns their hashes
+func (s *Storage) freezeRange(from, to uint64) ([]types.Hash, error) {
+	hashes := make([]types.Hash, 0, to-from)
+
+	for bn := from; bn < to; bn++ {
+		hash, ok, err := s.getDB(CANONICAL).Get(CANONICAL, common.EncodeUint64ToBytes(bn))
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("canonical hash of block %d not found", bn)
+		}
+
+		data := map[uint8][]byte{CANONICAL: hash}
+		key := getKey(bn, types.BytesToHash(hash))
+
+		for _, t := range []uint8{HEADER, BODY, RECEIPTS, DIFFICULTY} {
+			v, ok, err := s.getDB(t).Get(t, key)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok && (t == HEADER || t == DIFFICULTY) {
+				return nil, fmt.Errorf("block %d (%s) is incomplete", bn, types.BytesToHash(hash))
+			}
+
+			data[t] = v
+		}
+
+		if err := s.ancient.append(bn, data); err != nil {
+			return nil, err
+		}
+
+		hashes = append(hashes, types.BytesToHash(hash))
+	}
+
+	// the blocks must be on the disk before they are deleted from the database
+	if err := s.ancient.Sync(); err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
+// deleteBlocks deletes the frozen blocks from the database, starting at the given number
+func (s *Storage) deleteBlocks(from uint64, hashes []types.Hash) error {
+	w := s.NewWriter()
+
+	for i, hash := range hashes {
+		bn := from + uint64(i)
+		key := getKey(bn, hash)
+
+		w.deleteFromTable(CANONICAL, common.EncodeUint64ToBytes(bn))
+
+		for _, t := range []uint8{HEADER, BODY, RECEIPTS, DIFFICULTY} {
+			w.deleteFromTable(t, key)
+		}
+	}
+
+	return w.WriteBatch()
+}
+
+// deleteFrozen deletes the blocks which are still in the database after they were frozen,
+// which happens if the node stops between appending them to the ancient store and deleting them
+func (s *Storage) deleteFrozen() error {
+	frozen := s.ancient.Blocks()
+	from := frozen
+
+	for from > 0 && frozen-from < freezeBatchSize {
+		if _, ok, err := s.getDB(CANONICAL).Get(CANONICAL, common.EncodeUint64ToBytes(from-1)); err != nil {
+			return err
+		} else if !ok {
+			break
+		}
+
+		from--
+	}
+
+	if from == frozen {
+		return nil
+	}
+
+	hashes := make([]types.Hash, 0, frozen-from)
+
+	for bn := from; bn < frozen; bn++ {
+		hash, _, err := s.ancient.Retrieve(CANONICAL, bn)
+		if err != nil {
+			return err
+		}
+
+		hashes = append(hashes, types.BytesToHash(hash))
+	}
+
+	return s.deleteBlocks(from, hashes)
+}
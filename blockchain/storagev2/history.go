@@ -0,0 +1,159 @@
+package storagev2
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// historyPruneInterval is the interval the expired history is pruned at
+	historyPruneInterval = time.Minute
+
+	// historyPruneBatchSize is the number of blocks pruned in a single database batch
+	historyPruneBatchSize = 1000
+)
+
+// historyPruner prunes the bodies, receipts and transaction lookups of the canonical blocks
+// further than the retention from the head, the headers and canonical hashes are kept
+type historyPruner struct {
+	lock      sync.Mutex // serializes the prune passes
+	retention uint64
+	tail      atomic.Uint64 // the first block with the body and receipts kept
+	txTail    atomic.Uint64 // the first block with the transaction lookups kept
+
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+func (p *historyPruner) stop() {
+	if p.closeCh == nil {
+		return
+	}
+
+	close(p.closeCh)
+	<-p.doneCh
+
+	p.closeCh = nil
+}
+
+// EnableHistoryExpiry makes the storage periodically prune the bodies, receipts and transaction lookups
+// of the canonical blocks further than the retention from the head
+func (s *Storage) EnableHistoryExpiry(retention uint64) {
+	s.history.retention = retention
+	s.history.closeCh = make(chan struct{})
+	s.history.doneCh = make(chan struct{})
+
+	go s.runHistoryPruner()
+}
+
+func (s *Storage) runHistoryPruner() {
+	defer close(s.history.doneCh)
+
+	ticker := time.NewTicker(historyPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.PruneHistory(); err != nil {
+			s.logger.Error("failed to prune the expired history", "err", err)
+		}
+
+		select {
+		case <-s.history.closeCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// HistoryTail returns the number of the first block whose body and receipts are kept,
+// the older blocks only have their headers stored
+func (s *Storage) HistoryTail() uint64 {
+	return s.history.tail.Load()
+}
+
+// TxIndexTail returns the number of the first block whose transaction lookups are kept,
+// the transactions of the older blocks are not found by their hashes
+func (s *Storage) TxIndexTail() uint64 {
+	return s.history.txTail.Load()
+}
+
+// PruneHistory prunes the history of the canonical blocks further than the retention from the head
+// and returns the number of the pruned blocks
+func (s *Storage) PruneHistory() (uint64, error) {
+	s.history.lock.Lock()
+	defer s.history.lock.Unlock()
+
+	if s.history.retention == 0 {
+		return 0, nil
+	}
+
+	head, ok := s.ReadHeadNumber()
+	if !ok || head < s.history.retention {
+		return 0, nil
+	}
+
+	var (
+		start = s.HistoryTail()
+		limit = head - s.history.retention + 1
+	)
+
+	for from := start; from < limit; {
+		to := min(from+historyPruneBatchSize, limit)
+
+		if err := s.pruneRange(from, to); err != nil {
+			return s.HistoryTail() - start, err
+		}
+
+		s.logger.Debug("pruned the expired history", "from", from, "to", to-1)
+
+		from = to
+	}
+
+	return s.HistoryTail() - start, nil
+}
+
+// pruneRange deletes the bodies, receipts and transaction lookups of the canonical blocks in the range
+func (s *Storage) pruneRange(from, to uint64) error {
+	w := s.NewWriter()
+
+	for bn := from; bn < to; bn++ {
+		hash, ok := s.ReadCanonicalHash(bn)
+		if !ok {
+			return fmt.Errorf("canonical hash of block %d not found", bn)
+		}
+
+		key := getKey(bn, hash)
+
+		body := &types.Body{}
+		if err := s.readRLP(BODY, key, body); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+
+		for _, tx := range body.Transactions {
+			w.deleteFromTable(TX_LOOKUP, tx.ComputeHash().Hash().Bytes())
+		}
+
+		w.deleteFromTable(BODY, key)
+		w.deleteFromTable(RECEIPTS, key)
+	}
+
+	w.putHistoryTail(to)
+	w.putTxIndexTail(to)
+
+	// the blocks are reported as pruned before they are deleted, so the readers never get a partial block
+	prevTail, prevTxTail := s.history.tail.Swap(to), s.history.txTail.Swap(to)
+
+	if err := w.WriteBatch(); err != nil {
+		s.history.tail.Store(prevTail)
+		s.history.txTail.Store(prevTxTail)
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package storagev2
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_PruneHistory(t *testing.T) {
+	t.Parallel()
+
+	db := newLockedMemoryDB()
+
+	s, err := Open(hclog.NewNullLogger(), [2]Database{db, nil})
+	require.NoError(t, err)
+
+	headers := writeAncientTestChain(t, s, nil, 10)
+
+	// index the transactions of all blocks
+	w := s.NewWriter()
+
+	for _, header := range headers {
+		body, err := s.ReadBody(header.Number, header.Hash)
+		require.NoError(t, err)
+
+		for _, tx := range body.Transactions {
+			w.PutTxLookup(tx.Hash(), header.Number)
+		}
+	}
+
+	require.NoError(t, w.WriteBatch())
+
+	// nothing is pruned without the history expiry enabled
+	pruned, err := s.PruneHistory()
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+
+	s.history.retention = 4
+
+	pruned, err = s.PruneHistory()
+	require.NoError(t, err)
+
+	// blocks 0 - 5 are pruned, the last four are kept
+	require.Equal(t, uint64(6), pruned)
+	require.Equal(t, uint64(6), s.HistoryTail())
+
+	for _, header := range headers {
+		bn := header.Number
+
+		// headers and canonical hashes are kept
+		hash, ok := s.ReadCanonicalHash(bn)
+		require.True(t, ok)
+		require.Equal(t, header.Hash, hash)
+
+		_, err := s.ReadHeader(bn, hash)
+		require.NoError(t, err)
+
+		_, err = s.ReadBody(bn, hash)
+		_, receiptsErr := s.ReadReceipts(bn, hash)
+
+		if bn < 6 {
+			require.ErrorIs(t, err, ErrHistoryPruned)
+			require.ErrorIs(t, receiptsErr, ErrHistoryPruned)
+
+			_, ok, err := db.Get(BODY, getKey(bn, hash))
+			require.NoError(t, err)
+			require.False(t, ok)
+		} else {
+			require.NoError(t, err)
+			require.NoError(t, receiptsErr)
+		}
+	}
+
+	// the transaction lookups of the pruned blocks are deleted
+	require.Equal(t, uint64(6), s.TxIndexTail())
+
+	for i, tx := range generateTxs(t, 0, 20, addr1, &addr2) {
+		bn, err := s.ReadTxLookup(tx.Hash())
+		if i/2 < 6 {
+			require.ErrorIs(t, err, ErrNotFound)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, uint64(i/2), bn)
+		}
+	}
+
+	// the tail is persisted, so the pruned history stays unavailable after a restart
+	s, err = Open(hclog.NewNullLogger(), [2]Database{db, nil})
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), s.HistoryTail())
+	require.Equal(t, uint64(6), s.TxIndexTail())
+
+	_, err = s.ReadBody(5, headers[5].Hash)
+	require.ErrorIs(t, err, ErrHistoryPruned)
+
+	require.NoError(t, s.Close())
+}
@@ -0,0 +1,85 @@
+package storagev2
+
+import (
+	"fmt"
+)
+
+// tableNames are the names of the tables reported by the inspection
+var tableNames = map[uint8]string{
+	BODY:          "Body",
+	CANONICAL:     "Canonical",
+	DIFFICULTY:    "Difficulty",
+	HEADER:        "Header",
+	RECEIPTS:      "Receipts",
+	FORK:          "Fork",
+	HEAD_HASH:     "HeadHash",
+	HEAD_NUMBER:   "HeadNumber",
+	BLOCK_LOOKUP:  "BlockLookup",
+	TX_LOOKUP:     "TxLookup",
+	HISTORY_TAIL:  "HistoryTail",
+	CHECKPOINT:    "Checkpoint",
+	TX_INDEX_TAIL: "TxIndexTail",
+	SYNC_PIVOT:    "SyncPivot",
+}
+
+// TableStats holds the number of keys of a table and their size in bytes, values included
+type TableStats struct {
+	Name string `json:"name"`
+	Keys uint64 `json:"keys"`
+	Size uint64 `json:"size"`
+}
+
+// Add accounts the key and its value to the stats
+func (s *TableStats) Add(k, v []byte) {
+	s.Keys++
+	s.Size += uint64(len(k) + len(v))
+}
+
+// Inspector is implemented by the databases which can report the stats of their tables
+type Inspector interface {
+	// Inspect iterates over all keys of the database and returns the stats of each table
+	Inspect() ([]TableStats, error)
+}
+
+// TableName returns the name of the table
+func TableName(t uint8) string {
+	if name, ok := tableNames[t]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Table%d", t)
+}
+
+// Tables returns all tables in the order they are reported by the inspection
+func Tables() []uint8 {
+	return []uint8{
+		CANONICAL, HEADER, BODY, RECEIPTS, DIFFICULTY,
+		BLOCK_LOOKUP, TX_LOOKUP, FORK, HEAD_HASH, HEAD_NUMBER, HISTORY_TAIL, CHECKPOINT, TX_INDEX_TAIL,
+		SYNC_PIVOT,
+	}
+}
+
+// Inspect returns the stats of the tables of all databases of the storage
+func (s *Storage) Inspect() ([]TableStats, error) {
+	var stats []TableStats
+
+	for i, db := range s.db {
+		if db == nil {
+			continue
+		}
+
+		inspector, ok := db.(Inspector)
+		if !ok {
+			return nil, fmt.Errorf("database %d does not support the inspection", i)
+		}
+
+		dbStats, err := inspector.Inspect()
+		if err != nil {
+			return nil, err
+		}
+
+		stats = append(stats, dbStats...)
+	}
+
+	return stats, nil
+}
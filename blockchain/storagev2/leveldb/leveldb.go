@@ -1,7 +1,10 @@
 package leveldb
 
 import (
+	"bytes"
+
 	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
@@ -12,23 +15,36 @@ type levelDB struct {
 	db *leveldb.DB
 }
 
+// singleKeyTables are the tables holding a single key
+var singleKeyTables = map[uint8][]byte{
+	storagev2.FORK:          storagev2.FORK_KEY,
+	storagev2.HEAD_HASH:     storagev2.HEAD_HASH_KEY,
+	storagev2.HEAD_NUMBER:   storagev2.HEAD_NUMBER_KEY,
+	storagev2.HISTORY_TAIL:  storagev2.HISTORY_TAIL_KEY,
+	storagev2.CHECKPOINT:    storagev2.CHECKPOINT_KEY,
+	storagev2.TX_INDEX_TAIL: storagev2.TX_INDEX_TAIL_KEY,
+	storagev2.SYNC_PIVOT:    storagev2.SYNC_PIVOT_KEY,
+}
+
 var tableMapper = map[uint8][]byte{
-	storagev2.BODY:         []byte("b"), // DB key = block number + block hash + mapper, value = block body
-	storagev2.DIFFICULTY:   []byte("d"), // DB key = block number + block hash + mapper, value = block total diffculty
-	storagev2.HEADER:       []byte("h"), // DB key = block number + block hash + mapper, value = block header
-	storagev2.RECEIPTS:     []byte("r"), // DB key = block number + block hash + mapper, value = block receipts
-	storagev2.CANONICAL:    {},          // DB key = block number + mapper, value = block hash
-	storagev2.FORK:         {},          // DB key = FORK_KEY + mapper, value = fork hashes
-	storagev2.HEAD_HASH:    {},          // DB key = HEAD_HASH_KEY + mapper, value = head hash
-	storagev2.HEAD_NUMBER:  {},          // DB key = HEAD_NUMBER_KEY + mapper, value = head number
-	storagev2.BLOCK_LOOKUP: {},          // DB key = block hash + mapper, value = block number
-	storagev2.TX_LOOKUP:    {},          // DB key = tx hash + mapper, value = block number
+	storagev2.BODY:          []byte("b"), // DB key = block number + block hash + mapper, value = block body
+	storagev2.DIFFICULTY:    []byte("d"), // DB key = block number + block hash + mapper, value = block total diffculty
+	storagev2.HEADER:        []byte("h"), // DB key = block number + block hash + mapper, value = block header
+	storagev2.RECEIPTS:      []byte("r"), // DB key = block number + block hash + mapper, value = block receipts
+	storagev2.CANONICAL:     {},          // DB key = block number + mapper, value = block hash
+	storagev2.FORK:          {},          // DB key = FORK_KEY + mapper, value = fork hashes
+	storagev2.HEAD_HASH:     {},          // DB key = HEAD_HASH_KEY + mapper, value = head hash
+	storagev2.HEAD_NUMBER:   {},          // DB key = HEAD_NUMBER_KEY + mapper, value = head number
+	storagev2.BLOCK_LOOKUP:  {},          // DB key = block hash + mapper, value = block number
+	storagev2.TX_LOOKUP:     {},          // DB key = tx hash + mapper, value = block number
+	storagev2.HISTORY_TAIL:  {},          // DB key = HISTORY_TAIL_KEY + mapper, value = first block with the history kept
+	storagev2.CHECKPOINT:    {},          // DB key = CHECKPOINT_KEY + mapper, value = lowest block number + hash
+	storagev2.TX_INDEX_TAIL: {},          // DB key = TX_INDEX_TAIL_KEY + mapper, value = first block with tx lookups
+	storagev2.SYNC_PIVOT:    {},          // DB key = SYNC_PIVOT_KEY + mapper, value = last block synced without state
 }
 
 // NewLevelDBStorage creates the new storage reference with leveldb default options
 func NewLevelDBStorage(path string, logger hclog.Logger) (*storagev2.Storage, error) {
-	var ldbs [2]storagev2.Database
-
 	// Open LevelDB storage
 	// Set default options
 	options := &opt.Options{
@@ -36,6 +52,13 @@ func NewLevelDBStorage(path string, logger hclog.Logger) (*storagev2.Storage, er
 		WriteBuffer:        128 * opt.MiB, // Two of these are used internally
 	}
 
+	return NewLevelDBStorageWithOpt(path, logger, options)
+}
+
+// NewLevelDBStorageWithOpt creates the new storage reference with leveldb with custom options
+func NewLevelDBStorageWithOpt(path string, logger hclog.Logger, options *opt.Options) (*storagev2.Storage, error) {
+	var ldbs [2]storagev2.Database
+
 	maindb, err := openLevelDBStorage(path, options)
 	if err != nil {
 		return nil, err
@@ -82,3 +105,90 @@ func (l *levelDB) Close() error {
 func (l *levelDB) NewBatch() storagev2.Batch {
 	return newBatchLevelDB(l.db)
 }
+
+// Inspect iterates over all keys and returns the stats of each table. The tables share
+// the key space, so the keys are told apart by their length and the table suffix
+func (l *levelDB) Inspect() ([]storagev2.TableStats, error) {
+	var (
+		tables = storagev2.Tables()
+		stats  = make(map[uint8]*storagev2.TableStats, len(tables))
+		other  = storagev2.TableStats{Name: "Other"}
+	)
+
+	for _, t := range tables {
+		stats[t] = &storagev2.TableStats{Name: storagev2.TableName(t)}
+	}
+
+	it := l.db.NewIterator(nil, nil)
+	defer it.Release()
+
+	for it.Next() {
+		t, ok, err := l.tableOf(it.Key(), it.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			stats[t].Add(it.Key(), it.Value())
+		} else {
+			other.Add(it.Key(), it.Value())
+		}
+	}
+
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	result := make([]storagev2.TableStats, 0, len(tables)+1)
+	for _, t := range tables {
+		result = append(result, *stats[t])
+	}
+
+	if other.Keys > 0 {
+		result = append(result, other)
+	}
+
+	return result, nil
+}
+
+// tableOf returns the table the key belongs to
+func (l *levelDB) tableOf(k, v []byte) (uint8, bool, error) {
+	switch len(k) {
+	case 8:
+		for t, key := range singleKeyTables {
+			if bytes.Equal(k, key) {
+				return t, true, nil
+			}
+		}
+
+		return storagev2.CANONICAL, true, nil
+
+	case types.HashLength:
+		if len(v) != 8 {
+			return 0, false, nil
+		}
+
+		// block and transaction lookups are both keyed by a hash, but only a block has a header
+		headerKey := append(append(make([]byte, 0, 8+len(k)), v...), k...)
+
+		_, ok, err := l.Get(storagev2.HEADER, headerKey)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if ok {
+			return storagev2.BLOCK_LOOKUP, true, nil
+		}
+
+		return storagev2.TX_LOOKUP, true, nil
+
+	case 8 + types.HashLength + 1:
+		for t, mc := range tableMapper {
+			if len(mc) == 1 && k[len(k)-1] == mc[0] {
+				return t, true, nil
+			}
+		}
+	}
+
+	return 0, false, nil
+}
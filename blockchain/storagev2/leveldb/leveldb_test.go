@@ -189,3 +189,46 @@ insertloop:
 	t.Logf("\tdb size %d MBs", size/(1*opt.MiB))
 	wg.Wait()
 }
+
+func TestInspect(t *testing.T) {
+	s, cleanUpFn, _ := newStorage(t)
+	defer cleanUpFn()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blockCount := 3
+	blockchain := make(chan *types.FullBlock, 1)
+
+	go storagev2.GenerateBlocks(t, blockCount, blockchain, ctx)
+
+	// the generated blocks may share the transactions, so only the unique hashes are counted
+	txHashes := make(map[types.Hash]struct{})
+
+	for i := 0; i < blockCount; i++ {
+		b := <-blockchain
+		writeBlock(t, s, b)
+
+		for _, tx := range b.Block.Transactions {
+			txHashes[tx.Hash()] = struct{}{}
+		}
+	}
+
+	stats, err := s.Inspect()
+	require.NoError(t, err)
+
+	keys := make(map[string]uint64, len(stats))
+	for _, st := range stats {
+		keys[st.Name] = st.Keys
+	}
+
+	require.Equal(t, uint64(blockCount), keys["Canonical"])
+	require.Equal(t, uint64(blockCount), keys["Header"])
+	require.Equal(t, uint64(blockCount), keys["Body"])
+	require.Equal(t, uint64(blockCount), keys["Receipts"])
+	require.Equal(t, uint64(blockCount), keys["BlockLookup"])
+	require.Equal(t, uint64(len(txHashes)), keys["TxLookup"])
+	require.Equal(t, uint64(1), keys["HeadHash"])
+	require.Equal(t, uint64(1), keys["HeadNumber"])
+	require.Zero(t, keys["Other"])
+}
@@ -37,6 +37,10 @@ func (b *batchMdbx) Put(t uint8, k []byte, v []byte) {
 	}
 }
 
+func (b *batchMdbx) Delete(t uint8, k []byte) {
+	b.tx.Del(b.dbi[t], k, nil)
+}
+
 func (b *batchMdbx) Write() error {
 	defer runtime.UnlockOSThread()
 
@@ -33,16 +33,20 @@ var (
 )
 
 var tableMapper = map[uint8]string{
-	storagev2.BODY:         "Body",
-	storagev2.CANONICAL:    "Canonical",
-	storagev2.DIFFICULTY:   "Difficulty",
-	storagev2.HEADER:       "Header",
-	storagev2.RECEIPTS:     "Receipts",
-	storagev2.FORK:         "Fork",
-	storagev2.HEAD_HASH:    "HeadHash",
-	storagev2.HEAD_NUMBER:  "HeadNumber",
-	storagev2.BLOCK_LOOKUP: "BlockLookup",
-	storagev2.TX_LOOKUP:    "TxLookup",
+	storagev2.BODY:          "Body",
+	storagev2.CANONICAL:     "Canonical",
+	storagev2.DIFFICULTY:    "Difficulty",
+	storagev2.HEADER:        "Header",
+	storagev2.RECEIPTS:      "Receipts",
+	storagev2.FORK:          "Fork",
+	storagev2.HEAD_HASH:     "HeadHash",
+	storagev2.HEAD_NUMBER:   "HeadNumber",
+	storagev2.BLOCK_LOOKUP:  "BlockLookup",
+	storagev2.TX_LOOKUP:     "TxLookup",
+	storagev2.HISTORY_TAIL:  "HistoryTail",
+	storagev2.CHECKPOINT:    "Checkpoint",
+	storagev2.TX_INDEX_TAIL: "TxIndexTail",
+	storagev2.SYNC_PIVOT:    "SyncPivot",
 }
 
 // NewMdbxStorage creates the new storage reference for mdbx database
@@ -188,3 +192,50 @@ func (db *MdbxDB) Close() error {
 func (db *MdbxDB) NewBatch() storagev2.Batch {
 	return newBatchMdbx(db)
 }
+
+// Inspect iterates over all tables and returns their stats
+func (db *MdbxDB) Inspect() ([]storagev2.TableStats, error) {
+	tx, err := db.env.BeginTxn(nil, mdbx.Readonly)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Abort()
+
+	tables := storagev2.Tables()
+	result := make([]storagev2.TableStats, 0, len(tables))
+
+	for _, t := range tables {
+		stats := storagev2.TableStats{Name: storagev2.TableName(t)}
+
+		if err := inspectTable(tx, db.dbi[t], &stats); err != nil {
+			return nil, err
+		}
+
+		result = append(result, stats)
+	}
+
+	return result, nil
+}
+
+func inspectTable(tx *mdbx.Txn, dbi mdbx.DBI, stats *storagev2.TableStats) error {
+	cursor, err := tx.OpenCursor(dbi)
+	if err != nil {
+		return err
+	}
+
+	defer cursor.Close()
+
+	for {
+		k, v, err := cursor.Get(nil, nil, mdbx.Next)
+		if mdbx.IsNotFound(err) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		stats.Add(k, v)
+	}
+}
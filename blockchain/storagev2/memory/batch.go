@@ -7,7 +7,13 @@ import (
 
 type batchMemory struct {
 	db          []memoryKV
-	valuesToPut [storagev2.MAX_TABLES][][2][]byte
+	valuesToPut [storagev2.MAX_TABLES][]memoryOp
+}
+
+// memoryOp is a put or a delete of the key, in the order they were added to the batch
+type memoryOp struct {
+	k, v   []byte
+	delete bool
 }
 
 func newBatchMemory(db []memoryKV) *batchMemory {
@@ -15,13 +21,21 @@ func newBatchMemory(db []memoryKV) *batchMemory {
 }
 
 func (b *batchMemory) Put(t uint8, k []byte, v []byte) {
-	b.valuesToPut[t] = append(b.valuesToPut[t], [2][]byte{k, v})
+	b.valuesToPut[t] = append(b.valuesToPut[t], memoryOp{k: k, v: v})
+}
+
+func (b *batchMemory) Delete(t uint8, k []byte) {
+	b.valuesToPut[t] = append(b.valuesToPut[t], memoryOp{k: k, delete: true})
 }
 
 func (b *batchMemory) Write() error {
 	for i, j := range b.valuesToPut {
 		for _, x := range j {
-			b.db[i].kv[hex.EncodeToHex(x[0])] = x[1]
+			if x.delete {
+				delete(b.db[i].kv, hex.EncodeToHex(x.k))
+			} else {
+				b.db[i].kv[hex.EncodeToHex(x.k)] = x.v
+			}
 		}
 	}
 
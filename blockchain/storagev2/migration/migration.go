@@ -0,0 +1,389 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// DefaultBatchSize is the default number of blocks written to the destination in a single batch
+const DefaultBatchSize = uint64(1000)
+
+var (
+	errNoHead             = errors.New("legacy storage has no head block")
+	errBatchSize          = errors.New("batch size must be greater than zero")
+	errAlreadyCompleted   = errors.New("migration has already been completed")
+	errHeadHashMismatch   = errors.New("head hash mismatch after migration")
+	errHeadNumberMismatch = errors.New("head number mismatch after migration")
+	errChainChanged       = errors.New("legacy canonical chain changed since the migration was started, " +
+		"remove the checkpoint and the destination storage and restart the migration")
+)
+
+// Config is the configuration of the migration
+type Config struct {
+	// BatchSize is the number of blocks written in a single batch,
+	// the checkpoint is updated after every batch
+	BatchSize uint64
+	// CheckpointPath is the path of the file used to persist the migration progress
+	CheckpointPath string
+}
+
+// Checkpoint is the persisted progress of the migration, used to resume it
+type Checkpoint struct {
+	// NextBlock is the number of the next canonical block to be migrated
+	NextBlock uint64 `json:"nextBlock"`
+	// HeadHash is the hash of the legacy head at the time the migration was started
+	HeadHash types.Hash `json:"headHash"`
+	// Completed is set once the migration is finished and verified
+	Completed bool `json:"completed"`
+}
+
+// Result is the summary of a migration run
+type Result struct {
+	From       uint64     `json:"from"`
+	To         uint64     `json:"to"`
+	HeadHash   types.Hash `json:"headHash"`
+	Forks      int        `json:"forks"`
+	Resumed    bool       `json:"resumed"`
+	Migrated   uint64     `json:"migrated"`
+	TxLookups  uint64     `json:"txLookups"`
+	Checkpoint string     `json:"checkpoint"`
+}
+
+// ReadCheckpoint reads the checkpoint from the given path.
+// It returns nil if no checkpoint has been written yet
+func ReadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read migration checkpoint: %w", err)
+	}
+
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to decode migration checkpoint: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+func writeCheckpoint(path string, checkpoint *Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	// written to a temporary file first, so an interrupted write never corrupts the previous checkpoint
+	tmpPath := path + ".tmp"
+
+	if err := common.SaveFileSafe(tmpPath, data, 0660); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// Migrate copies the canonical chain, its total difficulties, receipts and lookups
+// and the known forks from the legacy hash-keyed storage into the storagev2 one.
+// Progress is persisted to the checkpoint after every batch, so an interrupted
+// migration continues from the last written batch. The head of the destination is
+// only written once all the blocks have been migrated, and is verified afterwards
+func Migrate(
+	ctx context.Context,
+	logger hclog.Logger,
+	src storage.Storage,
+	dst *storagev2.Storage,
+	config Config,
+) (*Result, error) {
+	if config.BatchSize == 0 {
+		return nil, errBatchSize
+	}
+
+	headNumber, ok := src.ReadHeadNumber()
+	if !ok {
+		return nil, errNoHead
+	}
+
+	headHash, ok := src.ReadHeadHash()
+	if !ok {
+		return nil, errNoHead
+	}
+
+	checkpoint, err := ReadCheckpoint(config.CheckpointPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		To:         headNumber,
+		HeadHash:   headHash,
+		Checkpoint: config.CheckpointPath,
+	}
+
+	if checkpoint != nil {
+		if checkpoint.Completed {
+			return nil, errAlreadyCompleted
+		}
+
+		// the legacy chain may have been extended since, but the migrated blocks must still be canonical
+		if err := verifyResume(src, dst, checkpoint.NextBlock); err != nil {
+			return nil, err
+		}
+
+		if checkpoint.HeadHash != headHash {
+			logger.Info("legacy head changed since the migration was started",
+				"previous", checkpoint.HeadHash, "current", headHash)
+		}
+
+		result.Resumed = true
+	} else {
+		checkpoint = &Checkpoint{}
+	}
+
+	checkpoint.HeadHash = headHash
+	result.From = checkpoint.NextBlock
+
+	logger.Info("migrating chain data", "from", checkpoint.NextBlock, "to", headNumber)
+
+	for checkpoint.NextBlock <= headNumber {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		to := checkpoint.NextBlock + config.BatchSize - 1
+		if to > headNumber {
+			to = headNumber
+		}
+
+		writer := dst.NewWriter()
+
+		for n := checkpoint.NextBlock; n <= to; n++ {
+			txs, err := migrateCanonicalBlock(src, writer, n)
+			if err != nil {
+				return nil, err
+			}
+
+			result.Migrated++
+			result.TxLookups += txs
+		}
+
+		if err := writer.WriteBatch(); err != nil {
+			return nil, fmt.Errorf("failed to write blocks %d-%d: %w", checkpoint.NextBlock, to, err)
+		}
+
+		checkpoint.NextBlock = to + 1
+
+		if err := writeCheckpoint(config.CheckpointPath, checkpoint); err != nil {
+			return nil, fmt.Errorf("failed to write migration checkpoint: %w", err)
+		}
+
+		logger.Info("migrated blocks", "to", to, "head", headNumber)
+	}
+
+	writer := dst.NewWriter()
+
+	forks, err := migrateForks(src, writer)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Forks = len(forks)
+
+	writer.PutHeadHash(headHash)
+	writer.PutHeadNumber(headNumber)
+
+	if err := writer.WriteBatch(); err != nil {
+		return nil, fmt.Errorf("failed to write head: %w", err)
+	}
+
+	if err := verifyHead(dst, headNumber, headHash); err != nil {
+		return nil, err
+	}
+
+	checkpoint.Completed = true
+
+	if err := writeCheckpoint(config.CheckpointPath, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to write migration checkpoint: %w", err)
+	}
+
+	logger.Info("migration completed", "head", headNumber, "hash", headHash)
+
+	return result, nil
+}
+
+// migrateCanonicalBlock writes the canonical block with the given number
+// and returns the number of written transaction lookups
+func migrateCanonicalBlock(src storage.Storage, writer *storagev2.Writer, n uint64) (uint64, error) {
+	hash, ok := src.ReadCanonicalHash(n)
+	if !ok {
+		return 0, fmt.Errorf("canonical hash for block %d not found", n)
+	}
+
+	txs, err := migrateBlock(src, writer, hash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to migrate block %d: %w", n, err)
+	}
+
+	writer.PutCanonicalHash(n, hash)
+
+	return txs, nil
+}
+
+// migrateBlock writes the header, body, receipts, total difficulty
+// and lookups of the block with the given hash
+func migrateBlock(src storage.Storage, writer *storagev2.Writer, hash types.Hash) (uint64, error) {
+	header, err := src.ReadHeader(hash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	header.ComputeHash()
+
+	if header.Hash != hash {
+		return 0, fmt.Errorf("header hash mismatch, expected %s but got %s", hash, header.Hash)
+	}
+
+	diff, ok := src.ReadTotalDifficulty(hash)
+	if !ok {
+		return 0, errors.New("total difficulty not found")
+	}
+
+	writer.PutHeader(header)
+	writer.PutTotalDifficulty(header.Number, hash, diff)
+	writer.PutBlockLookup(hash, header.Number)
+
+	// the genesis block is written without a body
+	body, err := src.ReadBody(hash)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return 0, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	var txs uint64
+
+	if body != nil {
+		writer.PutBody(header.Number, hash, body)
+
+		for _, tx := range body.Transactions {
+			writer.PutTxLookup(tx.Hash(), header.Number)
+			txs++
+		}
+	}
+
+	receipts, err := src.ReadReceipts(hash)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return 0, fmt.Errorf("failed to read receipts: %w", err)
+	}
+
+	if err == nil {
+		writer.PutReceipts(header.Number, hash, receipts)
+	}
+
+	return txs, nil
+}
+
+// migrateForks writes the fork heads and their blocks
+func migrateForks(src storage.Storage, writer *storagev2.Writer) ([]types.Hash, error) {
+	forks, err := src.ReadForks()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("failed to read forks: %w", err)
+	}
+
+	migrated := make([]types.Hash, 0, len(forks))
+
+	for _, fork := range forks {
+		if _, err := migrateBlock(src, writer, fork); err != nil {
+			// forks are not part of the canonical chain, so a missing one is not fatal
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to migrate fork %s: %w", fork, err)
+		}
+
+		migrated = append(migrated, fork)
+	}
+
+	writer.PutForks(migrated)
+
+	return migrated, nil
+}
+
+// verifyResume checks that the last migrated block is still canonical in the legacy storage,
+// and that it links to the block migrated before it. The earlier blocks are covered by the parent hashes
+func verifyResume(src storage.Storage, dst *storagev2.Storage, next uint64) error {
+	if next == 0 {
+		return nil
+	}
+
+	last := next - 1
+
+	srcHash, ok := src.ReadCanonicalHash(last)
+	if !ok {
+		return fmt.Errorf("%w: block %d is no longer canonical", errChainChanged, last)
+	}
+
+	dstHash, ok := dst.ReadCanonicalHash(last)
+	if !ok || dstHash != srcHash {
+		return fmt.Errorf("%w: canonical hash of block %d is %s, migrated %s", errChainChanged, last, srcHash, dstHash)
+	}
+
+	if last == 0 {
+		return nil
+	}
+
+	header, err := dst.ReadHeader(last, dstHash)
+	if err != nil {
+		return fmt.Errorf("failed to read migrated header %d: %w", last, err)
+	}
+
+	parentHash, ok := dst.ReadCanonicalHash(last - 1)
+	if !ok || header.ParentHash != parentHash {
+		return fmt.Errorf("%w: migrated block %d doesn't link to its parent %s", errChainChanged, last, parentHash)
+	}
+
+	return nil
+}
+
+// verifyHead checks that the destination head points to the migrated legacy head
+func verifyHead(dst *storagev2.Storage, headNumber uint64, headHash types.Hash) error {
+	number, ok := dst.ReadHeadNumber()
+	if !ok || number != headNumber {
+		return errHeadNumberMismatch
+	}
+
+	hash, ok := dst.ReadHeadHash()
+	if !ok || hash != headHash {
+		return errHeadHashMismatch
+	}
+
+	canonical, ok := dst.ReadCanonicalHash(headNumber)
+	if !ok || canonical != headHash {
+		return fmt.Errorf("%w: canonical hash of block %d is %s", errHeadHashMismatch, headNumber, canonical)
+	}
+
+	header, err := dst.ReadHeader(headNumber, headHash)
+	if err != nil {
+		return fmt.Errorf("failed to read migrated head header: %w", err)
+	}
+
+	if header.ComputeHash().Hash != headHash {
+		return fmt.Errorf("%w: migrated head header hashes to %s", errHeadHashMismatch, header.Hash)
+	}
+
+	if _, ok := dst.ReadTotalDifficulty(headNumber, headHash); !ok {
+		return errors.New("total difficulty of the migrated head not found")
+	}
+
+	return nil
+}
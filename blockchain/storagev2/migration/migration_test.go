@@ -0,0 +1,274 @@
+package migration
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	legacymemory "github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/memory"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+// newLegacyChain writes a canonical chain with the given number of blocks (genesis included)
+// and a single fork block into a legacy in-memory storage
+func newLegacyChain(t *testing.T, blocks int) (storage.Storage, []*types.Header, *types.Header) {
+	t.Helper()
+
+	src, err := legacymemory.NewMemoryStorage(nil)
+	require.NoError(t, err)
+
+	headers := make([]*types.Header, blocks)
+	batch := storage.NewBatchWriter(src)
+
+	for i := 0; i < blocks; i++ {
+		header := &types.Header{Number: uint64(i), Difficulty: 1, ExtraData: []byte{}}
+		if i > 0 {
+			header.ParentHash = headers[i-1].Hash
+		}
+
+		header.ComputeHash()
+		headers[i] = header
+
+		batch.PutHeader(header)
+		batch.PutCanonicalHash(header.Number, header.Hash)
+		batch.PutTotalDifficulty(header.Hash, big.NewInt(int64(i+1)))
+
+		// genesis is written without a body and receipts
+		if i == 0 {
+			continue
+		}
+
+		tx := types.NewTx(types.NewLegacyTx(
+			types.WithNonce(uint64(i)),
+			types.WithGasPrice(big.NewInt(1)),
+			types.WithValue(big.NewInt(1)),
+			types.WithSignatureValues(big.NewInt(1), big.NewInt(2), big.NewInt(3)),
+		))
+		tx.ComputeHash()
+
+		batch.PutBody(header.Hash, &types.Body{Transactions: []*types.Transaction{tx}})
+		batch.PutTxLookup(tx.Hash(), header.Hash)
+		batch.PutReceipts(header.Hash, []*types.Receipt{{
+			CumulativeGasUsed: uint64(i),
+			TxHash:            tx.Hash(),
+			Logs:              []*types.Log{},
+		}})
+	}
+
+	fork := &types.Header{Number: 1, Difficulty: 2, ParentHash: headers[0].Hash, ExtraData: []byte{0x1}}
+	fork.ComputeHash()
+
+	batch.PutHeader(fork)
+	batch.PutTotalDifficulty(fork.Hash, big.NewInt(3))
+	batch.PutForks([]types.Hash{fork.Hash})
+
+	head := headers[blocks-1]
+	batch.PutHeadHash(head.Hash)
+	batch.PutHeadNumber(head.Number)
+
+	require.NoError(t, batch.WriteBatch())
+
+	return src, headers, fork
+}
+
+func requireMigrated(t *testing.T, src storage.Storage, dst *storagev2.Storage, headers []*types.Header) {
+	t.Helper()
+
+	for _, header := range headers {
+		hash, ok := dst.ReadCanonicalHash(header.Number)
+		require.True(t, ok)
+		require.Equal(t, header.Hash, hash)
+
+		migrated, err := dst.ReadHeader(header.Number, header.Hash)
+		require.NoError(t, err)
+		require.Equal(t, header.Hash, migrated.ComputeHash().Hash)
+
+		bn, err := dst.ReadBlockLookup(header.Hash)
+		require.NoError(t, err)
+		require.Equal(t, header.Number, bn)
+
+		diff, ok := dst.ReadTotalDifficulty(header.Number, header.Hash)
+		require.True(t, ok)
+		require.Equal(t, big.NewInt(int64(header.Number+1)), diff)
+
+		if header.Number == 0 {
+			_, err := dst.ReadBody(header.Number, header.Hash)
+			require.ErrorIs(t, err, storagev2.ErrNotFound)
+
+			continue
+		}
+
+		expectedBody, err := src.ReadBody(header.Hash)
+		require.NoError(t, err)
+
+		body, err := dst.ReadBody(header.Number, header.Hash)
+		require.NoError(t, err)
+		require.Len(t, body.Transactions, 1)
+		require.Equal(t, expectedBody.Transactions[0].Hash(), body.Transactions[0].Hash())
+
+		bn, err = dst.ReadTxLookup(body.Transactions[0].Hash())
+		require.NoError(t, err)
+		require.Equal(t, header.Number, bn)
+
+		receipts, err := dst.ReadReceipts(header.Number, header.Hash)
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		require.Equal(t, header.Number, receipts[0].CumulativeGasUsed)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	src, headers, fork := newLegacyChain(t, 10)
+
+	dst, err := memory.NewMemoryStorage()
+	require.NoError(t, err)
+
+	checkpointPath := filepath.Join(t.TempDir(), "migration.json")
+
+	result, err := Migrate(context.Background(), hclog.NewNullLogger(), src, dst, Config{
+		BatchSize:      3,
+		CheckpointPath: checkpointPath,
+	})
+	require.NoError(t, err)
+	require.False(t, result.Resumed)
+	require.Equal(t, uint64(0), result.From)
+	require.Equal(t, uint64(9), result.To)
+	require.Equal(t, uint64(10), result.Migrated)
+	require.Equal(t, uint64(9), result.TxLookups)
+	require.Equal(t, 1, result.Forks)
+
+	requireMigrated(t, src, dst, headers)
+
+	headHash, ok := dst.ReadHeadHash()
+	require.True(t, ok)
+	require.Equal(t, headers[9].Hash, headHash)
+
+	forks, err := dst.ReadForks()
+	require.NoError(t, err)
+	require.Equal(t, []types.Hash{fork.Hash}, forks)
+
+	_, err = dst.ReadHeader(fork.Number, fork.Hash)
+	require.NoError(t, err)
+
+	checkpoint, err := ReadCheckpoint(checkpointPath)
+	require.NoError(t, err)
+	require.True(t, checkpoint.Completed)
+	require.Equal(t, uint64(10), checkpoint.NextBlock)
+
+	// running it again is rejected
+	_, err = Migrate(context.Background(), hclog.NewNullLogger(), src, dst, Config{
+		BatchSize:      3,
+		CheckpointPath: checkpointPath,
+	})
+	require.ErrorIs(t, err, errAlreadyCompleted)
+}
+
+func TestMigrate_Resume(t *testing.T) {
+	t.Parallel()
+
+	src, headers, _ := newLegacyChain(t, 10)
+
+	dst, err := memory.NewMemoryStorage()
+	require.NoError(t, err)
+
+	checkpointPath := filepath.Join(t.TempDir(), "migration.json")
+
+	// simulate an interrupted migration which wrote the first 6 blocks
+	writer := dst.NewWriter()
+
+	for _, header := range headers[:6] {
+		_, err := migrateCanonicalBlock(src, writer, header.Number)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.WriteBatch())
+	require.NoError(t, writeCheckpoint(checkpointPath, &Checkpoint{NextBlock: 6, HeadHash: headers[9].Hash}))
+
+	// the head is not written until the migration is done
+	_, ok := dst.ReadHeadHash()
+	require.False(t, ok)
+
+	result, err := Migrate(context.Background(), hclog.NewNullLogger(), src, dst, Config{
+		BatchSize:      DefaultBatchSize,
+		CheckpointPath: checkpointPath,
+	})
+	require.NoError(t, err)
+	require.True(t, result.Resumed)
+	require.Equal(t, uint64(6), result.From)
+	require.Equal(t, uint64(4), result.Migrated)
+
+	requireMigrated(t, src, dst, headers)
+}
+
+func TestMigrate_ResumeChainChanged(t *testing.T) {
+	t.Parallel()
+
+	src, headers, _ := newLegacyChain(t, 10)
+
+	dst, err := memory.NewMemoryStorage()
+	require.NoError(t, err)
+
+	checkpointPath := filepath.Join(t.TempDir(), "migration.json")
+
+	// simulate an interrupted migration which wrote the first 6 blocks
+	writer := dst.NewWriter()
+
+	for _, header := range headers[:6] {
+		_, err := migrateCanonicalBlock(src, writer, header.Number)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.WriteBatch())
+	require.NoError(t, writeCheckpoint(checkpointPath, &Checkpoint{NextBlock: 6, HeadHash: headers[9].Hash}))
+
+	// the legacy chain is reorganized below the migrated blocks
+	reorged := &types.Header{Number: 5, Difficulty: 2, ParentHash: headers[4].Hash, ExtraData: []byte{0x2}}
+	reorged.ComputeHash()
+
+	batch := storage.NewBatchWriter(src)
+	batch.PutHeader(reorged)
+	batch.PutCanonicalHash(reorged.Number, reorged.Hash)
+	require.NoError(t, batch.WriteBatch())
+
+	_, err = Migrate(context.Background(), hclog.NewNullLogger(), src, dst, Config{
+		BatchSize:      DefaultBatchSize,
+		CheckpointPath: checkpointPath,
+	})
+	require.ErrorIs(t, err, errChainChanged)
+
+	// the head of the destination is not written
+	_, ok := dst.ReadHeadHash()
+	require.False(t, ok)
+}
+
+func TestMigrate_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	src, _, _ := newLegacyChain(t, 5)
+
+	dst, err := memory.NewMemoryStorage()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	checkpointPath := filepath.Join(t.TempDir(), "migration.json")
+
+	_, err = Migrate(ctx, hclog.NewNullLogger(), src, dst, Config{
+		BatchSize:      DefaultBatchSize,
+		CheckpointPath: checkpointPath,
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	checkpoint, err := ReadCheckpoint(checkpointPath)
+	require.NoError(t, err)
+	require.Nil(t, checkpoint)
+}
@@ -0,0 +1,216 @@
+package storagev2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// reindexBatchSize is the number of blocks reindexed in a single database batch
+const reindexBatchSize = 1000
+
+var (
+	ErrReindexRunning      = errors.New("lookup reindex is already running")
+	errInvalidReindexRange = errors.New("invalid reindex range")
+)
+
+// ReindexProgress is the progress of the lookup tables rebuild
+type ReindexProgress struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+	// Next is the number of the next block to be reindexed
+	Next         uint64 `json:"next"`
+	BlockLookups uint64 `json:"blockLookups"`
+	TxLookups    uint64 `json:"txLookups"`
+	// Pruned is the number of blocks whose transaction lookups were not rebuilt because their history was pruned
+	Pruned uint64 `json:"pruned"`
+}
+
+// reindexer rebuilds the lookup tables in the background
+type reindexer struct {
+	lock     sync.Mutex
+	running  bool
+	progress ReindexProgress
+	err      error
+
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+func (r *reindexer) stop() {
+	r.lock.Lock()
+	cancel, doneCh := r.cancel, r.doneCh
+	r.lock.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	<-doneCh
+}
+
+// Reindex rebuilds the block and transaction lookups of the canonical blocks in the range
+// from their canonical hashes and bodies. The range is capped at the head block, the lookups
+// are written in batches and the progress is reported after every batch
+func (s *Storage) Reindex(
+	ctx context.Context,
+	from, to uint64,
+	onProgress func(ReindexProgress),
+) (ReindexProgress, error) {
+	progress := ReindexProgress{From: from, To: to, Next: from}
+
+	head, ok := s.ReadHeadNumber()
+	if !ok {
+		return progress, errors.New("storage has no head block")
+	}
+
+	if progress.To > head {
+		progress.To = head
+	}
+
+	if from > progress.To {
+		return progress, fmt.Errorf("%w: from %d is greater than to %d", errInvalidReindexRange, from, progress.To)
+	}
+
+	for progress.Next <= progress.To {
+		if err := ctx.Err(); err != nil {
+			return progress, err
+		}
+
+		end := min(progress.Next+reindexBatchSize-1, progress.To)
+
+		if err := s.reindexRange(&progress, end); err != nil {
+			return progress, err
+		}
+
+		if onProgress != nil {
+			onProgress(progress)
+		}
+	}
+
+	return progress, nil
+}
+
+// reindexRange writes the lookups of the blocks from the next block of the progress to the given end
+func (s *Storage) reindexRange(progress *ReindexProgress, end uint64) error {
+	// the history pruner deletes the transaction lookups, so the prune passes are held off
+	s.history.lock.Lock()
+	defer s.history.lock.Unlock()
+
+	var (
+		w           = s.NewWriter()
+		next        = progress.Next
+		blocks, txs uint64
+		pruned      uint64
+	)
+
+	for bn := next; bn <= end; bn++ {
+		hash, ok := s.ReadCanonicalHash(bn)
+		if !ok {
+			return fmt.Errorf("canonical hash for block %d not found", bn)
+		}
+
+		w.PutBlockLookup(hash, bn)
+		blocks++
+
+		if bn < s.HistoryTail() {
+			pruned++
+
+			continue
+		}
+
+		body, err := s.ReadBody(bn, hash)
+		if err != nil {
+			// the genesis block is written without a body
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+
+			return fmt.Errorf("failed to read body of block %d: %w", bn, err)
+		}
+
+		for _, tx := range body.Transactions {
+			w.PutTxLookup(tx.Hash(), bn)
+			txs++
+		}
+	}
+
+	if err := w.WriteBatch(); err != nil {
+		return fmt.Errorf("failed to write lookups of blocks %d-%d: %w", next, end, err)
+	}
+
+	progress.Next = end + 1
+	progress.BlockLookups += blocks
+	progress.TxLookups += txs
+	progress.Pruned += pruned
+
+	return nil
+}
+
+// StartReindex rebuilds the lookups of the canonical blocks in the range in the background.
+// Only a single reindex can run at a time, its progress is available through ReindexStatus
+func (s *Storage) StartReindex(from, to uint64) error {
+	s.reindexing.lock.Lock()
+	defer s.reindexing.lock.Unlock()
+
+	if s.reindexing.running {
+		return ErrReindexRunning
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.reindexing.running = true
+	s.reindexing.progress = ReindexProgress{From: from, To: to, Next: from}
+	s.reindexing.err = nil
+	s.reindexing.cancel = cancel
+	s.reindexing.doneCh = make(chan struct{})
+
+	go s.runReindex(ctx, from, to)
+
+	return nil
+}
+
+func (s *Storage) runReindex(ctx context.Context, from, to uint64) {
+	defer close(s.reindexing.doneCh)
+
+	s.logger.Info("reindexing lookups", "from", from, "to", to)
+
+	progress, err := s.Reindex(ctx, from, to, func(p ReindexProgress) {
+		s.reindexing.lock.Lock()
+		s.reindexing.progress = p
+		s.reindexing.lock.Unlock()
+
+		s.logger.Info("reindex progress", "next", p.Next, "to", p.To, "txs", p.TxLookups)
+	})
+
+	s.reindexing.lock.Lock()
+	defer s.reindexing.lock.Unlock()
+
+	s.reindexing.running = false
+	s.reindexing.progress = progress
+	s.reindexing.err = err
+
+	// releases the context of the finished reindex
+	s.reindexing.cancel()
+	s.reindexing.cancel = nil
+
+	if err != nil {
+		s.logger.Error("failed to reindex lookups", "next", progress.Next, "err", err)
+
+		return
+	}
+
+	s.logger.Info("reindexed lookups", "from", progress.From, "to", progress.To,
+		"blocks", progress.BlockLookups, "txs", progress.TxLookups)
+}
+
+// ReindexStatus returns the progress of the last background reindex, whether it is still
+// running and the error it failed with
+func (s *Storage) ReindexStatus() (ReindexProgress, bool, error) {
+	s.reindexing.lock.Lock()
+	defer s.reindexing.lock.Unlock()
+
+	return s.reindexing.progress, s.reindexing.running, s.reindexing.err
+}
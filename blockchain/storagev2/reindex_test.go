@@ -0,0 +1,97 @@
+package storagev2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_Reindex(t *testing.T) {
+	t.Parallel()
+
+	s, err := Open(hclog.NewNullLogger(), [2]Database{newLockedMemoryDB(), nil})
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	headers := writeAncientTestChain(t, s, nil, 10)
+	txs := generateTxs(t, 0, 20, addr1, &addr2)
+
+	// no transaction lookups were written with the chain
+	_, err = s.ReadTxLookup(txs[4].Hash())
+	require.ErrorIs(t, err, ErrNotFound)
+
+	var reported []ReindexProgress
+
+	progress, err := s.Reindex(context.Background(), 2, 100, func(p ReindexProgress) {
+		reported = append(reported, p)
+	})
+	require.NoError(t, err)
+
+	// the range is capped at the head
+	require.Equal(t, ReindexProgress{From: 2, To: 9, Next: 10, BlockLookups: 8, TxLookups: 16}, progress)
+	require.Equal(t, []ReindexProgress{progress}, reported)
+
+	for i, tx := range txs {
+		bn, err := s.ReadTxLookup(tx.Hash())
+
+		if i/2 < 2 {
+			require.ErrorIs(t, err, ErrNotFound)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, uint64(i/2), bn)
+		}
+	}
+
+	for _, header := range headers[2:] {
+		bn, err := s.ReadBlockLookup(header.Hash)
+		require.NoError(t, err)
+		require.Equal(t, header.Number, bn)
+	}
+
+	_, err = s.Reindex(context.Background(), 10, 20, nil)
+	require.ErrorIs(t, err, errInvalidReindexRange)
+}
+
+func TestStorage_StartReindex(t *testing.T) {
+	t.Parallel()
+
+	s, err := Open(hclog.NewNullLogger(), [2]Database{newLockedMemoryDB(), nil})
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	writeAncientTestChain(t, s, nil, 5)
+
+	require.NoError(t, s.StartReindex(0, 4))
+
+	require.Eventually(t, func() bool {
+		_, running, _ := s.ReindexStatus()
+
+		return !running
+	}, 5*time.Second, 10*time.Millisecond)
+
+	progress, _, err := s.ReindexStatus()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), progress.Next)
+	require.Equal(t, uint64(10), progress.TxLookups)
+
+	bn, err := s.ReadTxLookup(generateTxs(t, 0, 10, addr1, &addr2)[9].Hash())
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), bn)
+
+	// a failed reindex reports its error
+	require.NoError(t, s.StartReindex(6, 4))
+
+	require.Eventually(t, func() bool {
+		_, running, _ := s.ReindexStatus()
+
+		return !running
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, _, err = s.ReindexStatus()
+	require.ErrorIs(t, err, errInvalidReindexRange)
+}
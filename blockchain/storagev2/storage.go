@@ -17,11 +17,18 @@ type Database interface {
 type Batch interface {
 	Write() error
 	Put(t uint8, k []byte, v []byte)
+	Delete(t uint8, k []byte)
 }
 
 type Storage struct {
 	logger hclog.Logger
 	db     [2]Database
+
+	ancient  *AncientStore
+	freezing freezer
+	history  historyPruner
+
+	reindexing reindexer
 }
 
 type Writer struct {
@@ -41,11 +48,15 @@ const (
 //
 //nolint:stylecheck // needed because linter considers _ in name as an error
 const (
-	FORK         = uint8(0) | LOOKUP_INDEX
-	HEAD_HASH    = uint8(2) | LOOKUP_INDEX
-	HEAD_NUMBER  = uint8(4) | LOOKUP_INDEX
-	BLOCK_LOOKUP = uint8(6) | LOOKUP_INDEX
-	TX_LOOKUP    = uint8(8) | LOOKUP_INDEX
+	FORK          = uint8(0) | LOOKUP_INDEX
+	HEAD_HASH     = uint8(2) | LOOKUP_INDEX
+	HEAD_NUMBER   = uint8(4) | LOOKUP_INDEX
+	BLOCK_LOOKUP  = uint8(6) | LOOKUP_INDEX
+	TX_LOOKUP     = uint8(8) | LOOKUP_INDEX
+	HISTORY_TAIL  = uint8(10) | LOOKUP_INDEX
+	CHECKPOINT    = uint8(12) | LOOKUP_INDEX
+	TX_INDEX_TAIL = uint8(14) | LOOKUP_INDEX
+	SYNC_PIVOT    = uint8(16) | LOOKUP_INDEX
 )
 
 //nolint:stylecheck // needed because linter considers _ in name as an error
@@ -61,19 +72,52 @@ const (
 
 //nolint:stylecheck // needed because linter considers _ in name as an error
 var (
-	FORK_KEY        = []byte("0000000f")
-	HEAD_HASH_KEY   = []byte("0000000h")
-	HEAD_NUMBER_KEY = []byte("0000000n")
+	FORK_KEY          = []byte("0000000f")
+	HEAD_HASH_KEY     = []byte("0000000h")
+	HEAD_NUMBER_KEY   = []byte("0000000n")
+	HISTORY_TAIL_KEY  = []byte("0000000t")
+	CHECKPOINT_KEY    = []byte("0000000c")
+	TX_INDEX_TAIL_KEY = []byte("0000000x")
+	SYNC_PIVOT_KEY    = []byte("0000000p")
 )
 
 var ErrNotFound = fmt.Errorf("not found")
 var ErrInvalidData = fmt.Errorf("invalid data")
+var ErrHistoryPruned = fmt.Errorf("history pruned")
 
 func Open(logger hclog.Logger, db [2]Database) (*Storage, error) {
-	return &Storage{logger: logger, db: db}, nil
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	s := &Storage{logger: logger, db: db}
+
+	// the pruned history stays unavailable even if the history expiry is disabled later
+	if tail, ok := s.readHistoryTail(); ok {
+		s.history.tail.Store(tail)
+	}
+
+	if tail, ok := s.readTxIndexTail(); ok {
+		s.history.txTail.Store(tail)
+	}
+
+	return s, nil
 }
 
 func (s *Storage) Close() error {
+	s.reindexing.stop()
+	s.history.stop()
+
+	if s.ancient != nil {
+		s.freezing.stop()
+
+		if err := s.ancient.Close(); err != nil {
+			return err
+		}
+
+		s.ancient = nil
+	}
+
 	for i, db := range s.db {
 		if db != nil {
 			err := db.Close()
@@ -81,6 +81,10 @@ func (s *Storage) ReadHeader(bn uint64, bh types.Hash) (*types.Header, error) {
 
 // ReadBody reads the body
 func (s *Storage) ReadBody(bn uint64, bh types.Hash) (*types.Body, error) {
+	if bn < s.HistoryTail() {
+		return nil, ErrHistoryPruned
+	}
+
 	body := &types.Body{}
 	if err := s.readRLP(BODY, getKey(bn, bh), body); err != nil {
 		return nil, err
@@ -103,6 +107,10 @@ func (s *Storage) ReadBody(bn uint64, bh types.Hash) (*types.Body, error) {
 
 // ReadReceipts reads the receipts
 func (s *Storage) ReadReceipts(bn uint64, bh types.Hash) ([]*types.Receipt, error) {
+	if bn < s.HistoryTail() {
+		return nil, ErrHistoryPruned
+	}
+
 	receipts := &types.Receipts{}
 	err := s.readRLP(RECEIPTS, getKey(bn, bh), receipts)
 
@@ -116,6 +124,43 @@ func (s *Storage) ReadTxLookup(hash types.Hash) (uint64, error) {
 	return s.readLookup(TX_LOOKUP, hash)
 }
 
+// HISTORY TAIL //
+
+// readHistoryTail reads the number of the first block whose body and receipts were not pruned
+func (s *Storage) readHistoryTail() (uint64, bool) {
+	data, ok := s.get(HISTORY_TAIL, HISTORY_TAIL_KEY)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
+// TX INDEX TAIL //
+
+// readTxIndexTail reads the number of the first block whose transaction lookups were not pruned
+func (s *Storage) readTxIndexTail() (uint64, bool) {
+	data, ok := s.get(TX_INDEX_TAIL, TX_INDEX_TAIL_KEY)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
+// SYNC PIVOT //
+
+// ReadSyncPivot reads the number of the last block written by the state sync without being executed,
+// the state of the blocks before it is not available
+func (s *Storage) ReadSyncPivot() (uint64, bool) {
+	data, ok := s.get(SYNC_PIVOT, SYNC_PIVOT_KEY)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
 // BLOCK LOOKUP //
 
 // ReadBlockLookup reads the block number using the block hash
@@ -137,7 +182,7 @@ func (s *Storage) readLookup(t uint8, hash types.Hash) (uint64, error) {
 }
 
 func (s *Storage) readRLP(t uint8, k []byte, raw types.RLPUnmarshaler) error {
-	data, ok, err := s.getDB(t).Get(t, k)
+	data, ok, err := s.read(t, k)
 
 	if err != nil {
 		return err
@@ -163,7 +208,7 @@ func (s *Storage) readRLP(t uint8, k []byte, raw types.RLPUnmarshaler) error {
 }
 
 func (s *Storage) get(t uint8, k []byte) ([]byte, bool) {
-	data, ok, err := s.getDB(t).Get(t, k)
+	data, ok, err := s.read(t, k)
 
 	if err != nil {
 		return nil, false
@@ -172,6 +217,17 @@ func (s *Storage) get(t uint8, k []byte) ([]byte, bool) {
 	return data, ok
 }
 
+// read reads the key from the database, falling back to the ancient store for the frozen blocks.
+// Blocks are appended to the ancient store before they are deleted from the database
+func (s *Storage) read(t uint8, k []byte) ([]byte, bool, error) {
+	data, ok, err := s.getDB(t).Get(t, k)
+	if err != nil || ok || s.ancient == nil {
+		return data, ok, err
+	}
+
+	return s.ancient.get(t, k)
+}
+
 func (s *Storage) getDB(t uint8) Database {
 	i := getIndex(t)
 	if s.db[i] != nil {
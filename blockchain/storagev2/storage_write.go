@@ -29,6 +29,18 @@ func (w *Writer) PutTxLookup(hash types.Hash, bn uint64) {
 	w.putIntoTable(TX_LOOKUP, hash.Bytes(), common.EncodeUint64ToBytes(bn))
 }
 
+func (w *Writer) putHistoryTail(bn uint64) {
+	w.putIntoTable(HISTORY_TAIL, HISTORY_TAIL_KEY, common.EncodeUint64ToBytes(bn))
+}
+
+func (w *Writer) putTxIndexTail(bn uint64) {
+	w.putIntoTable(TX_INDEX_TAIL, TX_INDEX_TAIL_KEY, common.EncodeUint64ToBytes(bn))
+}
+
+func (w *Writer) PutSyncPivot(bn uint64) {
+	w.putIntoTable(SYNC_PIVOT, SYNC_PIVOT_KEY, common.EncodeUint64ToBytes(bn))
+}
+
 func (w *Writer) PutBlockLookup(hash types.Hash, bn uint64) {
 	w.putIntoTable(BLOCK_LOOKUP, hash.Bytes(), common.EncodeUint64ToBytes(bn))
 }
@@ -51,6 +63,18 @@ func (w *Writer) PutCanonicalHash(bn uint64, hash types.Hash) {
 	w.putIntoTable(CANONICAL, common.EncodeUint64ToBytes(bn), hash.Bytes())
 }
 
+func (w *Writer) DeleteCanonicalHash(bn uint64) {
+	w.deleteFromTable(CANONICAL, common.EncodeUint64ToBytes(bn))
+}
+
+func (w *Writer) DeleteBlockLookup(hash types.Hash) {
+	w.deleteFromTable(BLOCK_LOOKUP, hash.Bytes())
+}
+
+func (w *Writer) DeleteTxLookup(hash types.Hash) {
+	w.deleteFromTable(TX_LOOKUP, hash.Bytes())
+}
+
 func (w *Writer) PutTotalDifficulty(bn uint64, bh types.Hash, diff *big.Int) {
 	w.putIntoTable(DIFFICULTY, getKey(bn, bh), diff.Bytes())
 }
@@ -76,6 +100,10 @@ func (w *Writer) putIntoTable(t uint8, k []byte, data []byte) {
 	w.getBatch(t).Put(t, k, data)
 }
 
+func (w *Writer) deleteFromTable(t uint8, k []byte) {
+	w.getBatch(t).Delete(t, k)
+}
+
 func (w *Writer) WriteBatch() error {
 	for i, b := range w.batch {
 		if b != nil {
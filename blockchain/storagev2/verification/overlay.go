@@ -0,0 +1,58 @@
+package verification
+
+import (
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// OverlayStorage is a trie storage reading through to a base storage and keeping
+// all the writes in memory, so the base storage is never modified
+type OverlayStorage struct {
+	base  itrie.Storage
+	dirty itrie.Storage
+}
+
+var _ itrie.Storage = (*OverlayStorage)(nil)
+
+// NewOverlayStorage creates the in-memory overlay of the given trie storage
+func NewOverlayStorage(base itrie.Storage) *OverlayStorage {
+	return &OverlayStorage{
+		base:  base,
+		dirty: itrie.NewMemoryStorage(),
+	}
+}
+
+func (o *OverlayStorage) Put(k, v []byte) error {
+	return o.dirty.Put(k, v)
+}
+
+func (o *OverlayStorage) Get(k []byte) ([]byte, bool, error) {
+	if v, ok, err := o.dirty.Get(k); err != nil || ok {
+		return v, ok, err
+	}
+
+	return o.base.Get(k)
+}
+
+func (o *OverlayStorage) Batch() itrie.Batch {
+	return o.dirty.Batch()
+}
+
+func (o *OverlayStorage) SetCode(hash types.Hash, code []byte) error {
+	return o.dirty.SetCode(hash, code)
+}
+
+func (o *OverlayStorage) GetCode(hash types.Hash) ([]byte, bool) {
+	if code, ok := o.dirty.GetCode(hash); ok {
+		return code, true
+	}
+
+	return o.base.GetCode(hash)
+}
+
+// Close drops the in-memory writes, the base storage is left open
+func (o *OverlayStorage) Close() error {
+	o.dirty = itrie.NewMemoryStorage()
+
+	return nil
+}
@@ -0,0 +1,301 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/hashicorp/go-hclog"
+)
+
+// The checks reported by the verification
+const (
+	CheckCanonical = "canonical"
+	CheckBody      = "body"
+	CheckReceipts  = "receipts"
+	CheckTxLookup  = "txLookup"
+	CheckState     = "state"
+	CheckExecution = "execution"
+)
+
+// maxIssues is the number of issues after which the verification is stopped
+const maxIssues = 1000
+
+var (
+	errNoHead       = errors.New("storage has no head block")
+	errInvalidRange = errors.New("invalid block range")
+)
+
+// Config is the configuration of the verification
+type Config struct {
+	// From is the number of the first verified block
+	From uint64
+	// To is the number of the last verified block
+	To uint64
+	// State is the trie storage the state roots are looked up in, the check is skipped if not set
+	State itrie.Storage
+	// Executor re-executes the blocks to confirm their state roots, the blocks are not
+	// re-executed if not set. Its state should be backed by an OverlayStorage, so the
+	// re-execution leaves the verified trie storage untouched
+	Executor *state.Executor
+}
+
+// Issue is an inconsistency found by the verification
+type Issue struct {
+	Block uint64 `json:"block"`
+	Check string `json:"check"`
+	Error string `json:"error"`
+}
+
+// Result is the summary of a verification run
+type Result struct {
+	From     uint64  `json:"from"`
+	To       uint64  `json:"to"`
+	Verified uint64  `json:"verified"`
+	Executed uint64  `json:"executed"`
+	Pruned   uint64  `json:"pruned"`
+	Issues   []Issue `json:"issues"`
+}
+
+func (r *Result) addIssue(bn uint64, check string, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, Issue{Block: bn, Check: check, Error: fmt.Sprintf(format, args...)})
+}
+
+// Verify checks the canonical chain in the given block range. It checks that the canonical
+// hashes form a continuous chain, that the bodies and receipts match the roots of their
+// headers, that the transaction lookups point to their blocks and that the state roots are
+// available. Found inconsistencies are reported in the result, an error is only returned
+// if the verification could not be run
+func Verify(
+	ctx context.Context,
+	logger hclog.Logger,
+	s *storagev2.Storage,
+	config Config,
+) (*Result, error) {
+	headNumber, ok := s.ReadHeadNumber()
+	if !ok {
+		return nil, errNoHead
+	}
+
+	if config.To > headNumber {
+		config.To = headNumber
+	}
+
+	if config.From > config.To {
+		return nil, fmt.Errorf("%w: from %d is greater than to %d", errInvalidRange, config.From, config.To)
+	}
+
+	if config.Executor != nil {
+		config.Executor.GetHash = func(*types.Header) state.GetHashByNumber {
+			return func(n uint64) types.Hash {
+				hash, _ := s.ReadCanonicalHash(n)
+
+				return hash
+			}
+		}
+	}
+
+	result := &Result{
+		From:   config.From,
+		To:     config.To,
+		Issues: []Issue{},
+	}
+
+	// the parent of the first block is only used for the continuity check and the re-execution
+	var parent *types.Header
+
+	if config.From > 0 {
+		parent, _ = readCanonicalHeader(s, config.From-1)
+	}
+
+	logger.Info("verifying chain data", "from", config.From, "to", config.To)
+
+	for n := config.From; n <= config.To; n++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		parent = verifyBlock(logger, s, config, result, n, parent)
+		result.Verified++
+
+		if len(result.Issues) >= maxIssues {
+			logger.Warn("too many issues found, verification stopped", "block", n)
+
+			break
+		}
+
+		if n%10000 == 0 {
+			logger.Info("verified blocks", "to", n, "issues", len(result.Issues))
+		}
+	}
+
+	logger.Info("verification completed", "verified", result.Verified, "issues", len(result.Issues))
+
+	return result, nil
+}
+
+// verifyBlock verifies the canonical block with the given number and returns its header,
+// or nil if it could not be read
+func verifyBlock(
+	logger hclog.Logger,
+	s *storagev2.Storage,
+	config Config,
+	result *Result,
+	n uint64,
+	parent *types.Header,
+) *types.Header {
+	header, err := readCanonicalHeader(s, n)
+	if err != nil {
+		result.addIssue(n, CheckCanonical, "%v", err)
+
+		return nil
+	}
+
+	if n > 0 {
+		if parent == nil {
+			result.addIssue(n, CheckCanonical, "parent block %d not found", n-1)
+		} else if header.ParentHash != parent.Hash {
+			result.addIssue(n, CheckCanonical, "parent hash %s does not match canonical hash %s of block %d",
+				header.ParentHash, parent.Hash, n-1)
+		}
+	}
+
+	if config.State != nil && header.StateRoot != types.EmptyRootHash {
+		if _, ok, err := config.State.Get(header.StateRoot.Bytes()); err != nil {
+			result.addIssue(n, CheckState, "failed to read state root %s: %v", header.StateRoot, err)
+		} else if !ok {
+			result.addIssue(n, CheckState, "state root %s not found", header.StateRoot)
+		}
+	}
+
+	if n < s.HistoryTail() {
+		// the body and receipts of the block were pruned, only its header can be verified
+		result.Pruned++
+
+		return header
+	}
+
+	body, ok := verifyBody(s, result, header)
+	if !ok {
+		return header
+	}
+
+	if config.Executor != nil && n > 0 && parent != nil {
+		if err := execute(config.Executor, header, parent, body); err != nil {
+			logger.Debug("block re-execution failed", "block", n, "err", err)
+			result.addIssue(n, CheckExecution, "%v", err)
+		}
+
+		result.Executed++
+	}
+
+	return header
+}
+
+// readCanonicalHeader reads the header of the canonical block with the given number
+// and checks that it hashes to the canonical hash
+func readCanonicalHeader(s *storagev2.Storage, n uint64) (*types.Header, error) {
+	hash, ok := s.ReadCanonicalHash(n)
+	if !ok {
+		return nil, errors.New("canonical hash not found")
+	}
+
+	header, err := s.ReadHeader(n, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header %s: %w", hash, err)
+	}
+
+	if header.Number != n {
+		return nil, fmt.Errorf("header %s has number %d", hash, header.Number)
+	}
+
+	if header.ComputeHash().Hash != hash {
+		return nil, fmt.Errorf("header hashes to %s instead of canonical hash %s", header.Hash, hash)
+	}
+
+	return header, nil
+}
+
+// verifyBody checks the body, receipts and transaction lookups of the block against its header.
+// It returns the body and whether it can be re-executed
+func verifyBody(s *storagev2.Storage, result *Result, header *types.Header) (*types.Body, bool) {
+	n := header.Number
+
+	body, err := s.ReadBody(n, header.Hash)
+	if err != nil {
+		// the genesis block is written without a body
+		if !errors.Is(err, storagev2.ErrNotFound) || n > 0 {
+			result.addIssue(n, CheckBody, "failed to read body: %v", err)
+
+			return nil, false
+		}
+
+		body = &types.Body{}
+	}
+
+	if root := buildroot.CalculateTransactionsRoot(body.Transactions, n); root != header.TxRoot {
+		result.addIssue(n, CheckBody, "transactions root %s does not match header root %s", root, header.TxRoot)
+	}
+
+	if root := buildroot.CalculateUncleRoot(body.Uncles); root != header.Sha3Uncles {
+		result.addIssue(n, CheckBody, "uncles root %s does not match header root %s", root, header.Sha3Uncles)
+	}
+
+	receipts, err := s.ReadReceipts(n, header.Hash)
+	if err != nil && (!errors.Is(err, storagev2.ErrNotFound) || len(body.Transactions) > 0) {
+		result.addIssue(n, CheckReceipts, "failed to read receipts: %v", err)
+	} else if len(receipts) != len(body.Transactions) {
+		result.addIssue(n, CheckReceipts, "%d receipts for %d transactions", len(receipts), len(body.Transactions))
+	} else if root := buildroot.CalculateReceiptsRoot(receipts); root != header.ReceiptsRoot {
+		result.addIssue(n, CheckReceipts, "receipts root %s does not match header root %s", root, header.ReceiptsRoot)
+	}
+
+	for _, tx := range body.Transactions {
+		bn, err := s.ReadTxLookup(tx.Hash())
+		if err != nil {
+			result.addIssue(n, CheckTxLookup, "lookup of transaction %s: %v", tx.Hash(), err)
+		} else if bn != n {
+			result.addIssue(n, CheckTxLookup, "transaction %s points to block %d", tx.Hash(), bn)
+		}
+	}
+
+	return body, true
+}
+
+// execute re-executes the block on top of its parent state and checks the execution result
+func execute(executor *state.Executor, header, parent *types.Header, body *types.Body) error {
+	block := &types.Block{
+		Header:       header,
+		Transactions: body.Transactions,
+		Uncles:       body.Uncles,
+	}
+
+	txn, err := executor.ProcessBlock(parent.StateRoot, block, types.BytesToAddress(header.Miner))
+	if err != nil {
+		return fmt.Errorf("failed to process block: %w", err)
+	}
+
+	_, root, err := txn.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit the state changes: %w", err)
+	}
+
+	if root != header.StateRoot {
+		return fmt.Errorf("state root %s does not match header root %s", root, header.StateRoot)
+	}
+
+	if gas := txn.TotalGas(); gas != header.GasUsed {
+		return fmt.Errorf("gas used %d does not match header gas used %d", gas, header.GasUsed)
+	}
+
+	if root := buildroot.CalculateReceiptsRoot(txn.Receipts()); root != header.ReceiptsRoot {
+		return fmt.Errorf("receipts root %s does not match header root %s", root, header.ReceiptsRoot)
+	}
+
+	return nil
+}
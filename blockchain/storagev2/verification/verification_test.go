@@ -0,0 +1,214 @@
+package verification
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/memory"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	receiver = types.StringToAddress("2")
+	params   = &chain.Params{ChainID: 100, Forks: chain.AllForksEnabled.Copy().RemoveFork(chain.London)}
+)
+
+// newChain executes and writes a canonical chain with the given number of blocks (genesis included),
+// each block but the genesis one holds a single value transfer
+func newChain(t *testing.T, blocks int) (*storagev2.Storage, itrie.Storage, []*types.FullBlock) {
+	t.Helper()
+
+	s, err := memory.NewMemoryStorage()
+	require.NoError(t, err)
+
+	trie := itrie.NewMemoryStorage()
+	executor := state.NewExecutor(params, itrie.NewState(trie), hclog.NewNullLogger())
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash { return types.ZeroHash }
+	}
+
+	key, err := crypto.GenerateECDSAPrivateKey()
+	require.NoError(t, err)
+
+	sender := crypto.PubKeyToAddress(&key.PublicKey)
+	signer := crypto.NewEIP155Signer(uint64(params.ChainID))
+
+	genesisRoot, err := executor.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+		sender: {Balance: big.NewInt(1_000_000_000)},
+	}, types.ZeroHash)
+	require.NoError(t, err)
+
+	genesis := &types.Header{
+		Number:       0,
+		StateRoot:    genesisRoot,
+		TxRoot:       types.EmptyRootHash,
+		ReceiptsRoot: types.EmptyRootHash,
+		Sha3Uncles:   types.EmptyUncleHash,
+		GasLimit:     10_000_000,
+		ExtraData:    []byte{},
+	}
+	genesis.ComputeHash()
+
+	chainBlocks := []*types.FullBlock{{Block: &types.Block{Header: genesis}}}
+
+	for i := 1; i < blocks; i++ {
+		parent := chainBlocks[i-1].Block.Header
+
+		tx := types.NewTx(types.NewLegacyTx(
+			types.WithFrom(sender),
+			types.WithTo(&receiver),
+			types.WithNonce(uint64(i-1)),
+			types.WithGas(21000),
+			types.WithGasPrice(big.NewInt(1)),
+			types.WithValue(big.NewInt(1)),
+		))
+
+		tx, err := signer.SignTx(tx, key)
+		require.NoError(t, err)
+
+		tx.ComputeHash()
+
+		header := &types.Header{
+			ParentHash: parent.Hash,
+			Number:     uint64(i),
+			GasLimit:   parent.GasLimit,
+			Sha3Uncles: types.EmptyUncleHash,
+			ExtraData:  []byte{},
+			Timestamp:  uint64(i),
+		}
+
+		block := &types.Block{Header: header, Transactions: []*types.Transaction{tx}}
+
+		txn, err := executor.ProcessBlock(parent.StateRoot, block, types.ZeroAddress)
+		require.NoError(t, err)
+
+		_, root, err := txn.Commit()
+		require.NoError(t, err)
+
+		header.StateRoot = root
+		header.GasUsed = txn.TotalGas()
+		header.TxRoot = buildroot.CalculateTransactionsRoot(block.Transactions, header.Number)
+		header.ReceiptsRoot = buildroot.CalculateReceiptsRoot(txn.Receipts())
+		header.ComputeHash()
+
+		chainBlocks = append(chainBlocks, &types.FullBlock{Block: block, Receipts: txn.Receipts()})
+	}
+
+	w := s.NewWriter()
+
+	for _, b := range chainBlocks {
+		header := b.Block.Header
+
+		w.PutHeader(header)
+		w.PutCanonicalHash(header.Number, header.Hash)
+		w.PutBlockLookup(header.Hash, header.Number)
+
+		// genesis is written without a body and receipts
+		if header.Number == 0 {
+			continue
+		}
+
+		w.PutBody(header.Number, header.Hash, b.Block.Body())
+		w.PutReceipts(header.Number, header.Hash, b.Receipts)
+
+		for _, tx := range b.Block.Transactions {
+			w.PutTxLookup(tx.Hash(), header.Number)
+		}
+	}
+
+	head := chainBlocks[len(chainBlocks)-1].Block.Header
+
+	w.PutHeadHash(head.Hash)
+	w.PutHeadNumber(head.Number)
+	require.NoError(t, w.WriteBatch())
+
+	return s, trie, chainBlocks
+}
+
+func newExecutor(trie itrie.Storage) *state.Executor {
+	return state.NewExecutor(params, itrie.NewState(NewOverlayStorage(trie)), hclog.NewNullLogger())
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	s, trie, blocks := newChain(t, 5)
+
+	result, err := Verify(context.Background(), hclog.NewNullLogger(), s, Config{
+		From:     0,
+		To:       100,
+		State:    trie,
+		Executor: newExecutor(trie),
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Issues)
+	require.Equal(t, uint64(4), result.To)
+	require.Equal(t, uint64(5), result.Verified)
+	require.Equal(t, uint64(4), result.Executed)
+
+	// the re-execution leaves the trie storage untouched
+	_, ok, err := trie.Get(blocks[4].Block.Header.StateRoot.Bytes())
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// verifying a sub range checks the continuity with the block before it
+	result, err = Verify(context.Background(), hclog.NewNullLogger(), s, Config{From: 2, To: 3})
+	require.NoError(t, err)
+	require.Empty(t, result.Issues)
+	require.Equal(t, uint64(2), result.Verified)
+
+	_, err = Verify(context.Background(), hclog.NewNullLogger(), s, Config{From: 5, To: 10})
+	require.ErrorIs(t, err, errInvalidRange)
+}
+
+func TestVerify_Issues(t *testing.T) {
+	t.Parallel()
+
+	s, _, blocks := newChain(t, 5)
+
+	w := s.NewWriter()
+
+	// the transaction of block 2 points to block 3
+	w.PutTxLookup(blocks[2].Block.Transactions[0].Hash(), 3)
+
+	// the receipts of block 3 are missing
+	w.PutReceipts(3, blocks[3].Block.Hash(), []*types.Receipt{})
+
+	// block 4 is replaced by a block which is not a child of block 3
+	orphan := blocks[4].Block.Header.Copy()
+	orphan.ParentHash = types.StringToHash("1")
+	orphan.ComputeHash()
+
+	w.PutHeader(orphan)
+	w.PutCanonicalHash(4, orphan.Hash)
+	w.PutBody(4, orphan.Hash, blocks[4].Block.Body())
+	w.PutReceipts(4, orphan.Hash, blocks[4].Receipts)
+	require.NoError(t, w.WriteBatch())
+
+	// the state of the genesis block is missing
+	result, err := Verify(context.Background(), hclog.NewNullLogger(), s, Config{
+		From:  0,
+		To:    4,
+		State: itrie.NewMemoryStorage(),
+	})
+	require.NoError(t, err)
+
+	issues := make(map[uint64][]string)
+	for _, issue := range result.Issues {
+		issues[issue.Block] = append(issues[issue.Block], issue.Check)
+	}
+
+	require.Equal(t, []string{CheckState}, issues[0])
+	require.Equal(t, []string{CheckState, CheckTxLookup}, issues[2])
+	require.Equal(t, []string{CheckState, CheckReceipts}, issues[3])
+	require.Equal(t, []string{CheckCanonical, CheckState}, issues[4])
+}
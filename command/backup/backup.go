@@ -4,6 +4,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/spf13/cobra"
 
+	"github.com/0xPolygon/polygon-edge/command/backup/verify"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 )
 
@@ -20,6 +21,11 @@ func GetCommand() *cobra.Command {
 	setFlags(backupCmd)
 	helper.SetRequiredFlags(backupCmd, params.getRequiredFlags())
 
+	backupCmd.AddCommand(
+		// backup verify
+		verify.GetCommand(),
+	)
+
 	return backupCmd
 }
 
@@ -44,6 +50,21 @@ func setFlags(cmd *cobra.Command) {
 		"",
 		"the end height of the chain in backup",
 	)
+
+	cmd.Flags().BoolVar(
+		&params.incremental,
+		incrementalFlag,
+		false,
+		"continue the existing backup file from its last block, the file is created if it does not exist. "+
+			"the from height is only used for a new file",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.compress,
+		compressFlag,
+		true,
+		"compress the chunks of a new backup with zstd",
+	)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -11,9 +11,11 @@ import (
 )
 
 const (
-	outFlag  = "out"
-	fromFlag = "from"
-	toFlag   = "to"
+	outFlag         = "out"
+	fromFlag        = "from"
+	toFlag          = "to"
+	incrementalFlag = "incremental"
+	compressFlag    = "compress"
 )
 
 var (
@@ -28,6 +30,9 @@ var (
 type backupParams struct {
 	out string
 
+	incremental bool
+	compress    bool
+
 	fromRaw string
 	toRaw   string
 
@@ -83,9 +88,13 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 			Name:  "backup",
 			Level: hclog.LevelFromString("INFO"),
 		}),
-		p.from,
-		p.to,
-		p.out,
+		archive.BackupConfig{
+			From:        p.from,
+			To:          p.to,
+			OutPath:     p.out,
+			Incremental: p.incremental,
+			Compress:    p.compress,
+		},
 	)
 	if err != nil {
 		return err
@@ -99,8 +108,9 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 
 func (p *backupParams) getResult() command.CommandResult {
 	return &BackupResult{
-		From: p.resFrom,
-		To:   p.resTo,
-		Out:  p.out,
+		From:        p.resFrom,
+		To:          p.resTo,
+		Out:         p.out,
+		Incremental: p.incremental,
 	}
 }
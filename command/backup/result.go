@@ -11,6 +11,8 @@ type BackupResult struct {
 	From uint64 `json:"from"`
 	To   uint64 `json:"to"`
 	Out  string `json:"out"`
+	// Incremental is set when the blocks were appended to an existing backup
+	Incremental bool `json:"incremental"`
 }
 
 func (r *BackupResult) GetOutput() string {
@@ -22,6 +24,7 @@ func (r *BackupResult) GetOutput() string {
 		fmt.Sprintf("File|%s", r.Out),
 		fmt.Sprintf("From|%d", r.From),
 		fmt.Sprintf("To|%d", r.To),
+		fmt.Sprintf("Incremental|%t", r.Incremental),
 	}))
 
 	return buffer.String()
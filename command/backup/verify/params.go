@@ -0,0 +1,52 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+const (
+	fileFlag = "file"
+)
+
+var (
+	params = &verifyParams{}
+)
+
+type verifyParams struct {
+	file string
+}
+
+func (p *verifyParams) validateFlags() error {
+	if !common.FileExists(p.file) {
+		return fmt.Errorf("backup file %s does not exist", p.file)
+	}
+
+	return nil
+}
+
+func (p *verifyParams) getRequiredFlags() []string {
+	return []string{
+		fileFlag,
+	}
+}
+
+func (p *verifyParams) verify() (*VerifyResult, error) {
+	result, err := archive.VerifyBackup(p.file)
+	if err != nil {
+		return nil, fmt.Errorf("backup %s is invalid: %w", p.file, err)
+	}
+
+	return &VerifyResult{
+		File:    p.file,
+		Chunked: result.Chunked,
+		Codec:   result.Codec.String(),
+		Chunks:  result.Chunks,
+		From:    result.From,
+		To:      result.To,
+		Blocks:  result.Blocks,
+		Latest:  result.Latest.String(),
+	}, nil
+}
@@ -0,0 +1,41 @@
+package verify
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type VerifyResult struct {
+	File    string `json:"file"`
+	Chunked bool   `json:"chunked"`
+	Codec   string `json:"codec"`
+	Chunks  int    `json:"chunks"`
+	From    uint64 `json:"from"`
+	To      uint64 `json:"to"`
+	Blocks  uint64 `json:"blocks"`
+	Latest  string `json:"latest"`
+}
+
+func (r *VerifyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	format := "legacy"
+	if r.Chunked {
+		format = fmt.Sprintf("chunked (%d chunks, %s)", r.Chunks, r.Codec)
+	}
+
+	buffer.WriteString("\n[BACKUP VERIFY]\n")
+	buffer.WriteString("Backup file is valid:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.File),
+		fmt.Sprintf("Format|%s", format),
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d", r.To),
+		fmt.Sprintf("Blocks|%d", r.Blocks),
+		fmt.Sprintf("Latest hash|%s", r.Latest),
+	}))
+
+	return buffer.String()
+}
@@ -0,0 +1,49 @@
+package verify
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	verifyCmd := &cobra.Command{
+		Use: "verify",
+		Short: "Verifies the hash chain continuity of a backup file, the block bodies and the chunk checksums, " +
+			"without a running node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(verifyCmd)
+	helper.SetRequiredFlags(verifyCmd, params.getRequiredFlags())
+
+	return verifyCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.file,
+		fileFlag,
+		"",
+		"the path of the backup file",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := params.verify()
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/db/inspect"
+	"github.com/0xPolygon/polygon-edge/command/db/migrate"
+	"github.com/0xPolygon/polygon-edge/command/db/reindex"
+	"github.com/0xPolygon/polygon-edge/command/db/verify"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Top level command for the maintenance of the chain database. Only accepts subcommands.",
+	}
+
+	registerSubcommands(dbCmd)
+
+	return dbCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// db migrate-v2
+		migrate.GetCommand(),
+		// db inspect
+		inspect.GetCommand(),
+		// db verify
+		verify.GetCommand(),
+		// db reindex
+		reindex.GetCommand(),
+	)
+}
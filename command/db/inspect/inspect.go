@@ -0,0 +1,49 @@
+package inspect
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	inspectCmd := &cobra.Command{
+		Use: "inspect",
+		Short: "Reports the number of keys and their size in bytes for every table of the chain database " +
+			"and for the trie and consensus stores of a stopped node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(inspectCmd)
+
+	return inspectCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	_ = cmd.MarkFlagRequired(dataDirFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := params.inspect()
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
@@ -0,0 +1,193 @@
+package inspect
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/leveldb"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/hashicorp/go-hclog"
+	goleveldb "github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	dataDirFlag = "data-dir"
+
+	// boltOpenTimeout is the time to wait for the lock of a bolt store held by a running node
+	boltOpenTimeout = time.Second
+)
+
+// trieCategories are the kinds of the trie storage entries in the order they are reported
+var trieCategories = []string{"Node", "Code", "FlatAccount", "FlatStorage", "FlatMeta", "Preimage", "Other"}
+
+var (
+	params = &inspectParams{}
+)
+
+type inspectParams struct {
+	dataDir string
+}
+
+func (p *inspectParams) validateFlags() error {
+	if !common.DirectoryExists(p.blockchainPath()) {
+		return fmt.Errorf("chain database %s does not exist", p.blockchainPath())
+	}
+
+	return nil
+}
+
+func (p *inspectParams) blockchainPath() string {
+	return filepath.Join(p.dataDir, "blockchain")
+}
+
+func (p *inspectParams) inspect() (*InspectResult, error) {
+	result := &InspectResult{DataDir: p.dataDir}
+
+	if err := p.inspectBlockchain(result); err != nil {
+		return nil, fmt.Errorf("failed to inspect chain database: %w", err)
+	}
+
+	if err := p.inspectTrie(result); err != nil {
+		return nil, fmt.Errorf("failed to inspect trie database: %w", err)
+	}
+
+	if err := p.inspectConsensus(result); err != nil {
+		return nil, fmt.Errorf("failed to inspect consensus stores: %w", err)
+	}
+
+	return result, nil
+}
+
+func (p *inspectParams) inspectBlockchain(result *InspectResult) error {
+	s, err := leveldb.NewLevelDBStorageWithOpt(p.blockchainPath(), hclog.NewNullLogger(), &opt.Options{
+		ReadOnly:       true,
+		ErrorIfMissing: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	defer s.Close()
+
+	if ancientPath := filepath.Join(p.dataDir, "ancient"); common.DirectoryExists(ancientPath) {
+		ancient, err := storagev2.OpenAncientStore(ancientPath)
+		if err != nil {
+			return err
+		}
+
+		s.ReadAncient(ancient)
+	}
+
+	if result.Blockchain, err = s.Inspect(); err != nil {
+		return err
+	}
+
+	result.AncientBlocks = s.AncientBlocks()
+	result.HistoryTail = s.HistoryTail()
+
+	return nil
+}
+
+func (p *inspectParams) inspectTrie(result *InspectResult) error {
+	triePath := filepath.Join(p.dataDir, "trie")
+	if !common.DirectoryExists(triePath) {
+		return nil
+	}
+
+	db, err := goleveldb.OpenFile(triePath, &opt.Options{
+		ReadOnly:       true,
+		ErrorIfMissing: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	stats := make(map[string]*storagev2.TableStats, len(trieCategories))
+	for _, category := range trieCategories {
+		stats[category] = &storagev2.TableStats{Name: category}
+	}
+
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+
+	for it.Next() {
+		stats[itrie.KeyCategory(it.Key())].Add(it.Key(), it.Value())
+	}
+
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	for _, category := range trieCategories {
+		result.Trie = append(result.Trie, *stats[category])
+	}
+
+	return nil
+}
+
+// inspectConsensus reports the buckets of all bolt stores of the consensus
+func (p *inspectParams) inspectConsensus(result *InspectResult) error {
+	paths, err := filepath.Glob(filepath.Join(p.dataDir, "consensus", "polybft", "*.db"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		stats, err := inspectBoltStore(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		result.Consensus = append(result.Consensus, stats...)
+	}
+
+	return nil
+}
+
+func inspectBoltStore(path string) ([]storagev2.TableStats, error) {
+	db, err := bolt.Open(path, 0666, &bolt.Options{ReadOnly: true, Timeout: boltOpenTimeout})
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	var result []storagev2.TableStats
+
+	err = db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			stats := storagev2.TableStats{Name: fmt.Sprintf("%s/%s", filepath.Base(path), name)}
+			inspectBucket(b, &stats)
+
+			result = append(result, stats)
+
+			return nil
+		})
+	})
+
+	return result, err
+}
+
+// inspectBucket accounts the keys of the bucket and its nested buckets to the stats
+func inspectBucket(b *bolt.Bucket, stats *storagev2.TableStats) {
+	_ = b.ForEach(func(k, v []byte) error {
+		if v == nil {
+			if nested := b.Bucket(k); nested != nil {
+				inspectBucket(nested, stats)
+
+				return nil
+			}
+		}
+
+		stats.Add(k, v)
+
+		return nil
+	})
+}
@@ -0,0 +1,73 @@
+package inspect
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type InspectResult struct {
+	DataDir       string                 `json:"dataDir"`
+	Blockchain    []storagev2.TableStats `json:"blockchain"`
+	AncientBlocks uint64                 `json:"ancientBlocks"`
+	HistoryTail   uint64                 `json:"historyTail"`
+	Trie          []storagev2.TableStats `json:"trie"`
+	Consensus     []storagev2.TableStats `json:"consensus"`
+}
+
+func (r *InspectResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB INSPECT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Data directory|%s", r.DataDir),
+		fmt.Sprintf("Ancient blocks|%d", r.AncientBlocks),
+		fmt.Sprintf("History tail|%d", r.HistoryTail),
+	}))
+
+	writeTables(&buffer, "\n\n[BLOCKCHAIN]\n", r.Blockchain)
+	writeTables(&buffer, "\n\n[TRIE]\n", r.Trie)
+	writeTables(&buffer, "\n\n[CONSENSUS]\n", r.Consensus)
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+func writeTables(buffer *bytes.Buffer, title string, tables []storagev2.TableStats) {
+	buffer.WriteString(title)
+
+	rows := make([]string, 0, len(tables)+1)
+	rows = append(rows, "Table|Keys|Size")
+
+	var keys, size uint64
+
+	for _, t := range tables {
+		rows = append(rows, fmt.Sprintf("%s|%d|%s", t.Name, t.Keys, formatSize(t.Size)))
+
+		keys += t.Keys
+		size += t.Size
+	}
+
+	rows = append(rows, fmt.Sprintf("Total|%d|%s", keys, formatSize(size)))
+
+	buffer.WriteString(helper.FormatList(rows))
+}
+
+func formatSize(size uint64) string {
+	const unit = 1024
+
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.2f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
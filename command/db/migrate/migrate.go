@@ -0,0 +1,84 @@
+package migrate
+
+import (
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/migration"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use: "migrate-v2",
+		Short: "Migrates the chain data of a stopped node from the legacy hash-keyed store " +
+			"to the storagev2 store. An interrupted migration is resumed from its checkpoint",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(migrateCmd)
+
+	return migrateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.from,
+		fromFlag,
+		"",
+		"the path of the legacy leveldb chain database (usually <data-dir>/blockchain)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.to,
+		toFlag,
+		"",
+		"the path of the new storagev2 chain database",
+	)
+
+	cmd.Flags().StringVar(
+		&params.dbType,
+		dbTypeFlag,
+		levelDBType,
+		"the database type of the new chain database (leveldb or mdbx)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.batchSize,
+		batchSizeFlag,
+		migration.DefaultBatchSize,
+		"the number of blocks written per batch, the progress is checkpointed after every batch",
+	)
+
+	cmd.Flags().StringVar(
+		&params.checkpoint,
+		checkpointFlag,
+		"",
+		"the path of the migration checkpoint file (default: <to>.migration.json)",
+	)
+
+	_ = cmd.MarkFlagRequired(fromFlag)
+	_ = cmd.MarkFlagRequired(toFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := params.migrate()
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&MigrateResult{
+		Result: result,
+		From:   params.from,
+		To:     params.to,
+		DBType: params.dbType,
+	})
+}
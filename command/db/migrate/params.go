@@ -0,0 +1,136 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	legacyleveldb "github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/leveldb"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/mdbx"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/migration"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+const (
+	fromFlag       = "from"
+	toFlag         = "to"
+	dbTypeFlag     = "db-type"
+	batchSizeFlag  = "batch-size"
+	checkpointFlag = "checkpoint"
+
+	levelDBType = "leveldb"
+	mdbxType    = "mdbx"
+
+	checkpointSuffix = ".migration.json"
+)
+
+var (
+	params = &migrateParams{}
+)
+
+var (
+	errSameSourceAndDestination = errors.New("source and destination must be different paths")
+	errDestinationNotEmpty      = errors.New("destination already contains chain data and has no migration checkpoint")
+)
+
+type migrateParams struct {
+	from       string
+	to         string
+	dbType     string
+	batchSize  uint64
+	checkpoint string
+}
+
+func (p *migrateParams) validateFlags() error {
+	if p.dbType != levelDBType && p.dbType != mdbxType {
+		return fmt.Errorf("unsupported database type %q, expected %s or %s", p.dbType, levelDBType, mdbxType)
+	}
+
+	if p.batchSize == 0 {
+		return fmt.Errorf("--%s must be greater than zero", batchSizeFlag)
+	}
+
+	if filepath.Clean(p.from) == filepath.Clean(p.to) {
+		return errSameSourceAndDestination
+	}
+
+	if !common.DirectoryExists(p.from) {
+		return fmt.Errorf("legacy chain database %s does not exist", p.from)
+	}
+
+	if p.checkpoint == "" {
+		p.checkpoint = filepath.Clean(p.to) + checkpointSuffix
+	}
+
+	return nil
+}
+
+func (p *migrateParams) migrate() (*migration.Result, error) {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "migrate-v2",
+		Level: hclog.Info,
+	})
+
+	// the legacy database is only read from
+	src, err := legacyleveldb.NewLevelDBStorageWithOpt(p.from, logger, &opt.Options{
+		ReadOnly:       true,
+		ErrorIfMissing: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open legacy chain database: %w", err)
+	}
+
+	defer src.Close()
+
+	checkpoint, err := migration.ReadCheckpoint(p.checkpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	dst, err := p.openDestination(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open destination chain database: %w", err)
+	}
+
+	defer dst.Close()
+
+	// a fresh migration is only allowed into an empty store
+	if _, ok := dst.ReadHeadHash(); ok && checkpoint == nil {
+		return nil, errDestinationNotEmpty
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-common.GetTerminationSignalCh():
+			logger.Info("migration interrupted, it will be resumed from the last checkpoint on the next run")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return migration.Migrate(ctx, logger, src, dst, migration.Config{
+		BatchSize:      p.batchSize,
+		CheckpointPath: p.checkpoint,
+	})
+}
+
+func (p *migrateParams) openDestination(logger hclog.Logger) (*storagev2.Storage, error) {
+	if err := os.MkdirAll(p.to, 0750); err != nil {
+		return nil, err
+	}
+
+	if p.dbType == mdbxType {
+		return mdbx.NewMdbxStorage(p.to, logger)
+	}
+
+	return leveldb.NewLevelDBStorage(p.to, logger)
+}
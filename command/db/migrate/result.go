@@ -0,0 +1,37 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/migration"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type MigrateResult struct {
+	*migration.Result
+
+	From   string `json:"fromPath"`
+	To     string `json:"toPath"`
+	DBType string `json:"dbType"`
+}
+
+func (r *MigrateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB MIGRATE-V2]\n")
+	buffer.WriteString("Migrated chain data successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Legacy database|%s", r.From),
+		fmt.Sprintf("New database|%s (%s)", r.To, r.DBType),
+		fmt.Sprintf("Blocks|%d - %d", r.Result.From, r.Result.To),
+		fmt.Sprintf("Resumed|%t", r.Resumed),
+		fmt.Sprintf("Migrated blocks|%d", r.Migrated),
+		fmt.Sprintf("Transaction lookups|%d", r.TxLookups),
+		fmt.Sprintf("Forks|%d", r.Forks),
+		fmt.Sprintf("Head hash|%s", r.HeadHash),
+		fmt.Sprintf("Checkpoint|%s", r.Checkpoint),
+	}))
+
+	return buffer.String()
+}
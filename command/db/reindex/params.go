@@ -0,0 +1,164 @@
+package reindex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/leveldb"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	fromFlag    = "from"
+	toFlag      = "to"
+)
+
+var (
+	params = &reindexParams{}
+)
+
+type reindexParams struct {
+	dataDir string
+	from    uint64
+	to      uint64
+	toSet   bool
+}
+
+func (p *reindexParams) validateFlags() error {
+	if p.dataDir != "" && !common.DirectoryExists(p.blockchainPath()) {
+		return fmt.Errorf("chain database %s does not exist", p.blockchainPath())
+	}
+
+	if p.toSet && p.from > p.to {
+		return fmt.Errorf("--%s must not be greater than --%s", fromFlag, toFlag)
+	}
+
+	return nil
+}
+
+func (p *reindexParams) blockchainPath() string {
+	return filepath.Join(p.dataDir, "blockchain")
+}
+
+func newLogger() hclog.Logger {
+	return hclog.New(&hclog.LoggerOptions{
+		Name:  "reindex",
+		Level: hclog.Info,
+	})
+}
+
+// reindexOffline rebuilds the lookups in the chain database of a stopped node
+func (p *reindexParams) reindexOffline() (*ReindexResult, error) {
+	logger := newLogger()
+
+	s, err := leveldb.NewLevelDBStorageWithOpt(p.blockchainPath(), logger, &opt.Options{
+		ErrorIfMissing: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open chain database: %w", err)
+	}
+
+	defer s.Close()
+
+	if ancientPath := filepath.Join(p.dataDir, "ancient"); common.DirectoryExists(ancientPath) {
+		ancient, err := storagev2.OpenAncientStore(ancientPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open ancient store: %w", err)
+		}
+
+		s.ReadAncient(ancient)
+	}
+
+	to := p.to
+	if !p.toSet {
+		to = math.MaxUint64
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-common.GetTerminationSignalCh():
+			logger.Info("reindex interrupted")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	progress, err := s.Reindex(ctx, p.from, to, func(progress storagev2.ReindexProgress) {
+		logger.Info("reindex progress", "next", progress.Next, "to", progress.To, "txs", progress.TxLookups)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reindex block %d: %w", progress.Next, err)
+	}
+
+	return &ReindexResult{
+		ReindexProgress: progress,
+		DataDir:         p.dataDir,
+		Done:            true,
+	}, nil
+}
+
+// reindexOnline starts a background reindex on the running node and follows its progress
+func (p *reindexParams) reindexOnline(grpcAddress string) (*ReindexResult, error) {
+	logger := newLogger()
+
+	client, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-common.GetTerminationSignalCh():
+			// the reindex keeps running on the node
+			logger.Info("stopped following the reindex")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	stream, err := client.Reindex(ctx, &proto.ReindexRequest{From: p.from, To: p.to})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReindexResult{
+		ReindexProgress: storagev2.ReindexProgress{From: p.from, To: p.to, Next: p.from},
+	}
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) || ctx.Err() != nil {
+			return result, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		result.ReindexProgress = storagev2.ReindexProgress{
+			From:         event.From,
+			To:           event.To,
+			Next:         event.Next,
+			BlockLookups: event.BlockLookups,
+			TxLookups:    event.TxLookups,
+			Pruned:       event.Pruned,
+		}
+		result.Done = event.Done
+
+		logger.Info("reindex progress", "next", event.Next, "to", event.To, "txs", event.TxLookups)
+	}
+}
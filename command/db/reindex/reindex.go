@@ -0,0 +1,77 @@
+package reindex
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	reindexCmd := &cobra.Command{
+		Use: "reindex",
+		Short: "Rebuilds the block and transaction lookups of a block range from the canonical hashes and bodies. " +
+			"With --data-dir the lookups of a stopped node are rebuilt, " +
+			"otherwise a background reindex is started on the running node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(reindexCmd)
+
+	setFlags(reindexCmd)
+
+	return reindexCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.from,
+		fromFlag,
+		0,
+		"the number of the first reindexed block",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.to,
+		toFlag,
+		0,
+		"the number of the last reindexed block (default: the head block)",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.toSet = cmd.Flags().Changed(toFlag)
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	var (
+		result *ReindexResult
+		err    error
+	)
+
+	if params.dataDir != "" {
+		result, err = params.reindexOffline()
+	} else {
+		result, err = params.reindexOnline(helper.GetGRPCAddress(cmd))
+	}
+
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
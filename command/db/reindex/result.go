@@ -0,0 +1,44 @@
+package reindex
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ReindexResult struct {
+	storagev2.ReindexProgress
+
+	DataDir string `json:"dataDir,omitempty"`
+	// Done is false when the command stopped following a reindex still running on the node
+	Done bool `json:"done"`
+}
+
+func (r *ReindexResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	status := "done"
+	if !r.Done {
+		status = fmt.Sprintf("running, next block %d", r.Next)
+	}
+
+	rows := []string{
+		fmt.Sprintf("Blocks|%d - %d", r.From, r.To),
+		fmt.Sprintf("Status|%s", status),
+		fmt.Sprintf("Block lookups|%d", r.BlockLookups),
+		fmt.Sprintf("Transaction lookups|%d", r.TxLookups),
+		fmt.Sprintf("Pruned blocks|%d", r.Pruned),
+	}
+
+	if r.DataDir != "" {
+		rows = append([]string{fmt.Sprintf("Data directory|%s", r.DataDir)}, rows...)
+	}
+
+	buffer.WriteString("\n[DB REINDEX]\n")
+	buffer.WriteString(helper.FormatKV(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,155 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/leveldb"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/verification"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+const (
+	dataDirFlag     = "data-dir"
+	fromFlag        = "from"
+	toFlag          = "to"
+	reexecuteFlag   = "reexecute"
+	genesisPathFlag = "chain"
+)
+
+var (
+	params = &verifyParams{}
+)
+
+type verifyParams struct {
+	dataDir     string
+	from        uint64
+	to          uint64
+	toSet       bool
+	reexecute   bool
+	genesisPath string
+}
+
+func (p *verifyParams) validateFlags() error {
+	if !common.DirectoryExists(p.blockchainPath()) {
+		return fmt.Errorf("chain database %s does not exist", p.blockchainPath())
+	}
+
+	if !p.toSet {
+		p.to = math.MaxUint64
+	}
+
+	if p.from > p.to {
+		return fmt.Errorf("--%s must not be greater than --%s", fromFlag, toFlag)
+	}
+
+	if p.reexecute && !common.FileExists(p.genesisPath) {
+		return fmt.Errorf("genesis file %s does not exist", p.genesisPath)
+	}
+
+	return nil
+}
+
+func (p *verifyParams) blockchainPath() string {
+	return filepath.Join(p.dataDir, "blockchain")
+}
+
+func (p *verifyParams) verify() (*verification.Result, error) {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "verify",
+		Level: hclog.Info,
+	})
+
+	s, err := leveldb.NewLevelDBStorageWithOpt(p.blockchainPath(), logger, &opt.Options{
+		ReadOnly:       true,
+		ErrorIfMissing: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open chain database: %w", err)
+	}
+
+	defer s.Close()
+
+	if ancientPath := filepath.Join(p.dataDir, "ancient"); common.DirectoryExists(ancientPath) {
+		ancient, err := storagev2.OpenAncientStore(ancientPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open ancient store: %w", err)
+		}
+
+		s.ReadAncient(ancient)
+	}
+
+	config := verification.Config{
+		From: p.from,
+		To:   p.to,
+	}
+
+	if triePath := filepath.Join(p.dataDir, "trie"); common.DirectoryExists(triePath) {
+		trie, err := itrie.NewLevelDBStorageWithOpt(triePath, logger, &opt.Options{
+			ReadOnly:       true,
+			ErrorIfMissing: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open trie database: %w", err)
+		}
+
+		defer trie.Close()
+
+		config.State = trie
+	}
+
+	if p.reexecute {
+		if config.State == nil {
+			return nil, fmt.Errorf("trie database is required for the re-execution")
+		}
+
+		if config.Executor, err = p.newExecutor(config.State, logger); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-common.GetTerminationSignalCh():
+			logger.Info("verification interrupted")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return verification.Verify(ctx, logger, s, config)
+}
+
+// newExecutor creates the executor re-executing the blocks on an in-memory overlay of the trie storage
+func (p *verifyParams) newExecutor(trie itrie.Storage, logger hclog.Logger) (*state.Executor, error) {
+	cc, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load genesis file: %w", err)
+	}
+
+	executor := state.NewExecutor(
+		cc.Params,
+		itrie.NewState(verification.NewOverlayStorage(trie)),
+		logger.Named("executor"),
+	)
+
+	if cc.Params.GetEngine() == polybft.ConsensusName {
+		if executor.IsL1OriginatedToken, err = polybft.IsL1OriginatedTokenCheck(cc.Params); err != nil {
+			return nil, err
+		}
+	}
+
+	return executor, nil
+}
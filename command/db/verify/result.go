@@ -0,0 +1,45 @@
+package verify
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/verification"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type VerifyResult struct {
+	*verification.Result
+
+	DataDir string `json:"dataDir"`
+}
+
+func (r *VerifyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB VERIFY]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Data directory|%s", r.DataDir),
+		fmt.Sprintf("Blocks|%d - %d", r.From, r.To),
+		fmt.Sprintf("Verified blocks|%d", r.Verified),
+		fmt.Sprintf("Re-executed blocks|%d", r.Executed),
+		fmt.Sprintf("Pruned blocks|%d", r.Pruned),
+		fmt.Sprintf("Issues|%d", len(r.Issues)),
+	}))
+
+	if len(r.Issues) > 0 {
+		rows := make([]string, 0, len(r.Issues)+1)
+		rows = append(rows, "Block|Check|Error")
+
+		for _, issue := range r.Issues {
+			rows = append(rows, fmt.Sprintf("%d|%s|%s", issue.Block, issue.Check, issue.Error))
+		}
+
+		buffer.WriteString("\n\n[ISSUES]\n")
+		buffer.WriteString(helper.FormatList(rows))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
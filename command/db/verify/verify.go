@@ -0,0 +1,85 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	verifyCmd := &cobra.Command{
+		Use: "verify",
+		Short: "Verifies the canonical hash continuity, the header-body consistency, the transaction lookups " +
+			"and the state root availability for a block range of a stopped node. " +
+			"The blocks can optionally be re-executed to confirm their state roots",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(verifyCmd)
+
+	return verifyCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.from,
+		fromFlag,
+		0,
+		"the number of the first verified block",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.to,
+		toFlag,
+		0,
+		"the number of the last verified block (default: the head block)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.reexecute,
+		reexecuteFlag,
+		false,
+		"re-execute the blocks to confirm their state roots, the trie database is left untouched",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		genesisPathFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file of the chain, used for the re-execution",
+	)
+
+	_ = cmd.MarkFlagRequired(dataDirFlag)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.toSet = cmd.Flags().Changed(toFlag)
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := params.verify()
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&VerifyResult{
+		Result:  result,
+		DataDir: params.dataDir,
+	})
+}
@@ -0,0 +1,28 @@
+package evm
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command/evm/run"
+	"github.com/0xPolygon/polygon-edge/command/evm/t8n"
+)
+
+func GetCommand() *cobra.Command {
+	evmCmd := &cobra.Command{
+		Use:   "evm",
+		Short: "Top level command for running the EVM and state transitions offline. Only accepts subcommands.",
+	}
+
+	registerSubcommands(evmCmd)
+
+	return evmCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// evm t8n
+		t8n.GetCommand(),
+		// evm run
+		run.GetCommand(),
+	)
+}
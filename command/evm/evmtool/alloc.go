@@ -0,0 +1,121 @@
+package evmtool
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Alloc is the state of the accounts in the genesis alloc format
+type Alloc map[types.Address]*chain.GenesisAccount
+
+// ReadAlloc reads the alloc from the given JSON file
+func ReadAlloc(path string) (Alloc, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alloc file: %w", err)
+	}
+
+	alloc := Alloc{}
+	if err := json.Unmarshal(data, &alloc); err != nil {
+		return nil, fmt.Errorf("failed to decode alloc file: %w", err)
+	}
+
+	return alloc, nil
+}
+
+// BuildState creates an in-memory state holding the accounts of the alloc
+// and returns it along with its root
+func (a Alloc) BuildState() (state.State, types.Hash, error) {
+	s := itrie.NewState(itrie.NewMemoryStorage())
+	snap := s.NewSnapshot()
+
+	txn := state.NewTxn(snap)
+
+	for addr, account := range a {
+		txn.CreateAccount(addr)
+		txn.SetNonce(addr, account.Nonce)
+
+		if account.Balance != nil {
+			txn.SetBalance(addr, account.Balance)
+		}
+
+		if len(account.Code) != 0 {
+			txn.SetCode(addr, account.Code)
+		}
+
+		for k, v := range account.Storage {
+			txn.SetState(addr, k, v)
+		}
+	}
+
+	objs, err := txn.Commit(false)
+	if err != nil {
+		return nil, types.ZeroHash, err
+	}
+
+	_, root, err := snap.Commit(objs)
+	if err != nil {
+		return nil, types.ZeroHash, err
+	}
+
+	return s, types.BytesToHash(root), nil
+}
+
+// Apply updates the alloc with the objects committed by a state transition
+func (a Alloc) Apply(objs []*state.Object) {
+	for _, obj := range objs {
+		if obj.Deleted {
+			delete(a, obj.Address)
+
+			continue
+		}
+
+		account, ok := a[obj.Address]
+		if !ok {
+			account = &chain.GenesisAccount{}
+			a[obj.Address] = account
+		}
+
+		// an empty storage root means that the account has been (re)created
+		if obj.Root == types.EmptyRootHash || obj.Root == types.ZeroHash {
+			account.Storage = nil
+		}
+
+		account.Nonce = obj.Nonce
+		account.Balance = new(big.Int).Set(obj.Balance)
+
+		switch {
+		case obj.CodeHash == types.EmptyCodeHash || obj.CodeHash == types.ZeroHash:
+			account.Code = nil
+		case obj.DirtyCode:
+			account.Code = obj.Code
+		}
+
+		for _, entry := range obj.Storage {
+			key := types.BytesToHash(entry.Key)
+
+			if entry.Deleted {
+				delete(account.Storage, key)
+
+				continue
+			}
+
+			if account.Storage == nil {
+				account.Storage = map[types.Hash]types.Hash{}
+			}
+
+			account.Storage[key] = types.BytesToHash(entry.Val)
+		}
+
+		if len(account.Storage) == 0 {
+			account.Storage = nil
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package evmtool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestAlloc_Apply(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		addr3 = types.StringToAddress("3")
+		key1  = types.StringToHash("1")
+		key2  = types.StringToHash("2")
+	)
+
+	alloc := Alloc{
+		addr1: {
+			Balance: big.NewInt(10),
+			Code:    []byte{0x1},
+			Storage: map[types.Hash]types.Hash{key1: types.StringToHash("1"), key2: types.StringToHash("2")},
+		},
+		addr2: {
+			Balance: big.NewInt(20),
+		},
+	}
+
+	alloc.Apply([]*state.Object{
+		{
+			Address:  addr1,
+			Balance:  big.NewInt(5),
+			Nonce:    1,
+			Root:     types.StringToHash("0xabcd"),
+			CodeHash: types.StringToHash("0x1234"),
+			Storage: []*state.StorageObject{
+				{Key: key1.Bytes(), Deleted: true},
+				{Key: key2.Bytes(), Val: types.StringToHash("3").Bytes()},
+			},
+		},
+		{
+			Address: addr2,
+			Deleted: true,
+		},
+		{
+			Address:   addr3,
+			Balance:   big.NewInt(30),
+			Root:      types.EmptyRootHash,
+			CodeHash:  types.StringToHash("0x1234"),
+			DirtyCode: true,
+			Code:      []byte{0x3},
+		},
+	})
+
+	require.Equal(t, Alloc{
+		addr1: {
+			Balance: big.NewInt(5),
+			Nonce:   1,
+			Code:    []byte{0x1},
+			Storage: map[types.Hash]types.Hash{key2: types.StringToHash("3")},
+		},
+		addr3: {
+			Balance: big.NewInt(30),
+			Code:    []byte{0x3},
+		},
+	}, alloc)
+}
+
+func TestAlloc_BuildState(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+	alloc := Alloc{
+		addr: &chain.GenesisAccount{
+			Balance: big.NewInt(10),
+			Nonce:   2,
+			Code:    []byte{0x1},
+			Storage: map[types.Hash]types.Hash{types.StringToHash("1"): types.StringToHash("2")},
+		},
+	}
+
+	s, root, err := alloc.BuildState()
+	require.NoError(t, err)
+
+	snap, err := s.NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	txn := state.NewTxn(snap)
+	require.Equal(t, big.NewInt(10), txn.GetBalance(addr))
+	require.Equal(t, uint64(2), txn.GetNonce(addr))
+	require.Equal(t, []byte{0x1}, txn.GetCode(addr))
+	require.Equal(t, types.StringToHash("2"), txn.GetState(addr, types.StringToHash("1")))
+}
+
+func TestGetForks(t *testing.T) {
+	t.Parallel()
+
+	forks, err := GetForks("Istanbul")
+	require.NoError(t, err)
+
+	forksInTime := forks.At(0)
+	require.True(t, forksInTime.Istanbul)
+	require.True(t, forksInTime.Petersburg)
+	require.True(t, forksInTime.EIP155)
+	require.False(t, forksInTime.Berlin)
+	require.False(t, forksInTime.London)
+
+	_, err = GetForks("Shanghai")
+	require.ErrorIs(t, err, errUnsupportedFork)
+}
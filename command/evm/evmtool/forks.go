@@ -0,0 +1,60 @@
+package evmtool
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+)
+
+// DefaultFork is the fork used when none is specified
+const DefaultFork = "London"
+
+var errUnsupportedFork = errors.New("unsupported fork")
+
+// forkOrder lists the supported Ethereum forks in activation order,
+// along with the chain forks each of them enables on top of the previous ones
+var forkOrder = []struct {
+	name  string
+	forks []string
+}{
+	{name: "Frontier", forks: []string{chain.EIP3607}},
+	{name: "Homestead", forks: []string{chain.Homestead}},
+	{name: "EIP150", forks: []string{chain.EIP150}},
+	{name: "EIP158", forks: []string{chain.EIP155, chain.EIP158}},
+	{name: "Byzantium", forks: []string{chain.Byzantium}},
+	{name: "Constantinople", forks: []string{chain.Constantinople}},
+	{name: "ConstantinopleFix", forks: []string{chain.Petersburg}},
+	{name: "Istanbul", forks: []string{chain.Istanbul}},
+	{name: "Berlin", forks: []string{chain.Berlin}},
+	{name: "London", forks: []string{chain.London}},
+}
+
+// ForkNames returns the names of the supported forks
+func ForkNames() []string {
+	names := make([]string, len(forkOrder))
+	for i, f := range forkOrder {
+		names[i] = f.name
+	}
+
+	return names
+}
+
+// GetForks returns the chain forks enabled from genesis by the fork with the given name
+func GetForks(name string) (*chain.Forks, error) {
+	forks := chain.Forks{}
+
+	for _, f := range forkOrder {
+		for _, fork := range f.forks {
+			forks.SetFork(fork, chain.NewFork(0))
+		}
+
+		if f.name == name {
+			return &forks, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w %q, supported forks are: %s",
+		errUnsupportedFork, name, strings.Join(ForkNames(), ", "))
+}
@@ -0,0 +1,209 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command/evm/evmtool"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	codeFlag        = "code"
+	codeFileFlag    = "codefile"
+	inputFlag       = "input"
+	gasFlag         = "gas"
+	valueFlag       = "value"
+	senderFlag      = "sender"
+	receiverFlag    = "receiver"
+	prestateFlag    = "prestate"
+	forkFlag        = "state.fork"
+	traceFlag       = "trace"
+	traceMemoryFlag = "trace.memory"
+	traceNoStack    = "trace.nostack"
+	dumpFlag        = "dump"
+)
+
+var (
+	params = &runParams{}
+)
+
+var (
+	errInvalidValue = errors.New("invalid value")
+)
+
+type runParams struct {
+	code     string
+	codeFile string
+	input    string
+	gas      uint64
+	valueRaw string
+	sender   string
+	receiver string
+	prestate string
+	fork     string
+
+	trace        bool
+	traceMemory  bool
+	traceNoStack bool
+	dump         bool
+
+	value *big.Int
+}
+
+func (p *runParams) validateFlags() error {
+	value, err := common.ParseUint256orHex(&p.valueRaw)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidValue, err)
+	}
+
+	p.value = value
+
+	_, err = evmtool.GetForks(p.fork)
+
+	return err
+}
+
+// getCode returns the code to be executed, if no code is given
+// the code of the receiver from the prestate is executed
+func (p *runParams) getCode() ([]byte, error) {
+	code := p.code
+
+	if p.codeFile != "" {
+		data, err := os.ReadFile(p.codeFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read code file: %w", err)
+		}
+
+		code = strings.TrimSpace(string(data))
+	}
+
+	if code == "" {
+		return nil, nil
+	}
+
+	return hex.DecodeHex(code)
+}
+
+func (p *runParams) runCode() (*RunResult, error) {
+	forks, err := evmtool.GetForks(p.fork)
+	if err != nil {
+		return nil, err
+	}
+
+	alloc := evmtool.Alloc{}
+
+	if p.prestate != "" {
+		if alloc, err = evmtool.ReadAlloc(p.prestate); err != nil {
+			return nil, err
+		}
+	}
+
+	code, err := p.getCode()
+	if err != nil {
+		return nil, fmt.Errorf("invalid code: %w", err)
+	}
+
+	input, err := hex.DecodeHex(p.input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	sender := types.StringToAddress(p.sender)
+	receiver := types.StringToAddress(p.receiver)
+
+	if code != nil {
+		account, ok := alloc[receiver]
+		if !ok {
+			account = &chain.GenesisAccount{}
+			alloc[receiver] = account
+		}
+
+		account.Code = code
+	}
+
+	s, root, err := alloc.BuildState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build prestate: %w", err)
+	}
+
+	executor := state.NewExecutor(&chain.Params{
+		Forks:   forks,
+		ChainID: 1,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, s, hclog.NewNullLogger())
+
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	transition, err := executor.BeginTxn(root, &types.Header{GasLimit: p.gas}, types.ZeroAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var tracer *structtracer.StructTracer
+
+	if p.trace {
+		tracer = structtracer.NewStructTracer(structtracer.Config{
+			EnableMemory:     p.traceMemory,
+			EnableStack:      !p.traceNoStack,
+			EnableStorage:    true,
+			EnableStructLogs: true,
+		})
+
+		transition.SetTracer(tracer)
+	}
+
+	start := time.Now()
+	execResult := transition.Call2(sender, receiver, input, p.value, p.gas)
+	elapsed := time.Since(start)
+
+	result := &RunResult{
+		Output:        hex.EncodeToHex(execResult.ReturnValue),
+		GasUsed:       execResult.GasUsed,
+		ExecutionTime: elapsed.String(),
+	}
+
+	if execResult.Err != nil {
+		result.Error = execResult.Err.Error()
+	}
+
+	if tracer != nil {
+		trace, err := tracer.GetResult()
+		if err != nil {
+			return nil, err
+		}
+
+		if traceResult, ok := trace.(*structtracer.StructTraceResult); ok {
+			result.StructLogs = traceResult.StructLogs
+		}
+	}
+
+	if p.dump {
+		objs, err := transition.Txn().Commit(forks.At(0).EIP155)
+		if err != nil {
+			return nil, err
+		}
+
+		alloc.Apply(objs)
+		result.Alloc = alloc
+	}
+
+	return result, nil
+}
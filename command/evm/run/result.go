@@ -0,0 +1,62 @@
+package run
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/evm/evmtool"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
+)
+
+type RunResult struct {
+	Output        string                   `json:"output"`
+	GasUsed       uint64                   `json:"gasUsed"`
+	Error         string                   `json:"error,omitempty"`
+	ExecutionTime string                   `json:"executionTime"`
+	StructLogs    []structtracer.StructLog `json:"structLogs,omitempty"`
+	Alloc         evmtool.Alloc            `json:"alloc,omitempty"`
+}
+
+func (r *RunResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	for _, log := range r.StructLogs {
+		data, err := json.Marshal(log)
+		if err != nil {
+			return err.Error()
+		}
+
+		buffer.Write(data)
+		buffer.WriteString("\n")
+	}
+
+	buffer.WriteString("\n[EVM RUN]\n")
+
+	vals := []string{
+		fmt.Sprintf("Output|%s", r.Output),
+		fmt.Sprintf("Gas used|%d", r.GasUsed),
+		fmt.Sprintf("Execution time|%s", r.ExecutionTime),
+	}
+
+	if r.Error != "" {
+		vals = append(vals, fmt.Sprintf("Error|%s", r.Error))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	if r.Alloc != nil {
+		data, err := json.MarshalIndent(r.Alloc, "", "  ")
+		if err != nil {
+			return err.Error()
+		}
+
+		buffer.WriteString("\n[POST STATE]\n")
+		buffer.Write(data)
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,135 @@
+package run
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/evm/evmtool"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func GetCommand() *cobra.Command {
+	runCmd := &cobra.Command{
+		Use:     "run",
+		Short:   "Executes EVM bytecode, optionally tracing the executed opcodes",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(runCmd)
+
+	return runCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.code,
+		codeFlag,
+		"",
+		"the hex encoded bytecode to execute",
+	)
+
+	cmd.Flags().StringVar(
+		&params.codeFile,
+		codeFileFlag,
+		"",
+		"the file containing the hex encoded bytecode to execute",
+	)
+
+	cmd.Flags().StringVar(
+		&params.input,
+		inputFlag,
+		"",
+		"the hex encoded call input",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.gas,
+		gasFlag,
+		10000000,
+		"the gas limit of the execution",
+	)
+
+	cmd.Flags().StringVar(
+		&params.valueRaw,
+		valueFlag,
+		"0",
+		"the value transferred with the call",
+	)
+
+	cmd.Flags().StringVar(
+		&params.sender,
+		senderFlag,
+		types.BytesToAddress([]byte("sender")).String(),
+		"the address of the caller",
+	)
+
+	cmd.Flags().StringVar(
+		&params.receiver,
+		receiverFlag,
+		types.BytesToAddress([]byte("receiver")).String(),
+		"the address the code is executed at",
+	)
+
+	cmd.Flags().StringVar(
+		&params.prestate,
+		prestateFlag,
+		"",
+		"the prestate alloc file",
+	)
+
+	cmd.Flags().StringVar(
+		&params.fork,
+		forkFlag,
+		evmtool.DefaultFork,
+		"the fork rules to apply",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.trace,
+		traceFlag,
+		false,
+		"output the struct log trace of the execution",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.traceMemory,
+		traceMemoryFlag,
+		false,
+		"include the memory in the trace",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.traceNoStack,
+		traceNoStack,
+		false,
+		"exclude the stack from the trace",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.dump,
+		dumpFlag,
+		false,
+		"output the post state alloc",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(codeFlag, codeFileFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := params.runCode()
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
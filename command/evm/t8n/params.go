@@ -0,0 +1,223 @@
+package t8n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/command/evm/evmtool"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	inputAllocFlag      = "input.alloc"
+	inputTxsFlag        = "input.txs"
+	inputEnvFlag        = "input.env"
+	outputBasedirFlag   = "output.basedir"
+	outputResultFlag    = "output.result"
+	outputAllocFlag     = "output.alloc"
+	forkFlag            = "state.fork"
+	chainIDFlag         = "state.chainid"
+	rewardFlag          = "state.reward"
+	traceFlag           = "trace"
+	traceMemoryFlag     = "trace.memory"
+	traceNoStackFlag    = "trace.nostack"
+	traceReturnDataFlag = "trace.returndata"
+
+	// stdinInput reads all the inputs from a single JSON object passed through the standard input
+	stdinInput = "stdin"
+	// stdoutOutput writes the output to the standard output instead of a file
+	stdoutOutput = "stdout"
+)
+
+var (
+	params = &t8nParams{}
+)
+
+type t8nParams struct {
+	inputAlloc string
+	inputTxs   string
+	inputEnv   string
+
+	outputBasedir string
+	outputResult  string
+	outputAlloc   string
+
+	fork    string
+	chainID int64
+	reward  int64
+
+	trace           bool
+	traceMemory     bool
+	traceNoStack    bool
+	traceReturnData bool
+}
+
+// stdinInputs are all the inputs passed through the standard input
+type stdinInputs struct {
+	Alloc evmtool.Alloc `json:"alloc"`
+	Txs   []*txJSON     `json:"txs"`
+	Env   *Env          `json:"env"`
+}
+
+func (p *t8nParams) validateFlags() error {
+	_, err := evmtool.GetForks(p.fork)
+
+	return err
+}
+
+func (p *t8nParams) getTraceConfig() *structtracer.Config {
+	if !p.trace {
+		return nil
+	}
+
+	return &structtracer.Config{
+		EnableMemory:     p.traceMemory,
+		EnableStack:      !p.traceNoStack,
+		EnableStorage:    true,
+		EnableReturnData: p.traceReturnData,
+		EnableStructLogs: true,
+	}
+}
+
+// readInputs reads the prestate alloc, the environment and the transactions
+func (p *t8nParams) readInputs(stdin io.Reader) (*stdinInputs, error) {
+	inputs := &stdinInputs{}
+
+	if p.inputAlloc == stdinInput || p.inputTxs == stdinInput || p.inputEnv == stdinInput {
+		if err := json.NewDecoder(stdin).Decode(inputs); err != nil {
+			return nil, fmt.Errorf("failed to decode stdin: %w", err)
+		}
+	}
+
+	if p.inputAlloc != stdinInput {
+		alloc, err := evmtool.ReadAlloc(p.inputAlloc)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs.Alloc = alloc
+	}
+
+	if p.inputTxs != stdinInput {
+		if err := readJSONFile(p.inputTxs, &inputs.Txs); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.inputEnv != stdinInput {
+		if err := readJSONFile(p.inputEnv, &inputs.Env); err != nil {
+			return nil, err
+		}
+	}
+
+	if inputs.Alloc == nil {
+		inputs.Alloc = evmtool.Alloc{}
+	}
+
+	if inputs.Env == nil {
+		inputs.Env = &Env{}
+	}
+
+	return inputs, nil
+}
+
+func (p *t8nParams) applyTransition(stdin io.Reader) (*T8nResult, error) {
+	forks, err := evmtool.GetForks(p.fork)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := p.readInputs(stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &stateTransition{
+		forks:       forks,
+		chainID:     p.chainID,
+		reward:      p.reward,
+		traceConfig: p.getTraceConfig(),
+		writeTrace: func(index int, tx *types.Transaction, trace interface{}) error {
+			return p.writeFile(traceFileName(index, tx), trace)
+		},
+	}
+
+	signer := st.signer(inputs.Env)
+	txs := make([]*types.Transaction, len(inputs.Txs))
+
+	for i, tx := range inputs.Txs {
+		if txs[i], err = tx.toTransaction(signer); err != nil {
+			return nil, fmt.Errorf("failed to decode transaction %d: %w", i, err)
+		}
+	}
+
+	execResult, err := st.apply(inputs.Alloc, inputs.Env, txs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &T8nResult{}
+
+	if err := p.writeOutput(p.outputResult, execResult, &result.Result); err != nil {
+		return nil, err
+	}
+
+	if err := p.writeOutput(p.outputAlloc, inputs.Alloc, &result.Alloc); err != nil {
+		return nil, err
+	}
+
+	if result.Result == nil && result.Alloc == nil {
+		result.StateRoot = &execResult.StateRoot
+		result.Files = []string{
+			filepath.Join(p.outputBasedir, p.outputResult),
+			filepath.Join(p.outputBasedir, p.outputAlloc),
+		}
+	}
+
+	return result, nil
+}
+
+// writeOutput writes the output to the file with the given name,
+// or sets it to the stdout field if it should be written to the standard output
+func (p *t8nParams) writeOutput(name string, output interface{}, stdout *interface{}) error {
+	if name == stdoutOutput {
+		*stdout = output
+
+		return nil
+	}
+
+	return p.writeFile(name, output)
+}
+
+func (p *t8nParams) writeFile(name string, output interface{}) error {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if p.outputBasedir != "" {
+		if err := common.CreateDirSafe(p.outputBasedir, 0750); err != nil {
+			return err
+		}
+	}
+
+	return common.SaveFileSafe(filepath.Join(p.outputBasedir, name), data, 0660)
+}
+
+func readJSONFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	return nil
+}
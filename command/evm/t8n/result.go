@@ -0,0 +1,46 @@
+package t8n
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type T8nResult struct {
+	// Result and Alloc are set if they are written to the standard output
+	Result interface{} `json:"result,omitempty"`
+	Alloc  interface{} `json:"alloc,omitempty"`
+
+	StateRoot *types.Hash `json:"stateRoot,omitempty"`
+	Files     []string    `json:"files,omitempty"`
+}
+
+func (r *T8nResult) GetOutput() string {
+	// the outputs written to the standard output are printed as plain JSON,
+	// so they can be consumed by other tools
+	if r.Result != nil || r.Alloc != nil {
+		data, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return err.Error()
+		}
+
+		return string(data)
+	}
+
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE TRANSITION]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("State root|%s", r.StateRoot),
+	}))
+	buffer.WriteString("\n\nOutput files:\n")
+
+	for _, file := range r.Files {
+		buffer.WriteString(file + "\n")
+	}
+
+	return buffer.String()
+}
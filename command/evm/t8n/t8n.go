@@ -0,0 +1,134 @@
+package t8n
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/evm/evmtool"
+)
+
+func GetCommand() *cobra.Command {
+	t8nCmd := &cobra.Command{
+		Use:     "t8n",
+		Short:   "Applies transactions on top of a prestate and outputs the post state, compatible with the geth t8n tool",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(t8nCmd)
+
+	return t8nCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.inputAlloc,
+		inputAllocFlag,
+		"alloc.json",
+		"the prestate alloc file, or 'stdin' to read all the inputs from the standard input",
+	)
+
+	cmd.Flags().StringVar(
+		&params.inputTxs,
+		inputTxsFlag,
+		"txs.json",
+		"the transactions file, or 'stdin' to read all the inputs from the standard input",
+	)
+
+	cmd.Flags().StringVar(
+		&params.inputEnv,
+		inputEnvFlag,
+		"env.json",
+		"the block environment file, or 'stdin' to read all the inputs from the standard input",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputBasedir,
+		outputBasedirFlag,
+		"",
+		"the directory the output files are written to",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputResult,
+		outputResultFlag,
+		"result.json",
+		"the execution result file name, or 'stdout' to write it to the standard output",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputAlloc,
+		outputAllocFlag,
+		"alloc.json",
+		"the post state alloc file name, or 'stdout' to write it to the standard output",
+	)
+
+	cmd.Flags().StringVar(
+		&params.fork,
+		forkFlag,
+		evmtool.DefaultFork,
+		"the fork rules to apply",
+	)
+
+	cmd.Flags().Int64Var(
+		&params.chainID,
+		chainIDFlag,
+		1,
+		"the chain ID",
+	)
+
+	cmd.Flags().Int64Var(
+		&params.reward,
+		rewardFlag,
+		0,
+		"the block mining reward, a negative value disables the reward",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.trace,
+		traceFlag,
+		false,
+		"write the struct log trace of each transaction to the output directory",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.traceMemory,
+		traceMemoryFlag,
+		false,
+		"include the memory in the trace",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.traceNoStack,
+		traceNoStackFlag,
+		false,
+		"exclude the stack from the trace",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.traceReturnData,
+		traceReturnDataFlag,
+		false,
+		"include the return data in the trace",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := params.applyTransition(os.Stdin)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
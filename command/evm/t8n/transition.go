@@ -0,0 +1,491 @@
+package t8n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command/evm/evmtool"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+)
+
+var (
+	errBaseFeeMissing   = errors.New("currentBaseFee is required by the London fork")
+	errDifficultyTooBig = errors.New("currentDifficulty does not fit into uint64")
+	errBaseFeeTooBig    = errors.New("currentBaseFee does not fit into uint64")
+)
+
+// Env is the environment of the block the transactions are applied to
+type Env struct {
+	Coinbase    types.Address
+	GasLimit    uint64
+	Number      uint64
+	Timestamp   uint64
+	Difficulty  uint64
+	BaseFee     *big.Int
+	BlockHashes map[uint64]types.Hash
+}
+
+func (e *Env) UnmarshalJSON(data []byte) error {
+	type env struct {
+		Coinbase    types.Address         `json:"currentCoinbase"`
+		GasLimit    *string               `json:"currentGasLimit"`
+		Number      *string               `json:"currentNumber"`
+		Timestamp   *string               `json:"currentTimestamp"`
+		Difficulty  *string               `json:"currentDifficulty"`
+		BaseFee     *string               `json:"currentBaseFee"`
+		BlockHashes map[string]types.Hash `json:"blockHashes"`
+	}
+
+	var (
+		dec env
+		err error
+	)
+
+	if err = json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	e.Coinbase = dec.Coinbase
+
+	if e.GasLimit, err = common.ParseUint64orHex(dec.GasLimit); err != nil {
+		return fmt.Errorf("invalid currentGasLimit: %w", err)
+	}
+
+	if e.Number, err = common.ParseUint64orHex(dec.Number); err != nil {
+		return fmt.Errorf("invalid currentNumber: %w", err)
+	}
+
+	if e.Timestamp, err = common.ParseUint64orHex(dec.Timestamp); err != nil {
+		return fmt.Errorf("invalid currentTimestamp: %w", err)
+	}
+
+	difficulty, err := common.ParseUint256orHex(dec.Difficulty)
+	if err != nil {
+		return fmt.Errorf("invalid currentDifficulty: %w", err)
+	}
+
+	if difficulty != nil {
+		if !difficulty.IsUint64() {
+			return errDifficultyTooBig
+		}
+
+		e.Difficulty = difficulty.Uint64()
+	}
+
+	if e.BaseFee, err = common.ParseUint256orHex(dec.BaseFee); err != nil {
+		return fmt.Errorf("invalid currentBaseFee: %w", err)
+	}
+
+	if e.BaseFee != nil && !e.BaseFee.IsUint64() {
+		return errBaseFeeTooBig
+	}
+
+	e.BlockHashes = make(map[uint64]types.Hash, len(dec.BlockHashes))
+
+	for numberRaw, hash := range dec.BlockHashes {
+		number, err := common.ParseUint64orHex(&numberRaw)
+		if err != nil {
+			return fmt.Errorf("invalid block hash number %q: %w", numberRaw, err)
+		}
+
+		e.BlockHashes[number] = hash
+	}
+
+	return nil
+}
+
+func (e *Env) header() *types.Header {
+	header := &types.Header{
+		Miner:      e.Coinbase.Bytes(),
+		Difficulty: e.Difficulty,
+		GasLimit:   e.GasLimit,
+		Number:     e.Number,
+		Timestamp:  e.Timestamp,
+	}
+
+	if e.BaseFee != nil {
+		header.BaseFee = e.BaseFee.Uint64()
+	}
+
+	return header
+}
+
+// txJSON is a transaction in the format of the txs.json file,
+// unsigned transactions are signed with the given secret key
+type txJSON struct {
+	Type                 *string            `json:"type"`
+	ChainID              *string            `json:"chainId"`
+	Nonce                *string            `json:"nonce"`
+	To                   *types.Address     `json:"to"`
+	Gas                  *string            `json:"gas"`
+	GasPrice             *string            `json:"gasPrice"`
+	MaxPriorityFeePerGas *string            `json:"maxPriorityFeePerGas"`
+	MaxFeePerGas         *string            `json:"maxFeePerGas"`
+	Value                *string            `json:"value"`
+	Input                *string            `json:"input"`
+	Data                 *string            `json:"data"`
+	AccessList           types.TxAccessList `json:"accessList"`
+	V                    *string            `json:"v"`
+	R                    *string            `json:"r"`
+	S                    *string            `json:"s"`
+	SecretKey            *string            `json:"secretKey"`
+}
+
+// toTransaction converts the decoded transaction to a (signed) transaction
+func (t *txJSON) toTransaction(signer crypto.TxSigner) (*types.Transaction, error) {
+	txType, err := common.ParseUint64orHex(t.Type)
+	if err != nil {
+		return nil, fmt.Errorf("invalid type: %w", err)
+	}
+
+	nonce, err := common.ParseUint64orHex(t.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+
+	gas, err := common.ParseUint64orHex(t.Gas)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas: %w", err)
+	}
+
+	bigFields := map[string]*string{
+		"chainId":              t.ChainID,
+		"gasPrice":             t.GasPrice,
+		"maxPriorityFeePerGas": t.MaxPriorityFeePerGas,
+		"maxFeePerGas":         t.MaxFeePerGas,
+		"value":                t.Value,
+		"v":                    t.V,
+		"r":                    t.R,
+		"s":                    t.S,
+	}
+
+	bigValues := make(map[string]*big.Int, len(bigFields))
+
+	for name, raw := range bigFields {
+		value, err := common.ParseUint256orHex(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+
+		if value == nil {
+			value = new(big.Int)
+		}
+
+		bigValues[name] = value
+	}
+
+	input := t.Input
+	if input == nil {
+		input = t.Data
+	}
+
+	data, err := common.ParseBytes(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	options := []types.TxOption{
+		types.WithNonce(nonce),
+		types.WithTo(t.To),
+		types.WithGas(gas),
+		types.WithValue(bigValues["value"]),
+		types.WithInput(data),
+		types.WithSignatureValues(bigValues["v"], bigValues["r"], bigValues["s"]),
+	}
+
+	var txData types.TxData
+
+	switch types.TxType(txType) {
+	case types.LegacyTxType:
+		txData = types.NewLegacyTx(append(options,
+			types.WithGasPrice(bigValues["gasPrice"]))...)
+	case types.AccessListTxType:
+		txData = types.NewAccessListTx(append(options,
+			types.WithGasPrice(bigValues["gasPrice"]),
+			types.WithChainID(bigValues["chainId"]),
+			types.WithAccessList(t.AccessList))...)
+	case types.DynamicFeeTxType:
+		txData = types.NewDynamicFeeTx(append(options,
+			types.WithGasTipCap(bigValues["maxPriorityFeePerGas"]),
+			types.WithGasFeeCap(bigValues["maxFeePerGas"]),
+			types.WithChainID(bigValues["chainId"]),
+			types.WithAccessList(t.AccessList))...)
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %d", txType)
+	}
+
+	tx := types.NewTx(txData)
+
+	if t.SecretKey != nil {
+		keyBytes, err := common.ParseBytes(t.SecretKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secretKey: %w", err)
+		}
+
+		key, err := crypto.ParseECDSAPrivateKey(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secretKey: %w", err)
+		}
+
+		if tx, err = signer.SignTx(tx, key); err != nil {
+			return nil, fmt.Errorf("failed to sign transaction: %w", err)
+		}
+	}
+
+	return tx.ComputeHash(), nil
+}
+
+// ExecutionResult is the result of the state transition, in the format of the result.json file
+type ExecutionResult struct {
+	StateRoot   types.Hash    `json:"stateRoot"`
+	TxRoot      types.Hash    `json:"txRoot"`
+	ReceiptRoot types.Hash    `json:"receiptsRoot"`
+	LogsHash    types.Hash    `json:"logsHash"`
+	Bloom       types.Bloom   `json:"logsBloom"`
+	Receipts    []*Receipt    `json:"receipts"`
+	Rejected    []*RejectedTx `json:"rejected,omitempty"`
+	Difficulty  string        `json:"currentDifficulty"`
+	GasUsed     string        `json:"gasUsed"`
+	BaseFee     string        `json:"currentBaseFee,omitempty"`
+}
+
+// Receipt is the receipt of an applied transaction
+type Receipt struct {
+	Type              string         `json:"type"`
+	Root              string         `json:"root"`
+	Status            string         `json:"status"`
+	CumulativeGasUsed string         `json:"cumulativeGasUsed"`
+	LogsBloom         types.Bloom    `json:"logsBloom"`
+	Logs              []*Log         `json:"logs"`
+	TxHash            types.Hash     `json:"transactionHash"`
+	ContractAddress   *types.Address `json:"contractAddress,omitempty"`
+	GasUsed           string         `json:"gasUsed"`
+	BlockHash         types.Hash     `json:"blockHash"`
+	TransactionIndex  string         `json:"transactionIndex"`
+}
+
+// Log is a log emitted by an applied transaction
+type Log struct {
+	Address          types.Address `json:"address"`
+	Topics           []types.Hash  `json:"topics"`
+	Data             string        `json:"data"`
+	BlockNumber      string        `json:"blockNumber"`
+	TxHash           types.Hash    `json:"transactionHash"`
+	TransactionIndex string        `json:"transactionIndex"`
+	BlockHash        types.Hash    `json:"blockHash"`
+	LogIndex         string        `json:"logIndex"`
+}
+
+// RejectedTx is a transaction which could not be applied
+type RejectedTx struct {
+	Index int    `json:"index"`
+	Err   string `json:"error"`
+}
+
+// traceWriter stores the trace of the transaction at the given index
+type traceWriter func(index int, tx *types.Transaction, trace interface{}) error
+
+// stateTransition applies transactions on top of a prestate
+type stateTransition struct {
+	forks   *chain.Forks
+	chainID int64
+	// reward is the block mining reward, the reward is not applied if it's negative
+	reward int64
+
+	// traceConfig enables tracing of the transactions if set
+	traceConfig *structtracer.Config
+	writeTrace  traceWriter
+}
+
+// signer returns the transaction signer of the block
+func (st *stateTransition) signer(env *Env) crypto.TxSigner {
+	return crypto.NewSigner(st.forks.At(env.Number), uint64(st.chainID))
+}
+
+// apply applies the transactions on top of the prestate alloc,
+// which is updated to the post state
+func (st *stateTransition) apply(
+	alloc evmtool.Alloc,
+	env *Env,
+	txs []*types.Transaction,
+) (*ExecutionResult, error) {
+	forks := st.forks.At(env.Number)
+
+	if forks.London && env.BaseFee == nil {
+		return nil, errBaseFeeMissing
+	}
+
+	s, root, err := alloc.BuildState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build prestate: %w", err)
+	}
+
+	executor := state.NewExecutor(&chain.Params{
+		Forks:   st.forks,
+		ChainID: st.chainID,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, s, hclog.NewNullLogger())
+
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(number uint64) types.Hash {
+			return env.BlockHashes[number]
+		}
+	}
+
+	transition, err := executor.BeginTxn(root, env.header(), env.Coinbase)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		included = make([]*types.Transaction, 0, len(txs))
+		rejected = []*RejectedTx{}
+	)
+
+	for i, tx := range txs {
+		var tracer *structtracer.StructTracer
+
+		if st.traceConfig != nil {
+			tracer = structtracer.NewStructTracer(*st.traceConfig)
+			transition.SetTracer(tracer)
+		}
+
+		if err := transition.Write(tx); err != nil {
+			rejected = append(rejected, &RejectedTx{Index: i, Err: err.Error()})
+
+			continue
+		}
+
+		included = append(included, tx)
+
+		if tracer != nil {
+			trace, err := tracer.GetResult()
+			if err != nil {
+				return nil, err
+			}
+
+			if err := st.writeTrace(i, tx, trace); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	txn := transition.Txn()
+
+	if st.reward >= 0 {
+		txn.AddSealingReward(env.Coinbase, big.NewInt(st.reward))
+	}
+
+	objs, err := txn.Commit(forks.EIP155)
+	if err != nil {
+		return nil, err
+	}
+
+	snap, err := executor.StateAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	_, stateRoot, err := snap.Commit(objs)
+	if err != nil {
+		return nil, err
+	}
+
+	alloc.Apply(objs)
+
+	receipts := transition.Receipts()
+
+	result := &ExecutionResult{
+		StateRoot:   types.BytesToHash(stateRoot),
+		TxRoot:      buildroot.CalculateTransactionsRoot(included, env.Number),
+		ReceiptRoot: buildroot.CalculateReceiptsRoot(receipts),
+		LogsHash:    logsHash(receipts),
+		Bloom:       types.CreateBloom(receipts),
+		Receipts:    make([]*Receipt, len(receipts)),
+		Rejected:    rejected,
+		Difficulty:  hex.EncodeUint64(env.Difficulty),
+		GasUsed:     hex.EncodeUint64(transition.TotalGas()),
+	}
+
+	if env.BaseFee != nil {
+		result.BaseFee = hex.EncodeBig(env.BaseFee)
+	}
+
+	logIndex := uint64(0)
+
+	for i, receipt := range receipts {
+		result.Receipts[i] = newReceipt(receipt, env.Number, uint64(i), &logIndex)
+	}
+
+	return result, nil
+}
+
+func newReceipt(receipt *types.Receipt, blockNumber, txIndex uint64, logIndex *uint64) *Receipt {
+	status := uint64(0)
+	if receipt.Status != nil {
+		status = uint64(*receipt.Status)
+	}
+
+	res := &Receipt{
+		Type:              hex.EncodeUint64(uint64(receipt.TransactionType)),
+		Root:              "0x",
+		Status:            hex.EncodeUint64(status),
+		CumulativeGasUsed: hex.EncodeUint64(receipt.CumulativeGasUsed),
+		LogsBloom:         receipt.LogsBloom,
+		Logs:              make([]*Log, len(receipt.Logs)),
+		TxHash:            receipt.TxHash,
+		ContractAddress:   receipt.ContractAddress,
+		GasUsed:           hex.EncodeUint64(receipt.GasUsed),
+		TransactionIndex:  hex.EncodeUint64(txIndex),
+	}
+
+	for i, log := range receipt.Logs {
+		res.Logs[i] = &Log{
+			Address:          log.Address,
+			Topics:           log.Topics,
+			Data:             hex.EncodeToHex(log.Data),
+			BlockNumber:      hex.EncodeUint64(blockNumber),
+			TxHash:           receipt.TxHash,
+			TransactionIndex: hex.EncodeUint64(txIndex),
+			LogIndex:         hex.EncodeUint64(*logIndex),
+		}
+
+		*logIndex++
+	}
+
+	return res
+}
+
+// logsHash returns the hash of the RLP encoded logs of all the receipts
+func logsHash(receipts []*types.Receipt) (hash types.Hash) {
+	all := &types.Receipt{}
+	for _, receipt := range receipts {
+		all.Logs = append(all.Logs, receipt.Logs...)
+	}
+
+	ar := &fastrlp.Arena{}
+	keccak.Keccak256Rlp(hash[:0], all.MarshalLogsWith(ar))
+
+	return hash
+}
+
+// traceFileName returns the name of the trace file of the transaction at the given index
+func traceFileName(index int, tx *types.Transaction) string {
+	return "trace-" + strconv.Itoa(index) + "-" + tx.Hash().String() + ".json"
+}
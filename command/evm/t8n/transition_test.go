@@ -0,0 +1,159 @@
+package t8n
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command/evm/evmtool"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	secretKey = "0x45a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8"
+)
+
+var (
+	sender   = types.StringToAddress("0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b")
+	contract = types.StringToAddress("0x8a8eafb1cf62bfbeb1741769dae1a9dd47996192")
+	coinbase = types.StringToAddress("0xc94f5374fce5edbc8e2a8697c15331677e6ebf0b")
+)
+
+func TestEnv_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var env Env
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"currentCoinbase": "0xc94f5374fce5edbc8e2a8697c15331677e6ebf0b",
+		"currentDifficulty": "0x20000",
+		"currentGasLimit": "0x750a163df65e8a",
+		"currentNumber": "1",
+		"currentTimestamp": "1000",
+		"currentBaseFee": "0x10",
+		"blockHashes": {"0": "0x5e20a0453cecd065ea59c37ac63e079ee08998b6045136a8ce6635c7912ec0b6"}
+	}`), &env))
+
+	require.Equal(t, Env{
+		Coinbase:   coinbase,
+		GasLimit:   0x750a163df65e8a,
+		Number:     1,
+		Timestamp:  1000,
+		Difficulty: 0x20000,
+		BaseFee:    big.NewInt(0x10),
+		BlockHashes: map[uint64]types.Hash{
+			0: types.StringToHash("0x5e20a0453cecd065ea59c37ac63e079ee08998b6045136a8ce6635c7912ec0b6"),
+		},
+	}, env)
+
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"currentDifficulty": "0x10000000000000000"}`), &env),
+		errDifficultyTooBig)
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"currentBaseFee": "0x10000000000000000"}`), &env),
+		errBaseFeeTooBig)
+}
+
+func TestStateTransition_Apply(t *testing.T) {
+	t.Parallel()
+
+	forks, err := evmtool.GetForks("London")
+	require.NoError(t, err)
+
+	// the contract stores 1 at slot 0 and emits an empty log
+	alloc := evmtool.Alloc{
+		sender: {
+			Balance: big.NewInt(1e18),
+			Nonce:   1,
+		},
+		contract: {
+			Code: hex.MustDecodeHex("0x600160005560016000a0"),
+		},
+	}
+
+	env := &Env{
+		Coinbase: coinbase,
+		GasLimit: 10000000,
+		Number:   1,
+		BaseFee:  big.NewInt(0x10),
+	}
+
+	traces := map[int]interface{}{}
+
+	st := &stateTransition{
+		forks:   forks,
+		chainID: 1,
+		reward:  -1,
+		traceConfig: &structtracer.Config{
+			EnableStructLogs: true,
+		},
+		writeTrace: func(index int, tx *types.Transaction, trace interface{}) error {
+			traces[index] = trace
+
+			return nil
+		},
+	}
+
+	txs := make([]*types.Transaction, 0, 2)
+
+	for _, nonce := range []string{"0x1", "0x5"} {
+		nonce := nonce
+		gas, gasPrice := "0x20000", "0x20"
+		key := secretKey
+
+		tx, err := (&txJSON{
+			Nonce:     &nonce,
+			To:        &contract,
+			Gas:       &gas,
+			GasPrice:  &gasPrice,
+			SecretKey: &key,
+		}).toTransaction(st.signer(env))
+		require.NoError(t, err)
+
+		txs = append(txs, tx)
+	}
+
+	result, err := st.apply(alloc, env, txs)
+	require.NoError(t, err)
+
+	// the transaction with the invalid nonce is rejected
+	require.Len(t, result.Rejected, 1)
+	require.Equal(t, 1, result.Rejected[0].Index)
+
+	require.Len(t, result.Receipts, 1)
+	receipt := result.Receipts[0]
+	require.Equal(t, "0x1", receipt.Status)
+	require.Equal(t, txs[0].Hash(), receipt.TxHash)
+	require.Len(t, receipt.Logs, 1)
+	require.Equal(t, contract, receipt.Logs[0].Address)
+	require.Equal(t, receipt.GasUsed, result.GasUsed)
+
+	require.Len(t, traces, 1)
+	require.NotEmpty(t, traces[0].(*structtracer.StructTraceResult).StructLogs) //nolint:forcetypeassert
+
+	// the alloc is updated to the post state
+	require.Equal(t, uint64(2), alloc[sender].Nonce)
+	require.Equal(t, map[types.Hash]types.Hash{
+		types.ZeroHash: types.StringToHash("0x1"),
+	}, alloc[contract].Storage)
+	require.Contains(t, alloc, coinbase)
+
+	// the post state root matches the root of the post state alloc
+	_, root, err := alloc.BuildState()
+	require.NoError(t, err)
+	require.Equal(t, root, result.StateRoot)
+}
+
+func TestStateTransition_BaseFeeMissing(t *testing.T) {
+	t.Parallel()
+
+	st := &stateTransition{
+		forks: chain.AllForksEnabled,
+	}
+
+	_, err := st.apply(evmtool.Alloc{}, &Env{}, nil)
+	require.ErrorIs(t, err, errBaseFeeMissing)
+}
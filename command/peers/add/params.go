@@ -21,11 +21,13 @@ var (
 )
 
 const (
-	addrFlag = "addr"
+	addrFlag   = "addr"
+	staticFlag = "static"
 )
 
 type addParams struct {
 	peerAddresses []string
+	static        bool
 
 	systemClient proto.SystemClient
 
@@ -74,7 +76,8 @@ func (p *addParams) addPeer(peerAddress string) error {
 	if _, err := p.systemClient.PeersAdd(
 		context.Background(),
 		&proto.PeersAddRequest{
-			Id: peerAddress,
+			Id:     peerAddress,
+			Static: p.static,
 		},
 	); err != nil {
 		return err
@@ -89,5 +92,6 @@ func (p *addParams) getResult() command.CommandResult {
 		NumAdded:     len(p.addedPeers),
 		Peers:        p.addedPeers,
 		Errors:       p.addErrors,
+		Static:       p.static,
 	}
 }
@@ -27,6 +27,13 @@ func setFlags(cmd *cobra.Command) {
 		[]string{},
 		"the libp2p addresses of the peers",
 	)
+
+	cmd.Flags().BoolVar(
+		&params.static,
+		staticFlag,
+		false,
+		"keep the peers connected, redialing them whenever they disconnect",
+	)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -12,6 +12,7 @@ type PeersAddResult struct {
 	NumAdded     int      `json:"num_added"`
 	Peers        []string `json:"peers"`
 	Errors       []string `json:"errors"`
+	Static       bool     `json:"static"`
 }
 
 func (r *PeersAddResult) GetOutput() string {
@@ -21,6 +22,7 @@ func (r *PeersAddResult) GetOutput() string {
 	buffer.WriteString(helper.FormatKV([]string{
 		fmt.Sprintf("Peers listed|%d", r.NumRequested), // The number of peers the user wanted to add
 		fmt.Sprintf("Peers added|%d", r.NumAdded),      // The number of peers that have been added
+		fmt.Sprintf("Static|%t", r.Static),             // Whether the peers are kept connected
 	}))
 
 	if len(r.Peers) > 0 {
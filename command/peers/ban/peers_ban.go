@@ -0,0 +1,61 @@
+package ban
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	peersBanCmd := &cobra.Command{
+		Use:     "ban",
+		Short:   "Disconnects the specified peer and bans it, using the libp2p ID of the peer node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(peersBanCmd)
+	helper.SetRequiredFlags(peersBanCmd, params.getRequiredFlags())
+
+	return peersBanCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.peerID,
+		peerIDFlag,
+		"",
+		"libp2p node ID of a specific peer within p2p network",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.duration,
+		durationFlag,
+		0,
+		"the duration of the ban, the ban is permanent if not set",
+	)
+
+	cmd.Flags().StringVar(
+		&params.reason,
+		reasonFlag,
+		"banned by the operator",
+		"the reason of the ban",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.banPeer(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
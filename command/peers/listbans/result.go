@@ -0,0 +1,68 @@
+package listbans
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+type PeerBanResult struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+	Until  string `json:"until,omitempty"`
+}
+
+type PeersListBansResult struct {
+	Bans []PeerBanResult `json:"bans"`
+}
+
+func newPeersListBansResult(bans []*proto.PeerBan) *PeersListBansResult {
+	resultBans := make([]PeerBanResult, len(bans))
+	for i, b := range bans {
+		resultBans[i] = PeerBanResult{
+			ID:     b.Id,
+			Reason: b.Reason,
+		}
+
+		if b.Until != 0 {
+			resultBans[i].Until = time.Unix(b.Until, 0).UTC().Format(time.RFC3339)
+		}
+	}
+
+	return &PeersListBansResult{
+		Bans: resultBans,
+	}
+}
+
+func (r *PeersListBansResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BANNED PEERS]\n")
+
+	if len(r.Bans) == 0 {
+		buffer.WriteString("No banned peers found")
+	} else {
+		buffer.WriteString(fmt.Sprintf("Number of banned peers: %d\n\n", len(r.Bans)))
+
+		rows := make([]string, len(r.Bans)+1)
+		rows[0] = "ID|Until|Reason"
+
+		for i, b := range r.Bans {
+			until := b.Until
+			if until == "" {
+				until = "permanent"
+			}
+
+			rows[i+1] = fmt.Sprintf("%s|%s|%s", b.ID, until, b.Reason)
+		}
+
+		buffer.WriteString(helper.FormatList(rows))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
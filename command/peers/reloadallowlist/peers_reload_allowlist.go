@@ -0,0 +1,44 @@
+package reloadallowlist
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	peersReloadAllowlistCmd := &cobra.Command{
+		Use:   "reload-allowlist",
+		Short: "Reloads the allowlist file, and disconnects the peers which are no longer allowed",
+		Run:   runCommand,
+	}
+
+	return peersReloadAllowlistCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := reloadAllowlist(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&PeersReloadAllowlistResult{})
+}
+
+func reloadAllowlist(grpcAddress string) error {
+	client, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.PeersReloadAllowlist(context.Background(), &empty.Empty{})
+
+	return err
+}
@@ -0,0 +1,16 @@
+package reloadallowlist
+
+import (
+	"bytes"
+)
+
+type PeersReloadAllowlistResult struct{}
+
+func (r *PeersReloadAllowlistResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[ALLOWLIST RELOADED]\n")
+	buffer.WriteString("The peers which are no longer allowed were disconnected\n")
+
+	return buffer.String()
+}
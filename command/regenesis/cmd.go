@@ -18,6 +18,7 @@ func GetCommand() *cobra.Command {
 	genesisCMD := RegenesisCMD()
 	genesisCMD.AddCommand(GetRootCMD())
 	genesisCMD.AddCommand(HistoryTestCmd())
+	genesisCMD.AddCommand(ExportAllocCmd())
 
 	return genesisCMD
 }
@@ -0,0 +1,399 @@
+package regenesis
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	leveldb2 "github.com/0xPolygon/polygon-edge/blockchain/storagev2/leveldb"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+var (
+	exportTriePath  string
+	exportChainPath string
+	exportBlock     uint64
+	exportStateRoot string
+	exportOut       string
+	exportShardSize uint64
+
+	exportAllowMissingPreimages bool
+)
+
+/*
+Run: ./polygon-edge regenesis export-alloc --triedb "path_to_triedb" --chaindb "path_to_blockchain_db" \
+--block 1000 --out ./alloc.json
+*/
+func ExportAllocCmd() *cobra.Command {
+	exportAllocCmd := &cobra.Command{
+		Use: "export-alloc",
+		Short: "Exports the accounts, code and storage of the state at a block as a genesis alloc JSON file. " +
+			"The addresses and the storage slots are resolved from the preimages recorded by the node " +
+			"started with --record-preimages",
+	}
+
+	exportAllocCmd.Flags().StringVar(&exportTriePath, "triedb", "", "path to trie db")
+	exportAllocCmd.Flags().StringVar(&exportChainPath, "chaindb", "",
+		"path to chain db, used to find the state root of the block")
+	exportAllocCmd.Flags().Uint64Var(&exportBlock, "block", 0, "number of the exported block (default is head)")
+	exportAllocCmd.Flags().StringVar(&exportStateRoot, "stateRoot", "",
+		"state root of the exported state, used instead of the block")
+	exportAllocCmd.Flags().StringVar(&exportOut, "out", "alloc.json", "path of the alloc file")
+	exportAllocCmd.Flags().Uint64Var(&exportShardSize, "shard-size", 0,
+		"maximum number of accounts per alloc file, the files are numbered when set (default is a single file)")
+	exportAllocCmd.Flags().BoolVar(&exportAllowMissingPreimages, "allow-missing-preimages", false,
+		"skip the accounts and storage slots whose preimages were not recorded instead of failing, "+
+			"the skipped entries are listed in a report next to the alloc file")
+
+	exportAllocCmd.Run = func(cmd *cobra.Command, args []string) {
+		outputter := command.InitializeOutputter(exportAllocCmd)
+		defer outputter.WriteOutput()
+
+		if exportTriePath == "" || (exportChainPath == "" && exportStateRoot == "") {
+			outputter.SetError(errors.New("--triedb and either --chaindb or --stateRoot are required"))
+
+			return
+		}
+
+		result := &ExportAllocResult{}
+
+		if exportStateRoot != "" {
+			result.StateRoot = types.StringToHash(exportStateRoot)
+		} else {
+			header, err := readExportHeader(cmd.Flags().Changed("block"))
+			if err != nil {
+				outputter.SetError(err)
+
+				return
+			}
+
+			result.Block = header.Number
+			result.StateRoot = header.StateRoot
+		}
+
+		trie, err := itrie.NewLevelDBStorageWithOpt(exportTriePath, hclog.NewNullLogger(), &opt.Options{
+			ReadOnly:       true,
+			ErrorIfMissing: true,
+		})
+		if err != nil {
+			outputter.SetError(fmt.Errorf("open trie db error:%w", err))
+
+			return
+		}
+		defer trie.Close()
+
+		var (
+			writer = newAllocWriter(exportOut, exportShardSize)
+			report = newMissingPreimagesReport(exportOut)
+		)
+
+		config := itrie.DumpConfig{
+			AllowMissingPreimages: exportAllowMissingPreimages,
+			OnMissingPreimage: func(missing itrie.MissingPreimage) error {
+				if missing.Slot == nil {
+					result.SkippedAccounts++
+				} else {
+					result.SkippedSlots++
+				}
+
+				return report.write(missing)
+			},
+		}
+
+		err = itrie.DumpAlloc(trie, result.StateRoot, config, func(addr types.Address, account *chain.GenesisAccount) error {
+			result.Accounts++
+
+			return writer.write(addr, account)
+		})
+		if err == nil {
+			err = writer.close()
+		}
+
+		if err == nil {
+			err = report.close()
+		}
+
+		if err != nil {
+			writer.abort()
+			report.abort()
+
+			outputter.SetError(fmt.Errorf("export alloc error:%w", err))
+
+			return
+		}
+
+		result.Files = writer.files
+		result.MissingPreimages = report.path()
+
+		outputter.SetCommandResult(result)
+	}
+
+	return exportAllocCmd
+}
+
+// readExportHeader reads the header of the exported block, the head block if the block is not set
+func readExportHeader(blockSet bool) (*types.Header, error) {
+	st, err := leveldb2.NewLevelDBStorageWithOpt(exportChainPath, hclog.NewNullLogger(), &opt.Options{
+		ReadOnly:       true,
+		ErrorIfMissing: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open chain db error:%w", err)
+	}
+
+	defer st.Close()
+
+	// the old blocks may be moved to the ancient store next to the chain db
+	if ancientPath := filepath.Join(filepath.Dir(exportChainPath), "ancient"); common.DirectoryExists(ancientPath) {
+		ancient, err := storagev2.OpenAncientStore(ancientPath)
+		if err != nil {
+			return nil, fmt.Errorf("open ancient store error:%w", err)
+		}
+
+		st.ReadAncient(ancient)
+	}
+
+	number := exportBlock
+
+	if !blockSet {
+		var ok bool
+
+		if number, ok = st.ReadHeadNumber(); !ok {
+			return nil, errors.New("can't read head")
+		}
+	}
+
+	hash, ok := st.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("can't read canonical hash of block %d", number)
+	}
+
+	header, err := st.ReadHeader(number, hash)
+	if err != nil {
+		return nil, fmt.Errorf("can't read header of block %d: %w", number, err)
+	}
+
+	return header, nil
+}
+
+// allocWriter streams the accounts as JSON objects of address to genesis account,
+// split into numbered files of at most shardSize accounts if shardSize is set
+type allocWriter struct {
+	path      string
+	shardSize uint64
+
+	file     *os.File
+	buf      *bufio.Writer
+	accounts uint64
+	files    []string
+}
+
+func newAllocWriter(path string, shardSize uint64) *allocWriter {
+	return &allocWriter{
+		path:      path,
+		shardSize: shardSize,
+	}
+}
+
+func (w *allocWriter) write(addr types.Address, account *chain.GenesisAccount) error {
+	if w.file == nil || (w.shardSize > 0 && w.accounts == w.shardSize) {
+		if err := w.next(); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(account)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	if w.accounts == 0 {
+		separator = "\n"
+	}
+
+	if _, err := fmt.Fprintf(w.buf, "%s%q: %s", separator, addr.String(), data); err != nil {
+		return err
+	}
+
+	w.accounts++
+
+	return nil
+}
+
+// next closes the current file and opens the next shard
+func (w *allocWriter) next() error {
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+
+	path := w.path
+	if w.shardSize > 0 {
+		ext := filepath.Ext(w.path)
+		path = fmt.Sprintf("%s-%04d%s", strings.TrimSuffix(w.path, ext), len(w.files), ext)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+
+	w.file = file
+	w.buf = bufio.NewWriter(file)
+	w.accounts = 0
+	w.files = append(w.files, path)
+
+	_, err = w.buf.WriteString("{")
+
+	return err
+}
+
+func (w *allocWriter) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+
+	if _, err := w.buf.WriteString("\n}\n"); err != nil {
+		return err
+	}
+
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+
+	err := w.file.Close()
+	w.file = nil
+
+	return err
+}
+
+// close finishes the last file, an empty state is written as an empty alloc
+func (w *allocWriter) close() error {
+	if w.file == nil {
+		if err := w.next(); err != nil {
+			return err
+		}
+	}
+
+	return w.closeFile()
+}
+
+// abort removes the written files
+func (w *allocWriter) abort() {
+	if w.file != nil {
+		_ = w.file.Close()
+	}
+
+	for _, path := range w.files {
+		_ = os.Remove(path)
+	}
+}
+
+// missingPreimagesReport lists the skipped entries as JSON lines, the file is only created
+// once an entry is skipped
+type missingPreimagesReport struct {
+	allocPath string
+
+	file *os.File
+	buf  *bufio.Writer
+}
+
+func newMissingPreimagesReport(allocPath string) *missingPreimagesReport {
+	return &missingPreimagesReport{allocPath: allocPath}
+}
+
+// path returns the path of the report, it is empty if no entry was skipped
+func (r *missingPreimagesReport) path() string {
+	if r.file == nil {
+		return ""
+	}
+
+	return r.file.Name()
+}
+
+func (r *missingPreimagesReport) write(missing itrie.MissingPreimage) error {
+	if r.file == nil {
+		ext := filepath.Ext(r.allocPath)
+
+		file, err := os.OpenFile(strings.TrimSuffix(r.allocPath, ext)+"-missing-preimages.jsonl",
+			os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return err
+		}
+
+		r.file = file
+		r.buf = bufio.NewWriter(file)
+	}
+
+	data, err := json.Marshal(missing)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.buf.Write(data); err != nil {
+		return err
+	}
+
+	return r.buf.WriteByte('\n')
+}
+
+func (r *missingPreimagesReport) close() error {
+	if r.file == nil {
+		return nil
+	}
+
+	if err := r.buf.Flush(); err != nil {
+		return err
+	}
+
+	return r.file.Close()
+}
+
+// abort removes the report
+func (r *missingPreimagesReport) abort() {
+	if r.file == nil {
+		return
+	}
+
+	_ = r.file.Close()
+	_ = os.Remove(r.file.Name())
+}
+
+type ExportAllocResult struct {
+	Block     uint64     `json:"block"`
+	StateRoot types.Hash `json:"stateRoot"`
+	Accounts  uint64     `json:"accounts"`
+	Files     []string   `json:"files"`
+
+	SkippedAccounts  uint64 `json:"skippedAccounts,omitempty"`
+	SkippedSlots     uint64 `json:"skippedSlots,omitempty"`
+	MissingPreimages string `json:"missingPreimages,omitempty"`
+}
+
+func (r *ExportAllocResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[Alloc export SUCCESS]\n")
+	buffer.WriteString(fmt.Sprintf("state root %s of block %d, %d accounts written to %s\n",
+		r.StateRoot, r.Block, r.Accounts, strings.Join(r.Files, ", ")))
+
+	if r.MissingPreimages != "" {
+		buffer.WriteString(fmt.Sprintf("%d accounts and %d storage slots without preimages skipped, listed in %s\n",
+			r.SkippedAccounts, r.SkippedSlots, r.MissingPreimages))
+	}
+
+	return buffer.String()
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/command/backup"
 	"github.com/0xPolygon/polygon-edge/command/bridge"
+	"github.com/0xPolygon/polygon-edge/command/db"
+	"github.com/0xPolygon/polygon-edge/command/evm"
 	"github.com/0xPolygon/polygon-edge/command/genesis"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/loadtest"
@@ -59,6 +61,8 @@ func (rc *RootCommand) registerSubCommands() {
 		mint.GetCommand(),
 		validator.GetCommand(),
 		loadtest.GetCommand(),
+		evm.GetCommand(),
+		db.GetCommand(),
 	)
 }
 
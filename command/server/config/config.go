@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/network"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/hashicorp/hcl"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"gopkg.in/yaml.v3"
@@ -44,6 +45,21 @@ type Config struct {
 
 	MetricsInterval time.Duration `json:"metrics_interval" yaml:"metrics_interval"`
 
+	StateCacheSize       int `json:"state_cache_size" yaml:"state_cache_size"`
+	StateDirtyBufferSize int `json:"state_dirty_buffer_size" yaml:"state_dirty_buffer_size"`
+
+	RecordPreimages bool `json:"record_preimages" yaml:"record_preimages"`
+
+	AncientDistance        uint64 `json:"ancient_distance" yaml:"ancient_distance"`
+	HistoryRetentionBlocks uint64 `json:"history_retention_blocks" yaml:"history_retention_blocks"`
+
+	StateSync bool `json:"state_sync" yaml:"state_sync"`
+
+	CheckpointSync     string `json:"checkpoint_sync" yaml:"checkpoint_sync"`
+	CheckpointBackfill bool   `json:"checkpoint_backfill" yaml:"checkpoint_backfill"`
+
+	HeaderSync bool `json:"header_sync" yaml:"header_sync"`
+
 	EventTracker *EventTracker `json:"event_tracker" yaml:"event_tracker"`
 }
 
@@ -62,6 +78,14 @@ type Network struct {
 	MaxOutboundPeers  int64  `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
 	MaxInboundPeers   int64  `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
 	GossipMessageSize int    `json:"gossip_msg_size" yaml:"gossip_msg_size"`
+
+	StaticPeers  []string `json:"static_peers,omitempty" yaml:"static_peers,omitempty"`
+	TrustedPeers []string `json:"trusted_peers,omitempty" yaml:"trusted_peers,omitempty"`
+
+	AllowlistFile       string `json:"allowlist_file,omitempty" yaml:"allowlist_file,omitempty"`
+	AllowlistValidators bool   `json:"allowlist_validators,omitempty" yaml:"allowlist_validators,omitempty"`
+
+	RejectLegacyPeers bool `json:"reject_legacy_peers,omitempty" yaml:"reject_legacy_peers,omitempty"`
 }
 
 // TxPool defines the TxPool configuration params
@@ -159,6 +183,8 @@ func DefaultConfig() *Config {
 		ConcurrentRequestsDebug:  DefaultConcurrentRequestsDebug,
 		WebSocketReadLimit:       DefaultWebSocketReadLimit,
 		MetricsInterval:          DefaultMetricsInterval,
+		StateCacheSize:           itrie.DefaultCleanCacheSize,
+		StateDirtyBufferSize:     itrie.DefaultDirtyBufferSize,
 		EventTracker: &EventTracker{
 			SyncBatchSize:          DefaultSyncBatchSize,
 			NumBlockConfirmations:  DefaultNumBlockConfirmations,
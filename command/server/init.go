@@ -16,6 +16,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/syncer"
 )
 
 var (
@@ -58,6 +59,10 @@ func (p *serverParams) initRawParams() error {
 
 	p.relayer = p.rawConfig.Relayer
 
+	if err := p.initCheckpoint(); err != nil {
+		return err
+	}
+
 	return p.initAddresses()
 }
 
@@ -87,6 +92,20 @@ func (p *serverParams) initBlockGasTarget() error {
 	return nil
 }
 
+func (p *serverParams) initCheckpoint() error {
+	if p.rawConfig.CheckpointSync == "" {
+		return nil
+	}
+
+	var parseErr error
+
+	if p.checkpoint, parseErr = syncer.ParseCheckpoint(p.rawConfig.CheckpointSync); parseErr != nil {
+		return parseErr
+	}
+
+	return nil
+}
+
 func (p *serverParams) initSecretsConfig() error {
 	if !p.isSecretsConfigPathSet() {
 		return nil
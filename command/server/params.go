@@ -9,6 +9,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/hashicorp/go-hclog"
 	"github.com/multiformats/go-multiaddr"
 )
@@ -41,6 +43,11 @@ const (
 	tlsCertFileLocationFlag      = "tls-cert-file"
 	tlsKeyFileLocationFlag       = "tls-key-file"
 	gossipMessageSizeFlag        = "gossip-msg-size"
+	staticPeersFlag              = "static-peers"
+	trustedPeersFlag             = "trusted-peers"
+	allowlistFileFlag            = "allowlist-file"
+	allowlistValidatorsFlag      = "allowlist-validators"
+	rejectLegacyPeersFlag        = "reject-legacy-peers"
 
 	relayerFlag = "relayer"
 
@@ -49,6 +56,20 @@ const (
 
 	metricsIntervalFlag = "metrics-interval"
 
+	stateCacheSizeFlag       = "state-cache-size"
+	stateDirtyBufferSizeFlag = "state-dirty-buffer-size"
+	recordPreimagesFlag      = "record-preimages"
+
+	ancientDistanceFlag        = "ancient-distance"
+	historyRetentionBlocksFlag = "history-retention-blocks"
+
+	stateSyncFlag = "state-sync"
+
+	checkpointSyncFlag     = "checkpoint-sync"
+	checkpointBackfillFlag = "checkpoint-backfill"
+
+	headerSyncFlag = "header-sync"
+
 	// event tracker
 	trackerSyncBatchSizeFlag          = "sync-batch-size"
 	trackerNumBlockConfirmationsFlag  = "num-block-confirmations"
@@ -95,6 +116,8 @@ type serverParams struct {
 	logFileLocation string
 
 	relayer bool
+
+	checkpoint *syncer.Checkpoint
 }
 
 func (p *serverParams) isMaxPeersSet() bool {
@@ -150,6 +173,19 @@ func (p *serverParams) setJSONLogFormat(jsonLogFormat bool) {
 	p.rawConfig.JSONLogFormat = jsonLogFormat
 }
 
+// generateAllowlistConfig returns the configuration of the permissioned networking,
+// the networking is not permissioned if neither the allowlist file nor the validators are set
+func (p *serverParams) generateAllowlistConfig() *network.AllowlistConfig {
+	if p.rawConfig.Network.AllowlistFile == "" && !p.rawConfig.Network.AllowlistValidators {
+		return nil
+	}
+
+	return &network.AllowlistConfig{
+		Path:       p.rawConfig.Network.AllowlistFile,
+		Validators: p.rawConfig.Network.AllowlistValidators,
+	}
+}
+
 func (p *serverParams) generateConfig() *server.Config {
 	return &server.Config{
 		Chain: p.genesisConfig,
@@ -177,6 +213,10 @@ func (p *serverParams) generateConfig() *server.Config {
 			MaxOutboundPeers:  p.rawConfig.Network.MaxOutboundPeers,
 			Chain:             p.genesisConfig,
 			GossipMessageSize: p.rawConfig.Network.GossipMessageSize,
+			StaticPeers:       p.rawConfig.Network.StaticPeers,
+			TrustedPeers:      p.rawConfig.Network.TrustedPeers,
+			Allowlist:         p.generateAllowlistConfig(),
+			RejectLegacyPeers: p.rawConfig.Network.RejectLegacyPeers,
 		},
 		DataDir:            p.rawConfig.DataDir,
 		Seal:               p.rawConfig.ShouldSeal,
@@ -194,6 +234,17 @@ func (p *serverParams) generateConfig() *server.Config {
 
 		Relayer:         p.relayer,
 		MetricsInterval: p.rawConfig.MetricsInterval,
+		StateCache: itrie.CacheConfig{
+			CleanCacheSize:  p.rawConfig.StateCacheSize,
+			DirtyBufferSize: p.rawConfig.StateDirtyBufferSize,
+		},
+		RecordPreimages:        p.rawConfig.RecordPreimages,
+		AncientDistance:        p.rawConfig.AncientDistance,
+		HistoryRetentionBlocks: p.rawConfig.HistoryRetentionBlocks,
+		StateSync:              p.rawConfig.StateSync,
+		CheckpointSync:         p.checkpoint,
+		CheckpointBackfill:     p.rawConfig.CheckpointBackfill,
+		HeaderSync:             p.rawConfig.HeaderSync,
 		EventTracker: &server.EventTracker{
 			SyncBatchSize:          p.rawConfig.EventTracker.SyncBatchSize,
 			NumBlockConfirmations:  p.rawConfig.EventTracker.NumBlockConfirmations,
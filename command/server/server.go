@@ -169,6 +169,45 @@ func setFlags(cmd *cobra.Command) {
 		"the maximum size of a gossip message",
 	)
 
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.StaticPeers,
+		staticPeersFlag,
+		nil,
+		"the libp2p addresses of the peers which are always kept connected, regardless of the peer limits",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.TrustedPeers,
+		trustedPeersFlag,
+		nil,
+		"the libp2p IDs of the peers which are always accepted, regardless of the peer limits",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.Network.AllowlistFile,
+		allowlistFileFlag,
+		"",
+		"the JSON file listing the libp2p IDs (\"peers\") and the networks (\"cidrs\") allowed to connect, "+
+			"the connections of the other peers are refused. The file is reloaded by 'peers reload-allowlist'",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.Network.AllowlistValidators,
+		allowlistValidatorsFlag,
+		false,
+		"allow the peers of the current PolyBFT validator set to connect, along with the peers of the allowlist file. "+
+			"The validators prove their peer IDs with their validator keys in the handshake",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.Network.RejectLegacyPeers,
+		rejectLegacyPeersFlag,
+		false,
+		"reject the peers of the previous versions, which don't send the genesis and the fork ID in the handshake. "+
+			"They are accepted by default so the network can be upgraded node by node, "+
+			"enable it once all nodes are upgraded",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.TxPool.PriceLimit,
 		priceLimitFlag,
@@ -271,6 +310,78 @@ func setFlags(cmd *cobra.Command) {
 		"the interval (in seconds) at which special metrics are generated. a value of zero means the metrics are disabled",
 	)
 
+	cmd.Flags().IntVar(
+		&params.rawConfig.StateCacheSize,
+		stateCacheSizeFlag,
+		defaultConfig.StateCacheSize,
+		"the size (in megabytes) of the cache for the state trie nodes",
+	)
+
+	cmd.Flags().IntVar(
+		&params.rawConfig.StateDirtyBufferSize,
+		stateDirtyBufferSizeFlag,
+		defaultConfig.StateDirtyBufferSize,
+		"the size (in megabytes) up to which the written state trie nodes are buffered before being flushed "+
+			"to the database. buffered nodes are lost on an unclean shutdown, and the chain head is then rewound to the "+
+			"last block whose state is persisted. a value of zero writes them on every block",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.RecordPreimages,
+		recordPreimagesFlag,
+		defaultConfig.RecordPreimages,
+		"record the addresses and storage slots of the state trie keys, "+
+			"which are required to export the state with regenesis export-alloc",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.AncientDistance,
+		ancientDistanceFlag,
+		defaultConfig.AncientDistance,
+		"the number of the most recent blocks kept in the main database, older blocks are moved "+
+			"to the ancient store. a value of zero (default) keeps all blocks in the main database. "+
+			"the moved blocks are deleted from the main database, so the node can't be downgraded afterwards",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.HistoryRetentionBlocks,
+		historyRetentionBlocksFlag,
+		defaultConfig.HistoryRetentionBlocks,
+		"the number of the most recent blocks whose bodies, receipts and transaction lookups are kept, "+
+			"the older ones are pruned while their headers are kept. a value of zero keeps the whole history",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.StateSync,
+		stateSyncFlag,
+		defaultConfig.StateSync,
+		"a new node downloads the state of a recent block from its peers and continues from that block, "+
+			"instead of executing all the blocks from genesis. the older blocks are written without their receipts",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.CheckpointSync,
+		checkpointSyncFlag,
+		defaultConfig.CheckpointSync,
+		"a trusted block, given as <number>:<hash>, a new node starts syncing from instead of the genesis. "+
+			"the seals of the block are verified and its state is downloaded from the peers",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.CheckpointBackfill,
+		checkpointBackfillFlag,
+		defaultConfig.CheckpointBackfill,
+		"download the blocks preceding the checkpoint in the background, down to the genesis",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.HeaderSync,
+		headerSyncFlag,
+		defaultConfig.HeaderSync,
+		"download the headers from the best peer and verify their seals before the bodies of the blocks "+
+			"are downloaded from several peers at once",
+	)
+
 	{ // event tracker
 		cmd.Flags().Uint64Var(
 			&params.rawConfig.EventTracker.SyncBatchSize,
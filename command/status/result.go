@@ -3,15 +3,45 @@ package status
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
 )
 
 type StatusResult struct {
-	ChainID            int64  `json:"chain_id"`
-	CurrentBlockNumber int64  `json:"current_block_number"`
-	CurrentBlockHash   string `json:"current_block_hash"`
-	LibP2PAddress      string `json:"libp2p_address"`
+	ChainID            int64             `json:"chain_id"`
+	CurrentBlockNumber int64             `json:"current_block_number"`
+	CurrentBlockHash   string            `json:"current_block_hash"`
+	LibP2PAddress      string            `json:"libp2p_address"`
+	SyncPeers          []*SyncPeerResult `json:"sync_peers"`
+}
+
+type SyncPeerResult struct {
+	ID            string `json:"id"`
+	Score         int64  `json:"score"`
+	Failures      uint64 `json:"failures"`
+	Stalls        uint64 `json:"stalls"`
+	InvalidBlocks uint64 `json:"invalid_blocks"`
+	Bans          uint64 `json:"bans"`
+	BannedUntil   int64  `json:"banned_until,omitempty"`
+}
+
+func newSyncPeerResults(syncPeers []*proto.ServerStatus_SyncPeer) []*SyncPeerResult {
+	results := make([]*SyncPeerResult, len(syncPeers))
+	for i, p := range syncPeers {
+		results[i] = &SyncPeerResult{
+			ID:            p.Id,
+			Score:         p.Score,
+			Failures:      p.Failures,
+			Stalls:        p.Stalls,
+			InvalidBlocks: p.InvalidBlocks,
+			Bans:          p.Bans,
+			BannedUntil:   p.BannedUntil,
+		}
+	}
+
+	return results
 }
 
 func (r *StatusResult) GetOutput() string {
@@ -25,5 +55,24 @@ func (r *StatusResult) GetOutput() string {
 		fmt.Sprintf("Libp2p Address|%s", r.LibP2PAddress),
 	}))
 
+	if len(r.SyncPeers) > 0 {
+		buffer.WriteString("\n\n[SYNC PEERS]\n")
+
+		rows := make([]string, len(r.SyncPeers)+1)
+		rows[0] = "ID|Score|Failures|Stalls|Invalid Blocks|Bans|Banned Until"
+
+		for i, p := range r.SyncPeers {
+			bannedUntil := "-"
+			if until := time.Unix(p.BannedUntil, 0); time.Now().Before(until) {
+				bannedUntil = until.UTC().Format(time.RFC3339)
+			}
+
+			rows[i+1] = fmt.Sprintf("%s|%d|%d|%d|%d|%d|%s",
+				p.ID, p.Score, p.Failures, p.Stalls, p.InvalidBlocks, p.Bans, bannedUntil)
+		}
+
+		buffer.WriteString(helper.FormatList(rows))
+	}
+
 	return buffer.String()
 }
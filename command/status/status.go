@@ -40,6 +40,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		CurrentBlockNumber: statusResponse.Current.Number,
 		CurrentBlockHash:   statusResponse.Current.Hash,
 		LibP2PAddress:      statusResponse.P2PAddr,
+		SyncPeers:          newSyncPeerResults(statusResponse.SyncPeers),
 	})
 }
 
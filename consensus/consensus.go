@@ -11,6 +11,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -35,6 +37,9 @@ type Consensus interface {
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
 
+	// GetSyncPeerReputations retrieves the sync reputation of the peers, if any
+	GetSyncPeerReputations() []*syncer.PeerReputation
+
 	// GetLatestChainConfig retrieves the latest chain configuration
 	GetLatestChainConfig() (*chain.Params, error)
 
@@ -89,6 +94,17 @@ type Params struct {
 
 	MetricsInterval time.Duration
 
+	// StateStorage is the storage of the state tries, which are served to the syncing peers
+	StateStorage itrie.Storage
+	// StateSync enables the state sync of a new node
+	StateSync bool
+	// CheckpointSync is the trusted block a new node starts syncing from, instead of the genesis
+	CheckpointSync *syncer.Checkpoint
+	// CheckpointBackfill enables the download of the blocks preceding the checkpoint
+	CheckpointBackfill bool
+	// HeaderSync enables the verification of the headers before the bodies of their blocks are synced
+	HeaderSync bool
+
 	// event tracker
 	EventTracker *EventTracker
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/consensus"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -250,6 +251,10 @@ func (d *Dev) GetSyncProgression() *progress.Progression {
 	return nil
 }
 
+func (d *Dev) GetSyncPeerReputations() []*syncer.PeerReputation {
+	return nil
+}
+
 func (d *Dev) Close() error {
 	close(d.closeCh)
 
@@ -6,6 +6,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/consensus"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -75,6 +76,10 @@ func (d *Dummy) GetSyncProgression() *progress.Progression {
 	return nil
 }
 
+func (d *Dummy) GetSyncPeerReputations() []*syncer.PeerReputation {
+	return nil
+}
+
 func (d *Dummy) Close() error {
 	close(d.closeCh)
 
@@ -0,0 +1,69 @@
+package polybft
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var errCheckpointValidatorsMismatch = errors.New("validator snapshot does not match the checkpoint validators hash")
+
+// ValidatorSnapshot returns the JSON encoded validator set which seals the block of the given number,
+// it is served to the peers syncing from a checkpoint
+func (p *Polybft) ValidatorSnapshot(blockNumber uint64) ([]byte, error) {
+	if blockNumber == 0 {
+		return nil, errors.New("genesis block has no validator seals")
+	}
+
+	validators, err := p.GetValidators(blockNumber-1, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return validators.Marshal()
+}
+
+// VerifyCheckpoint verifies the committed seals of the checkpoint header against the validator set,
+// which must be the one whose hash is carried in the header extra. The validator set is stored as
+// the snapshot of the checkpoint epoch, so the following blocks are verified without the preceding ones
+func (p *Polybft) VerifyCheckpoint(header *types.Header, snapshot []byte) error {
+	extra, err := GetIbftExtra(header.ExtraData)
+	if err != nil {
+		return fmt.Errorf("failed to decode extra of checkpoint block %d: %w", header.Number, err)
+	}
+
+	if extra.Committed == nil || extra.Checkpoint == nil {
+		return fmt.Errorf("%w: checkpoint block %d has no committed signatures", blockchain.ErrInvalidSeal, header.Number)
+	}
+
+	var validators validator.AccountSet
+	if err := validators.Unmarshal(snapshot); err != nil {
+		return fmt.Errorf("failed to decode validator snapshot: %w", err)
+	}
+
+	validatorsHash, err := validators.Hash()
+	if err != nil {
+		return err
+	}
+
+	if validatorsHash != extra.Checkpoint.CurrentValidatorsHash {
+		return fmt.Errorf("%w: have %s, want %s",
+			errCheckpointValidatorsMismatch, validatorsHash, extra.Checkpoint.CurrentValidatorsHash)
+	}
+
+	checkpointHash, err := extra.Checkpoint.Hash(p.blockchain.GetChainID(), header.Number, header.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to calculate proposal hash: %w", err)
+	}
+
+	if err := extra.Committed.Verify(header.Number, validators, checkpointHash,
+		signer.DomainCheckpointManager, p.logger); err != nil {
+		return fmt.Errorf("failed to verify signatures of checkpoint block %d: %w", header.Number, err)
+	}
+
+	return p.validatorsCache.seedSnapshot(header, extra.Checkpoint.EpochNumber, validators)
+}
@@ -15,6 +15,7 @@ import (
 	hcf "github.com/hashicorp/go-hclog"
 	bolt "go.etcd.io/bbolt"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
@@ -92,6 +93,9 @@ type runtimeConfig struct {
 	bridgeTopic     topic
 	consensusConfig *consensus.Config
 	eventTracker    *consensus.EventTracker
+
+	// validatorSetChanged is notified of the validator set on start, and whenever an epoch begins
+	validatorSetChanged func(validator.AccountSet)
 }
 
 // consensusRuntime is a struct that provides consensus runtime features like epoch, state and event management
@@ -190,9 +194,18 @@ func newConsensusRuntime(log hcf.Logger, config *runtimeConfig) (*consensusRunti
 		return nil, fmt.Errorf("could not commit db tx to init consensus runtime: %w", err)
 	}
 
+	runtime.notifyValidatorSet()
+
 	return runtime, nil
 }
 
+// notifyValidatorSet notifies the validator set of the current epoch, if anyone is interested
+func (c *consensusRuntime) notifyValidatorSet() {
+	if c.config.validatorSetChanged != nil {
+		c.config.validatorSetChanged(c.epoch.Validators)
+	}
+}
+
 // close is used to tear down allocated resources
 func (c *consensusRuntime) close() {
 	c.bridgeManager.Close()
@@ -378,6 +391,10 @@ func (c *consensusRuntime) OnBlockInserted(fullBlock *types.FullBlock) {
 	c.epoch = epoch
 	c.lastBuiltBlock = fullBlock.Block.Header
 
+	if isEndOfEpoch {
+		c.notifyValidatorSet()
+	}
+
 	endTime := time.Now().UTC()
 
 	c.logger.Debug("OnBlockInserted finished", "elapsedTime", endTime.Sub(startTime),
@@ -1084,6 +1101,12 @@ func (c *consensusRuntime) getFirstBlockOfEpoch(epochNumber uint64, latestHeader
 		firstBlockInEpoch = blockHeader.Number
 		blockHeader, blockExtra, err = getBlockData(blockHeader.Number-1, c.config.blockchain)
 
+		if errors.Is(err, blockchain.ErrNoBlock) && firstBlockInEpoch > 1 {
+			// the blocks preceding the checkpoint of a chain synced from it are missing,
+			// so the epoch is taken to start at the checkpoint
+			return firstBlockInEpoch, nil
+		}
+
 		if err != nil {
 			return 0, err
 		}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/bls"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
 	"github.com/0xPolygon/polygon-edge/crypto"
@@ -133,11 +134,13 @@ func (i *Extra) ValidateFinalizedData(header *types.Header, parent *types.Header
 	// validate committed signatures
 	blockNumber := header.Number
 	if i.Committed == nil {
-		return fmt.Errorf("failed to verify signatures for block %d, because signatures are not present", blockNumber)
+		return fmt.Errorf("%w: failed to verify signatures for block %d, because signatures are not present",
+			blockchain.ErrInvalidSeal, blockNumber)
 	}
 
 	if i.Checkpoint == nil {
-		return fmt.Errorf("failed to verify signatures for block %d, because checkpoint data are not present", blockNumber)
+		return fmt.Errorf("%w: failed to verify signatures for block %d, because checkpoint data are not present",
+			blockchain.ErrInvalidSeal, blockNumber)
 	}
 
 	// validate current block signatures
@@ -179,8 +182,8 @@ func (i *Extra) ValidateParentSignatures(blockNumber uint64, consensusBackend po
 	}
 
 	if i.Parent == nil {
-		return fmt.Errorf("failed to verify signatures for parent of block %d because signatures are not present",
-			blockNumber)
+		return fmt.Errorf("%w: failed to verify signatures for parent of block %d because signatures are not present",
+			blockchain.ErrInvalidSeal, blockNumber)
 	}
 
 	parentValidators, err := consensusBackend.GetValidators(blockNumber-2, parents)
@@ -256,17 +259,18 @@ func (s *Signature) UnmarshalRLPWith(v *fastrlp.Value) error {
 	return nil
 }
 
-// Verify is used to verify aggregated signature based on current validator set, message hash and domain
+// Verify is used to verify aggregated signature based on current validator set, message hash and domain.
+// The returned errors wrap blockchain.ErrInvalidSeal, as the signature is the seal of the block
 func (s *Signature) Verify(blockNumber uint64, validators validator.AccountSet,
 	hash types.Hash, domain []byte, logger hclog.Logger) error {
 	signers, err := validators.GetFilteredValidators(s.Bitmap)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %w", blockchain.ErrInvalidSeal, err)
 	}
 
 	validatorSet := validator.NewValidatorSet(validators, logger)
 	if !validatorSet.HasQuorum(blockNumber, signers.GetAddressesAsSet()) {
-		return fmt.Errorf("quorum not reached")
+		return fmt.Errorf("%w: quorum not reached", blockchain.ErrInvalidSeal)
 	}
 
 	blsPublicKeys := make([]*bls.PublicKey, len(signers))
@@ -276,11 +280,11 @@ func (s *Signature) Verify(blockNumber uint64, validators validator.AccountSet,
 
 	aggs, err := bls.UnmarshalSignature(s.AggregatedSignature)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %w", blockchain.ErrInvalidSeal, err)
 	}
 
 	if !aggs.VerifyAggregated(blsPublicKeys, hash[:], domain) {
-		return fmt.Errorf("could not verify aggregated signature")
+		return fmt.Errorf("%w: could not verify aggregated signature", blockchain.ErrInvalidSeal)
 	}
 
 	return nil
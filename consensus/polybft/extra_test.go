@@ -296,7 +296,7 @@ func TestExtra_ValidateFinalizedData_UnhappyPath(t *testing.T) {
 	err = extra.ValidateFinalizedData(
 		header, parent, nil, chainID, polyBackendMock, signer.DomainCheckpointManager, hclog.NewNullLogger())
 	require.ErrorContains(t, err,
-		fmt.Sprintf("failed to verify signatures for block %d (proposal hash %s): quorum not reached", headerNum, checkpointHash))
+		fmt.Sprintf("failed to verify signatures for block %d (proposal hash %s): invalid block seal: quorum not reached", headerNum, checkpointHash))
 
 	// incorrect parent extra size
 	validSignature := createSignature(t, validators.GetPrivateIdentities(), checkpointHash, signer.DomainCheckpointManager)
@@ -353,7 +353,7 @@ func TestExtra_ValidateParentSignatures(t *testing.T) {
 	err = extra.ValidateParentSignatures(
 		headerNum, polyBackendMock, nil, parent, parentExtra, chainID, signer.DomainCheckpointManager, hclog.NewNullLogger())
 	require.ErrorContains(t, err,
-		fmt.Sprintf("failed to verify signatures for parent of block %d (proposal hash: %s): could not verify aggregated signature", headerNum, parentCheckpointHash))
+		fmt.Sprintf("failed to verify signatures for parent of block %d (proposal hash: %s): invalid block seal: could not verify aggregated signature", headerNum, parentCheckpointHash))
 
 	// valid signature provided
 	validSig := createSignature(t, validators.GetPrivateIdentities(), parentCheckpointHash, signer.DomainCheckpointManager)
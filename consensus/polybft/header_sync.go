@@ -0,0 +1,103 @@
+package polybft
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	errCurrentValidatorsMismatch = errors.New("current validators hashes don't match")
+	errNextValidatorsMismatch    = errors.New("next validators hashes don't match")
+)
+
+// VerifyHeaders verifies the consecutive headers following the local head, before the bodies of their blocks
+// are downloaded. The committed seals of each header are verified against the validators derived from
+// the preceding headers, by applying the validator set deltas of the epoch ending ones
+func (p *Polybft) VerifyHeaders(headers []*types.Header) error {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	parent, ok := p.blockchain.GetHeaderByHash(headers[0].ParentHash)
+	if !ok {
+		return fmt.Errorf("unable to get parent header by hash for block number %d", headers[0].Number)
+	}
+
+	parentExtra, err := GetIbftExtra(parent.ExtraData)
+	if err != nil {
+		return fmt.Errorf("failed to verify header for block %d. get parent extra error = %w", headers[0].Number, err)
+	}
+
+	validators, err := p.GetValidators(parent.Number, nil)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve validators for block %d: %w", headers[0].Number, err)
+	}
+
+	validatorsHash, err := validators.Hash()
+	if err != nil {
+		return err
+	}
+
+	var (
+		chainID        = p.blockchain.GetChainID()
+		blockTimeDrift = p.runtime.getCurrentBlockTimeDrift()
+	)
+
+	for _, header := range headers {
+		if err := validateHeaderFields(parent, header, blockTimeDrift); err != nil {
+			return fmt.Errorf("failed to validate header for block %d. error = %w", header.Number, err)
+		}
+
+		extra, err := GetIbftExtra(header.ExtraData)
+		if err != nil {
+			return fmt.Errorf("failed to verify header for block %d. get extra error = %w", header.Number, err)
+		}
+
+		if extra.Committed == nil || extra.Checkpoint == nil {
+			return fmt.Errorf("%w: failed to verify signatures for block %d, because signatures are not present",
+				blockchain.ErrInvalidSeal, header.Number)
+		}
+
+		if err := extra.Checkpoint.ValidateBasic(parentExtra.Checkpoint); err != nil {
+			return fmt.Errorf("failed to verify checkpoint of block %d: %w", header.Number, err)
+		}
+
+		if validatorsHash != extra.Checkpoint.CurrentValidatorsHash {
+			return fmt.Errorf("failed to verify header for block %d: %w", header.Number, errCurrentValidatorsMismatch)
+		}
+
+		checkpointHash, err := extra.Checkpoint.Hash(chainID, header.Number, header.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to calculate proposal hash: %w", err)
+		}
+
+		if err := extra.Committed.Verify(header.Number, validators, checkpointHash,
+			signer.DomainCheckpointManager, p.logger); err != nil {
+			return fmt.Errorf("failed to verify signatures for block %d (proposal hash %s): %w",
+				header.Number, checkpointHash, err)
+		}
+
+		// the validator set changes after the epoch ending blocks
+		if extra.Validators != nil && !extra.Validators.IsEmpty() {
+			if validators, err = validators.ApplyDelta(extra.Validators); err != nil {
+				return fmt.Errorf("failed to apply validator set delta of block %d: %w", header.Number, err)
+			}
+
+			if validatorsHash, err = validators.Hash(); err != nil {
+				return err
+			}
+		}
+
+		if validatorsHash != extra.Checkpoint.NextValidatorsHash {
+			return fmt.Errorf("failed to verify header for block %d: %w", header.Number, errNextValidatorsMismatch)
+		}
+
+		parent, parentExtra = header, extra
+	}
+
+	return nil
+}
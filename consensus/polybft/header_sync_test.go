@@ -0,0 +1,156 @@
+package polybft
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolybft_VerifyHeaders(t *testing.T) {
+	t.Parallel()
+
+	const epochSize = 10
+
+	validators := validator.NewTestValidators(t, 6)
+	validatorSet := validators.GetPublicIdentities()
+	accounts := validators.GetPrivateIdentities()
+
+	// the validator set changes at the end of the first epoch
+	validatorSetParent, validatorSetCurrent := validatorSet[:len(validatorSet)-1], validatorSet[1:]
+	accountSetParent, accountSetCurrent := accounts[:len(accounts)-1], accounts[1:]
+
+	parentHash, err := validatorSetParent.Hash()
+	require.NoError(t, err)
+
+	currentHash, err := validatorSetCurrent.Hash()
+	require.NoError(t, err)
+
+	epochDelta, err := validator.CreateValidatorSetDelta(validatorSetParent, validatorSetCurrent)
+	require.NoError(t, err)
+
+	genesisDelta, err := validator.CreateValidatorSetDelta(nil, validatorSetParent)
+	require.NoError(t, err)
+
+	genesis := &types.Header{Number: 0, Timestamp: uint64(time.Now().UTC().Unix()) - 100}
+	genesis.ExtraData = (&Extra{Validators: genesisDelta, Checkpoint: &CheckpointData{}}).MarshalRLPTo(nil)
+	genesis.ComputeHash()
+
+	// sealHeader sets the extra of the header and seals it by the accounts
+	sealHeader := func(header *types.Header, extra *Extra, accounts []*wallet.Account) {
+		extra.Committed = &Signature{}
+		header.ExtraData = extra.MarshalRLPTo(nil)
+		header.ComputeHash()
+
+		checkpointHash, err := extra.Checkpoint.Hash(0, header.Number, header.Hash)
+		require.NoError(t, err)
+
+		extra.Committed = createSignature(t, accounts, checkpointHash, signer.DomainCheckpointManager)
+		header.ExtraData = extra.MarshalRLPTo(nil)
+	}
+
+	// createHeaders creates the headers following the genesis, the tamper function modifies
+	// the extra of a header before it is sealed
+	createHeaders := func(tamper func(number uint64, extra *Extra) []*wallet.Account) []*types.Header {
+		headers := make([]*types.Header, 0, epochSize+2)
+		parent := genesis
+
+		for i := uint64(1); i <= epochSize+2; i++ {
+			header := &types.Header{
+				Number:     i,
+				ParentHash: parent.Hash,
+				Timestamp:  parent.Timestamp + 1,
+				MixHash:    PolyBFTMixDigest,
+				Difficulty: 1,
+			}
+
+			extra := &Extra{Checkpoint: &CheckpointData{
+				EpochNumber:           1,
+				CurrentValidatorsHash: parentHash,
+				NextValidatorsHash:    parentHash,
+			}}
+			sealers := accountSetParent
+
+			switch {
+			case i == epochSize:
+				extra.Validators = epochDelta
+				extra.Checkpoint.NextValidatorsHash = currentHash
+			case i > epochSize:
+				extra.Checkpoint = &CheckpointData{
+					EpochNumber:           2,
+					CurrentValidatorsHash: currentHash,
+					NextValidatorsHash:    currentHash,
+				}
+				sealers = accountSetCurrent
+			}
+
+			if tamper != nil {
+				if accounts := tamper(i, extra); accounts != nil {
+					sealers = accounts
+				}
+			}
+
+			sealHeader(header, extra, sealers)
+
+			headers = append(headers, header)
+			parent = header
+		}
+
+		return headers
+	}
+
+	headersMap := &testHeadersMap{}
+	headersMap.addHeader(genesis)
+
+	blockchainMock := new(blockchainMock)
+	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(headersMap.getHeader)
+	blockchainMock.On("GetHeaderByHash", mock.Anything).Return(headersMap.getHeaderByHash)
+
+	polybft := &Polybft{
+		logger:     hclog.NewNullLogger(),
+		blockchain: blockchainMock,
+		validatorsCache: newValidatorsSnapshotCache(
+			hclog.NewNullLogger(),
+			newTestState(t),
+			blockchainMock,
+		),
+		runtime: &consensusRuntime{
+			epoch: &epochMetadata{
+				CurrentClientConfig: &PolyBFTConfig{EpochSize: epochSize, BlockTimeDrift: 10},
+			},
+		},
+	}
+
+	// the validators of the second epoch are derived from the delta of the epoch ending header
+	require.NoError(t, polybft.VerifyHeaders(createHeaders(nil)))
+
+	// the headers of the second epoch sealed by the validators of the first one
+	headers := createHeaders(func(number uint64, _ *Extra) []*wallet.Account {
+		if number > epochSize {
+			return accountSetParent
+		}
+
+		return nil
+	})
+	require.ErrorContains(t, polybft.VerifyHeaders(headers), "failed to verify signatures for block 11")
+
+	// the epoch ending header which does not commit to the validators of the next epoch
+	headers = createHeaders(func(number uint64, extra *Extra) []*wallet.Account {
+		if number == epochSize {
+			extra.Checkpoint.NextValidatorsHash = parentHash
+		}
+
+		return nil
+	})
+	require.ErrorIs(t, polybft.VerifyHeaders(headers), errNextValidatorsMismatch)
+
+	// the headers must follow the local head
+	headers = createHeaders(nil)
+	require.ErrorContains(t, polybft.VerifyHeaders(headers[1:]), "unable to get parent header")
+}
@@ -396,6 +396,12 @@ func (tp *syncerMock) GetSyncProgression() *progress.Progression {
 	return args[0].(*progress.Progression)
 }
 
+func (tp *syncerMock) PeerReputations() []*syncer.PeerReputation {
+	args := tp.Called()
+
+	return args[0].([]*syncer.PeerReputation)
+}
+
 func (tp *syncerMock) HasSyncPeer() bool {
 	args := tp.Called()
 
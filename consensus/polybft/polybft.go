@@ -24,6 +24,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/network"
+	networkCommon "github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -469,12 +470,29 @@ func (p *Polybft) Initialize() error {
 	// set key
 	p.key = wallet.NewKey(account)
 
+	if err := p.setupValidatorPeers(); err != nil {
+		return err
+	}
+
 	// create and set syncer
 	p.syncer = syncer.NewSyncer(
 		p.config.Logger.Named("syncer"),
 		p.config.Network,
 		p.config.Blockchain,
 		time.Duration(p.config.BlockTime)*3*time.Second,
+		syncer.StateSyncConfig{
+			Storage: p.config.StateStorage,
+			Enabled: p.config.StateSync,
+		},
+		syncer.CheckpointSyncConfig{
+			Checkpoint: p.config.CheckpointSync,
+			Backfill:   p.config.CheckpointBackfill,
+			Verifier:   p,
+		},
+		syncer.HeaderSyncConfig{
+			Enabled:  p.config.HeaderSync,
+			Verifier: p,
+		},
 	)
 
 	// set blockchain backend
@@ -590,6 +608,8 @@ func (p *Polybft) initRuntime() error {
 		bridgeTopic:     p.bridgeTopic,
 		consensusConfig: p.config.Config,
 		eventTracker:    p.config.EventTracker,
+
+		validatorSetChanged: p.allowValidatorPeers,
 	}
 
 	runtime, err := newConsensusRuntime(p.logger, runtimeConfig)
@@ -602,6 +622,39 @@ func (p *Polybft) initRuntime() error {
 	return nil
 }
 
+// setupValidatorPeers signs the peer ID of the node with the validator key, so the nodes allowing the validator
+// set accept the peer of the validator, and maps the genesis validators to the peers of their multiaddrs
+func (p *Polybft) setupValidatorPeers() error {
+	if p.config.Network == nil {
+		return nil
+	}
+
+	if err := p.config.Network.SetValidatorSigner(wallet.NewEcdsaSigner(p.key)); err != nil {
+		return err
+	}
+
+	for _, v := range p.genesisClientConfig.InitialValidatorSet {
+		info, err := networkCommon.StringToAddrInfo(v.MultiAddr)
+		if err != nil {
+			continue
+		}
+
+		p.config.Network.AddValidatorPeer(v.Address, info.ID)
+	}
+
+	return nil
+}
+
+// allowValidatorPeers allows the peers of the validator set to connect, if the networking is permissioned.
+// The validators prove their peer IDs in the handshake
+func (p *Polybft) allowValidatorPeers(validators validator.AccountSet) {
+	if p.config.Network == nil {
+		return
+	}
+
+	p.config.Network.SetAllowedValidators(validators.GetAddresses())
+}
+
 // startRuntime starts consensus runtime
 func (p *Polybft) startRuntime() error {
 	go p.startConsensusProtocol()
@@ -729,6 +782,11 @@ func (p *Polybft) GetSyncProgression() *progress.Progression {
 	return p.syncer.GetSyncProgression()
 }
 
+// GetSyncPeerReputations retrieves the sync reputation of the peers
+func (p *Polybft) GetSyncPeerReputations() []*syncer.PeerReputation {
+	return p.syncer.PeerReputations()
+}
+
 // VerifyHeader implements consensus.Engine and checks whether a header conforms to the consensus rules
 func (p *Polybft) VerifyHeader(header *types.Header) error {
 	// Short circuit if the header is known
@@ -78,24 +78,11 @@ func (v *validatorsSnapshotCache) GetSnapshot(
 		v.lock.Unlock()
 	}()
 
-	_, extra, err := getBlockData(blockNumber, v.blockchain)
+	epochToGetSnapshot, err := v.getSnapshotEpoch(blockNumber)
 	if err != nil {
 		return nil, err
 	}
 
-	isEpochEndingBlock, err := isEpochEndingBlock(blockNumber, extra, v.blockchain)
-	if err != nil && !errors.Is(err, blockchain.ErrNoBlock) {
-		// if there is no block after given block, we assume its not epoch ending block
-		// but, it's a regular use case, and we should not stop the snapshot calculation
-		// because there are cases we need the snapshot for the latest block in chain
-		return nil, err
-	}
-
-	epochToGetSnapshot := extra.Checkpoint.EpochNumber
-	if !isEpochEndingBlock {
-		epochToGetSnapshot--
-	}
-
 	v.logger.Trace("Retrieving snapshot started...", "Block", blockNumber, "Epoch", epochToGetSnapshot)
 
 	latestValidatorSnapshot, err := v.getLastCachedSnapshot(epochToGetSnapshot, tx)
@@ -172,6 +159,66 @@ func (v *validatorsSnapshotCache) GetSnapshot(
 	return latestValidatorSnapshot.Snapshot.Copy(), nil
 }
 
+// getSnapshotEpoch returns the epoch of the snapshot holding the validators of the block following the given one
+func (v *validatorsSnapshotCache) getSnapshotEpoch(blockNumber uint64) (uint64, error) {
+	_, extra, err := getBlockData(blockNumber, v.blockchain)
+	if errors.Is(err, blockchain.ErrNoBlock) {
+		// the blocks preceding the checkpoint of a chain synced from it are missing,
+		// the validators of the following block are the ones of its epoch then
+		if _, childExtra, childErr := getBlockData(blockNumber+1, v.blockchain); childErr == nil {
+			return childExtra.Checkpoint.EpochNumber - 1, nil
+		}
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	isEpochEndingBlock, err := isEpochEndingBlock(blockNumber, extra, v.blockchain)
+	if err != nil && !errors.Is(err, blockchain.ErrNoBlock) {
+		// if there is no block after given block, we assume its not epoch ending block
+		// but, it's a regular use case, and we should not stop the snapshot calculation
+		// because there are cases we need the snapshot for the latest block in chain
+		return 0, err
+	}
+
+	epochToGetSnapshot := extra.Checkpoint.EpochNumber
+	if !isEpochEndingBlock {
+		epochToGetSnapshot--
+	}
+
+	return epochToGetSnapshot, nil
+}
+
+// seedSnapshot stores the validators of the checkpoint block of a chain synced from it, so the snapshots
+// of the following epochs are computed without the blocks preceding the checkpoint. The validators are
+// the snapshot of the previous epoch, whose ending block is taken to be the parent of the checkpoint
+func (v *validatorsSnapshotCache) seedSnapshot(checkpoint *types.Header, epoch uint64,
+	validators validator.AccountSet) error {
+	// the db transaction is opened before the lock is taken, the same as in GetSnapshot
+	tx, err := v.state.beginDBTransaction(true)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	snapshot := &validatorSnapshot{
+		Epoch:            epoch - 1,
+		EpochEndingBlock: checkpoint.Number - 1,
+		Snapshot:         validators,
+	}
+
+	if err := v.storeSnapshot(snapshot, tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 // computeSnapshot gets desired block header by block number, extracts its extra and applies given delta to the snapshot
 func (v *validatorsSnapshotCache) computeSnapshot(
 	existingSnapshot *validatorSnapshot,
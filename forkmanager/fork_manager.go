@@ -207,6 +207,40 @@ func (fm *forkManager) GetForkBlock(name string) (uint64, error) {
 	return fork.FromBlockNumber, nil
 }
 
+// GetForkBlocks returns the distinct blocks the active forks are enabled from, in ascending order.
+// The forks enabled from the genesis block are omitted
+func (fm *forkManager) GetForkBlocks() []uint64 {
+	fm.lock.Lock()
+	defer fm.lock.Unlock()
+
+	blocks := make([]uint64, 0, len(fm.forkMap))
+
+	for _, fork := range fm.forkMap {
+		if fork.IsActive && fork.FromBlockNumber > 0 {
+			blocks = append(blocks, fork.FromBlockNumber)
+		}
+	}
+
+	return SortedForkBlocks(blocks)
+}
+
+// SortedForkBlocks sorts the fork blocks in ascending order, and removes the duplicates
+func SortedForkBlocks(blocks []uint64) []uint64 {
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i] < blocks[j]
+	})
+
+	distinct := blocks[:0]
+
+	for i, block := range blocks {
+		if i == 0 || block != blocks[i-1] {
+			distinct = append(distinct, block)
+		}
+	}
+
+	return distinct
+}
+
 func (fm *forkManager) addHandler(handlerName HandlerDesc, blockNumber uint64, handlerCont HandlerContainer) {
 	if handlers, exists := fm.handlersMap[handlerName]; !exists {
 		fm.handlersMap[handlerName] = []forkHandler{
@@ -347,3 +347,27 @@ func TestForkManager_HandlerPrecedence(t *testing.T) {
 	assert.Equal(t, "B", execute(HandlerA, 0))
 	assert.NoError(t, forkManager.DeactivateFork(ForkB))
 }
+
+func TestForkManager_GetForkBlocks(t *testing.T) {
+	t.Parallel()
+
+	forkManager := &forkManager{
+		forkMap:     map[string]*Fork{},
+		handlersMap: map[HandlerDesc][]forkHandler{},
+	}
+
+	for _, name := range []string{ForkA, ForkB, ForkC, ForkD, ForkE} {
+		forkManager.RegisterFork(name, &ForkParams{})
+	}
+
+	assert.NoError(t, forkManager.ActivateFork(ForkA, 0))
+	assert.NoError(t, forkManager.ActivateFork(ForkB, 30))
+	assert.NoError(t, forkManager.ActivateFork(ForkC, 10))
+	assert.NoError(t, forkManager.ActivateFork(ForkD, 30))
+
+	// the genesis fork, the duplicated block and the inactive fork are omitted
+	assert.Equal(t, []uint64{10, 30}, forkManager.GetForkBlocks())
+
+	assert.NoError(t, forkManager.DeactivateFork(ForkC))
+	assert.Equal(t, []uint64{30}, forkManager.GetForkBlocks())
+}
@@ -9,11 +9,15 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/gasprofiler"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
-const callTracerName = "callTracer"
+const (
+	callTracerName  = "callTracer"
+	gasProfilerName = "gasProfiler"
+)
 
 var (
 	defaultTraceTimeout = 5 * time.Second
@@ -80,13 +84,21 @@ func NewDebug(store debugStore, requestsPerSecond uint64) *Debug {
 }
 
 type TraceConfig struct {
-	EnableMemory      bool    `json:"enableMemory"`
-	DisableStack      bool    `json:"disableStack"`
-	DisableStorage    bool    `json:"disableStorage"`
-	EnableReturnData  bool    `json:"enableReturnData"`
-	DisableStructLogs bool    `json:"disableStructLogs"`
-	Timeout           *string `json:"timeout"`
-	Tracer            string  `json:"tracer"`
+	EnableMemory      bool          `json:"enableMemory"`
+	DisableStack      bool          `json:"disableStack"`
+	DisableStorage    bool          `json:"disableStorage"`
+	EnableReturnData  bool          `json:"enableReturnData"`
+	DisableStructLogs bool          `json:"disableStructLogs"`
+	Timeout           *string       `json:"timeout"`
+	Tracer            string        `json:"tracer"`
+	TracerConfig      *TracerConfig `json:"tracerConfig"`
+}
+
+// TracerConfig holds the options of the native tracers
+type TracerConfig struct {
+	WithLog     bool `json:"withLog"`
+	OnlyTopCall bool `json:"onlyTopCall"`
+	Folded      bool `json:"folded"`
 }
 
 func (d *Debug) TraceBlockByNumber(
@@ -228,7 +240,17 @@ func (d *Debug) traceBlock(
 
 	defer cancel()
 
-	return d.store.TraceBlock(block, tracer)
+	results, err := d.store.TraceBlock(block, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	// gas profiles are aggregated across the whole block
+	if config.Tracer == gasProfilerName {
+		return gasprofiler.MergeResults(results)
+	}
+
+	return results, nil
 }
 
 // newTracer creates new tracer by config
@@ -254,9 +276,25 @@ func newTracer(config *TraceConfig) (
 
 	var tracer tracer.Tracer
 
-	if config.Tracer == callTracerName {
-		tracer = &calltracer.CallTracer{}
-	} else {
+	switch config.Tracer {
+	case callTracerName:
+		callConfig := calltracer.Config{}
+
+		if config.TracerConfig != nil {
+			callConfig.WithLog = config.TracerConfig.WithLog
+			callConfig.OnlyTopCall = config.TracerConfig.OnlyTopCall
+		}
+
+		tracer = calltracer.NewCallTracer(callConfig)
+	case gasProfilerName:
+		profilerConfig := gasprofiler.Config{}
+
+		if config.TracerConfig != nil {
+			profilerConfig.Folded = config.TracerConfig.Folded
+		}
+
+		tracer = gasprofiler.NewGasProfiler(profilerConfig)
+	default:
 		tracer = structtracer.NewStructTracer(structtracer.Config{
 			EnableMemory:     config.EnableMemory && !config.DisableStructLogs,
 			EnableStack:      !config.DisableStack && !config.DisableStructLogs,
@@ -11,6 +11,8 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/gasprofiler"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -171,6 +173,22 @@ func TestDebugTraceConfigDecode(t *testing.T) {
 				DisableStructLogs: true,
 			},
 		},
+		{
+			input: `{
+				"tracer": "callTracer",
+				"tracerConfig": {
+					"withLog": true,
+					"onlyTopCall": true
+				}
+			}`,
+			expected: TraceConfig{
+				Tracer: "callTracer",
+				TracerConfig: &TracerConfig{
+					WithLog:     true,
+					OnlyTopCall: true,
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -248,6 +266,29 @@ func TestTraceBlockByNumber(t *testing.T) {
 			result: testTraceResults,
 			err:    false,
 		},
+		{
+			name:        "should aggregate gas profiles of the block",
+			blockNumber: 10,
+			config:      &TraceConfig{Tracer: gasProfilerName},
+			store: &debugEndpointMockStore{
+				getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+					return testBlock10, true
+				},
+				traceBlockFn: func(block *types.Block, tracer tracer.Tracer) ([]interface{}, error) {
+					return []interface{}{
+						&gasprofiler.Result{GasUsed: 21000},
+						&gasprofiler.Result{GasUsed: 30000},
+					}, nil
+				},
+			},
+			result: &gasprofiler.Result{
+				GasUsed:   51000,
+				Contracts: []*gasprofiler.ContractGas{},
+				Functions: []*gasprofiler.FunctionGas{},
+				Opcodes:   []*gasprofiler.OpcodeGas{},
+			},
+			err: false,
+		},
 		{
 			name:        "should return errTraceGenesisBlock for genesis block",
 			blockNumber: 0,
@@ -833,4 +874,50 @@ func Test_newTracer(t *testing.T) {
 			EnableStructLogs: false,
 		}, st.Config)
 	})
+	t.Run("should create call tracer with tracer config", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer: callTracerName,
+			TracerConfig: &TracerConfig{
+				WithLog:     true,
+				OnlyTopCall: true,
+			},
+		})
+
+		t.Cleanup(func() {
+			cancel()
+		})
+
+		assert.NoError(t, err)
+
+		ct, ok := tracer.(*calltracer.CallTracer)
+		require.True(t, ok)
+
+		assert.Equal(t, calltracer.Config{
+			WithLog:     true,
+			OnlyTopCall: true,
+		}, ct.Config)
+	})
+	t.Run("should create gas profiler", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer: gasProfilerName,
+			TracerConfig: &TracerConfig{
+				Folded: true,
+			},
+		})
+
+		t.Cleanup(func() {
+			cancel()
+		})
+
+		assert.NoError(t, err)
+
+		gp, ok := tracer.(*gasprofiler.GasProfiler)
+		require.True(t, ok)
+
+		assert.Equal(t, gasprofiler.Config{Folded: true}, gp.Config)
+	})
 }
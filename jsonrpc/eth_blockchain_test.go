@@ -54,6 +54,26 @@ func TestEth_Block_GetBlockByNumber(t *testing.T) {
 	}
 }
 
+func TestEth_Block_GetBlockByNumber_HistoryPruned(t *testing.T) {
+	store := &mockBlockStore{historyTail: 5}
+	for i := 0; i < 10; i++ {
+		store.add(newTestBlock(uint64(i), hash1))
+	}
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.GetBlockByNumber(BlockNumber(4), false)
+	assert.ErrorIs(t, err, ErrHistoryPruned)
+	assert.Nil(t, res)
+
+	// genesis and the blocks from the tail are still served
+	for _, num := range []BlockNumber{0, 5} {
+		res, err = eth.GetBlockByNumber(num, false)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	}
+}
+
 func TestEth_Block_GetBlockByHash(t *testing.T) {
 	store := &mockBlockStore{}
 	store.add(newTestBlock(1, hash1))
@@ -308,6 +328,27 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 		assert.Nil(t, res)
 	})
 
+	t.Run("returns history pruned error if transaction not found in the pruned node", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockBlockStore{historyTail: 5, txIndexTail: 5}
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.GetTransactionReceipt(hash1)
+
+		assert.ErrorIs(t, err, ErrHistoryPruned)
+		assert.Nil(t, res)
+
+		// a pending transaction is known not to be mined yet
+		txn := newTestTransaction(uint64(0), addr0)
+		store.pendingTxns = append(store.pendingTxns, txn)
+
+		res, err = eth.GetTransactionReceipt(txn.Hash())
+
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
 	t.Run("returns correct receipt data for found transaction", func(t *testing.T) {
 		t.Parallel()
 
@@ -716,6 +757,8 @@ type mockBlockStore struct {
 	returnValue     []byte
 	forksInTime     chain.ForksInTime
 	baseFee         uint64
+	historyTail     uint64
+	txIndexTail     uint64
 
 	maxPriorityFeePerGasFn func() (*big.Int, error)
 }
@@ -843,6 +886,14 @@ func (m *mockBlockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, e
 	return receipts, nil
 }
 
+func (m *mockBlockStore) HistoryTail() uint64 {
+	return m.historyTail
+}
+
+func (m *mockBlockStore) TxIndexTail() uint64 {
+	return m.txIndexTail
+}
+
 func (m *mockBlockStore) GetBlockByNumber(blockNumber uint64, full bool) (*types.Block, bool) {
 	for _, b := range m.blocks {
 		if b.Number() == blockNumber {
@@ -65,6 +65,12 @@ type ethBlockchainStore interface {
 	// GetReceiptsByHash returns the receipts for a block hash
 	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
 
+	// HistoryTail returns the number of the first block with the body and receipts available
+	HistoryTail() uint64
+
+	// TxIndexTail returns the number of the first block with the transaction lookups available
+	TxIndexTail() uint64
+
 	// GetAvgGasPrice returns the average gas price
 	GetAvgGasPrice() *big.Int
 
@@ -132,6 +138,10 @@ func (e *Eth) GetBlockByNumber(number BlockNumber, fullTx bool) (interface{}, er
 		return nil, err
 	}
 
+	if err := e.checkHistoryPruned(num); err != nil {
+		return nil, err
+	}
+
 	block, ok := e.store.GetBlockByNumber(num, true)
 	if !ok {
 		return nil, nil
@@ -148,6 +158,11 @@ func (e *Eth) GetBlockByNumber(number BlockNumber, fullTx bool) (interface{}, er
 func (e *Eth) GetBlockByHash(hash types.Hash, fullTx bool) (interface{}, error) {
 	block, ok := e.store.GetBlockByHash(hash, true)
 	if !ok {
+		// the header of the block is kept when its body is pruned
+		if header, ok := e.store.GetHeaderByHash(hash); ok {
+			return nil, e.checkHistoryPruned(header.Number)
+		}
+
 		return nil, nil
 	}
 
@@ -208,6 +223,29 @@ func (e *Eth) headerFilterExtra(header *types.Header) (*types.Header, error) {
 	return headerCopy, err
 }
 
+// checkHistoryPruned returns an error if the body and receipts of the block were pruned
+func (e *Eth) checkHistoryPruned(num uint64) error {
+	// genesis has no body, so it is served in full regardless
+	if tail := e.store.HistoryTail(); num > 0 && num < tail {
+		return fmt.Errorf("%w: bodies and receipts of the blocks before %d are not available", ErrHistoryPruned, tail)
+	}
+
+	return nil
+}
+
+// checkTxIndexPruned returns an error if the transaction which is not found may be in a block whose
+// transaction lookups were pruned. A pending transaction is known not to be mined yet
+func (e *Eth) checkTxIndexPruned(hash types.Hash) error {
+	if tail := e.store.TxIndexTail(); tail > 1 {
+		if _, pending := e.store.GetPendingTx(hash); !pending {
+			return fmt.Errorf("%w: transaction not found, the transactions of the blocks before %d are not indexed",
+				ErrHistoryPruned, tail)
+		}
+	}
+
+	return nil
+}
+
 func (e *Eth) filterExtra(block *types.Block) error {
 	headerCopy, err := e.headerFilterExtra(block.Header)
 	if err != nil {
@@ -290,6 +328,10 @@ func (e *Eth) GetTransactionByBlockNumberAndIndex(number BlockNumber, index argU
 		return nil, err
 	}
 
+	if err := e.checkHistoryPruned(num); err != nil {
+		return nil, err
+	}
+
 	block, ok := e.store.GetBlockByNumber(num, true)
 	if !ok {
 		return nil, nil
@@ -396,6 +438,11 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 		return resultTxn, nil
 	}
 
+	// 3. Check if the txn may be in the pruned history
+	if err := e.checkTxIndexPruned(hash); err != nil {
+		return nil, err
+	}
+
 	// Transaction not found in state or TxPool
 	e.logger.Warn(
 		fmt.Sprintf("Transaction with hash [%s] not found", hash),
@@ -408,10 +455,19 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 	blockNum, ok := e.store.ReadTxLookup(hash)
 	if !ok {
+		// the lookups of the pruned transactions are deleted, so the txn may be in the pruned history
+		if err := e.checkTxIndexPruned(hash); err != nil {
+			return nil, err
+		}
+
 		// txn not found
 		return nil, nil
 	}
 
+	if err := e.checkHistoryPruned(blockNum); err != nil {
+		return nil, err
+	}
+
 	block, ok := e.store.GetBlockByNumber(blockNum, true)
 	if !ok {
 		// block not found
@@ -468,6 +524,10 @@ func (e *Eth) GetBlockReceipts(number BlockNumber) (interface{}, error) {
 		return nil, err
 	}
 
+	if err := e.checkHistoryPruned(num); err != nil {
+		return nil, err
+	}
+
 	block, ok := e.store.GetBlockByNumber(num, true)
 	if !ok {
 		return nil, ErrBlockNotFound
@@ -145,6 +145,14 @@ func (m *mockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
 	return receipts, nil
 }
 
+func (m *mockStore) HistoryTail() uint64 {
+	return 0
+}
+
+func (m *mockStore) TxIndexTail() uint64 {
+	return 0
+}
+
 func (m *mockStore) SubscribeEvents() blockchain.Subscription {
 	return m.subscription
 }
@@ -0,0 +1,336 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	helperCommon "github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+var (
+	errAllowlistDisabled = errors.New("permissioned networking is disabled")
+	errPeerNotAllowed    = errors.New("peer is not allowed")
+)
+
+// AllowlistConfig is the configuration of the permissioned networking,
+// the connections of the peers which are not allowed are refused
+type AllowlistConfig struct {
+	// Path is the JSON file listing the IDs of the allowed peers, and the networks the allowed
+	// peers connect from. It is reloaded at runtime
+	Path string
+
+	// Validators allows the peers of the current validator set, along with the peers of the file.
+	// The validators prove their peer IDs by signing them with their validator keys in the handshake,
+	// so the peers which are not listed are connected until the handshake checks their proofs
+	Validators bool
+}
+
+// ValidatorSigner is the validator key of the node, it signs the peer ID of the node so the peer
+// of the validator is allowed by the nodes allowing the validator set
+type ValidatorSigner interface {
+	// Address returns the address of the validator
+	Address() types.Address
+
+	// Sign signs the hash with the ECDSA key of the validator
+	Sign(hash []byte) ([]byte, error)
+}
+
+// allowlistFile is the format of the allowlist file
+type allowlistFile struct {
+	Peers []string `json:"peers"`
+	CIDRs []string `json:"cidrs"`
+}
+
+// peerAllowlist keeps the peers which are allowed to connect to the node, or to be dialed by it.
+// A peer is allowed if its ID is listed, if it connects from an allowed network, or if it is
+// the peer of a validator of the current set
+type peerAllowlist struct {
+	path          string
+	hasValidators bool // whether the peers of the validator set are allowed
+
+	lock           sync.RWMutex
+	peers          map[peer.ID]struct{}
+	validators     map[types.Address]struct{} // the addresses of the current validator set
+	validatorPeers map[peer.ID]types.Address  // the validators whose peers are known
+	networks       []*net.IPNet
+}
+
+// newPeerAllowlist creates the allowlist and loads its file, it returns nil if the allowlist is disabled
+func newPeerAllowlist(config *AllowlistConfig) (*peerAllowlist, error) {
+	if config == nil || (config.Path == "" && !config.Validators) {
+		return nil, nil //nolint:nilnil
+	}
+
+	a := &peerAllowlist{
+		path:           config.Path,
+		hasValidators:  config.Validators,
+		peers:          make(map[peer.ID]struct{}),
+		validators:     make(map[types.Address]struct{}),
+		validatorPeers: make(map[peer.ID]types.Address),
+	}
+
+	if err := a.load(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// load reads the allowlist file, and replaces the allowed peers and networks.
+// The allowlist is left unchanged if the file is invalid
+func (a *peerAllowlist) load() error {
+	if a.path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(a.path)
+	if err != nil {
+		return fmt.Errorf("failed to read allowlist: %w", err)
+	}
+
+	var file allowlistFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to decode allowlist: %w", err)
+	}
+
+	peers := make(map[peer.ID]struct{}, len(file.Peers))
+
+	for _, rawID := range file.Peers {
+		peerID, err := peer.Decode(rawID)
+		if err != nil {
+			return fmt.Errorf("invalid allowlist peer %s: %w", rawID, err)
+		}
+
+		peers[peerID] = struct{}{}
+	}
+
+	networks := make([]*net.IPNet, 0, len(file.CIDRs))
+
+	for _, cidr := range file.CIDRs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("invalid allowlist network %s: %w", cidr, err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.peers = peers
+	a.networks = networks
+
+	return nil
+}
+
+// setValidators replaces the addresses of the validator set
+func (a *peerAllowlist) setValidators(addresses []types.Address) {
+	validators := make(map[types.Address]struct{}, len(addresses))
+	for _, address := range addresses {
+		validators[address] = struct{}{}
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.validators = validators
+}
+
+// addValidatorPeer maps the validator to its peer, replacing the previous peer of the validator
+func (a *peerAllowlist) addValidatorPeer(address types.Address, peerID peer.ID) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for knownID, knownAddress := range a.validatorPeers {
+		if knownAddress == address {
+			delete(a.validatorPeers, knownID)
+		}
+	}
+
+	a.validatorPeers[peerID] = address
+}
+
+// allowsPeer checks if the ID of the peer is allowed
+func (a *peerAllowlist) allowsPeer(peerID peer.ID) bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if _, ok := a.peers[peerID]; ok {
+		return true
+	}
+
+	address, ok := a.validatorPeers[peerID]
+	if !ok {
+		return false
+	}
+
+	_, ok = a.validators[address]
+
+	return ok
+}
+
+// allowsAddr checks if the address is in an allowed network
+func (a *peerAllowlist) allowsAddr(addr multiaddr.Multiaddr) bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if len(a.networks) == 0 || addr == nil {
+		return false
+	}
+
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return false
+	}
+
+	for _, network := range a.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checksHandshake checks if the peers which are not allowed yet are connected, so the validators
+// among them prove their peer IDs in the handshake
+func (a *peerAllowlist) checksHandshake() bool {
+	return a.hasValidators
+}
+
+// hasNetworks checks if there are allowed networks, the peers which are not listed
+// may connect from them
+func (a *peerAllowlist) hasNetworks() bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return len(a.networks) > 0
+}
+
+// allows checks if the peer connecting from or dialed at the address is allowed
+func (a *peerAllowlist) allows(peerID peer.ID, addr multiaddr.Multiaddr) bool {
+	return a.allowsPeer(peerID) || a.allowsAddr(addr)
+}
+
+// ReloadAllowlist reloads the allowlist file, and disconnects the peers which are no longer allowed
+func (s *Server) ReloadAllowlist() error {
+	if s.allowlist == nil {
+		return errAllowlistDisabled
+	}
+
+	if err := s.allowlist.load(); err != nil {
+		return err
+	}
+
+	s.logger.Info("Allowlist reloaded", "path", s.allowlist.path)
+
+	s.disconnectDisallowedPeers()
+
+	return nil
+}
+
+// SetAllowedValidators sets the addresses of the current validator set, whose peers are allowed to connect
+// if the allowlist allows the validators. The peers which are no longer allowed are disconnected
+func (s *Server) SetAllowedValidators(addresses []types.Address) {
+	if s.allowlist == nil || !s.allowlist.hasValidators {
+		return
+	}
+
+	s.allowlist.setValidators(addresses)
+	s.disconnectDisallowedPeers()
+}
+
+// AddValidatorPeer maps the validator to its peer known from the configuration, like the peers of the genesis
+// validators, so the peer is allowed while the validator is in the validator set without proving its peer ID
+func (s *Server) AddValidatorPeer(address types.Address, peerID peer.ID) {
+	if s.allowlist == nil || !s.allowlist.hasValidators {
+		return
+	}
+
+	s.allowlist.addValidatorPeer(address, peerID)
+}
+
+// SetValidatorSigner signs the peer ID of the node with its validator key, the signature is sent
+// in the handshake. It has to be set before the server is started
+func (s *Server) SetValidatorSigner(signer ValidatorSigner) error {
+	signature, err := signer.Sign(validatorPeerHash(s.config.Chain.Params.ChainID, s.host.ID()))
+	if err != nil {
+		return fmt.Errorf("failed to sign the peer ID: %w", err)
+	}
+
+	s.validatorSignature = signature
+
+	return nil
+}
+
+// ValidatorSignature returns the signature of the peer ID of the node by its validator key,
+// nil if the node is not a validator
+func (s *Server) ValidatorSignature() []byte {
+	return s.validatorSignature
+}
+
+// AuthorizePeer checks that the peer which completed the handshake is allowed. The peer which is not
+// allowed by its ID or address is allowed if the signature proves it is the peer of a current validator
+func (s *Server) AuthorizePeer(peerID peer.ID, validatorSignature []byte) error {
+	if s.allowlist == nil || s.allowlist.allowsPeer(peerID) || s.allowsPeerAddr(peerID) {
+		return nil
+	}
+
+	if !s.allowlist.hasValidators || len(validatorSignature) == 0 {
+		return errPeerNotAllowed
+	}
+
+	pub, err := crypto.RecoverPubKey(validatorSignature, validatorPeerHash(s.config.Chain.Params.ChainID, peerID))
+	if err != nil {
+		return fmt.Errorf("%w: invalid validator signature: %w", errPeerNotAllowed, err)
+	}
+
+	address := crypto.PubKeyToAddress(pub)
+
+	// the peer is kept, so it is allowed once its validator joins the validator set
+	s.allowlist.addValidatorPeer(address, peerID)
+
+	if !s.allowlist.allowsPeer(peerID) {
+		return fmt.Errorf("%w: %s is not a current validator", errPeerNotAllowed, address)
+	}
+
+	return nil
+}
+
+// allowsPeerAddr checks if the peer is connected from an allowed network
+func (s *Server) allowsPeerAddr(peerID peer.ID) bool {
+	for _, conn := range s.host.Network().ConnsToPeer(peerID) {
+		if s.allowlist.allowsAddr(conn.RemoteMultiaddr()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validatorPeerHash returns the hash of the peer ID signed by the validator of the peer,
+// the chain ID prevents the signature from being reused on another chain
+func validatorPeerHash(chainID int64, peerID peer.ID) []byte {
+	return crypto.Keccak256([]byte("validator peer"), helperCommon.EncodeUint64ToBytes(uint64(chainID)), []byte(peerID))
+}
+
+// disconnectDisallowedPeers disconnects the connected peers which are not allowed
+func (s *Server) disconnectDisallowedPeers() {
+	for _, conn := range s.host.Network().Conns() {
+		peerID := conn.RemotePeer()
+
+		if !s.allowlist.allows(peerID, conn.RemoteMultiaddr()) {
+			s.DisconnectFromPeer(peerID, "not allowed")
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package network
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/test"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// refusedJoinTimeout is the time the refused peers are given to connect
+const refusedJoinTimeout = 5 * time.Second
+
+func writeAllowlist(t *testing.T, path string, peers []peer.ID, cidrs []string) {
+	t.Helper()
+
+	file := allowlistFile{CIDRs: cidrs}
+	for _, peerID := range peers {
+		file.Peers = append(file.Peers, peerID.String())
+	}
+
+	data, err := json.Marshal(file)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+func TestPeerAllowlist_Disabled(t *testing.T) {
+	t.Parallel()
+
+	allowlist, err := newPeerAllowlist(nil)
+	require.NoError(t, err)
+	assert.Nil(t, allowlist)
+
+	allowlist, err = newPeerAllowlist(&AllowlistConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, allowlist)
+}
+
+func TestPeerAllowlist_ValidatorsWithoutFile(t *testing.T) {
+	t.Parallel()
+
+	// the validators prove their peers in the handshake, so the file is optional
+	allowlist, err := newPeerAllowlist(&AllowlistConfig{Validators: true})
+	require.NoError(t, err)
+	assert.True(t, allowlist.checksHandshake())
+	assert.False(t, allowlist.allowsPeer(test.RandPeerIDFatal(t)))
+}
+
+func TestPeerAllowlist_PeersAndNetworks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path      = filepath.Join(t.TempDir(), "allowlist.json")
+		listed    = test.RandPeerIDFatal(t)
+		validator = test.RandPeerIDFatal(t)
+		unknown   = test.RandPeerIDFatal(t)
+		address   = types.StringToAddress("1")
+		inside    = multiaddr.StringCast("/ip4/10.0.1.5/tcp/1478")
+		outside   = multiaddr.StringCast("/ip4/192.168.0.1/tcp/1478")
+	)
+
+	writeAllowlist(t, path, []peer.ID{listed}, []string{"10.0.0.0/16"})
+
+	allowlist, err := newPeerAllowlist(&AllowlistConfig{Path: path, Validators: true})
+	require.NoError(t, err)
+
+	// the peer of the validator is allowed while the validator is in the validator set
+	allowlist.addValidatorPeer(address, validator)
+	assert.False(t, allowlist.allows(validator, outside))
+
+	allowlist.setValidators([]types.Address{address})
+
+	assert.True(t, allowlist.allows(listed, outside))
+	assert.True(t, allowlist.allows(validator, outside))
+	assert.True(t, allowlist.allows(unknown, inside))
+	assert.False(t, allowlist.allows(unknown, outside))
+	assert.False(t, allowlist.allows(unknown, nil))
+
+	// the new peer of the validator replaces the previous one
+	allowlist.addValidatorPeer(address, unknown)
+	assert.False(t, allowlist.allows(validator, outside))
+	assert.True(t, allowlist.allows(unknown, outside))
+
+	// the validators are replaced when the validator set changes
+	allowlist.setValidators(nil)
+	assert.False(t, allowlist.allows(unknown, outside))
+
+	// the reloaded file replaces the listed peers and networks
+	writeAllowlist(t, path, []peer.ID{unknown}, nil)
+	require.NoError(t, allowlist.load())
+
+	assert.False(t, allowlist.allows(listed, outside))
+	assert.True(t, allowlist.allows(unknown, outside))
+	assert.False(t, allowlist.hasNetworks())
+
+	// an invalid file leaves the allowlist unchanged
+	require.NoError(t, os.WriteFile(path, []byte(`{"cidrs":["10.0.0.0/33"]}`), 0600))
+	require.Error(t, allowlist.load())
+	assert.True(t, allowlist.allows(unknown, outside))
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"peers":["invalid"]}`), 0600))
+	require.Error(t, allowlist.load())
+	assert.True(t, allowlist.allows(unknown, outside))
+}
+
+func TestAllowlist_RefusesUnknownPeers(t *testing.T) {
+	servers, createErr := createServers(3, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	path := filepath.Join(t.TempDir(), "allowlist.json")
+	writeAllowlist(t, path, []peer.ID{servers[1].host.ID()}, nil)
+
+	server, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.NoDiscover = true
+			c.Allowlist = &AllowlistConfig{Path: path}
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create server, %v", createErr)
+	}
+
+	servers = append(servers, server)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	// the listed peer is accepted, the unknown peer is refused
+	require.NoError(t, JoinAndWait(servers[1], server, DefaultBufferTimeout, DefaultJoinTimeout))
+	require.Error(t, JoinAndWait(servers[0], server, refusedJoinTimeout, refusedJoinTimeout))
+	assert.False(t, server.hasPeer(servers[0].host.ID()))
+
+	// the unknown peer is not dialed either
+	require.Error(t, JoinAndWait(server, servers[2], refusedJoinTimeout, refusedJoinTimeout))
+
+	// the reloaded allowlist disconnects the peer which is no longer listed, and allows the new one
+	writeAllowlist(t, path, []peer.ID{servers[0].host.ID()}, nil)
+	require.NoError(t, server.ReloadAllowlist())
+
+	disconnectCtx, disconnectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer disconnectFn()
+
+	_, disconnectErr := WaitUntilPeerDisconnectsFrom(disconnectCtx, server, servers[1].host.ID())
+	require.NoError(t, disconnectErr)
+
+	require.NoError(t, JoinAndWait(server, servers[0], DefaultBufferTimeout, DefaultJoinTimeout))
+}
+
+// testValidatorSigner signs with a random ECDSA key
+type testValidatorSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func newTestValidatorSigner(t *testing.T) *testValidatorSigner {
+	t.Helper()
+
+	key, err := crypto.GenerateECDSAPrivateKey()
+	require.NoError(t, err)
+
+	return &testValidatorSigner{key: key}
+}
+
+func (s *testValidatorSigner) Address() types.Address {
+	return crypto.PubKeyToAddress(&s.key.PublicKey)
+}
+
+func (s *testValidatorSigner) Sign(hash []byte) ([]byte, error) {
+	return crypto.Sign(s.key, hash)
+}
+
+func TestAllowlist_Validators(t *testing.T) {
+	var (
+		validator = newTestValidatorSigner(t)
+		candidate = newTestValidatorSigner(t)
+		servers   = make([]*Server, 0, 4)
+	)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	// the validators allowlist has no file, the peers are proven by their validator signatures
+	server, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.NoDiscover = true
+			c.Allowlist = &AllowlistConfig{Validators: true}
+		},
+	})
+	require.NoError(t, createErr)
+
+	servers = append(servers, server)
+	server.SetAllowedValidators([]types.Address{validator.Address()})
+
+	for _, signer := range []ValidatorSigner{validator, candidate, nil} {
+		signer := signer
+
+		peerServer, createErr := CreateServer(&CreateServerParams{
+			ConfigCallback: func(c *Config) {
+				c.NoDiscover = true
+			},
+			ServerCallback: func(s *Server) {
+				if signer != nil {
+					require.NoError(t, s.SetValidatorSigner(signer))
+				}
+			},
+		})
+		require.NoError(t, createErr)
+
+		servers = append(servers, peerServer)
+	}
+
+	// the peer of the current validator is accepted, the peers of a candidate and of a non-validator are refused
+	require.NoError(t, JoinAndWait(servers[1], server, DefaultBufferTimeout, DefaultJoinTimeout))
+	require.Error(t, JoinAndWait(servers[2], server, refusedJoinTimeout, refusedJoinTimeout))
+	require.Error(t, JoinAndWait(servers[3], server, refusedJoinTimeout, refusedJoinTimeout))
+
+	// the peer of the candidate is known, it is allowed once the candidate joins the validator set
+	server.SetAllowedValidators([]types.Address{validator.Address(), candidate.Address()})
+	assert.True(t, server.allowlist.allowsPeer(servers[2].host.ID()))
+
+	// the peer of the validator leaving the validator set is disconnected
+	server.SetAllowedValidators([]types.Address{candidate.Address()})
+
+	disconnectCtx, disconnectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer disconnectFn()
+
+	_, disconnectErr := WaitUntilPeerDisconnectsFrom(disconnectCtx, server, servers[1].host.ID())
+	require.NoError(t, disconnectErr)
+}
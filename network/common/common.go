@@ -13,10 +13,27 @@ import (
 type DialPriority uint64
 
 const (
+	PriorityStaticDial    DialPriority = 0
 	PriorityRequestedDial DialPriority = 1
 	PriorityRandomDial    DialPriority = 10
 )
 
+// PeerPenalty is the score change of a peer misbehavior, reported to the peer reputation
+type PeerPenalty int64
+
+const (
+	// PenaltyFailedDial is reported for a peer which can't be dialed. The failed dials alone can't drop
+	// the score of the peer below half of the ban threshold, so an unreachable peer is dialed last but never banned
+	PenaltyFailedDial PeerPenalty = -5
+	// PenaltyProtocolError is reported for a peer which fails the handshake or violates a protocol
+	PenaltyProtocolError PeerPenalty = -20
+	// PenaltyInvalidGossip is reported for a peer which gossips malformed or invalid messages
+	PenaltyInvalidGossip PeerPenalty = -25
+	// PenaltyInvalidBlock is reported for a peer proven to serve an invalid block. A single report does not
+	// ban the peer, as a peer may relay a block it did not produce
+	PenaltyInvalidBlock PeerPenalty = -50
+)
+
 const (
 	DiscProto     = "/disc/0.1"
 	IdentityProto = "/id/0.1"
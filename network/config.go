@@ -22,6 +22,10 @@ type Config struct {
 	Chain             *chain.Chain           // the reference to the chain configuration
 	SecretsManager    secrets.SecretsManager // the secrets manager used for key storage
 	GossipMessageSize int                    // the maximum size of a gossip message
+	StaticPeers       []string               // the addresses of the peers which are always kept connected
+	TrustedPeers      []string               // the IDs of the peers which are accepted regardless of the peer limits
+	Allowlist         *AllowlistConfig       // the peers allowed to connect, the networking is not permissioned if nil
+	RejectLegacyPeers bool                   // reject the peers which don't send the genesis and the fork ID
 }
 
 func DefaultConfig() *Config {
@@ -153,7 +153,18 @@ func (d *DiscoveryService) HandleNetworkEvent(peerEvent *event.PeerEvent) {
 // ConnectToBootnodes attempts to connect to the bootnodes
 // and add them to the peer / routing table
 func (d *DiscoveryService) ConnectToBootnodes(bootnodes []*peer.AddrInfo) {
-	for _, nodeInfo := range bootnodes {
+	d.addNodesToTable(bootnodes)
+}
+
+// RestoreRoutingTable adds the routing table entries persisted by the previous runs
+// to the peer / routing table
+func (d *DiscoveryService) RestoreRoutingTable(nodes []*peer.AddrInfo) {
+	d.addNodesToTable(nodes)
+}
+
+// addNodesToTable adds the nodes to the peer / routing table, logging the failures
+func (d *DiscoveryService) addNodesToTable(nodes []*peer.AddrInfo) {
+	for _, nodeInfo := range nodes {
 		if err := d.addToTable(nodeInfo); err != nil {
 			d.logger.Error(
 				"Failed to add new peer to routing table",
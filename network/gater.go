@@ -0,0 +1,60 @@
+package network
+
+import (
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+var _ connmgr.ConnectionGater = (*connectionGater)(nil)
+
+// connectionGater rejects the connections from and to the banned peers,
+// so a disconnected banned peer is neither redialed nor accepted again.
+// If the networking is permissioned, it also rejects the peers which are not allowed,
+// unless the validators are allowed, then the handshake checks the proofs of the validators
+// and disconnects the other peers
+type connectionGater struct {
+	reputation *peerReputation
+	allowlist  *peerAllowlist // nil if the networking is not permissioned
+}
+
+// InterceptPeerDial rejects the dials of the banned peers, and of the peers which are not allowed.
+// The address is not known yet, so the peers which are not listed are dialed if there are allowed networks
+func (g *connectionGater) InterceptPeerDial(peerID peer.ID) bool {
+	if g.reputation.isBanned(peerID) {
+		return false
+	}
+
+	return g.allowlist == nil || g.allowlist.checksHandshake() || g.allowlist.allowsPeer(peerID) ||
+		g.allowlist.hasNetworks()
+}
+
+// InterceptAddrDial rejects the dials of the banned peers, and of the peers which are not allowed
+func (g *connectionGater) InterceptAddrDial(peerID peer.ID, addr multiaddr.Multiaddr) bool {
+	if g.reputation.isBanned(peerID) {
+		return false
+	}
+
+	return g.allowlist == nil || g.allowlist.checksHandshake() || g.allowlist.allows(peerID, addr)
+}
+
+// InterceptAccept accepts the inbound connections, the remote peer is not known before the handshake
+func (g *connectionGater) InterceptAccept(network.ConnMultiaddrs) bool {
+	return true
+}
+
+// InterceptSecured rejects the authenticated connections of the banned peers, and of the peers which are not allowed
+func (g *connectionGater) InterceptSecured(_ network.Direction, peerID peer.ID, addrs network.ConnMultiaddrs) bool {
+	if g.reputation.isBanned(peerID) {
+		return false
+	}
+
+	return g.allowlist == nil || g.allowlist.checksHandshake() || g.allowlist.allows(peerID, addrs.RemoteMultiaddr())
+}
+
+// InterceptUpgraded accepts the upgraded connections, they were secured before
+func (g *connectionGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
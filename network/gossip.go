@@ -7,6 +7,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -24,6 +25,9 @@ const (
 type Topic struct {
 	logger hclog.Logger
 
+	// reportPeer reports the peers forwarding malformed messages to the peer reputation
+	reportPeer func(peerID peer.ID, penalty common.PeerPenalty, reason string)
+
 	topic     *pubsub.Topic
 	typ       reflect.Type
 	closeCh   chan struct{}
@@ -112,6 +116,10 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 				t.logger.Error("failed to unmarshal topic", "err", err)
 				metrics.IncrCounter([]string{networkMetrics, "bad_messages"}, float32(1))
 
+				if t.reportPeer != nil {
+					t.reportPeer(msg.ReceivedFrom, common.PenaltyInvalidGossip, "malformed gossip message")
+				}
+
 				return
 			}
 
@@ -129,10 +137,11 @@ func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
 	}
 
 	tt := &Topic{
-		logger:  s.logger.Named(protoID),
-		topic:   topic,
-		typ:     reflect.TypeOf(obj).Elem(),
-		closeCh: make(chan struct{}),
+		logger:     s.logger.Named(protoID),
+		reportPeer: s.ReportPeer,
+		topic:      topic,
+		typ:        reflect.TypeOf(obj).Elem(),
+		closeCh:    make(chan struct{}),
 	}
 	tt.closed.Store(false)
 
@@ -0,0 +1,128 @@
+package identity
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+
+	"github.com/0xPolygon/polygon-edge/network/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrInvalidGenesis    = errors.New("invalid genesis")
+	ErrIncompatibleForks = errors.New("incompatible fork schedule")
+)
+
+// ChainInfo provides the genesis, the head and the fork schedule of the local chain to the handshake
+type ChainInfo interface {
+	// Genesis returns the hash of the genesis block
+	Genesis() types.Hash
+
+	// Header returns the header of the head block
+	Header() *types.Header
+
+	// ForkBlocks returns the distinct blocks the activated and the scheduled forks
+	// are enabled from, in ascending order. The forks enabled from the genesis block are omitted
+	ForkBlocks() []uint64
+}
+
+// ForkID identifies the fork schedule a node follows, in the manner of EIP-2124.
+// The hash covers the genesis and the forks the node has passed, and next is the first fork ahead of it
+type ForkID struct {
+	Hash uint32
+	Next uint64
+}
+
+// toProto converts the fork ID to its proto representation
+func (id ForkID) toProto() *proto.Status_ForkID {
+	return &proto.Status_ForkID{
+		Hash: id.Hash,
+		Next: id.Next,
+	}
+}
+
+// newForkID returns the fork ID of the chain at the head block
+func newForkID(genesis types.Hash, forks []uint64, head uint64) ForkID {
+	hash := crc32.ChecksumIEEE(genesis.Bytes())
+
+	for _, fork := range forks {
+		if fork > head {
+			return ForkID{Hash: hash, Next: fork}
+		}
+
+		hash = forkChecksum(hash, fork)
+	}
+
+	return ForkID{Hash: hash, Next: 0}
+}
+
+// checkForkID checks if the fork ID of the remote peer is compatible with the local chain at the head block.
+// The peer is compatible if it follows the same fork schedule, even if one of the nodes is behind the other:
+//
+// - if both passed the same forks, the remote peer must not schedule a fork the local node has passed
+//
+// - if the remote peer is behind, the next fork it schedules must be the next local fork it has to pass
+//
+// - if the remote peer is ahead, it must have passed the local forks
+func checkForkID(genesis types.Hash, forks []uint64, head uint64, remote ForkID) error {
+	// the checksums of the genesis, and of each passed fork
+	sums := make([]uint32, len(forks)+1)
+	sums[0] = crc32.ChecksumIEEE(genesis.Bytes())
+
+	for i, fork := range forks {
+		sums[i+1] = forkChecksum(sums[i], fork)
+	}
+
+	// the sentinel fork is never passed
+	forks = append(forks[:len(forks):len(forks)], math.MaxUint64)
+
+	for i, fork := range forks {
+		if head >= fork {
+			continue
+		}
+
+		// both nodes passed the same forks
+		if sums[i] == remote.Hash {
+			if remote.Next > 0 && head >= remote.Next {
+				return fmt.Errorf("%w: the local node passed the remote fork at block %d", ErrIncompatibleForks, remote.Next)
+			}
+
+			return nil
+		}
+
+		// the remote peer is behind
+		for j := 0; j < i; j++ {
+			if sums[j] == remote.Hash {
+				if forks[j] != remote.Next {
+					return fmt.Errorf("%w: the remote node is stale, next fork %d, expected %d",
+						ErrIncompatibleForks, remote.Next, forks[j])
+				}
+
+				return nil
+			}
+		}
+
+		// the remote peer is ahead
+		for j := i + 1; j < len(sums); j++ {
+			if sums[j] == remote.Hash {
+				return nil
+			}
+		}
+
+		break
+	}
+
+	return fmt.Errorf("%w: unknown fork hash %08x", ErrIncompatibleForks, remote.Hash)
+}
+
+// forkChecksum adds the fork block to the checksum
+func forkChecksum(hash uint32, fork uint64) uint32 {
+	var block [8]byte
+
+	binary.BigEndian.PutUint64(block[:], fork)
+
+	return crc32.Update(hash, crc32.IEEETable, block[:])
+}
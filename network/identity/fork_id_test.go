@@ -0,0 +1,105 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForkID_Check(t *testing.T) {
+	t.Parallel()
+
+	var (
+		genesis = types.StringToHash("0x1")
+		forks   = []uint64{100, 200}
+	)
+
+	testTable := []struct {
+		name   string
+		head   uint64
+		remote ForkID
+		err    error
+	}{
+		{
+			"same forks, same next fork",
+			50,
+			newForkID(genesis, forks, 50),
+			nil,
+		},
+		{
+			"same forks, the remote peer schedules a fork ahead",
+			50,
+			ForkID{Hash: newForkID(genesis, forks, 50).Hash, Next: 150},
+			nil,
+		},
+		{
+			"same forks, the remote peer schedules a fork the local node passed",
+			150,
+			ForkID{Hash: newForkID(genesis, forks, 150).Hash, Next: 120},
+			ErrIncompatibleForks,
+		},
+		{
+			"the remote peer is behind",
+			250,
+			newForkID(genesis, forks, 150),
+			nil,
+		},
+		{
+			"the remote peer is behind and stale",
+			250,
+			ForkID{Hash: newForkID(genesis, forks, 150).Hash, Next: 0},
+			ErrIncompatibleForks,
+		},
+		{
+			"the remote peer is ahead",
+			50,
+			newForkID(genesis, forks, 250),
+			nil,
+		},
+		{
+			"another fork schedule",
+			150,
+			newForkID(genesis, []uint64{120}, 150),
+			ErrIncompatibleForks,
+		},
+		{
+			"another genesis",
+			50,
+			newForkID(types.StringToHash("0x2"), forks, 50),
+			ErrIncompatibleForks,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkForkID(genesis, forks, testCase.head, testCase.remote)
+			if testCase.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, testCase.err)
+			}
+		})
+	}
+}
+
+func TestForkID_Next(t *testing.T) {
+	t.Parallel()
+
+	var (
+		genesis = types.StringToHash("0x1")
+		forks   = []uint64{100, 200}
+	)
+
+	assert.Equal(t, uint64(100), newForkID(genesis, forks, 99).Next)
+	assert.Equal(t, uint64(200), newForkID(genesis, forks, 100).Next)
+	assert.Equal(t, uint64(0), newForkID(genesis, forks, 200).Next)
+
+	// the hash changes once a fork is passed
+	assert.Equal(t, newForkID(genesis, forks, 0).Hash, newForkID(genesis, forks, 99).Hash)
+	assert.NotEqual(t, newForkID(genesis, forks, 99).Hash, newForkID(genesis, forks, 100).Hash)
+}
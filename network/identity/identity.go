@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/event"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/network/proto"
@@ -19,6 +22,7 @@ const PeerID = "peerID"
 var (
 	ErrInvalidChainID   = errors.New("invalid chain ID")
 	ErrNoAvailableSlots = errors.New("no available Slots")
+	ErrLegacyPeer       = errors.New("peer does not send its genesis and fork ID")
 )
 
 // networkingServer defines the base communication interface between
@@ -34,6 +38,9 @@ type networkingServer interface {
 	// DisconnectFromPeer attempts to disconnect from the specified peer
 	DisconnectFromPeer(peerID peer.ID, reason string)
 
+	// ReportPeer applies the penalty of a misbehavior to the reputation of the peer
+	ReportPeer(peerID peer.ID, penalty common.PeerPenalty, reason string)
+
 	// AddPeer adds a peer to the networking server's peer store
 	AddPeer(id peer.ID, direction network.Direction)
 
@@ -52,6 +59,20 @@ type networkingServer interface {
 
 	// HasFreeConnectionSlot checks if there are available outbound connection slots [Thread safe]
 	HasFreeConnectionSlot(direction network.Direction) bool
+
+	// IsTrustedPeer checks if the peer is a static or a trusted peer, which is connected
+	// regardless of the connection slots [Thread safe]
+	IsTrustedPeer(peerID peer.ID) bool
+
+	// PERMISSIONED NETWORKING //
+
+	// ValidatorSignature returns the signature of the peer ID of the node by its validator key,
+	// nil if the node is not a validator
+	ValidatorSignature() []byte
+
+	// AuthorizePeer checks that the peer is allowed to connect, the validator signature proves
+	// the peer of a validator [Thread safe]
+	AuthorizePeer(peerID peer.ID, validatorSignature []byte) error
 }
 
 // IdentityService is a networking service used to handle peer handshaking.
@@ -63,8 +84,13 @@ type IdentityService struct {
 	logger                 hclog.Logger     // The IdentityService logger
 	baseServer             networkingServer // The interface towards the base networking server
 
-	chainID int64   // The chain ID of the network
-	hostID  peer.ID // The base networking server's host peer ID
+	chainID int64     // The chain ID of the network
+	chain   ChainInfo // The local chain, the genesis and the forks of the peers are not checked if nil
+	hostID  peer.ID   // The base networking server's host peer ID
+
+	// rejectLegacyPeers rejects the peers of the previous versions, which don't send the genesis
+	// and the fork ID, they are accepted by default so the network can be upgraded node by node
+	rejectLegacyPeers bool
 }
 
 // NewIdentityService returns a new instance of the IdentityService
@@ -72,13 +98,17 @@ func NewIdentityService(
 	server networkingServer,
 	logger hclog.Logger,
 	chainID int64,
+	chain ChainInfo,
 	hostID peer.ID,
+	rejectLegacyPeers bool,
 ) *IdentityService {
 	return &IdentityService{
-		logger:     logger.Named("identity"),
-		baseServer: server,
-		chainID:    chainID,
-		hostID:     hostID,
+		logger:            logger.Named("identity"),
+		baseServer:        server,
+		chainID:           chainID,
+		chain:             chain,
+		hostID:            hostID,
+		rejectLegacyPeers: rejectLegacyPeers,
 	}
 }
 
@@ -93,7 +123,8 @@ func (i *IdentityService) GetNotifyBundle() *network.NotifyBundle {
 				return
 			}
 
-			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) {
+			if !i.baseServer.IsTrustedPeer(peerID) &&
+				!i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) {
 				i.disconnectFromPeer(peerID, ErrNoAvailableSlots.Error())
 
 				return
@@ -106,6 +137,8 @@ func (i *IdentityService) GetNotifyBundle() *network.NotifyBundle {
 				eventType := event.PeerDialCompleted
 
 				if err := i.handleConnected(peerID, conn.Stat().Direction); err != nil {
+					i.handleRejected(peerID, err)
+
 					// Close the connection to the peer
 					i.disconnectFromPeer(peerID, err.Error())
 
@@ -125,6 +158,25 @@ func (i *IdentityService) GetNotifyBundle() *network.NotifyBundle {
 	}
 }
 
+// handleRejected records the peer rejected by the handshake. The peer proven to follow another chain
+// is penalized, so it is not dialed again and again, while a legacy peer may follow the same chain
+// and is not penalized
+func (i *IdentityService) handleRejected(peerID peer.ID, err error) {
+	reason, ok := rejectReason(err)
+	if !ok {
+		return
+	}
+
+	i.logger.Warn("Peer rejected", "peer", peerID, "reason", reason, "err", err)
+
+	metrics.IncrCounterWithLabels([]string{"network", "rejected_peers"}, 1,
+		[]metrics.Label{{Name: "reason", Value: string(reason)}})
+
+	if reason != RejectLegacy {
+		i.baseServer.ReportPeer(peerID, common.PenaltyProtocolError, err.Error())
+	}
+}
+
 // hasPendingStatus checks if a peer is pending handshake [Thread safe]
 func (i *IdentityService) hasPendingStatus(id peer.ID) bool {
 	_, ok := i.pendingPeerConnections.Load(id)
@@ -182,6 +234,16 @@ func (i *IdentityService) handleConnected(peerID peer.ID, direction network.Dire
 		return ErrInvalidChainID
 	}
 
+	// Validate that the peers follow the same genesis and fork schedule
+	if err := i.checkChain(resp); err != nil {
+		return err
+	}
+
+	// Validate that the peer is allowed, if the networking is permissioned
+	if err := i.baseServer.AuthorizePeer(peerID, resp.ValidatorSignature); err != nil {
+		return err
+	}
+
 	// If this is a NOT temporary connection, save it
 	if !resp.TemporaryDial && !status.TemporaryDial {
 		i.baseServer.AddPeer(peerID, direction)
@@ -205,11 +267,78 @@ func (i *IdentityService) Hello(_ context.Context, req *proto.Status) (*proto.St
 
 // constructStatus constructs a status response of the current node
 func (i *IdentityService) constructStatus(peerID peer.ID) *proto.Status {
-	return &proto.Status{
+	status := &proto.Status{
 		Metadata: map[string]string{
 			PeerID: i.hostID.String(),
 		},
-		Chain:         i.chainID,
-		TemporaryDial: i.baseServer.IsTemporaryDial(peerID),
+		Chain:              i.chainID,
+		TemporaryDial:      i.baseServer.IsTemporaryDial(peerID),
+		ValidatorSignature: i.baseServer.ValidatorSignature(),
+	}
+
+	if i.chain != nil {
+		genesis := i.chain.Genesis()
+
+		status.Genesis = genesis.String()
+		status.ForkID = newForkID(genesis, i.chain.ForkBlocks(), i.chain.Header().Number).toProto()
+	}
+
+	return status
+}
+
+// checkChain checks that the peer follows the same genesis and fork schedule as the local chain.
+// The peers of the previous versions don't send the genesis and the fork ID, so only the fields
+// they send are checked, and they are rejected only if the legacy peers are rejected
+func (i *IdentityService) checkChain(status *proto.Status) error {
+	if i.chain == nil {
+		return nil
+	}
+
+	genesis := i.chain.Genesis()
+
+	if status.Genesis != "" {
+		if remoteGenesis := types.StringToHash(status.Genesis); remoteGenesis != genesis {
+			return fmt.Errorf("%w: %s, expected %s", ErrInvalidGenesis, remoteGenesis, genesis)
+		}
+	}
+
+	if status.Genesis == "" || status.ForkID == nil {
+		if i.rejectLegacyPeers {
+			return ErrLegacyPeer
+		}
+
+		return nil
+	}
+
+	return checkForkID(genesis, i.chain.ForkBlocks(), i.chain.Header().Number, ForkID{
+		Hash: status.ForkID.Hash,
+		Next: status.ForkID.Next,
+	})
+}
+
+// RejectReason is the reason code of a peer rejected by the handshake, for following another chain
+type RejectReason string
+
+const (
+	RejectChainID RejectReason = "chain_id"
+	RejectGenesis RejectReason = "genesis"
+	RejectForks   RejectReason = "forks"
+	RejectLegacy  RejectReason = "legacy"
+)
+
+// rejectReason returns the reason code of the handshake error, if the peer follows another chain
+// or is a rejected legacy peer
+func rejectReason(err error) (RejectReason, bool) {
+	switch {
+	case errors.Is(err, ErrLegacyPeer):
+		return RejectLegacy, true
+	case errors.Is(err, ErrInvalidChainID):
+		return RejectChainID, true
+	case errors.Is(err, ErrInvalidGenesis):
+		return RejectGenesis, true
+	case errors.Is(err, ErrIncompatibleForks):
+		return RejectForks, true
+	default:
+		return "", false
 	}
 }
@@ -2,10 +2,14 @@ package identity
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/proto"
 	networkTesting "github.com/0xPolygon/polygon-edge/network/testing"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -122,3 +126,247 @@ func TestHandshake_Errors(t *testing.T) {
 	// Make sure no peers have been  added to the base networking server
 	assert.Len(t, peersArray, 0)
 }
+
+// mockChain is a local chain with a fixed genesis, head and fork schedule
+type mockChain struct {
+	genesis types.Hash
+	head    uint64
+	forks   []uint64
+}
+
+func (c *mockChain) Genesis() types.Hash {
+	return c.genesis
+}
+
+func (c *mockChain) Header() *types.Header {
+	return &types.Header{Number: c.head}
+}
+
+func (c *mockChain) ForkBlocks() []uint64 {
+	return c.forks
+}
+
+// TestHandshake_IncompatibleChain tests that the peers following another genesis
+// or fork schedule are rejected
+func TestHandshake_IncompatibleChain(t *testing.T) {
+	localChain := &mockChain{
+		genesis: types.StringToHash("0x1"),
+		head:    150,
+		forks:   []uint64{100, 200},
+	}
+
+	testTable := []struct {
+		name   string
+		remote *mockChain
+		err    error
+		reason RejectReason
+	}{
+		{
+			"same chain",
+			&mockChain{genesis: localChain.genesis, head: 50, forks: localChain.forks},
+			nil,
+			"",
+		},
+		{
+			"another genesis",
+			&mockChain{genesis: types.StringToHash("0x2"), head: 150, forks: localChain.forks},
+			ErrInvalidGenesis,
+			RejectGenesis,
+		},
+		{
+			"another fork schedule",
+			&mockChain{genesis: localChain.genesis, head: 150, forks: []uint64{120, 200}},
+			ErrIncompatibleForks,
+			RejectForks,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			peersArray := make([]peer.ID, 0)
+
+			identityService := newIdentityService(
+				func(server *networkTesting.MockNetworkingServer) {
+					server.HookAddPeer(func(id peer.ID, direction network.Direction) {
+						peersArray = append(peersArray, id)
+					})
+
+					// the remote peer responds with the status of its chain
+					server.GetMockIdentityClient().HookHello(func(
+						ctx context.Context,
+						in *proto.Status,
+						opts ...grpc.CallOption,
+					) (*proto.Status, error) {
+						return (&IdentityService{chain: testCase.remote, baseServer: server}).constructStatus(""), nil
+					})
+				},
+			)
+
+			identityService.chain = localChain
+
+			connectErr := identityService.handleConnected("TestPeer", network.DirInbound)
+			if testCase.err == nil {
+				assert.NoError(t, connectErr)
+				assert.Len(t, peersArray, 1)
+
+				return
+			}
+
+			assert.ErrorIs(t, connectErr, testCase.err)
+			assert.Len(t, peersArray, 0)
+
+			reason, ok := rejectReason(connectErr)
+			assert.True(t, ok)
+			assert.Equal(t, testCase.reason, reason)
+		})
+	}
+}
+
+// TestHandshake_LegacyPeer tests that the peers which don't send the genesis and the fork ID
+// are accepted, unless the legacy peers are rejected
+func TestHandshake_LegacyPeer(t *testing.T) {
+	for _, rejectLegacyPeers := range []bool{false, true} {
+		rejectLegacyPeers := rejectLegacyPeers
+
+		t.Run(fmt.Sprintf("rejected %t", rejectLegacyPeers), func(t *testing.T) {
+			peersArray := make([]peer.ID, 0)
+
+			identityService := newIdentityService(
+				func(server *networkTesting.MockNetworkingServer) {
+					server.HookAddPeer(func(id peer.ID, direction network.Direction) {
+						peersArray = append(peersArray, id)
+					})
+
+					// the legacy peer responds without the genesis and the fork ID
+					server.GetMockIdentityClient().HookHello(func(
+						ctx context.Context,
+						in *proto.Status,
+						opts ...grpc.CallOption,
+					) (*proto.Status, error) {
+						return (&IdentityService{baseServer: server}).constructStatus(""), nil
+					})
+				},
+			)
+
+			identityService.chain = &mockChain{genesis: types.StringToHash("0x1")}
+			identityService.rejectLegacyPeers = rejectLegacyPeers
+
+			connectErr := identityService.handleConnected("TestPeer", network.DirInbound)
+			if !rejectLegacyPeers {
+				assert.NoError(t, connectErr)
+				assert.Len(t, peersArray, 1)
+
+				return
+			}
+
+			assert.ErrorIs(t, connectErr, ErrLegacyPeer)
+			assert.Len(t, peersArray, 0)
+
+			reason, ok := rejectReason(connectErr)
+			assert.True(t, ok)
+			assert.Equal(t, RejectLegacy, reason)
+		})
+	}
+}
+
+// TestHandshake_LegacyPeerGenesis tests that a peer sending a different genesis without
+// the fork ID is rejected even if the legacy peers are accepted
+func TestHandshake_LegacyPeerGenesis(t *testing.T) {
+	identityService := newIdentityService(
+		func(server *networkTesting.MockNetworkingServer) {
+			server.GetMockIdentityClient().HookHello(func(
+				ctx context.Context,
+				in *proto.Status,
+				opts ...grpc.CallOption,
+			) (*proto.Status, error) {
+				status := (&IdentityService{baseServer: server}).constructStatus("")
+				status.Genesis = types.StringToHash("0x2").String()
+
+				return status, nil
+			})
+		},
+	)
+
+	identityService.chain = &mockChain{genesis: types.StringToHash("0x1")}
+
+	assert.ErrorIs(t, identityService.handleConnected("TestPeer", network.DirInbound), ErrInvalidGenesis)
+}
+
+// TestHandleRejected tests that only the peers proven to follow another chain are penalized
+func TestHandleRejected(t *testing.T) {
+	testTable := []struct {
+		name      string
+		err       error
+		penalized bool
+	}{
+		{"another genesis", ErrInvalidGenesis, true},
+		{"another fork schedule", ErrIncompatibleForks, true},
+		{"another chain ID", ErrInvalidChainID, true},
+		{"legacy peer", ErrLegacyPeer, false},
+		{"failed handshake", errors.New("stream reset"), false},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			reported := false
+
+			identityService := newIdentityService(
+				func(server *networkTesting.MockNetworkingServer) {
+					server.HookReportPeer(func(peer.ID, common.PeerPenalty, string) {
+						reported = true
+					})
+				},
+			)
+
+			identityService.handleRejected("TestPeer", testCase.err)
+			assert.Equal(t, testCase.penalized, reported)
+		})
+	}
+}
+
+// TestHandshake_AuthorizePeer tests that the validator signature is exchanged in the handshake,
+// and that the peers which are not allowed are rejected
+func TestHandshake_AuthorizePeer(t *testing.T) {
+	var (
+		errNotAllowed = errors.New("not allowed")
+		signature     = []byte{1, 2, 3}
+		received      []byte
+	)
+
+	peersArray := make([]peer.ID, 0)
+
+	identityService := newIdentityService(
+		func(server *networkTesting.MockNetworkingServer) {
+			server.HookAddPeer(func(id peer.ID, direction network.Direction) {
+				peersArray = append(peersArray, id)
+			})
+
+			server.HookValidatorSignature(func() []byte {
+				return signature
+			})
+
+			server.HookAuthorizePeer(func(_ peer.ID, validatorSignature []byte) error {
+				received = validatorSignature
+
+				return errNotAllowed
+			})
+
+			// the remote peer responds with the same validator signature
+			server.GetMockIdentityClient().HookHello(func(
+				ctx context.Context,
+				in *proto.Status,
+				opts ...grpc.CallOption,
+			) (*proto.Status, error) {
+				return (&IdentityService{baseServer: server}).constructStatus(""), nil
+			})
+		},
+	)
+
+	assert.ErrorIs(t, identityService.handleConnected("TestPeer", network.DirInbound), errNotAllowed)
+	assert.Equal(t, signature, received)
+	assert.Len(t, peersArray, 0)
+}
@@ -0,0 +1,296 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	helperCommon "github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// knownPeersFile is the name of the file the known peers are persisted to, in the networking data directory
+	knownPeersFile = "peers.json"
+
+	// knownPeersFlushInterval is the interval the known peers are persisted at
+	knownPeersFlushInterval = time.Minute
+
+	// knownPeerExpiry is the duration after which a peer which was not seen is forgotten
+	knownPeerExpiry = 7 * 24 * time.Hour
+
+	// maxKnownPeers is the maximum number of the persisted peers, the most recently seen ones are kept
+	maxKnownPeers = 256
+)
+
+// knownPeer is a peer the node was connected to, or which was in its routing table
+type knownPeer struct {
+	ID       peer.ID   `json:"id"`
+	Addrs    []string  `json:"addrs"`
+	LastSeen time.Time `json:"lastSeen"`
+
+	// Routing is set if the peer was in the routing table of the discovery service
+	Routing bool `json:"routing"`
+}
+
+// addrInfo returns the address info of the peer, skipping the addresses which can't be parsed
+func (p *knownPeer) addrInfo() *peer.AddrInfo {
+	info := &peer.AddrInfo{
+		ID:    p.ID,
+		Addrs: make([]multiaddr.Multiaddr, 0, len(p.Addrs)),
+	}
+
+	for _, rawAddr := range p.Addrs {
+		if addr, err := multiaddr.NewMultiaddr(rawAddr); err == nil {
+			info.Addrs = append(info.Addrs, addr)
+		}
+	}
+
+	return info
+}
+
+// knownPeers keeps the addresses of the peers the node has seen, so a restarted node
+// can reconnect to the network without the bootnodes
+type knownPeers struct {
+	logger hclog.Logger
+
+	lock  sync.Mutex
+	peers map[peer.ID]*knownPeer
+
+	path string // the file the peers are persisted to, the peers are kept in memory only if empty
+	now  func() time.Time
+}
+
+// newKnownPeers creates the known peers, and loads the peers persisted in the data directory.
+// The persisted peers are a cache, a file which can't be read is logged and ignored
+func newKnownPeers(logger hclog.Logger, dataDir string) *knownPeers {
+	k := &knownPeers{
+		logger: logger,
+		peers:  make(map[peer.ID]*knownPeer),
+		now:    time.Now,
+	}
+
+	if dataDir == "" {
+		return k
+	}
+
+	k.path = filepath.Join(dataDir, knownPeersFile)
+
+	data, err := os.ReadFile(k.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return k
+	} else if err != nil {
+		logger.Warn("failed to read known peers", "path", k.path, "err", err)
+
+		return k
+	}
+
+	var peers []*knownPeer
+	if err := json.Unmarshal(data, &peers); err != nil {
+		logger.Warn("failed to decode known peers", "path", k.path, "err", err)
+
+		return k
+	}
+
+	for _, p := range peers {
+		if p.ID != "" && len(p.Addrs) > 0 {
+			k.peers[p.ID] = p
+		}
+	}
+
+	k.expire()
+
+	return k
+}
+
+// seen records the peer as seen now, along with its addresses
+func (k *knownPeers) seen(info peer.AddrInfo) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	p := k.getOrAdd(info.ID)
+	p.LastSeen = k.now()
+
+	if len(info.Addrs) > 0 {
+		p.Addrs = addrsToStrings(info.Addrs)
+	}
+}
+
+// setRouting marks the peers of the routing table. The peers which are not known yet are recorded
+// as seen now, with the addresses of the peer store
+func (k *knownPeers) setRouting(routing []peer.ID, addrs func(peer.ID) []multiaddr.Multiaddr) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	for _, p := range k.peers {
+		p.Routing = false
+	}
+
+	for _, peerID := range routing {
+		p, ok := k.peers[peerID]
+		if !ok {
+			peerAddrs := addrs(peerID)
+			if len(peerAddrs) == 0 {
+				continue
+			}
+
+			p = k.getOrAdd(peerID)
+			p.LastSeen = k.now()
+			p.Addrs = addrsToStrings(peerAddrs)
+		}
+
+		p.Routing = true
+	}
+}
+
+func (k *knownPeers) getOrAdd(peerID peer.ID) *knownPeer {
+	p, ok := k.peers[peerID]
+	if !ok {
+		p = &knownPeer{ID: peerID}
+		k.peers[peerID] = p
+	}
+
+	return p
+}
+
+// list returns copies of the known peers with addresses, the most recently seen first
+func (k *knownPeers) list() []*knownPeer {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	return k.listLocked()
+}
+
+func (k *knownPeers) listLocked() []*knownPeer {
+	peers := make([]*knownPeer, 0, len(k.peers))
+
+	for _, p := range k.peers {
+		if len(p.Addrs) > 0 {
+			peerCopy := *p
+			peers = append(peers, &peerCopy)
+		}
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		if !peers[i].LastSeen.Equal(peers[j].LastSeen) {
+			return peers[i].LastSeen.After(peers[j].LastSeen)
+		}
+
+		return peers[i].ID < peers[j].ID
+	})
+
+	return peers
+}
+
+// expire forgets the peers which were not seen for the expiry duration,
+// and the least recently seen peers above the maximum number of peers
+func (k *knownPeers) expire() {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	deadline := k.now().Add(-knownPeerExpiry)
+
+	for i, p := range k.listLocked() {
+		if i >= maxKnownPeers || p.LastSeen.Before(deadline) {
+			delete(k.peers, p.ID)
+		}
+	}
+}
+
+// persist writes the known peers to the data directory
+func (k *knownPeers) persist() {
+	if k.path == "" {
+		return
+	}
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	data, err := json.MarshalIndent(k.listLocked(), "", "  ")
+	if err != nil {
+		k.logger.Error("failed to encode known peers", "err", err)
+
+		return
+	}
+
+	if err := helperCommon.SaveFileSafe(k.path, data, 0600); err != nil {
+		k.logger.Error("failed to persist known peers", "path", k.path, "err", err)
+	}
+}
+
+func addrsToStrings(addrs []multiaddr.Multiaddr) []string {
+	raw := make([]string, len(addrs))
+	for i, addr := range addrs {
+		raw[i] = addr.String()
+	}
+
+	return raw
+}
+
+// restoreKnownPeers adds the peers known from the previous runs to the peer store, and dials them
+// ahead of the bootnodes. It returns the peers which were in the routing table
+func (s *Server) restoreKnownPeers() []*peer.AddrInfo {
+	var (
+		restored = 0
+		routing  = make([]*peer.AddrInfo, 0)
+	)
+
+	for _, p := range s.knownPeers.list() {
+		info := p.addrInfo()
+		if info.ID == s.host.ID() || len(info.Addrs) == 0 {
+			continue
+		}
+
+		s.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.AddressTTL)
+		s.addToDialQueue(info, common.PriorityRequestedDial)
+
+		restored++
+
+		if p.Routing {
+			routing = append(routing, info)
+		}
+	}
+
+	if restored > 0 {
+		s.logger.Info("Restored known peers", "peers", restored, "routing", len(routing))
+	}
+
+	return routing
+}
+
+// flushKnownPeers records the connected peers and the routing table, and persists the known peers
+func (s *Server) flushKnownPeers() {
+	for _, p := range s.Peers() {
+		s.knownPeers.seen(s.host.Peerstore().PeerInfo(p.Info.ID))
+	}
+
+	if s.discovery != nil {
+		s.knownPeers.setRouting(s.discovery.RoutingTablePeers(), s.host.Peerstore().Addrs)
+	}
+
+	s.knownPeers.expire()
+	s.knownPeers.persist()
+}
+
+// runKnownPeersFlush persists the known peers periodically, until the server is closed
+func (s *Server) runKnownPeersFlush() {
+	ticker := time.NewTicker(knownPeersFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flushKnownPeers()
+		case <-s.closeCh:
+			return
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/test"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnownPeers_PersistAndExpire(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dataDir  = t.TempDir()
+		now      = time.Now()
+		addr     = multiaddr.StringCast("/ip4/127.0.0.1/tcp/10001")
+		seen     = test.RandPeerIDFatal(t)
+		routing  = test.RandPeerIDFatal(t)
+		stale    = test.RandPeerIDFatal(t)
+		noAddrs  = test.RandPeerIDFatal(t)
+		addrsMap = map[peer.ID][]multiaddr.Multiaddr{routing: {addr}}
+	)
+
+	known := newKnownPeers(hclog.NewNullLogger(), dataDir)
+	known.now = func() time.Time {
+		return now
+	}
+
+	known.seen(peer.AddrInfo{ID: seen, Addrs: []multiaddr.Multiaddr{addr}})
+	known.seen(peer.AddrInfo{ID: stale, Addrs: []multiaddr.Multiaddr{addr}})
+
+	// the peers of the routing table are recorded, unless their addresses are unknown
+	known.setRouting([]peer.ID{seen, routing, noAddrs}, func(peerID peer.ID) []multiaddr.Multiaddr {
+		return addrsMap[peerID]
+	})
+
+	// the peer which was not seen for the expiry duration is forgotten
+	known.peers[stale].LastSeen = now.Add(-knownPeerExpiry - time.Second)
+
+	known.expire()
+	known.persist()
+
+	// the known peers are loaded on restart
+	reloaded := newKnownPeers(hclog.NewNullLogger(), dataDir)
+
+	peers := reloaded.list()
+	require.Len(t, peers, 2)
+
+	for _, p := range peers {
+		assert.Contains(t, []peer.ID{seen, routing}, p.ID)
+		assert.True(t, p.Routing)
+		assert.Equal(t, []string{addr.String()}, p.Addrs)
+		assert.True(t, p.LastSeen.Equal(now))
+	}
+}
+
+func TestKnownPeers_ReconnectAfterRestart(t *testing.T) {
+	dataDir := t.TempDir()
+
+	noDiscoverConfig := func(c *Config) {
+		c.NoDiscover = true
+	}
+
+	peerServer, createErr := CreateServer(&CreateServerParams{ConfigCallback: noDiscoverConfig})
+	if createErr != nil {
+		t.Fatalf("Unable to create server, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, []*Server{peerServer})
+	})
+
+	restartedConfig := func(c *Config) {
+		c.NoDiscover = true
+		c.DataDir = dataDir
+	}
+
+	server, createErr := CreateServer(&CreateServerParams{ConfigCallback: restartedConfig})
+	if createErr != nil {
+		t.Fatalf("Unable to create server, %v", createErr)
+	}
+
+	require.NoError(t, JoinAndWait(server, peerServer, DefaultBufferTimeout, DefaultJoinTimeout))
+	require.NoError(t, server.Close())
+
+	// the restarted node dials the peers known from the previous run on its own
+	restarted, createErr := CreateServer(&CreateServerParams{ConfigCallback: restartedConfig})
+	if createErr != nil {
+		t.Fatalf("Unable to create server, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, []*Server{restarted})
+	})
+
+	connectCtx, connectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer connectFn()
+
+	_, connectErr := WaitUntilPeerConnectsTo(connectCtx, restarted, peerServer.host.ID())
+	require.NoError(t, connectErr)
+}
@@ -98,6 +98,37 @@ func (m *Status) validate(all bool) error {
 
 	// no validation rules for TemporaryDial
 
+	if all {
+		switch v := interface{}(m.GetForkID()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, StatusValidationError{
+					field:  "ForkID",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, StatusValidationError{
+					field:  "ForkID",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetForkID()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return StatusValidationError{
+				field:  "ForkID",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for ValidatorSignature
+
 	if len(errors) > 0 {
 		return StatusMultiError(errors)
 	}
@@ -277,3 +308,107 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = Status_KeyValidationError{}
+
+// Validate checks the field values on Status_ForkID with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Status_ForkID) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Status_ForkID with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Status_ForkIDMultiError, or
+// nil if none found.
+func (m *Status_ForkID) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Status_ForkID) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Hash
+
+	// no validation rules for Next
+
+	if len(errors) > 0 {
+		return Status_ForkIDMultiError(errors)
+	}
+
+	return nil
+}
+
+// Status_ForkIDMultiError is an error wrapping multiple validation errors
+// returned by Status_ForkID.ValidateAll() if the designated constraints aren't
+// met.
+type Status_ForkIDMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Status_ForkIDMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Status_ForkIDMultiError) AllErrors() []error { return m }
+
+// Status_ForkIDValidationError is the validation error returned by
+// Status_ForkID.Validate if the designated constraints aren't met.
+type Status_ForkIDValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Status_ForkIDValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Status_ForkIDValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Status_ForkIDValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Status_ForkIDValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Status_ForkIDValidationError) ErrorName() string { return "Status_ForkIDValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Status_ForkIDValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStatus_ForkID.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Status_ForkIDValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Status_ForkIDValidationError{}
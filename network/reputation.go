@@ -0,0 +1,354 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	helperCommon "github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// banScoreThreshold is the score at which a peer is disconnected and banned
+	banScoreThreshold = -100
+
+	// failedDialScoreFloor is the lowest score the failed dials alone can drop a peer to
+	failedDialScoreFloor = banScoreThreshold / 2
+
+	// DefaultBanDuration is the duration of the ban of a peer whose score drops to the threshold
+	DefaultBanDuration = time.Hour
+
+	// reputationDecayInterval is the interval the scores of the peers decay at
+	reputationDecayInterval = time.Minute
+
+	// reputationDecayRate is the part of the score a peer recovers every decay interval
+	reputationDecayRate = 0.1
+
+	// bansFile is the name of the file the bans are persisted to, in the networking data directory
+	bansFile = "bans.json"
+)
+
+var errPeerNotBanned = errors.New("peer is not banned")
+
+// PeerBan is a ban of a peer, the banned peer is disconnected and its connections are rejected
+type PeerBan struct {
+	ID     peer.ID `json:"id"`
+	Reason string  `json:"reason"`
+
+	// Until is the time the ban expires at, the ban is permanent if it is zero
+	Until time.Time `json:"until"`
+}
+
+// IsActive returns whether the ban is in effect at the given time
+func (b *PeerBan) IsActive(now time.Time) bool {
+	return b.Until.IsZero() || now.Before(b.Until)
+}
+
+// peerReputation scores the peers by their reported misbehaviors, and keeps the bans of the peers
+// whose scores dropped to the threshold. The scores decay towards zero over time,
+// while the bans are persisted and outlive the restarts of the node
+type peerReputation struct {
+	logger hclog.Logger
+
+	lock   sync.Mutex
+	scores map[peer.ID]float64
+	bans   map[peer.ID]*PeerBan
+
+	path string // the file the bans are persisted to, the bans are kept in memory only if empty
+	now  func() time.Time
+}
+
+// newPeerReputation creates the peer reputation, and loads the bans persisted in the data directory
+func newPeerReputation(logger hclog.Logger, dataDir string) (*peerReputation, error) {
+	r := &peerReputation{
+		logger: logger,
+		scores: make(map[peer.ID]float64),
+		bans:   make(map[peer.ID]*PeerBan),
+		now:    time.Now,
+	}
+
+	if dataDir == "" {
+		return r, nil
+	}
+
+	r.path = filepath.Join(dataDir, bansFile)
+
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read peer bans: %w", err)
+	}
+
+	var bans []*PeerBan
+	if err := json.Unmarshal(data, &bans); err != nil {
+		return nil, fmt.Errorf("failed to decode peer bans: %w", err)
+	}
+
+	now := r.now()
+
+	for _, ban := range bans {
+		if ban.IsActive(now) {
+			r.bans[ban.ID] = ban
+		}
+	}
+
+	return r, nil
+}
+
+// report applies the penalty to the score of the peer, and returns whether the peer got banned
+func (r *peerReputation) report(peerID peer.ID, penalty common.PeerPenalty, reason string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.isBannedLocked(peerID) {
+		return false
+	}
+
+	score := r.scores[peerID] + float64(penalty)
+	if score > banScoreThreshold {
+		r.scores[peerID] = score
+
+		return false
+	}
+
+	r.banLocked(peerID, DefaultBanDuration, fmt.Sprintf("reputation dropped to %.0f: %s", score, reason))
+
+	return true
+}
+
+// reportFailedDial applies the failed dial penalty to the score of the peer, the score is not dropped
+// below the failed dial floor, so the failed dials never ban the peer
+func (r *peerReputation) reportFailedDial(peerID peer.ID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	score, ok := r.scores[peerID]
+	if r.isBannedLocked(peerID) || (ok && score <= failedDialScoreFloor) {
+		return
+	}
+
+	r.scores[peerID] = math.Max(score+float64(common.PenaltyFailedDial), failedDialScoreFloor)
+}
+
+// score returns the current score of the peer, zero for a peer without reports
+func (r *peerReputation) score(peerID peer.ID) float64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.scores[peerID]
+}
+
+// ban bans the peer for the given duration, or permanently if the duration is zero
+func (r *peerReputation) ban(peerID peer.ID, duration time.Duration, reason string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.banLocked(peerID, duration, reason)
+}
+
+func (r *peerReputation) banLocked(peerID peer.ID, duration time.Duration, reason string) {
+	ban := &PeerBan{ID: peerID, Reason: reason}
+	if duration > 0 {
+		ban.Until = r.now().Add(duration)
+	}
+
+	r.bans[peerID] = ban
+	delete(r.scores, peerID)
+
+	metrics.SetGauge([]string{networkMetrics, "banned_peers"}, float32(len(r.bans)))
+
+	r.persistLocked()
+}
+
+// unban lifts the ban of the peer, the peer starts over with a neutral score
+func (r *peerReputation) unban(peerID peer.ID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.isBannedLocked(peerID) {
+		return fmt.Errorf("%w: %s", errPeerNotBanned, peerID)
+	}
+
+	delete(r.bans, peerID)
+
+	metrics.SetGauge([]string{networkMetrics, "banned_peers"}, float32(len(r.bans)))
+
+	r.persistLocked()
+
+	return nil
+}
+
+// isBanned returns whether the peer is banned
+func (r *peerReputation) isBanned(peerID peer.ID) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.isBannedLocked(peerID)
+}
+
+func (r *peerReputation) isBannedLocked(peerID peer.ID) bool {
+	ban, ok := r.bans[peerID]
+
+	return ok && ban.IsActive(r.now())
+}
+
+// listBans returns copies of the active bans, ordered by the peer IDs
+func (r *peerReputation) listBans() []*PeerBan {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var (
+		now  = r.now()
+		bans = make([]*PeerBan, 0, len(r.bans))
+	)
+
+	for _, ban := range r.bans {
+		if ban.IsActive(now) {
+			banCopy := *ban
+			bans = append(bans, &banCopy)
+		}
+	}
+
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].ID < bans[j].ID
+	})
+
+	return bans
+}
+
+// decay moves the scores of the peers towards zero, and drops the expired bans
+func (r *peerReputation) decay() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for peerID, score := range r.scores {
+		score *= 1 - reputationDecayRate
+
+		if math.Abs(score) < 1 {
+			delete(r.scores, peerID)
+		} else {
+			r.scores[peerID] = score
+		}
+	}
+
+	now := r.now()
+	expired := false
+
+	for peerID, ban := range r.bans {
+		if !ban.IsActive(now) {
+			delete(r.bans, peerID)
+
+			expired = true
+		}
+	}
+
+	if expired {
+		metrics.SetGauge([]string{networkMetrics, "banned_peers"}, float32(len(r.bans)))
+
+		r.persistLocked()
+	}
+}
+
+// persistLocked writes the bans to the data directory, a failure is logged, the bans stay in effect
+// until the node is restarted
+func (r *peerReputation) persistLocked() {
+	if r.path == "" {
+		return
+	}
+
+	bans := make([]*PeerBan, 0, len(r.bans))
+	for _, ban := range r.bans {
+		bans = append(bans, ban)
+	}
+
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].ID < bans[j].ID
+	})
+
+	data, err := json.MarshalIndent(bans, "", "  ")
+	if err != nil {
+		r.logger.Error("failed to encode peer bans", "err", err)
+
+		return
+	}
+
+	if err := helperCommon.SaveFileSafe(r.path, data, 0600); err != nil {
+		r.logger.Error("failed to persist peer bans", "path", r.path, "err", err)
+	}
+}
+
+// ReportPeer applies the penalty of a misbehavior to the reputation of the peer. The peer whose
+// score drops to the threshold is disconnected and banned for the default ban duration.
+// The static and the trusted peers are configured by the operator, so they are never penalized
+func (s *Server) ReportPeer(peerID peer.ID, penalty common.PeerPenalty, reason string) {
+	if peerID == "" || peerID == s.host.ID() || s.IsTrustedPeer(peerID) {
+		return
+	}
+
+	s.logger.Debug("peer reported", "id", peerID, "penalty", penalty, "reason", reason)
+
+	if s.reputation.report(peerID, penalty, reason) {
+		s.logger.Warn("peer banned for its reputation", "id", peerID, "duration", DefaultBanDuration, "reason", reason)
+
+		s.DisconnectFromPeer(peerID, "banned: "+reason)
+	}
+}
+
+// reportFailedDial lowers the score of the peer which can't be dialed, so its next dials are deprioritized.
+// An unreachable peer is not misbehaving, so it is never banned for the failed dials
+func (s *Server) reportFailedDial(peerID peer.ID) {
+	if peerID == "" || peerID == s.host.ID() || s.IsTrustedPeer(peerID) {
+		return
+	}
+
+	s.reputation.reportFailedDial(peerID)
+}
+
+// BanPeer disconnects the peer and bans it for the given duration, or permanently if the duration is zero
+func (s *Server) BanPeer(peerID peer.ID, duration time.Duration, reason string) {
+	s.logger.Info("banning peer", "id", peerID, "duration", duration, "reason", reason)
+
+	s.reputation.ban(peerID, duration, reason)
+	s.DisconnectFromPeer(peerID, "banned: "+reason)
+}
+
+// UnbanPeer lifts the ban of the peer
+func (s *Server) UnbanPeer(peerID peer.ID) error {
+	return s.reputation.unban(peerID)
+}
+
+// IsBanned returns whether the peer is banned
+func (s *Server) IsBanned(peerID peer.ID) bool {
+	return s.reputation.isBanned(peerID)
+}
+
+// Bans returns the active bans of the peers
+func (s *Server) Bans() []*PeerBan {
+	return s.reputation.listBans()
+}
+
+// runReputationDecay decays the scores of the peers until the server is closed
+func (s *Server) runReputationDecay() {
+	ticker := time.NewTicker(reputationDecayInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.reputation.decay()
+		case <-s.closeCh:
+			return
+		}
+	}
+}
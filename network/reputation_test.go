@@ -0,0 +1,212 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPeerReputation(t *testing.T, dataDir string, now *time.Time) *peerReputation {
+	t.Helper()
+
+	reputation, err := newPeerReputation(hclog.NewNullLogger(), dataDir)
+	require.NoError(t, err)
+
+	reputation.now = func() time.Time {
+		return *now
+	}
+
+	return reputation
+}
+
+func TestPeerReputation_ReportBans(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now        = time.Unix(1_000_000, 0)
+		reputation = newTestPeerReputation(t, "", &now)
+		peerID     = peer.ID("peer")
+	)
+
+	// the penalties accumulate up to the threshold
+	for i := 0; i < 3; i++ {
+		assert.False(t, reputation.report(peerID, common.PenaltyInvalidGossip, "invalid gossip"))
+	}
+
+	assert.False(t, reputation.isBanned(peerID))
+	assert.True(t, reputation.report(peerID, common.PenaltyInvalidGossip, "invalid gossip"))
+	assert.True(t, reputation.isBanned(peerID))
+
+	// a banned peer is not banned again
+	assert.False(t, reputation.report(peerID, common.PenaltyInvalidBlock, "invalid block"))
+
+	bans := reputation.listBans()
+	require.Len(t, bans, 1)
+	assert.Equal(t, peerID, bans[0].ID)
+	assert.Equal(t, now.Add(DefaultBanDuration), bans[0].Until)
+
+	// the ban expires
+	now = now.Add(DefaultBanDuration)
+
+	assert.False(t, reputation.isBanned(peerID))
+	assert.Empty(t, reputation.listBans())
+}
+
+func TestPeerReputation_InvalidBlock(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now        = time.Unix(1_000_000, 0)
+		reputation = newTestPeerReputation(t, "", &now)
+		peerID     = peer.ID("peer")
+	)
+
+	// a single invalid block does not ban the peer, a repeated one does
+	assert.False(t, reputation.report(peerID, common.PenaltyInvalidBlock, "invalid block"))
+	assert.False(t, reputation.isBanned(peerID))
+
+	assert.True(t, reputation.report(peerID, common.PenaltyInvalidBlock, "invalid block"))
+	assert.True(t, reputation.isBanned(peerID))
+}
+
+func TestPeerReputation_FailedDials(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now        = time.Unix(1_000_000, 0)
+		reputation = newTestPeerReputation(t, "", &now)
+		peerID     = peer.ID("peer")
+	)
+
+	reputation.reportFailedDial(peerID)
+	assert.Equal(t, float64(common.PenaltyFailedDial), reputation.score(peerID))
+
+	// the failed dials alone never ban the peer
+	for i := 0; i < 100; i++ {
+		reputation.reportFailedDial(peerID)
+	}
+
+	assert.False(t, reputation.isBanned(peerID))
+	assert.Equal(t, float64(failedDialScoreFloor), reputation.score(peerID))
+
+	// the penalty decays once the peer is reachable again
+	reputation.decay()
+
+	assert.Greater(t, reputation.score(peerID), float64(failedDialScoreFloor))
+
+	// a failed dial does not raise the score of a peer reported for misbehaving
+	reputation.report(peerID, common.PenaltyProtocolError, "protocol error")
+	score := reputation.score(peerID)
+
+	reputation.reportFailedDial(peerID)
+	assert.Equal(t, score, reputation.score(peerID))
+}
+
+func TestPeerReputation_Decay(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now        = time.Unix(1_000_000, 0)
+		reputation = newTestPeerReputation(t, "", &now)
+		peerID     = peer.ID("peer")
+	)
+
+	reputation.report(peerID, common.PenaltyProtocolError, "protocol error")
+	reputation.decay()
+
+	assert.InDelta(t, -18, reputation.scores[peerID], 0.001)
+
+	// the score is dropped once it decays close to zero
+	for i := 0; i < 30; i++ {
+		reputation.decay()
+	}
+
+	assert.NotContains(t, reputation.scores, peerID)
+
+	// the expired bans are dropped
+	reputation.ban(peerID, time.Minute, "test")
+	reputation.decay()
+
+	assert.Contains(t, reputation.bans, peerID)
+
+	now = now.Add(time.Minute)
+	reputation.decay()
+
+	assert.NotContains(t, reputation.bans, peerID)
+}
+
+func TestPeerReputation_PersistBans(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now       = time.Now()
+		dataDir   = t.TempDir()
+		permanent = test.RandPeerIDFatal(t)
+		temporary = test.RandPeerIDFatal(t)
+		lifted    = test.RandPeerIDFatal(t)
+	)
+
+	reputation := newTestPeerReputation(t, dataDir, &now)
+
+	reputation.ban(permanent, 0, "permanent")
+	reputation.ban(temporary, time.Hour, "temporary")
+	reputation.ban(lifted, time.Hour, "lifted")
+
+	require.NoError(t, reputation.unban(lifted))
+	require.ErrorIs(t, reputation.unban(lifted), errPeerNotBanned)
+
+	// the bans are loaded on restart
+	reloaded, err := newPeerReputation(hclog.NewNullLogger(), dataDir)
+	require.NoError(t, err)
+
+	bans := make(map[peer.ID]*PeerBan)
+	for _, ban := range reloaded.listBans() {
+		bans[ban.ID] = ban
+	}
+
+	require.Len(t, bans, 2)
+	require.Contains(t, bans, permanent)
+	require.Contains(t, bans, temporary)
+
+	assert.True(t, bans[permanent].Until.IsZero())
+	assert.Equal(t, "permanent", bans[permanent].Reason)
+	assert.True(t, bans[temporary].Until.Equal(now.Add(time.Hour)))
+}
+
+func TestBanPeer_Disconnects(t *testing.T) {
+	t.Parallel()
+
+	servers, createErr := createServers(2, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		for _, server := range servers {
+			assert.NoError(t, server.Close())
+		}
+	})
+
+	require.NoError(t, JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout))
+
+	servers[0].BanPeer(servers[1].host.ID(), 0, "test")
+
+	assert.True(t, servers[0].IsBanned(servers[1].host.ID()))
+
+	// the connections of the banned peer are rejected
+	require.Eventually(t, func() bool {
+		return !servers[0].hasPeer(servers[1].host.ID())
+	}, DefaultLeaveTimeout, 100*time.Millisecond)
+
+	assert.Error(t, JoinAndWait(servers[1], servers[0], 5*time.Second, 5*time.Second))
+
+	// the peer can connect once the ban is lifted
+	require.NoError(t, servers[0].UnbanPeer(servers[1].host.ID()))
+	assert.NoError(t, JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout))
+}
@@ -88,6 +88,18 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	reputation *peerReputation // the reputation of the peers and their bans
+
+	allowlist *peerAllowlist // the peers allowed to connect, nil if the networking is not permissioned
+
+	validatorSignature []byte // the signature of the peer ID by the validator key, nil if the node is not a validator
+
+	peerLists *peerLists // the static and the trusted peers
+
+	knownPeers *knownPeers // the peers seen by the node, persisted across the restarts
+
+	blockchain Blockchain // the local chain checked by the identity handshake, only the chain ID is checked if nil
 }
 
 // NewServer returns a new instance of the networking server
@@ -118,12 +130,23 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		return addrs
 	}
 
+	reputation, err := newPeerReputation(logger, config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	allowlist, err := newPeerAllowlist(config.Allowlist)
+	if err != nil {
+		return nil, err
+	}
+
 	host, err := libp2p.New(
 		// Use noise as the encryption protocol
 		libp2p.Security(noise.ID, noise.New),
 		libp2p.ListenAddrs(listenAddr),
 		libp2p.AddrsFactory(addrsFactory),
 		libp2p.Identity(key),
+		libp2p.ConnectionGater(&connectionGater{reputation: reputation, allowlist: allowlist}),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create libp2p stack: %w", err)
@@ -145,6 +168,10 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		emitterPeerEvent: emitter,
 		protocols:        map[string]Protocol{},
 		secretsManager:   config.SecretsManager,
+		reputation:       reputation,
+		allowlist:        allowlist,
+		peerLists:        newPeerLists(),
+		knownPeers:       newKnownPeers(logger, config.DataDir),
 		bootnodes: &bootnodesWrapper{
 			bootnodeArr:       make([]*peer.AddrInfo, 0),
 			bootnodesMap:      make(map[peer.ID]*peer.AddrInfo),
@@ -254,6 +281,9 @@ func (s *Server) Start() error {
 		return fmt.Errorf("unable to setup identity, %w", setupErr)
 	}
 
+	// Dial the peers known from the previous runs ahead of the bootnodes
+	knownRouting := s.restoreKnownPeers()
+
 	// Set up the peer discovery mechanism if needed
 	if !s.config.NoDiscover {
 		// Parse the bootnode data
@@ -262,13 +292,20 @@ func (s *Server) Start() error {
 		}
 
 		// Setup and start the discovery service
-		if setupErr := s.setupDiscovery(); setupErr != nil {
+		if setupErr := s.setupDiscovery(knownRouting); setupErr != nil {
 			return fmt.Errorf("unable to setup discovery, %w", setupErr)
 		}
 	}
 
+	if setupErr := s.setupPeerLists(); setupErr != nil {
+		return fmt.Errorf("unable to parse static and trusted peers, %w", setupErr)
+	}
+
 	go s.runDial()
 	go s.keepAliveMinimumPeerConnections()
+	go s.runReputationDecay()
+	go s.runStaticPeers()
+	go s.runKnownPeersFlush()
 
 	// watch for disconnected peers
 	s.host.Network().Notify(&network.NotifyBundle{
@@ -355,7 +392,7 @@ func (s *Server) keepAliveMinimumPeerConnections() {
 // Essentially, the networking server monitors for any open connection slots
 // and attempts to fill them as soon as they open up
 func (s *Server) runDial() {
-	slots := NewSlots(s.connectionCounts.maxOutboundConnectionCount)
+	slots := newPeerSlots(s.connectionCounts.maxOutboundConnectionCount)
 	ctx, cancel := context.WithCancel(context.Background())
 
 	defer cancel()
@@ -366,8 +403,10 @@ func (s *Server) runDial() {
 		case
 			peerEvent.PeerFailedToConnect,
 			peerEvent.PeerDisconnected:
-			slots.Release()
-			s.logger.Debug("slot released", "event", event.Type, "peerID", event.PeerID)
+			// only the dials which took a slot release it, the static peers are dialed without one
+			if slots.release(event.PeerID) {
+				s.logger.Debug("slot released", "event", event.Type, "peerID", event.PeerID)
+			}
 		}
 	}); err != nil {
 		s.logger.Error(
@@ -399,10 +438,13 @@ func (s *Server) runDial() {
 				continue
 			}
 
-			s.logger.Debug("Waiting for a dialing slot", "addr", peerInfo, "local", s.host.ID())
+			// the static peers bypass the outbound peer limit
+			if !s.isStaticPeer(peerInfo.ID) {
+				s.logger.Debug("Waiting for a dialing slot", "addr", peerInfo, "local", s.host.ID())
 
-			if closed := slots.Take(ctx); closed {
-				return
+				if closed := slots.take(ctx, peerInfo.ID); closed {
+					return
+				}
 			}
 
 			// the connection process is async because it involves connection (here) +
@@ -413,6 +455,7 @@ func (s *Server) runDial() {
 				if err := s.host.Connect(ctx, *peerInfo); err != nil {
 					s.logger.Debug("failed to dial", "addr", peerInfo, "err", err.Error())
 
+					s.reportFailedDial(peerInfo.ID)
 					s.emitEvent(peerInfo.ID, peerEvent.PeerFailedToConnect)
 				}
 			}()
@@ -481,6 +524,8 @@ func (s *Server) removePeer(peerID peer.ID) {
 		return
 	}
 
+	s.knownPeers.seen(s.host.Peerstore().PeerInfo(peerID))
+
 	// Emit the event alerting listeners
 	s.emitEvent(peerID, peerEvent.PeerDisconnected)
 }
@@ -581,6 +626,8 @@ func (s *Server) joinPeer(peerInfo *peer.AddrInfo) {
 }
 
 func (s *Server) Close() error {
+	s.flushKnownPeers()
+
 	err := s.host.Close()
 	s.dialQueue.Close()
 
@@ -646,6 +693,17 @@ func (s *Server) AddrInfo() *peer.AddrInfo {
 }
 
 func (s *Server) addToDialQueue(addr *peer.AddrInfo, priority common.DialPriority) {
+	if s.reputation.isBanned(addr.ID) {
+		s.logger.Debug("skipping dial of banned peer", "id", addr.ID)
+
+		return
+	}
+
+	// the peers with lower scores, like the ones which failed to be dialed, are dialed after the others
+	if score := s.reputation.score(addr.ID); score < 0 {
+		priority += common.DialPriority(-score)
+	}
+
 	s.dialQueue.AddTask(addr, priority)
 	s.emitEvent(addr.ID, peerEvent.PeerAddedToDialQueue)
 }
@@ -187,8 +187,9 @@ func (s *Server) RemoveTemporaryDial(peerID peer.ID) {
 	s.temporaryDials.Delete(peerID)
 }
 
-// setupDiscovery Sets up the discovery service for the node
-func (s *Server) setupDiscovery() error {
+// setupDiscovery Sets up the discovery service for the node,
+// restoring the routing table entries known from the previous runs
+func (s *Server) setupDiscovery(knownRouting []*peer.AddrInfo) error {
 	// Set up a fresh routing table
 	keyID := kb.ConvertPeerID(s.host.ID())
 
@@ -233,6 +234,10 @@ func (s *Server) setupDiscovery() error {
 	// Register the actual discovery service as a valid protocol
 	s.registerDiscoveryService(discoveryService)
 
+	// Restore the routing table of the previous runs, so the known peers are queried
+	// for their peer sets even when the bootnodes are unreachable
+	discoveryService.RestoreRoutingTable(knownRouting)
+
 	// Make sure the discovery service has the bootnodes in its routing table,
 	// and instantiates connections to them
 	discoveryService.ConnectToBootnodes(s.bootnodes.getBootnodes())
@@ -3,11 +3,14 @@ package network
 import (
 	"math/big"
 
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/forkmanager"
 	"github.com/0xPolygon/polygon-edge/network/common"
 	peerEvent "github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/0xPolygon/polygon-edge/network/grpc"
 	"github.com/0xPolygon/polygon-edge/network/identity"
 	"github.com/0xPolygon/polygon-edge/network/proto"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
 	kbucket "github.com/libp2p/go-libp2p-kbucket"
 	"github.com/libp2p/go-libp2p-kbucket/keyspace"
@@ -74,6 +77,8 @@ func (s *Server) addPeerInfo(id peer.ID, direction network.Direction) bool {
 
 	s.peers[id] = connectionInfo
 
+	s.knownPeers.seen(connectionInfo.Info)
+
 	// Update connection counters
 	s.connectionCounts.UpdateConnCountByDirection(1, direction)
 	s.updateConnCountMetrics(direction)
@@ -104,14 +109,63 @@ func (s *Server) IsTemporaryDial(peerID peer.ID) bool {
 	return ok
 }
 
+// Blockchain is the local chain, the identity handshake checks that the peers follow the same genesis
+// and fork schedule
+type Blockchain interface {
+	// Genesis returns the hash of the genesis block
+	Genesis() types.Hash
+
+	// Header returns the header of the head block
+	Header() *types.Header
+}
+
+// SetBlockchain sets the local chain checked by the identity handshake, it has to be set before the server starts.
+// Only the chain ID is checked if the blockchain is not set
+func (s *Server) SetBlockchain(blockchain Blockchain) {
+	s.blockchain = blockchain
+}
+
+// handshakeChain provides the local chain to the identity handshake
+type handshakeChain struct {
+	Blockchain
+
+	forks *chain.Forks
+}
+
+// ForkBlocks returns the blocks of the forks of the chain configuration, and of the forks activated at runtime
+func (c *handshakeChain) ForkBlocks() []uint64 {
+	blocks := forkmanager.GetInstance().GetForkBlocks()
+
+	if c.forks != nil {
+		for _, fork := range *c.forks {
+			if fork.Block > 0 {
+				blocks = append(blocks, fork.Block)
+			}
+		}
+	}
+
+	return forkmanager.SortedForkBlocks(blocks)
+}
+
 // setupIdentity sets up the identity service for the node
 func (s *Server) setupIdentity() error {
+	var chainInfo identity.ChainInfo
+
+	if s.blockchain != nil {
+		chainInfo = &handshakeChain{
+			Blockchain: s.blockchain,
+			forks:      s.config.Chain.Params.Forks,
+		}
+	}
+
 	// Create an instance of the identity service
 	identityService := identity.NewIdentityService(
 		s,
 		s.logger,
 		s.config.Chain.Params.ChainID,
+		chainInfo,
 		s.host.ID(),
+		s.config.RejectLegacyPeers,
 	)
 
 	// Register the identity service protocol
@@ -2,6 +2,9 @@ package network
 
 import (
 	"context"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 // Slots is synchronization structure
@@ -37,3 +40,65 @@ func (s Slots) Release() {
 	default: // No slot available to release, do nothing
 	}
 }
+
+// peerSlots keeps the slots taken by the dials of the peers. A peer holds at most one slot, from its dial
+// until the dial fails or the peer disconnects, and the slot is released even if the peer no longer
+// needs one by then, e.g. it was added to the static peers
+type peerSlots struct {
+	slots Slots
+
+	lock  sync.Mutex
+	taken map[peer.ID]struct{}
+}
+
+// newPeerSlots creates the peer slots with maximal slots available
+func newPeerSlots(maximal int64) *peerSlots {
+	return &peerSlots{
+		slots: NewSlots(maximal),
+		taken: make(map[peer.ID]struct{}),
+	}
+}
+
+// take takes a slot for the dial of the peer, unless the peer holds one already.
+// It blocks until the slot is available, and returns true if the context is done
+func (p *peerSlots) take(ctx context.Context, peerID peer.ID) bool {
+	if p.holds(peerID) {
+		return false
+	}
+
+	if closed := p.slots.Take(ctx); closed {
+		return true
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.taken[peerID] = struct{}{}
+
+	return false
+}
+
+// release releases the slot held by the peer, and returns whether the peer held one
+func (p *peerSlots) release(peerID peer.ID) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.taken[peerID]; !ok {
+		return false
+	}
+
+	delete(p.taken, peerID)
+	p.slots.Release()
+
+	return true
+}
+
+// holds checks if the peer holds a slot
+func (p *peerSlots) holds(peerID peer.ID) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	_, ok := p.taken[peerID]
+
+	return ok
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,3 +40,32 @@ func TestSlots(t *testing.T) {
 	assert.False(t, closed2)
 	assert.GreaterOrEqual(t, time.Now().UTC(), tm.Add(time.Millisecond*500*2))
 }
+
+func TestPeerSlots(t *testing.T) {
+	t.Parallel()
+
+	slots := newPeerSlots(2)
+
+	// the peer which did not take a slot releases nothing, e.g. an inbound or a static peer
+	assert.False(t, slots.release(peer.ID("static")))
+
+	assert.False(t, slots.take(context.Background(), peer.ID("A")))
+	assert.False(t, slots.take(context.Background(), peer.ID("B")))
+
+	// the peer holding a slot does not take another one
+	assert.False(t, slots.take(context.Background(), peer.ID("A")))
+
+	// the slot is released once, by the peer which took it
+	assert.True(t, slots.release(peer.ID("A")))
+	assert.False(t, slots.release(peer.ID("A")))
+	assert.False(t, slots.holds(peer.ID("A")))
+
+	assert.False(t, slots.take(context.Background(), peer.ID("C")))
+	assert.True(t, slots.holds(peer.ID("C")))
+
+	// all the slots are taken
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	assert.True(t, slots.take(ctx, peer.ID("D")))
+}
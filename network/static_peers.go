@@ -0,0 +1,212 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// staticPeersCheckInterval is the interval the connections to the static peers are checked at
+	staticPeersCheckInterval = 5 * time.Second
+
+	// staticPeerMinBackoff is the delay of the first redial of a disconnected static peer,
+	// it doubles with each following failed dial
+	staticPeerMinBackoff = 5 * time.Second
+
+	// staticPeerMaxBackoff is the maximum delay between the redials of a static peer
+	staticPeerMaxBackoff = 5 * time.Minute
+)
+
+var errStaticPeerIsHost = errors.New("unable to add the host as a static peer")
+
+// staticPeer is a peer which is always kept connected
+type staticPeer struct {
+	info *peer.AddrInfo
+
+	backoff  time.Duration // the delay of the next redial
+	nextDial time.Time     // the time the peer can be redialed at
+}
+
+// peerLists keeps the static peers, which are dialed and redialed by the node,
+// and the trusted peers, which are accepted by the node regardless of the peer limits.
+// The static peers are trusted as well
+type peerLists struct {
+	lock    sync.RWMutex
+	static  map[peer.ID]*staticPeer
+	trusted map[peer.ID]struct{}
+}
+
+func newPeerLists() *peerLists {
+	return &peerLists{
+		static:  make(map[peer.ID]*staticPeer),
+		trusted: make(map[peer.ID]struct{}),
+	}
+}
+
+// addStatic adds the static peer, and returns whether it was not present already
+func (l *peerLists) addStatic(info *peer.AddrInfo) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if _, ok := l.static[info.ID]; ok {
+		return false
+	}
+
+	l.static[info.ID] = &staticPeer{info: info}
+
+	return true
+}
+
+// addTrusted adds the trusted peer
+func (l *peerLists) addTrusted(peerID peer.ID) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.trusted[peerID] = struct{}{}
+}
+
+// isStatic checks if the peer is a static peer [Thread safe]
+func (l *peerLists) isStatic(peerID peer.ID) bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	_, ok := l.static[peerID]
+
+	return ok
+}
+
+// isTrusted checks if the peer is a trusted or a static peer [Thread safe]
+func (l *peerLists) isTrusted(peerID peer.ID) bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if _, ok := l.trusted[peerID]; ok {
+		return true
+	}
+
+	_, ok := l.static[peerID]
+
+	return ok
+}
+
+// dueStatic returns the disconnected static peers whose backoff has passed,
+// and doubles their backoff. The backoff of the connected static peers is reset
+func (l *peerLists) dueStatic(isConnected func(peer.ID) bool, now time.Time) []*peer.AddrInfo {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	due := make([]*peer.AddrInfo, 0)
+
+	for peerID, static := range l.static {
+		if isConnected(peerID) {
+			static.backoff = 0
+			static.nextDial = time.Time{}
+
+			continue
+		}
+
+		if now.Before(static.nextDial) {
+			continue
+		}
+
+		if static.backoff == 0 {
+			static.backoff = staticPeerMinBackoff
+		} else {
+			static.backoff = min(2*static.backoff, staticPeerMaxBackoff)
+		}
+
+		static.nextDial = now.Add(static.backoff)
+
+		due = append(due, static.info)
+	}
+
+	return due
+}
+
+// setupPeerLists parses the static and the trusted peers of the config
+func (s *Server) setupPeerLists() error {
+	for _, rawAddr := range s.config.StaticPeers {
+		info, err := common.StringToAddrInfo(rawAddr)
+		if err != nil {
+			return fmt.Errorf("failed to parse static peer %s: %w", rawAddr, err)
+		}
+
+		if info.ID == s.host.ID() {
+			s.logger.Info("Omitting static peer with same ID as host", "id", info.ID)
+
+			continue
+		}
+
+		s.peerLists.addStatic(info)
+	}
+
+	for _, rawID := range s.config.TrustedPeers {
+		peerID, err := peer.Decode(rawID)
+		if err != nil {
+			return fmt.Errorf("failed to parse trusted peer %s: %w", rawID, err)
+		}
+
+		s.peerLists.addTrusted(peerID)
+	}
+
+	return nil
+}
+
+// AddStaticPeer adds a static peer, which is dialed right away, and redialed whenever it disconnects
+func (s *Server) AddStaticPeer(rawPeerMultiaddr string) error {
+	info, err := common.StringToAddrInfo(rawPeerMultiaddr)
+	if err != nil {
+		return err
+	}
+
+	if info.ID == s.host.ID() {
+		return errStaticPeerIsHost
+	}
+
+	if s.peerLists.addStatic(info) {
+		s.logger.Info("Static peer added", "addr", info)
+	}
+
+	s.dialStaticPeers()
+
+	return nil
+}
+
+// IsTrustedPeer checks if the peer is a static or a trusted peer, which is connected
+// regardless of the connection slots [Thread safe]
+func (s *Server) IsTrustedPeer(peerID peer.ID) bool {
+	return s.peerLists.isTrusted(peerID)
+}
+
+// isStaticPeer checks if the peer is a static peer [Thread safe]
+func (s *Server) isStaticPeer(peerID peer.ID) bool {
+	return s.peerLists.isStatic(peerID)
+}
+
+// runStaticPeers redials the disconnected static peers, with backoff, until the server is closed
+func (s *Server) runStaticPeers() {
+	ticker := time.NewTicker(staticPeersCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		s.dialStaticPeers()
+
+		select {
+		case <-ticker.C:
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// dialStaticPeers adds the disconnected static peers whose backoff has passed to the dial queue
+func (s *Server) dialStaticPeers() {
+	for _, info := range s.peerLists.dueStatic(s.IsConnected, time.Now()) {
+		s.addToDialQueue(info, common.PriorityStaticDial)
+	}
+}
@@ -0,0 +1,134 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerLists_DueStatic(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lists     = newPeerLists()
+		info      = &peer.AddrInfo{ID: peer.ID("static")}
+		now       = time.Unix(1_000_000, 0)
+		connected = false
+	)
+
+	isConnected := func(peer.ID) bool {
+		return connected
+	}
+
+	assert.True(t, lists.addStatic(info))
+	assert.False(t, lists.addStatic(info))
+
+	assert.True(t, lists.isStatic(info.ID))
+	assert.True(t, lists.isTrusted(info.ID))
+
+	// the peer is dialed right away
+	assert.Equal(t, []*peer.AddrInfo{info}, lists.dueStatic(isConnected, now))
+	assert.Empty(t, lists.dueStatic(isConnected, now))
+
+	// the backoff doubles with each redial
+	now = now.Add(staticPeerMinBackoff)
+	assert.Len(t, lists.dueStatic(isConnected, now), 1)
+
+	now = now.Add(staticPeerMinBackoff)
+	assert.Empty(t, lists.dueStatic(isConnected, now))
+
+	now = now.Add(staticPeerMinBackoff)
+	assert.Len(t, lists.dueStatic(isConnected, now), 1)
+
+	// the backoff is capped
+	for i := 0; i < 10; i++ {
+		now = now.Add(staticPeerMaxBackoff)
+		lists.dueStatic(isConnected, now)
+	}
+
+	assert.Equal(t, staticPeerMaxBackoff, lists.static[info.ID].backoff)
+
+	// the backoff is reset once the peer is connected
+	connected = true
+	assert.Empty(t, lists.dueStatic(isConnected, now))
+
+	connected = false
+	assert.Len(t, lists.dueStatic(isConnected, now), 1)
+	assert.Equal(t, staticPeerMinBackoff, lists.static[info.ID].backoff)
+}
+
+func TestTrustedPeer_BypassesInboundLimit(t *testing.T) {
+	trustedServer, createErr := CreateServer(nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create server, %v", createErr)
+	}
+
+	server, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.MaxInboundPeers = 0
+			c.NoDiscover = true
+			c.TrustedPeers = []string{trustedServer.host.ID().String()}
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create server, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, []*Server{trustedServer, server})
+	})
+
+	// the trusted peer is accepted although there are no inbound slots
+	require.NoError(t, JoinAndWait(trustedServer, server, DefaultBufferTimeout, DefaultJoinTimeout))
+	assert.True(t, server.hasPeer(trustedServer.host.ID()))
+}
+
+func TestStaticPeer_Redialed(t *testing.T) {
+	servers, createErr := createServers(2, map[int]*CreateServerParams{
+		0: {
+			ConfigCallback: func(c *Config) {
+				c.MaxOutboundPeers = 0
+				c.NoDiscover = true
+			},
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	staticAddr, err := common.AddrInfoToString(servers[1].AddrInfo())
+	require.NoError(t, err)
+
+	// the static peer is dialed although there are no outbound slots
+	require.NoError(t, servers[0].AddStaticPeer(staticAddr))
+
+	connectCtx, connectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer connectFn()
+
+	_, connectErr := WaitUntilPeerConnectsTo(connectCtx, servers[0], servers[1].host.ID())
+	require.NoError(t, connectErr)
+
+	// the static peer is redialed once it disconnects
+	servers[0].DisconnectFromPeer(servers[1].host.ID(), "bye")
+
+	disconnectCtx, disconnectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer disconnectFn()
+
+	_, disconnectErr := WaitUntilPeerDisconnectsFrom(disconnectCtx, servers[0], servers[1].host.ID())
+	require.NoError(t, disconnectErr)
+
+	reconnectCtx, reconnectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer reconnectFn()
+
+	_, reconnectErr := WaitUntilPeerConnectsTo(reconnectCtx, servers[0], servers[1].host.ID())
+	require.NoError(t, reconnectErr)
+}
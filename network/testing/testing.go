@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/0xPolygon/polygon-edge/network/proto"
 	"github.com/libp2p/go-libp2p/core/network"
@@ -25,11 +26,15 @@ type MockNetworkingServer struct {
 	// Identity Hooks
 	newIdentityClientFn      newIdentityClientDelegate
 	disconnectFromPeerFn     disconnectFromPeerDelegate
+	reportPeerFn             reportPeerDelegate
 	addPeerFn                addPeerDelegate
 	updatePendingConnCountFn updatePendingConnCountDelegate
 	emitEventFn              emitEventDelegate
 	isTemporaryDialFn        isTemporaryDialDelegate
 	hasFreeConnectionSlotFn  hasFreeConnectionSlotDelegate
+	isTrustedPeerFn          isTrustedPeerDelegate
+	validatorSignatureFn     validatorSignatureDelegate
+	authorizePeerFn          authorizePeerDelegate
 
 	// Discovery Hooks
 	newDiscoveryClientFn       newDiscoveryClientDelegate
@@ -69,11 +74,15 @@ func (m *MockNetworkingServer) GetMockPeerMetrics() *MockPeerMetrics {
 // Required for Identity
 type newIdentityClientDelegate func(peer.ID) (proto.IdentityClient, error)
 type disconnectFromPeerDelegate func(peer.ID, string)
+type reportPeerDelegate func(peer.ID, common.PeerPenalty, string)
 type addPeerDelegate func(peer.ID, network.Direction)
 type updatePendingConnCountDelegate func(int64, network.Direction)
 type emitEventDelegate func(*event.PeerEvent)
 type isTemporaryDialDelegate func(peer.ID) bool
 type hasFreeConnectionSlotDelegate func(network.Direction) bool
+type isTrustedPeerDelegate func(peer.ID) bool
+type validatorSignatureDelegate func() []byte
+type authorizePeerDelegate func(peer.ID, []byte) error
 
 // Required for Discovery
 type getRandomBootnodeDelegate func() *peer.AddrInfo
@@ -120,6 +129,16 @@ func (m *MockNetworkingServer) HookDisconnectFromPeer(fn disconnectFromPeerDeleg
 	m.disconnectFromPeerFn = fn
 }
 
+func (m *MockNetworkingServer) ReportPeer(peerID peer.ID, penalty common.PeerPenalty, reason string) {
+	if m.reportPeerFn != nil {
+		m.reportPeerFn(peerID, penalty, reason)
+	}
+}
+
+func (m *MockNetworkingServer) HookReportPeer(fn reportPeerDelegate) {
+	m.reportPeerFn = fn
+}
+
 func (m *MockNetworkingServer) AddPeer(id peer.ID, direction network.Direction) {
 	if m.addPeerFn != nil {
 		m.addPeerFn(id, direction)
@@ -174,6 +193,42 @@ func (m *MockNetworkingServer) HookHasFreeConnectionSlot(fn hasFreeConnectionSlo
 	m.hasFreeConnectionSlotFn = fn
 }
 
+func (m *MockNetworkingServer) IsTrustedPeer(peerID peer.ID) bool {
+	if m.isTrustedPeerFn != nil {
+		return m.isTrustedPeerFn(peerID)
+	}
+
+	return false
+}
+
+func (m *MockNetworkingServer) HookIsTrustedPeer(fn isTrustedPeerDelegate) {
+	m.isTrustedPeerFn = fn
+}
+
+func (m *MockNetworkingServer) ValidatorSignature() []byte {
+	if m.validatorSignatureFn != nil {
+		return m.validatorSignatureFn()
+	}
+
+	return nil
+}
+
+func (m *MockNetworkingServer) HookValidatorSignature(fn validatorSignatureDelegate) {
+	m.validatorSignatureFn = fn
+}
+
+func (m *MockNetworkingServer) AuthorizePeer(peerID peer.ID, validatorSignature []byte) error {
+	if m.authorizePeerFn != nil {
+		return m.authorizePeerFn(peerID, validatorSignature)
+	}
+
+	return nil
+}
+
+func (m *MockNetworkingServer) HookAuthorizePeer(fn authorizePeerDelegate) {
+	m.authorizePeerFn = fn
+}
+
 func (m *MockNetworkingServer) GetRandomBootnode() *peer.AddrInfo {
 	if m.getRandomBootnodeFn != nil {
 		return m.getRandomBootnodeFn()
@@ -9,6 +9,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer"
 )
 
 const DefaultGRPCPort int = 9632
@@ -53,6 +55,20 @@ type Config struct {
 	MetricsInterval time.Duration
 
 	EventTracker *EventTracker
+
+	StateCache itrie.CacheConfig
+
+	RecordPreimages bool
+
+	AncientDistance        uint64
+	HistoryRetentionBlocks uint64
+
+	StateSync bool
+
+	CheckpointSync     *syncer.Checkpoint
+	CheckpointBackfill bool
+
+	HeaderSync bool
 }
 
 // Telemetry holds the config details for metric services
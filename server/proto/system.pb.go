@@ -82,10 +82,11 @@ type ServerStatus struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Network int64               `protobuf:"varint,1,opt,name=network,proto3" json:"network,omitempty"`
-	Genesis string              `protobuf:"bytes,2,opt,name=genesis,proto3" json:"genesis,omitempty"`
-	Current *ServerStatus_Block `protobuf:"bytes,3,opt,name=current,proto3" json:"current,omitempty"`
-	P2PAddr string              `protobuf:"bytes,4,opt,name=p2pAddr,proto3" json:"p2pAddr,omitempty"`
+	Network   int64                    `protobuf:"varint,1,opt,name=network,proto3" json:"network,omitempty"`
+	Genesis   string                   `protobuf:"bytes,2,opt,name=genesis,proto3" json:"genesis,omitempty"`
+	Current   *ServerStatus_Block      `protobuf:"bytes,3,opt,name=current,proto3" json:"current,omitempty"`
+	P2PAddr   string                   `protobuf:"bytes,4,opt,name=p2pAddr,proto3" json:"p2pAddr,omitempty"`
+	SyncPeers []*ServerStatus_SyncPeer `protobuf:"bytes,5,rep,name=syncPeers,proto3" json:"syncPeers,omitempty"`
 }
 
 func (x *ServerStatus) Reset() {
@@ -148,6 +149,13 @@ func (x *ServerStatus) GetP2PAddr() string {
 	return ""
 }
 
+func (x *ServerStatus) GetSyncPeers() []*ServerStatus_SyncPeer {
+	if x != nil {
+		return x.SyncPeers
+	}
+	return nil
+}
+
 type Peer struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -217,6 +225,8 @@ type PeersAddRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// keep the peer connected, redialing it whenever it disconnects
+	Static bool `protobuf:"varint,2,opt,name=static,proto3" json:"static,omitempty"`
 }
 
 func (x *PeersAddRequest) Reset() {
@@ -258,6 +268,13 @@ func (x *PeersAddRequest) GetId() string {
 	return ""
 }
 
+func (x *PeersAddRequest) GetStatic() bool {
+	if x != nil {
+		return x.Static
+	}
+	return false
+}
+
 type PeersAddResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -399,6 +416,228 @@ func (x *PeersListResponse) GetPeers() []*Peer {
 	return nil
 }
 
+type PeersBanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the duration of the ban in seconds, permanent when zero
+	Duration int64  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	Reason   string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *PeersBanRequest) Reset() {
+	*x = PeersBanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_proto_system_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeersBanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeersBanRequest) ProtoMessage() {}
+
+func (x *PeersBanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_system_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeersBanRequest.ProtoReflect.Descriptor instead.
+func (*PeersBanRequest) Descriptor() ([]byte, []int) {
+	return file_server_proto_system_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PeersBanRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PeersBanRequest) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *PeersBanRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type PeersUnbanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *PeersUnbanRequest) Reset() {
+	*x = PeersUnbanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_proto_system_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeersUnbanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeersUnbanRequest) ProtoMessage() {}
+
+func (x *PeersUnbanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_system_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeersUnbanRequest.ProtoReflect.Descriptor instead.
+func (*PeersUnbanRequest) Descriptor() ([]byte, []int) {
+	return file_server_proto_system_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PeersUnbanRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PeersListBansResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bans []*PeerBan `protobuf:"bytes,1,rep,name=bans,proto3" json:"bans,omitempty"`
+}
+
+func (x *PeersListBansResponse) Reset() {
+	*x = PeersListBansResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_proto_system_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeersListBansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeersListBansResponse) ProtoMessage() {}
+
+func (x *PeersListBansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_system_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeersListBansResponse.ProtoReflect.Descriptor instead.
+func (*PeersListBansResponse) Descriptor() ([]byte, []int) {
+	return file_server_proto_system_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PeersListBansResponse) GetBans() []*PeerBan {
+	if x != nil {
+		return x.Bans
+	}
+	return nil
+}
+
+type PeerBan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// the unix time the ban expires at, permanent when zero
+	Until int64 `protobuf:"varint,3,opt,name=until,proto3" json:"until,omitempty"`
+}
+
+func (x *PeerBan) Reset() {
+	*x = PeerBan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_proto_system_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerBan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerBan) ProtoMessage() {}
+
+func (x *PeerBan) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_system_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerBan.ProtoReflect.Descriptor instead.
+func (*PeerBan) Descriptor() ([]byte, []int) {
+	return file_server_proto_system_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PeerBan) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PeerBan) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PeerBan) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
 type BlockByNumberRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -410,7 +649,7 @@ type BlockByNumberRequest struct {
 func (x *BlockByNumberRequest) Reset() {
 	*x = BlockByNumberRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_proto_system_proto_msgTypes[7]
+		mi := &file_server_proto_system_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -423,7 +662,7 @@ func (x *BlockByNumberRequest) String() string {
 func (*BlockByNumberRequest) ProtoMessage() {}
 
 func (x *BlockByNumberRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_server_proto_system_proto_msgTypes[7]
+	mi := &file_server_proto_system_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -436,7 +675,7 @@ func (x *BlockByNumberRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockByNumberRequest.ProtoReflect.Descriptor instead.
 func (*BlockByNumberRequest) Descriptor() ([]byte, []int) {
-	return file_server_proto_system_proto_rawDescGZIP(), []int{7}
+	return file_server_proto_system_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *BlockByNumberRequest) GetNumber() uint64 {
@@ -457,7 +696,7 @@ type BlockResponse struct {
 func (x *BlockResponse) Reset() {
 	*x = BlockResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_proto_system_proto_msgTypes[8]
+		mi := &file_server_proto_system_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -470,7 +709,7 @@ func (x *BlockResponse) String() string {
 func (*BlockResponse) ProtoMessage() {}
 
 func (x *BlockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_server_proto_system_proto_msgTypes[8]
+	mi := &file_server_proto_system_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -483,7 +722,7 @@ func (x *BlockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockResponse.ProtoReflect.Descriptor instead.
 func (*BlockResponse) Descriptor() ([]byte, []int) {
-	return file_server_proto_system_proto_rawDescGZIP(), []int{8}
+	return file_server_proto_system_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *BlockResponse) GetData() []byte {
@@ -505,7 +744,7 @@ type ExportRequest struct {
 func (x *ExportRequest) Reset() {
 	*x = ExportRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_proto_system_proto_msgTypes[9]
+		mi := &file_server_proto_system_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -518,7 +757,7 @@ func (x *ExportRequest) String() string {
 func (*ExportRequest) ProtoMessage() {}
 
 func (x *ExportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_server_proto_system_proto_msgTypes[9]
+	mi := &file_server_proto_system_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -531,7 +770,7 @@ func (x *ExportRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
 func (*ExportRequest) Descriptor() ([]byte, []int) {
-	return file_server_proto_system_proto_rawDescGZIP(), []int{9}
+	return file_server_proto_system_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ExportRequest) GetFrom() uint64 {
@@ -563,7 +802,7 @@ type ExportEvent struct {
 func (x *ExportEvent) Reset() {
 	*x = ExportEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_proto_system_proto_msgTypes[10]
+		mi := &file_server_proto_system_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -576,7 +815,7 @@ func (x *ExportEvent) String() string {
 func (*ExportEvent) ProtoMessage() {}
 
 func (x *ExportEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_server_proto_system_proto_msgTypes[10]
+	mi := &file_server_proto_system_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -589,7 +828,7 @@ func (x *ExportEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExportEvent.ProtoReflect.Descriptor instead.
 func (*ExportEvent) Descriptor() ([]byte, []int) {
-	return file_server_proto_system_proto_rawDescGZIP(), []int{10}
+	return file_server_proto_system_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ExportEvent) GetFrom() uint64 {
@@ -620,6 +859,157 @@ func (x *ExportEvent) GetData() []byte {
 	return nil
 }
 
+type ReindexRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From uint64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	// the head block when zero
+	To uint64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *ReindexRequest) Reset() {
+	*x = ReindexRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_proto_system_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReindexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexRequest) ProtoMessage() {}
+
+func (x *ReindexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_system_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexRequest.ProtoReflect.Descriptor instead.
+func (*ReindexRequest) Descriptor() ([]byte, []int) {
+	return file_server_proto_system_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReindexRequest) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ReindexRequest) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type ReindexEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From         uint64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To           uint64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	Next         uint64 `protobuf:"varint,3,opt,name=next,proto3" json:"next,omitempty"`
+	BlockLookups uint64 `protobuf:"varint,4,opt,name=blockLookups,proto3" json:"blockLookups,omitempty"`
+	TxLookups    uint64 `protobuf:"varint,5,opt,name=txLookups,proto3" json:"txLookups,omitempty"`
+	Pruned       uint64 `protobuf:"varint,6,opt,name=pruned,proto3" json:"pruned,omitempty"`
+	Done         bool   `protobuf:"varint,7,opt,name=done,proto3" json:"done,omitempty"`
+}
+
+func (x *ReindexEvent) Reset() {
+	*x = ReindexEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_proto_system_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReindexEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexEvent) ProtoMessage() {}
+
+func (x *ReindexEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_system_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexEvent.ProtoReflect.Descriptor instead.
+func (*ReindexEvent) Descriptor() ([]byte, []int) {
+	return file_server_proto_system_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReindexEvent) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ReindexEvent) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *ReindexEvent) GetNext() uint64 {
+	if x != nil {
+		return x.Next
+	}
+	return 0
+}
+
+func (x *ReindexEvent) GetBlockLookups() uint64 {
+	if x != nil {
+		return x.BlockLookups
+	}
+	return 0
+}
+
+func (x *ReindexEvent) GetTxLookups() uint64 {
+	if x != nil {
+		return x.TxLookups
+	}
+	return 0
+}
+
+func (x *ReindexEvent) GetPruned() uint64 {
+	if x != nil {
+		return x.Pruned
+	}
+	return 0
+}
+
+func (x *ReindexEvent) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
 type BlockchainEvent_Header struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -632,7 +1022,7 @@ type BlockchainEvent_Header struct {
 func (x *BlockchainEvent_Header) Reset() {
 	*x = BlockchainEvent_Header{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_proto_system_proto_msgTypes[11]
+		mi := &file_server_proto_system_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -645,7 +1035,7 @@ func (x *BlockchainEvent_Header) String() string {
 func (*BlockchainEvent_Header) ProtoMessage() {}
 
 func (x *BlockchainEvent_Header) ProtoReflect() protoreflect.Message {
-	mi := &file_server_proto_system_proto_msgTypes[11]
+	mi := &file_server_proto_system_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -687,7 +1077,7 @@ type ServerStatus_Block struct {
 func (x *ServerStatus_Block) Reset() {
 	*x = ServerStatus_Block{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_proto_system_proto_msgTypes[12]
+		mi := &file_server_proto_system_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -700,7 +1090,7 @@ func (x *ServerStatus_Block) String() string {
 func (*ServerStatus_Block) ProtoMessage() {}
 
 func (x *ServerStatus_Block) ProtoReflect() protoreflect.Message {
-	mi := &file_server_proto_system_proto_msgTypes[12]
+	mi := &file_server_proto_system_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -730,6 +1120,102 @@ func (x *ServerStatus_Block) GetHash() string {
 	return ""
 }
 
+type ServerStatus_SyncPeer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Score         int64  `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	Failures      uint64 `protobuf:"varint,3,opt,name=failures,proto3" json:"failures,omitempty"`
+	Stalls        uint64 `protobuf:"varint,4,opt,name=stalls,proto3" json:"stalls,omitempty"`
+	InvalidBlocks uint64 `protobuf:"varint,5,opt,name=invalidBlocks,proto3" json:"invalidBlocks,omitempty"`
+	Bans          uint64 `protobuf:"varint,6,opt,name=bans,proto3" json:"bans,omitempty"`
+	// unix time the ban of the peer expires at, zero if the peer was never banned
+	BannedUntil int64 `protobuf:"varint,7,opt,name=bannedUntil,proto3" json:"bannedUntil,omitempty"`
+}
+
+func (x *ServerStatus_SyncPeer) Reset() {
+	*x = ServerStatus_SyncPeer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_proto_system_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerStatus_SyncPeer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerStatus_SyncPeer) ProtoMessage() {}
+
+func (x *ServerStatus_SyncPeer) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_system_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerStatus_SyncPeer.ProtoReflect.Descriptor instead.
+func (*ServerStatus_SyncPeer) Descriptor() ([]byte, []int) {
+	return file_server_proto_system_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *ServerStatus_SyncPeer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ServerStatus_SyncPeer) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *ServerStatus_SyncPeer) GetFailures() uint64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *ServerStatus_SyncPeer) GetStalls() uint64 {
+	if x != nil {
+		return x.Stalls
+	}
+	return 0
+}
+
+func (x *ServerStatus_SyncPeer) GetInvalidBlocks() uint64 {
+	if x != nil {
+		return x.InvalidBlocks
+	}
+	return 0
+}
+
+func (x *ServerStatus_SyncPeer) GetBans() uint64 {
+	if x != nil {
+		return x.Bans
+	}
+	return 0
+}
+
+func (x *ServerStatus_SyncPeer) GetBannedUntil() int64 {
+	if x != nil {
+		return x.BannedUntil
+	}
+	return 0
+}
+
 var File_server_proto_system_proto protoreflect.FileDescriptor
 
 var file_server_proto_system_proto_rawDesc = []byte{
@@ -749,7 +1235,7 @@ var file_server_proto_system_proto_rawDesc = []byte{
 	0x64, 0x1a, 0x34, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6e,
 	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6e, 0x75, 0x6d,
 	0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0xc3, 0x01, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76,
+	0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0xbf, 0x03, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76,
 	0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77,
 	0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f,
 	0x72, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x18, 0x02, 0x20,
@@ -758,72 +1244,142 @@ var file_server_proto_system_proto_rawDesc = []byte{
 	0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e,
 	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x18,
 	0x0a, 0x07, 0x70, 0x32, 0x70, 0x41, 0x64, 0x64, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x70, 0x32, 0x70, 0x41, 0x64, 0x64, 0x72, 0x1a, 0x33, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x4a, 0x0a,
-	0x04, 0x50, 0x65, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
-	0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
-	0x6f, 0x6c, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x22, 0x53, 0x0a, 0x0f, 0x50, 0x65, 0x65,
-	0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x30, 0xfa, 0x42, 0x2d, 0x72, 0x2b, 0x32,
-	0x29, 0x5e, 0x5c, 0x2f, 0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x2e, 0x5f,
-	0x7e, 0x2d, 0x5d, 0x2b, 0x28, 0x5c, 0x2f, 0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d,
-	0x39, 0x2e, 0x5f, 0x7e, 0x2d, 0x5d, 0x2b, 0x29, 0x2a, 0x24, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2c,
-	0x0a, 0x10, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3e, 0x0a, 0x12,
-	0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x28, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x18,
-	0xfa, 0x42, 0x15, 0x72, 0x13, 0x32, 0x11, 0x5e, 0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30,
-	0x2d, 0x39, 0x5d, 0x7b, 0x31, 0x2c, 0x7d, 0x24, 0x52, 0x02, 0x69, 0x64, 0x22, 0x33, 0x0a, 0x11,
-	0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x1e, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72,
-	0x73, 0x22, 0x2e, 0x0a, 0x14, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x79, 0x4e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x22, 0x23, 0x0a, 0x0d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x33, 0x0a, 0x0d, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
-	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x5d, 0x0a, 0x0b, 0x45,
-	0x78, 0x70, 0x6f, 0x72, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72,
-	0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e,
-	0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x16,
-	0x0a, 0x06, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
-	0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0x8d, 0x03, 0x0a, 0x06, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x35, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x76, 0x31, 0x2e,
-	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x35, 0x0a, 0x08,
-	0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x12, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65,
-	0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
-	0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74,
-	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65,
-	0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x2f, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16,
-	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72,
-	0x12, 0x3a, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x63, 0x68, 0x61, 0x69, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x0d,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x79, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x18, 0x2e,
-	0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x79, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x45, 0x78,
-	0x70, 0x6f, 0x72, 0x74, 0x12, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70,
-	0x6f, 0x72, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x0f, 0x5a, 0x0d, 0x2f, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x07, 0x70, 0x32, 0x70, 0x41, 0x64, 0x64, 0x72, 0x12, 0x37, 0x0a, 0x09, 0x73, 0x79, 0x6e, 0x63,
+	0x50, 0x65, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x53, 0x79,
+	0x6e, 0x63, 0x50, 0x65, 0x65, 0x72, 0x52, 0x09, 0x73, 0x79, 0x6e, 0x63, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x1a, 0x33, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x1a, 0xc0, 0x01, 0x0a, 0x08, 0x53, 0x79, 0x6e, 0x63, 0x50,
+	0x65, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x66, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x73, 0x12, 0x24, 0x0a,
+	0x0d, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x04, 0x62, 0x61, 0x6e, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x61, 0x6e, 0x6e, 0x65,
+	0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x61,
+	0x6e, 0x6e, 0x65, 0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0x4a, 0x0a, 0x04, 0x50, 0x65, 0x65,
+	0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x61, 0x64, 0x64, 0x72, 0x73, 0x22, 0x6b, 0x0a, 0x0f, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x30, 0xfa, 0x42, 0x2d, 0x72, 0x2b, 0x32, 0x29, 0x5e, 0x5c, 0x2f,
+	0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x2e, 0x5f, 0x7e, 0x2d, 0x5d, 0x2b,
+	0x28, 0x5c, 0x2f, 0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x2e, 0x5f, 0x7e,
+	0x2d, 0x5d, 0x2b, 0x29, 0x2a, 0x24, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x69, 0x63, 0x22, 0x2c, 0x0a, 0x10, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x3e, 0x0a, 0x12, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x18, 0xfa, 0x42, 0x15, 0x72, 0x13, 0x32, 0x11, 0x5e, 0x5b, 0x41, 0x2d, 0x5a,
+	0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x5d, 0x7b, 0x31, 0x2c, 0x7d, 0x24, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x33, 0x0a, 0x11, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x05,
+	0x70, 0x65, 0x65, 0x72, 0x73, 0x22, 0x6f, 0x0a, 0x0f, 0x50, 0x65, 0x65, 0x72, 0x73, 0x42, 0x61,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x18, 0xfa, 0x42, 0x15, 0x72, 0x13, 0x32, 0x11, 0x5e, 0x5b, 0x41,
+	0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x5d, 0x7b, 0x31, 0x2c, 0x7d, 0x24, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x3d, 0x0a, 0x11, 0x50, 0x65, 0x65, 0x72, 0x73, 0x55,
+	0x6e, 0x62, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x18, 0xfa, 0x42, 0x15, 0x72, 0x13, 0x32, 0x11,
+	0x5e, 0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x5d, 0x7b, 0x31, 0x2c, 0x7d,
+	0x24, 0x52, 0x02, 0x69, 0x64, 0x22, 0x38, 0x0a, 0x15, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69,
+	0x73, 0x74, 0x42, 0x61, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f,
+	0x0a, 0x04, 0x62, 0x61, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x42, 0x61, 0x6e, 0x52, 0x04, 0x62, 0x61, 0x6e, 0x73, 0x22,
+	0x47, 0x0a, 0x07, 0x50, 0x65, 0x65, 0x72, 0x42, 0x61, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0x2e, 0x0a, 0x14, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x42, 0x79, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x23, 0x0a, 0x0d, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x33, 0x0a,
+	0x0d, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02,
+	0x74, 0x6f, 0x22, 0x5d, 0x0a, 0x0b, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x22, 0x34, 0x0a, 0x0e, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x22, 0xb4, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02,
+	0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x6e, 0x65, 0x78, 0x74,
+	0x12, 0x22, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x6f, 0x6f,
+	0x6b, 0x75, 0x70, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x78, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x78, 0x4c, 0x6f, 0x6f, 0x6b, 0x75,
+	0x70, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f,
+	0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x32, 0xc2,
+	0x05, 0x0a, 0x06, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x35, 0x0a, 0x09, 0x47, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x35, 0x0a, 0x08, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x12, 0x13, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x73,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x65, 0x65, 0x72, 0x12, 0x37, 0x0a, 0x08, 0x50, 0x65, 0x65, 0x72, 0x73, 0x42, 0x61, 0x6e,
+	0x12, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x42, 0x61, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3b, 0x0a,
+	0x0a, 0x50, 0x65, 0x65, 0x72, 0x73, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x12, 0x15, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x42, 0x0a, 0x0d, 0x50, 0x65,
+	0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x6e, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69,
+	0x73, 0x74, 0x42, 0x61, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46,
+	0x0a, 0x14, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6c, 0x6c,
+	0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3a, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x30, 0x01, 0x12, 0x3c, 0x0a, 0x0d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x79, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x79,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2e, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x11, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01,
+	0x12, 0x31, 0x0a, 0x07, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x10, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x30, 0x01, 0x42, 0x0f, 0x5a, 0x0d, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -838,7 +1394,7 @@ func file_server_proto_system_proto_rawDescGZIP() []byte {
 	return file_server_proto_system_proto_rawDescData
 }
 
-var file_server_proto_system_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_server_proto_system_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_server_proto_system_proto_goTypes = []interface{}{
 	(*BlockchainEvent)(nil),        // 0: v1.BlockchainEvent
 	(*ServerStatus)(nil),           // 1: v1.ServerStatus
@@ -847,38 +1403,57 @@ var file_server_proto_system_proto_goTypes = []interface{}{
 	(*PeersAddResponse)(nil),       // 4: v1.PeersAddResponse
 	(*PeersStatusRequest)(nil),     // 5: v1.PeersStatusRequest
 	(*PeersListResponse)(nil),      // 6: v1.PeersListResponse
-	(*BlockByNumberRequest)(nil),   // 7: v1.BlockByNumberRequest
-	(*BlockResponse)(nil),          // 8: v1.BlockResponse
-	(*ExportRequest)(nil),          // 9: v1.ExportRequest
-	(*ExportEvent)(nil),            // 10: v1.ExportEvent
-	(*BlockchainEvent_Header)(nil), // 11: v1.BlockchainEvent.Header
-	(*ServerStatus_Block)(nil),     // 12: v1.ServerStatus.Block
-	(*emptypb.Empty)(nil),          // 13: google.protobuf.Empty
+	(*PeersBanRequest)(nil),        // 7: v1.PeersBanRequest
+	(*PeersUnbanRequest)(nil),      // 8: v1.PeersUnbanRequest
+	(*PeersListBansResponse)(nil),  // 9: v1.PeersListBansResponse
+	(*PeerBan)(nil),                // 10: v1.PeerBan
+	(*BlockByNumberRequest)(nil),   // 11: v1.BlockByNumberRequest
+	(*BlockResponse)(nil),          // 12: v1.BlockResponse
+	(*ExportRequest)(nil),          // 13: v1.ExportRequest
+	(*ExportEvent)(nil),            // 14: v1.ExportEvent
+	(*ReindexRequest)(nil),         // 15: v1.ReindexRequest
+	(*ReindexEvent)(nil),           // 16: v1.ReindexEvent
+	(*BlockchainEvent_Header)(nil), // 17: v1.BlockchainEvent.Header
+	(*ServerStatus_Block)(nil),     // 18: v1.ServerStatus.Block
+	(*ServerStatus_SyncPeer)(nil),  // 19: v1.ServerStatus.SyncPeer
+	(*emptypb.Empty)(nil),          // 20: google.protobuf.Empty
 }
 var file_server_proto_system_proto_depIdxs = []int32{
-	11, // 0: v1.BlockchainEvent.added:type_name -> v1.BlockchainEvent.Header
-	11, // 1: v1.BlockchainEvent.removed:type_name -> v1.BlockchainEvent.Header
-	12, // 2: v1.ServerStatus.current:type_name -> v1.ServerStatus.Block
-	2,  // 3: v1.PeersListResponse.peers:type_name -> v1.Peer
-	13, // 4: v1.System.GetStatus:input_type -> google.protobuf.Empty
-	3,  // 5: v1.System.PeersAdd:input_type -> v1.PeersAddRequest
-	13, // 6: v1.System.PeersList:input_type -> google.protobuf.Empty
-	5,  // 7: v1.System.PeersStatus:input_type -> v1.PeersStatusRequest
-	13, // 8: v1.System.Subscribe:input_type -> google.protobuf.Empty
-	7,  // 9: v1.System.BlockByNumber:input_type -> v1.BlockByNumberRequest
-	9,  // 10: v1.System.Export:input_type -> v1.ExportRequest
-	1,  // 11: v1.System.GetStatus:output_type -> v1.ServerStatus
-	4,  // 12: v1.System.PeersAdd:output_type -> v1.PeersAddResponse
-	6,  // 13: v1.System.PeersList:output_type -> v1.PeersListResponse
-	2,  // 14: v1.System.PeersStatus:output_type -> v1.Peer
-	0,  // 15: v1.System.Subscribe:output_type -> v1.BlockchainEvent
-	8,  // 16: v1.System.BlockByNumber:output_type -> v1.BlockResponse
-	10, // 17: v1.System.Export:output_type -> v1.ExportEvent
-	11, // [11:18] is the sub-list for method output_type
-	4,  // [4:11] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	17, // 0: v1.BlockchainEvent.added:type_name -> v1.BlockchainEvent.Header
+	17, // 1: v1.BlockchainEvent.removed:type_name -> v1.BlockchainEvent.Header
+	18, // 2: v1.ServerStatus.current:type_name -> v1.ServerStatus.Block
+	19, // 3: v1.ServerStatus.syncPeers:type_name -> v1.ServerStatus.SyncPeer
+	2,  // 4: v1.PeersListResponse.peers:type_name -> v1.Peer
+	10, // 5: v1.PeersListBansResponse.bans:type_name -> v1.PeerBan
+	20, // 6: v1.System.GetStatus:input_type -> google.protobuf.Empty
+	3,  // 7: v1.System.PeersAdd:input_type -> v1.PeersAddRequest
+	20, // 8: v1.System.PeersList:input_type -> google.protobuf.Empty
+	5,  // 9: v1.System.PeersStatus:input_type -> v1.PeersStatusRequest
+	7,  // 10: v1.System.PeersBan:input_type -> v1.PeersBanRequest
+	8,  // 11: v1.System.PeersUnban:input_type -> v1.PeersUnbanRequest
+	20, // 12: v1.System.PeersListBans:input_type -> google.protobuf.Empty
+	20, // 13: v1.System.PeersReloadAllowlist:input_type -> google.protobuf.Empty
+	20, // 14: v1.System.Subscribe:input_type -> google.protobuf.Empty
+	11, // 15: v1.System.BlockByNumber:input_type -> v1.BlockByNumberRequest
+	13, // 16: v1.System.Export:input_type -> v1.ExportRequest
+	15, // 17: v1.System.Reindex:input_type -> v1.ReindexRequest
+	1,  // 18: v1.System.GetStatus:output_type -> v1.ServerStatus
+	4,  // 19: v1.System.PeersAdd:output_type -> v1.PeersAddResponse
+	6,  // 20: v1.System.PeersList:output_type -> v1.PeersListResponse
+	2,  // 21: v1.System.PeersStatus:output_type -> v1.Peer
+	20, // 22: v1.System.PeersBan:output_type -> google.protobuf.Empty
+	20, // 23: v1.System.PeersUnban:output_type -> google.protobuf.Empty
+	9,  // 24: v1.System.PeersListBans:output_type -> v1.PeersListBansResponse
+	20, // 25: v1.System.PeersReloadAllowlist:output_type -> google.protobuf.Empty
+	0,  // 26: v1.System.Subscribe:output_type -> v1.BlockchainEvent
+	12, // 27: v1.System.BlockByNumber:output_type -> v1.BlockResponse
+	14, // 28: v1.System.Export:output_type -> v1.ExportEvent
+	16, // 29: v1.System.Reindex:output_type -> v1.ReindexEvent
+	18, // [18:30] is the sub-list for method output_type
+	6,  // [6:18] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_server_proto_system_proto_init() }
@@ -972,7 +1547,7 @@ func file_server_proto_system_proto_init() {
 			}
 		}
 		file_server_proto_system_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockByNumberRequest); i {
+			switch v := v.(*PeersBanRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -984,7 +1559,7 @@ func file_server_proto_system_proto_init() {
 			}
 		}
 		file_server_proto_system_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockResponse); i {
+			switch v := v.(*PeersUnbanRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -996,7 +1571,7 @@ func file_server_proto_system_proto_init() {
 			}
 		}
 		file_server_proto_system_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExportRequest); i {
+			switch v := v.(*PeersListBansResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1008,7 +1583,7 @@ func file_server_proto_system_proto_init() {
 			}
 		}
 		file_server_proto_system_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExportEvent); i {
+			switch v := v.(*PeerBan); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1020,7 +1595,7 @@ func file_server_proto_system_proto_init() {
 			}
 		}
 		file_server_proto_system_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockchainEvent_Header); i {
+			switch v := v.(*BlockByNumberRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1032,6 +1607,78 @@ func file_server_proto_system_proto_init() {
 			}
 		}
 		file_server_proto_system_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_proto_system_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_proto_system_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_proto_system_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReindexRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_proto_system_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReindexEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_proto_system_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockchainEvent_Header); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_proto_system_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ServerStatus_Block); i {
 			case 0:
 				return &v.state
@@ -1043,6 +1690,18 @@ func file_server_proto_system_proto_init() {
 				return nil
 			}
 		}
+		file_server_proto_system_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerStatus_SyncPeer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1050,7 +1709,7 @@ func file_server_proto_system_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_server_proto_system_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -260,6 +260,40 @@ func (m *ServerStatus) validate(all bool) error {
 
 	// no validation rules for P2PAddr
 
+	for idx, item := range m.GetSyncPeers() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ServerStatusValidationError{
+						field:  fmt.Sprintf("SyncPeers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ServerStatusValidationError{
+						field:  fmt.Sprintf("SyncPeers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ServerStatusValidationError{
+					field:  fmt.Sprintf("SyncPeers[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return ServerStatusMultiError(errors)
 	}
@@ -470,6 +504,8 @@ func (m *PeersAddRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	// no validation rules for Static
+
 	if len(errors) > 0 {
 		return PeersAddRequestMultiError(errors)
 	}
@@ -903,44 +939,57 @@ var _ interface {
 	ErrorName() string
 } = PeersListResponseValidationError{}
 
-// Validate checks the field values on BlockByNumberRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *BlockByNumberRequest) Validate() error {
+// Validate checks the field values on PeersBanRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PeersBanRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on BlockByNumberRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// BlockByNumberRequestMultiError, or nil if none found.
-func (m *BlockByNumberRequest) ValidateAll() error {
+// ValidateAll checks the field values on PeersBanRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// PeersBanRequestMultiError, or nil if none found.
+func (m *PeersBanRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *BlockByNumberRequest) validate(all bool) error {
+func (m *PeersBanRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Number
+	if !_PeersBanRequest_Id_Pattern.MatchString(m.GetId()) {
+		err := PeersBanRequestValidationError{
+			field:  "Id",
+			reason: "value does not match regex pattern \"^[A-Za-z0-9]{1,}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Duration
+
+	// no validation rules for Reason
 
 	if len(errors) > 0 {
-		return BlockByNumberRequestMultiError(errors)
+		return PeersBanRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// BlockByNumberRequestMultiError is an error wrapping multiple validation
-// errors returned by BlockByNumberRequest.ValidateAll() if the designated
-// constraints aren't met.
-type BlockByNumberRequestMultiError []error
+// PeersBanRequestMultiError is an error wrapping multiple validation errors
+// returned by PeersBanRequest.ValidateAll() if the designated constraints
+// aren't met.
+type PeersBanRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m BlockByNumberRequestMultiError) Error() string {
+func (m PeersBanRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -949,11 +998,11 @@ func (m BlockByNumberRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m BlockByNumberRequestMultiError) AllErrors() []error { return m }
+func (m PeersBanRequestMultiError) AllErrors() []error { return m }
 
-// BlockByNumberRequestValidationError is the validation error returned by
-// BlockByNumberRequest.Validate if the designated constraints aren't met.
-type BlockByNumberRequestValidationError struct {
+// PeersBanRequestValidationError is the validation error returned by
+// PeersBanRequest.Validate if the designated constraints aren't met.
+type PeersBanRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -961,24 +1010,22 @@ type BlockByNumberRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e BlockByNumberRequestValidationError) Field() string { return e.field }
+func (e PeersBanRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e BlockByNumberRequestValidationError) Reason() string { return e.reason }
+func (e PeersBanRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e BlockByNumberRequestValidationError) Cause() error { return e.cause }
+func (e PeersBanRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e BlockByNumberRequestValidationError) Key() bool { return e.key }
+func (e PeersBanRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e BlockByNumberRequestValidationError) ErrorName() string {
-	return "BlockByNumberRequestValidationError"
-}
+func (e PeersBanRequestValidationError) ErrorName() string { return "PeersBanRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e BlockByNumberRequestValidationError) Error() string {
+func (e PeersBanRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -990,14 +1037,14 @@ func (e BlockByNumberRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sBlockByNumberRequest.%s: %s%s",
+		"invalid %sPeersBanRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = BlockByNumberRequestValidationError{}
+var _ error = PeersBanRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1005,46 +1052,57 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = BlockByNumberRequestValidationError{}
+} = PeersBanRequestValidationError{}
 
-// Validate checks the field values on BlockResponse with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *BlockResponse) Validate() error {
+var _PeersBanRequest_Id_Pattern = regexp.MustCompile("^[A-Za-z0-9]{1,}$")
+
+// Validate checks the field values on PeersUnbanRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PeersUnbanRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on BlockResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in BlockResponseMultiError, or
-// nil if none found.
-func (m *BlockResponse) ValidateAll() error {
+// ValidateAll checks the field values on PeersUnbanRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// PeersUnbanRequestMultiError, or nil if none found.
+func (m *PeersUnbanRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *BlockResponse) validate(all bool) error {
+func (m *PeersUnbanRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Data
+	if !_PeersUnbanRequest_Id_Pattern.MatchString(m.GetId()) {
+		err := PeersUnbanRequestValidationError{
+			field:  "Id",
+			reason: "value does not match regex pattern \"^[A-Za-z0-9]{1,}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
 	if len(errors) > 0 {
-		return BlockResponseMultiError(errors)
+		return PeersUnbanRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// BlockResponseMultiError is an error wrapping multiple validation errors
-// returned by BlockResponse.ValidateAll() if the designated constraints
+// PeersUnbanRequestMultiError is an error wrapping multiple validation errors
+// returned by PeersUnbanRequest.ValidateAll() if the designated constraints
 // aren't met.
-type BlockResponseMultiError []error
+type PeersUnbanRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m BlockResponseMultiError) Error() string {
+func (m PeersUnbanRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1053,11 +1111,11 @@ func (m BlockResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m BlockResponseMultiError) AllErrors() []error { return m }
+func (m PeersUnbanRequestMultiError) AllErrors() []error { return m }
 
-// BlockResponseValidationError is the validation error returned by
-// BlockResponse.Validate if the designated constraints aren't met.
-type BlockResponseValidationError struct {
+// PeersUnbanRequestValidationError is the validation error returned by
+// PeersUnbanRequest.Validate if the designated constraints aren't met.
+type PeersUnbanRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1065,22 +1123,24 @@ type BlockResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e BlockResponseValidationError) Field() string { return e.field }
+func (e PeersUnbanRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e BlockResponseValidationError) Reason() string { return e.reason }
+func (e PeersUnbanRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e BlockResponseValidationError) Cause() error { return e.cause }
+func (e PeersUnbanRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e BlockResponseValidationError) Key() bool { return e.key }
+func (e PeersUnbanRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e BlockResponseValidationError) ErrorName() string { return "BlockResponseValidationError" }
+func (e PeersUnbanRequestValidationError) ErrorName() string {
+	return "PeersUnbanRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e BlockResponseValidationError) Error() string {
+func (e PeersUnbanRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1092,14 +1152,14 @@ func (e BlockResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sBlockResponse.%s: %s%s",
+		"invalid %sPeersUnbanRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = BlockResponseValidationError{}
+var _ error = PeersUnbanRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1107,48 +1167,80 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = BlockResponseValidationError{}
+} = PeersUnbanRequestValidationError{}
 
-// Validate checks the field values on ExportRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *ExportRequest) Validate() error {
+var _PeersUnbanRequest_Id_Pattern = regexp.MustCompile("^[A-Za-z0-9]{1,}$")
+
+// Validate checks the field values on PeersListBansResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *PeersListBansResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ExportRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in ExportRequestMultiError, or
-// nil if none found.
-func (m *ExportRequest) ValidateAll() error {
+// ValidateAll checks the field values on PeersListBansResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// PeersListBansResponseMultiError, or nil if none found.
+func (m *PeersListBansResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ExportRequest) validate(all bool) error {
+func (m *PeersListBansResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for From
+	for idx, item := range m.GetBans() {
+		_, _ = idx, item
 
-	// no validation rules for To
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PeersListBansResponseValidationError{
+						field:  fmt.Sprintf("Bans[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PeersListBansResponseValidationError{
+						field:  fmt.Sprintf("Bans[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PeersListBansResponseValidationError{
+					field:  fmt.Sprintf("Bans[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
 
 	if len(errors) > 0 {
-		return ExportRequestMultiError(errors)
+		return PeersListBansResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// ExportRequestMultiError is an error wrapping multiple validation errors
-// returned by ExportRequest.ValidateAll() if the designated constraints
-// aren't met.
-type ExportRequestMultiError []error
+// PeersListBansResponseMultiError is an error wrapping multiple validation
+// errors returned by PeersListBansResponse.ValidateAll() if the designated
+// constraints aren't met.
+type PeersListBansResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ExportRequestMultiError) Error() string {
+func (m PeersListBansResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1157,11 +1249,11 @@ func (m ExportRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ExportRequestMultiError) AllErrors() []error { return m }
+func (m PeersListBansResponseMultiError) AllErrors() []error { return m }
 
-// ExportRequestValidationError is the validation error returned by
-// ExportRequest.Validate if the designated constraints aren't met.
-type ExportRequestValidationError struct {
+// PeersListBansResponseValidationError is the validation error returned by
+// PeersListBansResponse.Validate if the designated constraints aren't met.
+type PeersListBansResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1169,22 +1261,24 @@ type ExportRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ExportRequestValidationError) Field() string { return e.field }
+func (e PeersListBansResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ExportRequestValidationError) Reason() string { return e.reason }
+func (e PeersListBansResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ExportRequestValidationError) Cause() error { return e.cause }
+func (e PeersListBansResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ExportRequestValidationError) Key() bool { return e.key }
+func (e PeersListBansResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ExportRequestValidationError) ErrorName() string { return "ExportRequestValidationError" }
+func (e PeersListBansResponseValidationError) ErrorName() string {
+	return "PeersListBansResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e ExportRequestValidationError) Error() string {
+func (e PeersListBansResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1196,14 +1290,14 @@ func (e ExportRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sExportRequest.%s: %s%s",
+		"invalid %sPeersListBansResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ExportRequestValidationError{}
+var _ error = PeersListBansResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1211,51 +1305,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ExportRequestValidationError{}
+} = PeersListBansResponseValidationError{}
 
-// Validate checks the field values on ExportEvent with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
+// Validate checks the field values on PeerBan with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *ExportEvent) Validate() error {
+func (m *PeerBan) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ExportEvent with the rules defined in
-// the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in ExportEventMultiError, or
-// nil if none found.
-func (m *ExportEvent) ValidateAll() error {
+// ValidateAll checks the field values on PeerBan with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in PeerBanMultiError, or nil if none found.
+func (m *PeerBan) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ExportEvent) validate(all bool) error {
+func (m *PeerBan) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for From
-
-	// no validation rules for To
+	// no validation rules for Id
 
-	// no validation rules for Latest
+	// no validation rules for Reason
 
-	// no validation rules for Data
+	// no validation rules for Until
 
 	if len(errors) > 0 {
-		return ExportEventMultiError(errors)
+		return PeerBanMultiError(errors)
 	}
 
 	return nil
 }
 
-// ExportEventMultiError is an error wrapping multiple validation errors
-// returned by ExportEvent.ValidateAll() if the designated constraints aren't met.
-type ExportEventMultiError []error
+// PeerBanMultiError is an error wrapping multiple validation errors returned
+// by PeerBan.ValidateAll() if the designated constraints aren't met.
+type PeerBanMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ExportEventMultiError) Error() string {
+func (m PeerBanMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1264,11 +1355,11 @@ func (m ExportEventMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ExportEventMultiError) AllErrors() []error { return m }
+func (m PeerBanMultiError) AllErrors() []error { return m }
 
-// ExportEventValidationError is the validation error returned by
-// ExportEvent.Validate if the designated constraints aren't met.
-type ExportEventValidationError struct {
+// PeerBanValidationError is the validation error returned by PeerBan.Validate
+// if the designated constraints aren't met.
+type PeerBanValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1276,22 +1367,22 @@ type ExportEventValidationError struct {
 }
 
 // Field function returns field value.
-func (e ExportEventValidationError) Field() string { return e.field }
+func (e PeerBanValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ExportEventValidationError) Reason() string { return e.reason }
+func (e PeerBanValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ExportEventValidationError) Cause() error { return e.cause }
+func (e PeerBanValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ExportEventValidationError) Key() bool { return e.key }
+func (e PeerBanValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ExportEventValidationError) ErrorName() string { return "ExportEventValidationError" }
+func (e PeerBanValidationError) ErrorName() string { return "PeerBanValidationError" }
 
 // Error satisfies the builtin error interface
-func (e ExportEventValidationError) Error() string {
+func (e PeerBanValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1303,14 +1394,14 @@ func (e ExportEventValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sExportEvent.%s: %s%s",
+		"invalid %sPeerBan.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ExportEventValidationError{}
+var _ error = PeerBanValidationError{}
 
 var _ interface {
 	Field() string
@@ -1318,24 +1409,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ExportEventValidationError{}
+} = PeerBanValidationError{}
 
-// Validate checks the field values on BlockchainEvent_Header with the rules
+// Validate checks the field values on BlockByNumberRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *BlockchainEvent_Header) Validate() error {
+func (m *BlockByNumberRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on BlockchainEvent_Header with the rules
+// ValidateAll checks the field values on BlockByNumberRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// BlockchainEvent_HeaderMultiError, or nil if none found.
-func (m *BlockchainEvent_Header) ValidateAll() error {
+// BlockByNumberRequestMultiError, or nil if none found.
+func (m *BlockByNumberRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *BlockchainEvent_Header) validate(all bool) error {
+func (m *BlockByNumberRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -1344,22 +1435,20 @@ func (m *BlockchainEvent_Header) validate(all bool) error {
 
 	// no validation rules for Number
 
-	// no validation rules for Hash
-
 	if len(errors) > 0 {
-		return BlockchainEvent_HeaderMultiError(errors)
+		return BlockByNumberRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// BlockchainEvent_HeaderMultiError is an error wrapping multiple validation
-// errors returned by BlockchainEvent_Header.ValidateAll() if the designated
+// BlockByNumberRequestMultiError is an error wrapping multiple validation
+// errors returned by BlockByNumberRequest.ValidateAll() if the designated
 // constraints aren't met.
-type BlockchainEvent_HeaderMultiError []error
+type BlockByNumberRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m BlockchainEvent_HeaderMultiError) Error() string {
+func (m BlockByNumberRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1368,11 +1457,11 @@ func (m BlockchainEvent_HeaderMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m BlockchainEvent_HeaderMultiError) AllErrors() []error { return m }
+func (m BlockByNumberRequestMultiError) AllErrors() []error { return m }
 
-// BlockchainEvent_HeaderValidationError is the validation error returned by
-// BlockchainEvent_Header.Validate if the designated constraints aren't met.
-type BlockchainEvent_HeaderValidationError struct {
+// BlockByNumberRequestValidationError is the validation error returned by
+// BlockByNumberRequest.Validate if the designated constraints aren't met.
+type BlockByNumberRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1380,24 +1469,24 @@ type BlockchainEvent_HeaderValidationError struct {
 }
 
 // Field function returns field value.
-func (e BlockchainEvent_HeaderValidationError) Field() string { return e.field }
+func (e BlockByNumberRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e BlockchainEvent_HeaderValidationError) Reason() string { return e.reason }
+func (e BlockByNumberRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e BlockchainEvent_HeaderValidationError) Cause() error { return e.cause }
+func (e BlockByNumberRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e BlockchainEvent_HeaderValidationError) Key() bool { return e.key }
+func (e BlockByNumberRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e BlockchainEvent_HeaderValidationError) ErrorName() string {
-	return "BlockchainEvent_HeaderValidationError"
+func (e BlockByNumberRequestValidationError) ErrorName() string {
+	return "BlockByNumberRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e BlockchainEvent_HeaderValidationError) Error() string {
+func (e BlockByNumberRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1409,14 +1498,650 @@ func (e BlockchainEvent_HeaderValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sBlockchainEvent_Header.%s: %s%s",
+		"invalid %sBlockByNumberRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = BlockchainEvent_HeaderValidationError{}
+var _ error = BlockByNumberRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BlockByNumberRequestValidationError{}
+
+// Validate checks the field values on BlockResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BlockResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BlockResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BlockResponseMultiError, or
+// nil if none found.
+func (m *BlockResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BlockResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return BlockResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// BlockResponseMultiError is an error wrapping multiple validation errors
+// returned by BlockResponse.ValidateAll() if the designated constraints
+// aren't met.
+type BlockResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BlockResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BlockResponseMultiError) AllErrors() []error { return m }
+
+// BlockResponseValidationError is the validation error returned by
+// BlockResponse.Validate if the designated constraints aren't met.
+type BlockResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BlockResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BlockResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BlockResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BlockResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BlockResponseValidationError) ErrorName() string { return "BlockResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BlockResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBlockResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BlockResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BlockResponseValidationError{}
+
+// Validate checks the field values on ExportRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ExportRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ExportRequestMultiError, or
+// nil if none found.
+func (m *ExportRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for From
+
+	// no validation rules for To
+
+	if len(errors) > 0 {
+		return ExportRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportRequestMultiError is an error wrapping multiple validation errors
+// returned by ExportRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ExportRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportRequestMultiError) AllErrors() []error { return m }
+
+// ExportRequestValidationError is the validation error returned by
+// ExportRequest.Validate if the designated constraints aren't met.
+type ExportRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportRequestValidationError) ErrorName() string { return "ExportRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ExportRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportRequestValidationError{}
+
+// Validate checks the field values on ExportEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ExportEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ExportEventMultiError, or
+// nil if none found.
+func (m *ExportEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for From
+
+	// no validation rules for To
+
+	// no validation rules for Latest
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return ExportEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportEventMultiError is an error wrapping multiple validation errors
+// returned by ExportEvent.ValidateAll() if the designated constraints aren't met.
+type ExportEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportEventMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportEventMultiError) AllErrors() []error { return m }
+
+// ExportEventValidationError is the validation error returned by
+// ExportEvent.Validate if the designated constraints aren't met.
+type ExportEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportEventValidationError) ErrorName() string { return "ExportEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ExportEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportEventValidationError{}
+
+// Validate checks the field values on ReindexRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ReindexRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReindexRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ReindexRequestMultiError,
+// or nil if none found.
+func (m *ReindexRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReindexRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for From
+
+	// no validation rules for To
+
+	if len(errors) > 0 {
+		return ReindexRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReindexRequestMultiError is an error wrapping multiple validation errors
+// returned by ReindexRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ReindexRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReindexRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReindexRequestMultiError) AllErrors() []error { return m }
+
+// ReindexRequestValidationError is the validation error returned by
+// ReindexRequest.Validate if the designated constraints aren't met.
+type ReindexRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReindexRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReindexRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReindexRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReindexRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReindexRequestValidationError) ErrorName() string { return "ReindexRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReindexRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReindexRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReindexRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReindexRequestValidationError{}
+
+// Validate checks the field values on ReindexEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ReindexEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReindexEvent with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ReindexEventMultiError, or
+// nil if none found.
+func (m *ReindexEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReindexEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for From
+
+	// no validation rules for To
+
+	// no validation rules for Next
+
+	// no validation rules for BlockLookups
+
+	// no validation rules for TxLookups
+
+	// no validation rules for Pruned
+
+	// no validation rules for Done
+
+	if len(errors) > 0 {
+		return ReindexEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReindexEventMultiError is an error wrapping multiple validation errors
+// returned by ReindexEvent.ValidateAll() if the designated constraints aren't met.
+type ReindexEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReindexEventMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReindexEventMultiError) AllErrors() []error { return m }
+
+// ReindexEventValidationError is the validation error returned by
+// ReindexEvent.Validate if the designated constraints aren't met.
+type ReindexEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReindexEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReindexEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReindexEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReindexEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReindexEventValidationError) ErrorName() string { return "ReindexEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReindexEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReindexEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReindexEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReindexEventValidationError{}
+
+// Validate checks the field values on BlockchainEvent_Header with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BlockchainEvent_Header) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BlockchainEvent_Header with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BlockchainEvent_HeaderMultiError, or nil if none found.
+func (m *BlockchainEvent_Header) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BlockchainEvent_Header) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Number
+
+	// no validation rules for Hash
+
+	if len(errors) > 0 {
+		return BlockchainEvent_HeaderMultiError(errors)
+	}
+
+	return nil
+}
+
+// BlockchainEvent_HeaderMultiError is an error wrapping multiple validation
+// errors returned by BlockchainEvent_Header.ValidateAll() if the designated
+// constraints aren't met.
+type BlockchainEvent_HeaderMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BlockchainEvent_HeaderMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BlockchainEvent_HeaderMultiError) AllErrors() []error { return m }
+
+// BlockchainEvent_HeaderValidationError is the validation error returned by
+// BlockchainEvent_Header.Validate if the designated constraints aren't met.
+type BlockchainEvent_HeaderValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BlockchainEvent_HeaderValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BlockchainEvent_HeaderValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BlockchainEvent_HeaderValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BlockchainEvent_HeaderValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BlockchainEvent_HeaderValidationError) ErrorName() string {
+	return "BlockchainEvent_HeaderValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BlockchainEvent_HeaderValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBlockchainEvent_Header.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BlockchainEvent_HeaderValidationError{}
 
 var _ interface {
 	Field() string
@@ -1531,3 +2256,119 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = ServerStatus_BlockValidationError{}
+
+// Validate checks the field values on ServerStatus_SyncPeer with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *ServerStatus_SyncPeer) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ServerStatus_SyncPeer with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// ServerStatus_SyncPeerMultiError, or nil if none found.
+func (m *ServerStatus_SyncPeer) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ServerStatus_SyncPeer) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Score
+
+	// no validation rules for Failures
+
+	// no validation rules for Stalls
+
+	// no validation rules for InvalidBlocks
+
+	// no validation rules for Bans
+
+	// no validation rules for BannedUntil
+
+	if len(errors) > 0 {
+		return ServerStatus_SyncPeerMultiError(errors)
+	}
+
+	return nil
+}
+
+// ServerStatus_SyncPeerMultiError is an error wrapping multiple validation
+// errors returned by ServerStatus_SyncPeer.ValidateAll() if the designated
+// constraints aren't met.
+type ServerStatus_SyncPeerMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ServerStatus_SyncPeerMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ServerStatus_SyncPeerMultiError) AllErrors() []error { return m }
+
+// ServerStatus_SyncPeerValidationError is the validation error returned by
+// ServerStatus_SyncPeer.Validate if the designated constraints aren't met.
+type ServerStatus_SyncPeerValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ServerStatus_SyncPeerValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ServerStatus_SyncPeerValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ServerStatus_SyncPeerValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ServerStatus_SyncPeerValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ServerStatus_SyncPeerValidationError) ErrorName() string {
+	return "ServerStatus_SyncPeerValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ServerStatus_SyncPeerValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServerStatus_SyncPeer.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ServerStatus_SyncPeerValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ServerStatus_SyncPeerValidationError{}
@@ -31,12 +31,22 @@ type SystemClient interface {
 	PeersList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersListResponse, error)
 	// PeersInfo returns the info of a peer
 	PeersStatus(ctx context.Context, in *PeersStatusRequest, opts ...grpc.CallOption) (*Peer, error)
+	// PeersBan disconnects a peer and bans it
+	PeersBan(ctx context.Context, in *PeersBanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// PeersUnban lifts the ban of a peer
+	PeersUnban(ctx context.Context, in *PeersUnbanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// PeersListBans returns the list of banned peers
+	PeersListBans(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersListBansResponse, error)
+	// PeersReloadAllowlist reloads the allowlist of the permissioned networking
+	PeersReloadAllowlist(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Subscribe subscribes to blockchain events
 	Subscribe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (System_SubscribeClient, error)
 	// Export returns blockchain data
 	BlockByNumber(ctx context.Context, in *BlockByNumberRequest, opts ...grpc.CallOption) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (System_ExportClient, error)
+	// Reindex rebuilds the block and transaction lookups in the background and streams its progress
+	Reindex(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (System_ReindexClient, error)
 }
 
 type systemClient struct {
@@ -83,6 +93,42 @@ func (c *systemClient) PeersStatus(ctx context.Context, in *PeersStatusRequest,
 	return out, nil
 }
 
+func (c *systemClient) PeersBan(ctx context.Context, in *PeersBanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.System/PeersBan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) PeersUnban(ctx context.Context, in *PeersUnbanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.System/PeersUnban", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) PeersListBans(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersListBansResponse, error) {
+	out := new(PeersListBansResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/PeersListBans", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) PeersReloadAllowlist(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.System/PeersReloadAllowlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *systemClient) Subscribe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (System_SubscribeClient, error) {
 	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[0], "/v1.System/Subscribe", opts...)
 	if err != nil {
@@ -156,6 +202,38 @@ func (x *systemExportClient) Recv() (*ExportEvent, error) {
 	return m, nil
 }
 
+func (c *systemClient) Reindex(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (System_ReindexClient, error) {
+	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[2], "/v1.System/Reindex", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &systemReindexClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type System_ReindexClient interface {
+	Recv() (*ReindexEvent, error)
+	grpc.ClientStream
+}
+
+type systemReindexClient struct {
+	grpc.ClientStream
+}
+
+func (x *systemReindexClient) Recv() (*ReindexEvent, error) {
+	m := new(ReindexEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SystemServer is the server API for System service.
 // All implementations must embed UnimplementedSystemServer
 // for forward compatibility
@@ -168,12 +246,22 @@ type SystemServer interface {
 	PeersList(context.Context, *emptypb.Empty) (*PeersListResponse, error)
 	// PeersInfo returns the info of a peer
 	PeersStatus(context.Context, *PeersStatusRequest) (*Peer, error)
+	// PeersBan disconnects a peer and bans it
+	PeersBan(context.Context, *PeersBanRequest) (*emptypb.Empty, error)
+	// PeersUnban lifts the ban of a peer
+	PeersUnban(context.Context, *PeersUnbanRequest) (*emptypb.Empty, error)
+	// PeersListBans returns the list of banned peers
+	PeersListBans(context.Context, *emptypb.Empty) (*PeersListBansResponse, error)
+	// PeersReloadAllowlist reloads the allowlist of the permissioned networking
+	PeersReloadAllowlist(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	// Subscribe subscribes to blockchain events
 	Subscribe(*emptypb.Empty, System_SubscribeServer) error
 	// Export returns blockchain data
 	BlockByNumber(context.Context, *BlockByNumberRequest) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(*ExportRequest, System_ExportServer) error
+	// Reindex rebuilds the block and transaction lookups in the background and streams its progress
+	Reindex(*ReindexRequest, System_ReindexServer) error
 	mustEmbedUnimplementedSystemServer()
 }
 
@@ -193,6 +281,18 @@ func (UnimplementedSystemServer) PeersList(context.Context, *emptypb.Empty) (*Pe
 func (UnimplementedSystemServer) PeersStatus(context.Context, *PeersStatusRequest) (*Peer, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PeersStatus not implemented")
 }
+func (UnimplementedSystemServer) PeersBan(context.Context, *PeersBanRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersBan not implemented")
+}
+func (UnimplementedSystemServer) PeersUnban(context.Context, *PeersUnbanRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersUnban not implemented")
+}
+func (UnimplementedSystemServer) PeersListBans(context.Context, *emptypb.Empty) (*PeersListBansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersListBans not implemented")
+}
+func (UnimplementedSystemServer) PeersReloadAllowlist(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersReloadAllowlist not implemented")
+}
 func (UnimplementedSystemServer) Subscribe(*emptypb.Empty, System_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
@@ -202,6 +302,9 @@ func (UnimplementedSystemServer) BlockByNumber(context.Context, *BlockByNumberRe
 func (UnimplementedSystemServer) Export(*ExportRequest, System_ExportServer) error {
 	return status.Errorf(codes.Unimplemented, "method Export not implemented")
 }
+func (UnimplementedSystemServer) Reindex(*ReindexRequest, System_ReindexServer) error {
+	return status.Errorf(codes.Unimplemented, "method Reindex not implemented")
+}
 func (UnimplementedSystemServer) mustEmbedUnimplementedSystemServer() {}
 
 // UnsafeSystemServer may be embedded to opt out of forward compatibility for this service.
@@ -287,6 +390,78 @@ func _System_PeersStatus_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _System_PeersBan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeersBanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersBan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/PeersBan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersBan(ctx, req.(*PeersBanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_PeersUnban_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeersUnbanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersUnban(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/PeersUnban",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersUnban(ctx, req.(*PeersUnbanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_PeersListBans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersListBans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/PeersListBans",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersListBans(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_PeersReloadAllowlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersReloadAllowlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/PeersReloadAllowlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersReloadAllowlist(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _System_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(emptypb.Empty)
 	if err := stream.RecvMsg(m); err != nil {
@@ -347,6 +522,27 @@ func (x *systemExportServer) Send(m *ExportEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _System_Reindex_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReindexRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemServer).Reindex(m, &systemReindexServer{stream})
+}
+
+type System_ReindexServer interface {
+	Send(*ReindexEvent) error
+	grpc.ServerStream
+}
+
+type systemReindexServer struct {
+	grpc.ServerStream
+}
+
+func (x *systemReindexServer) Send(m *ReindexEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // System_ServiceDesc is the grpc.ServiceDesc for System service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -370,6 +566,22 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PeersStatus",
 			Handler:    _System_PeersStatus_Handler,
 		},
+		{
+			MethodName: "PeersBan",
+			Handler:    _System_PeersBan_Handler,
+		},
+		{
+			MethodName: "PeersUnban",
+			Handler:    _System_PeersUnban_Handler,
+		},
+		{
+			MethodName: "PeersListBans",
+			Handler:    _System_PeersListBans_Handler,
+		},
+		{
+			MethodName: "PeersReloadAllowlist",
+			Handler:    _System_PeersReloadAllowlist_Handler,
+		},
 		{
 			MethodName: "BlockByNumber",
 			Handler:    _System_BlockByNumber_Handler,
@@ -386,6 +598,11 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _System_Export_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Reindex",
+			Handler:       _System_Reindex_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "server/proto/system.proto",
 }
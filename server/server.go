@@ -56,6 +56,7 @@ type Server struct {
 	logger       hclog.Logger
 	config       *Config
 	state        state.State
+	trieState    *itrie.State
 	stateStorage itrie.Storage
 
 	consensus consensus.Consensus
@@ -208,15 +209,23 @@ func NewServer(config *Config) (*Server, error) {
 	}
 
 	// start blockchain object
-	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(m.config.DataDir, "trie"), logger)
+	trieStorage, err := itrie.NewLevelDBStorage(filepath.Join(m.config.DataDir, "trie"), logger)
 	if err != nil {
 		return nil, err
 	}
 
+	// block import and RPC reads share the trie node caches
+	stateStorage := itrie.NewCachedStorage(trieStorage, m.config.StateCache)
+
 	m.stateStorage = stateStorage
 
 	st := itrie.NewState(stateStorage)
+	if m.config.RecordPreimages {
+		st.EnablePreimages()
+	}
+
 	m.state = st
+	m.trieState = st
 
 	m.executor = state.NewExecutor(config.Chain.Params, st, logger.Named("executor"))
 
@@ -310,7 +319,10 @@ func NewServer(config *Config) (*Server, error) {
 	signer := crypto.NewSigner(config.Chain.Params.Forks.At(0), uint64(m.config.Chain.Params.ChainID))
 
 	// create storage instance for blockchain
-	var db *storagev2.Storage
+	var (
+		db      *storagev2.Storage
+		ancient *storagev2.AncientStore
+	)
 	{
 		if m.config.DataDir == "" {
 			db, err = memory.NewMemoryStorage()
@@ -325,6 +337,23 @@ func NewServer(config *Config) (*Server, error) {
 			if err != nil {
 				return nil, err
 			}
+
+			// the frozen blocks stay readable once the ancient store is disabled, as they are no longer
+			// in the main database. The freezer starts once the head is recovered, see below
+			ancientPath := filepath.Join(m.config.DataDir, "ancient")
+
+			if m.config.AncientDistance > 0 || common.DirectoryExists(ancientPath) {
+				ancient, err = storagev2.OpenAncientStore(ancientPath)
+				if err != nil {
+					return nil, err
+				}
+
+				db.ReadAncient(ancient)
+			}
+		}
+
+		if m.config.HistoryRetentionBlocks > 0 {
+			db.EnableHistoryExpiry(m.config.HistoryRetentionBlocks)
 		}
 	}
 
@@ -349,6 +378,9 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.executor.GetHash = m.blockchain.GetHashHelper
 
+	// the head without state left by an unclean shutdown is rewound on startup
+	m.blockchain.SetStateChecker(st)
+
 	{
 		hub := &txpoolHub{
 			state:      m.state,
@@ -393,6 +425,20 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// the blocks are moved to the ancient store only after the head recovery confirmed a consistent head
+	if ancient != nil && m.config.AncientDistance > 0 {
+		db.EnableAncient(ancient, m.config.AncientDistance)
+	}
+
+	// serve the state reads from the flat state of the head and the following blocks
+	if err := st.EnableFlatState(
+		logger.Named("flat-state"),
+		m.blockchain.Header().StateRoot,
+		itrie.DefaultFlatDiffLayers,
+	); err != nil {
+		return nil, err
+	}
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -403,6 +449,9 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// the peers following another genesis or fork schedule are rejected by the handshake
+	m.network.SetBlockchain(m.blockchain)
+
 	if err := m.network.Start(); err != nil {
 		return nil, err
 	}
@@ -582,17 +631,22 @@ func (s *Server) setupConsensus() error {
 
 	consensus, err := engine(
 		&consensus.Params{
-			Context:         context.Background(),
-			Config:          config,
-			TxPool:          s.txpool,
-			Network:         s.network,
-			Blockchain:      s.blockchain,
-			Executor:        s.executor,
-			Grpc:            s.grpcServer,
-			Logger:          s.logger,
-			SecretsManager:  s.secretsManager,
-			BlockTime:       uint64(blockTime.Seconds()),
-			MetricsInterval: s.config.MetricsInterval,
+			Context:            context.Background(),
+			Config:             config,
+			TxPool:             s.txpool,
+			Network:            s.network,
+			Blockchain:         s.blockchain,
+			Executor:           s.executor,
+			Grpc:               s.grpcServer,
+			Logger:             s.logger,
+			SecretsManager:     s.secretsManager,
+			BlockTime:          uint64(blockTime.Seconds()),
+			MetricsInterval:    s.config.MetricsInterval,
+			StateStorage:       s.stateStorage,
+			StateSync:          s.config.StateSync,
+			CheckpointSync:     s.config.CheckpointSync,
+			CheckpointBackfill: s.config.CheckpointBackfill,
+			HeaderSync:         s.config.HeaderSync,
 			// event tracker
 			EventTracker: &consensus.EventTracker{
 				NumBlockConfirmations:  s.config.EventTracker.NumBlockConfirmations,
@@ -935,8 +989,13 @@ func (s *Server) Chain() *chain.Chain {
 	return s.chain
 }
 
-// JoinPeer attempts to add a new peer to the networking server
-func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
+// JoinPeer attempts to add a new peer to the networking server,
+// a static peer is kept connected and redialed whenever it disconnects
+func (s *Server) JoinPeer(rawPeerMultiaddr string, static bool) error {
+	if static {
+		return s.network.AddStaticPeer(rawPeerMultiaddr)
+	}
+
 	return s.network.JoinPeer(rawPeerMultiaddr)
 }
 
@@ -957,6 +1016,11 @@ func (s *Server) Close() {
 		s.logger.Error("failed to close consensus", "err", err.Error())
 	}
 
+	// Persist the flat state of the head, so it is not regenerated on restart
+	if err := s.trieState.CloseFlatState(s.blockchain.Header().StateRoot); err != nil {
+		s.logger.Error("failed to close flat state", "err", err.Error())
+	}
+
 	// Close the state storage
 	if err := s.stateStorage.Close(); err != nil {
 		s.logger.Error("failed to close storage for trie", "err", err.Error())
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/network/common"
@@ -27,6 +28,8 @@ type systemService struct {
 // Current: { Number: <blockNumber>; Hash: <headerHash> }
 //
 // P2PAddr: <libp2pAddress>
+//
+// SyncPeers: [{ Id: <peerID>; Score: <score>; ... }]
 func (s *systemService) GetStatus(ctx context.Context, req *empty.Empty) (*proto.ServerStatus, error) {
 	header := s.server.blockchain.Header()
 
@@ -44,6 +47,23 @@ func (s *systemService) GetStatus(ctx context.Context, req *empty.Empty) (*proto
 		P2PAddr: addr,
 	}
 
+	for _, rep := range s.server.consensus.GetSyncPeerReputations() {
+		syncPeer := &proto.ServerStatus_SyncPeer{
+			Id:            rep.ID.String(),
+			Score:         rep.Score,
+			Failures:      rep.Failures,
+			Stalls:        rep.Stalls,
+			InvalidBlocks: rep.InvalidBlocks,
+			Bans:          rep.Bans,
+		}
+
+		if !rep.BannedUntil.IsZero() {
+			syncPeer.BannedUntil = rep.BannedUntil.Unix()
+		}
+
+		status.SyncPeers = append(status.SyncPeers, syncPeer)
+	}
+
 	return status, nil
 }
 
@@ -90,12 +110,18 @@ func (s *systemService) Subscribe(req *empty.Empty, stream proto.System_Subscrib
 
 // PeersAdd implements the 'peers add' operator service
 func (s *systemService) PeersAdd(_ context.Context, req *proto.PeersAddRequest) (*proto.PeersAddResponse, error) {
-	if joinErr := s.server.JoinPeer(req.Id); joinErr != nil {
+	if joinErr := s.server.JoinPeer(req.Id, req.Static); joinErr != nil {
 		return &proto.PeersAddResponse{
 			Message: "Unable to successfully add peer",
 		}, joinErr
 	}
 
+	if req.Static {
+		return &proto.PeersAddResponse{
+			Message: "Peer address added to the static peers",
+		}, nil
+	}
+
 	return &proto.PeersAddResponse{
 		Message: "Peer address marked ready for dialing",
 	}, nil
@@ -161,6 +187,67 @@ func (s *systemService) PeersList(
 	return resp, nil
 }
 
+// PeersBan implements the 'peers ban' operator service
+func (s *systemService) PeersBan(_ context.Context, req *proto.PeersBanRequest) (*empty.Empty, error) {
+	if req.Duration < 0 {
+		return nil, fmt.Errorf("invalid ban duration %d", req.Duration)
+	}
+
+	peerID, err := peer.Decode(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.server.network.BanPeer(peerID, time.Duration(req.Duration)*time.Second, req.Reason)
+
+	return &empty.Empty{}, nil
+}
+
+// PeersUnban implements the 'peers unban' operator service
+func (s *systemService) PeersUnban(_ context.Context, req *proto.PeersUnbanRequest) (*empty.Empty, error) {
+	peerID, err := peer.Decode(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.server.network.UnbanPeer(peerID); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// PeersListBans implements the 'peers list-bans' operator service
+func (s *systemService) PeersListBans(_ context.Context, _ *empty.Empty) (*proto.PeersListBansResponse, error) {
+	resp := &proto.PeersListBansResponse{
+		Bans: []*proto.PeerBan{},
+	}
+
+	for _, ban := range s.server.network.Bans() {
+		peerBan := &proto.PeerBan{
+			Id:     ban.ID.String(),
+			Reason: ban.Reason,
+		}
+
+		if !ban.Until.IsZero() {
+			peerBan.Until = ban.Until.Unix()
+		}
+
+		resp.Bans = append(resp.Bans, peerBan)
+	}
+
+	return resp, nil
+}
+
+// PeersReloadAllowlist implements the 'peers reload-allowlist' operator service
+func (s *systemService) PeersReloadAllowlist(_ context.Context, _ *empty.Empty) (*empty.Empty, error) {
+	if err := s.server.network.ReloadAllowlist(); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
 // BlockByNumber implements the BlockByNumber operator service
 func (s *systemService) BlockByNumber(
 	ctx context.Context,
@@ -227,6 +314,58 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	return nil
 }
 
+// reindexProgressInterval is the interval of the reindex progress events
+const reindexProgressInterval = time.Second
+
+// Reindex starts the background rebuild of the block and transaction lookups and streams
+// its progress until it is done. The reindex keeps running if the client disconnects
+func (s *systemService) Reindex(req *proto.ReindexRequest, stream proto.System_ReindexServer) error {
+	to := req.To
+	if to == 0 {
+		to = s.server.blockchain.Header().Number
+	}
+
+	if req.From > to {
+		return errors.New("to must not be less than from")
+	}
+
+	if err := s.server.blockchain.StartReindex(req.From, to); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(reindexProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		progress, running, err := s.server.blockchain.ReindexStatus()
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(&proto.ReindexEvent{
+			From:         progress.From,
+			To:           progress.To,
+			Next:         progress.Next,
+			BlockLookups: progress.BlockLookups,
+			TxLookups:    progress.TxLookups,
+			Pruned:       progress.Pruned,
+			Done:         !running,
+		}); err != nil {
+			return err
+		}
+
+		if !running {
+			return nil
+		}
+	}
+}
+
 const (
 	defaultMaxGRPCPayloadSize uint64 = 512 * 1024 // 4MB
 
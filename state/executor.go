@@ -779,6 +779,7 @@ func (t *Transition) Call2(
 	gas uint64,
 ) *runtime.ExecutionResult {
 	c := runtime.NewContractCall(1, caller, caller, to, value, gas, t.state.GetCode(to), input)
+	c.CodeHash = t.state.GetCodeHash(to)
 
 	return t.applyCall(c, runtime.Call, t)
 }
@@ -912,7 +913,7 @@ func (t *Transition) hasCodeOrNonce(addr types.Address) bool {
 		(storageRoot != types.EmptyRootHash && storageRoot != types.ZeroHash) // non-empty storage
 }
 
-func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtime.ExecutionResult {
+func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) (result *runtime.ExecutionResult) {
 	gasLimit := c.Gas
 
 	if c.Depth > int(1024)+1 {
@@ -964,12 +965,10 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		}
 	}
 
-	var result *runtime.ExecutionResult
-
-	t.captureCallStart(c, evm.CREATE)
+	t.captureCallStart(c, runtime.Create)
 
 	defer func() {
-		// pass result to be set later
+		// the returned result is set once the deferred call runs
 		t.captureCallEnd(c, result)
 	}()
 
@@ -1130,6 +1129,10 @@ func (t *Transition) GetBlockHash(number int64) (res types.Hash) {
 
 func (t *Transition) EmitLog(addr types.Address, topics []types.Hash, data []byte) {
 	t.state.EmitLog(addr, topics, data)
+
+	if t.ctx.Tracer != nil {
+		t.ctx.Tracer.CaptureLog(addr, topics, data)
+	}
 }
 
 func (t *Transition) GetCodeSize(addr types.Address) int {
@@ -1361,6 +1364,12 @@ func (t *Transition) captureCallEnd(c *runtime.Contract, result *runtime.Executi
 		return
 	}
 
+	// the tracers accounting the gas of each call get the gas left, as the calls
+	// which execute no opcode, such as the precompiled contracts, report no state
+	if gasTracer, ok := t.ctx.Tracer.(tracer.CallGasTracer); ok {
+		gasTracer.CallGasLeft(c.Depth, result.GasLeft)
+	}
+
 	t.ctx.Tracer.CallEnd(
 		c.Depth,
 		result.ReturnValue,
@@ -0,0 +1,341 @@
+package itrie
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/golang-lru/simplelru"
+)
+
+const (
+	// trieCacheMetrics is the prefix of the trie cache metrics
+	trieCacheMetrics = "trie_cache"
+
+	// DefaultCleanCacheSize is the default size of the clean node cache in megabytes
+	DefaultCleanCacheSize = 256
+
+	// DefaultDirtyBufferSize is the default size of the dirty node buffer in megabytes,
+	// zero means that the nodes are written to the database on every commit
+	DefaultDirtyBufferSize = 0
+
+	megabyte = 1024 * 1024
+)
+
+// CacheConfig is the configuration of the trie node caches
+type CacheConfig struct {
+	// CleanCacheSize is the maximum size of the nodes read from
+	// or written to the database kept in memory, in megabytes
+	CleanCacheSize int
+
+	// DirtyBufferSize is the size in megabytes the written nodes are buffered up to
+	// before they are flushed to the database in a single batch.
+	// Buffered nodes are lost if the node is not shut down gracefully, while the blocks
+	// they belong to may already be persisted. Every flush writes whole commits, so the state
+	// of a block is either complete or its root is missing, and on startup the chain head
+	// is walked back to the last block whose state root is available
+	DirtyBufferSize int
+}
+
+// CacheStats are the statistics of the trie node caches
+type CacheStats struct {
+	CleanHits   uint64
+	CleanMisses uint64
+	CleanSize   int
+	DirtyHits   uint64
+	DirtySize   int
+	Flushes     uint64
+}
+
+// CachedStorage is a trie storage keeping recently used nodes in a size bounded
+// clean cache and buffering the written ones before flushing them in batches.
+// Sizes are accounted as the length of the keys and values
+type CachedStorage struct {
+	Storage
+
+	lock sync.Mutex
+
+	clean      *simplelru.LRU
+	cleanSize  int
+	cleanLimit int
+
+	dirty      map[string][]byte
+	dirtySize  int
+	dirtyLimit int
+
+	// generation is increased on every write, a value read from the database
+	// is only cached if nothing was written while it was being read
+	generation uint64
+
+	cleanHits   atomic.Uint64
+	cleanMisses atomic.Uint64
+	dirtyHits   atomic.Uint64
+	flushes     atomic.Uint64
+
+	// reported are the counters already published as metrics
+	reported CacheStats
+}
+
+// NewCachedStorage wraps the trie storage with the clean node cache and the dirty node buffer
+func NewCachedStorage(storage Storage, config CacheConfig) *CachedStorage {
+	c := &CachedStorage{
+		Storage:    storage,
+		cleanLimit: config.CleanCacheSize * megabyte,
+		dirtyLimit: config.DirtyBufferSize * megabyte,
+		dirty:      map[string][]byte{},
+	}
+
+	// the number of entries is bounded by their size instead
+	c.clean, _ = simplelru.NewLRU(int(^uint(0)>>1), func(key, value interface{}) {
+		c.cleanSize -= entrySize(key.(string), value.([]byte)) //nolint:forcetypeassert
+	})
+
+	return c
+}
+
+func entrySize(key string, value []byte) int {
+	return len(key) + len(value)
+}
+
+// Get returns the value from the dirty buffer or the clean cache,
+// reading it from the database and caching it otherwise
+func (c *CachedStorage) Get(k []byte) ([]byte, bool, error) {
+	key := string(k)
+
+	c.lock.Lock()
+
+	if value, ok := c.dirty[key]; ok {
+		c.lock.Unlock()
+		c.dirtyHits.Add(1)
+
+		return value, true, nil
+	}
+
+	if value, ok := c.clean.Get(key); ok {
+		c.lock.Unlock()
+		c.cleanHits.Add(1)
+
+		return value.([]byte), true, nil //nolint:forcetypeassert
+	}
+
+	generation := c.generation
+
+	c.lock.Unlock()
+	c.cleanMisses.Add(1)
+
+	value, ok, err := c.Storage.Get(k)
+	if err != nil || !ok {
+		return value, ok, err
+	}
+
+	c.lock.Lock()
+
+	// a concurrent write may have cached a newer value, which must not be replaced
+	if c.generation == generation {
+		c.addClean(key, value)
+	}
+
+	c.lock.Unlock()
+
+	return value, true, nil
+}
+
+// Put writes the value directly to the database
+func (c *CachedStorage) Put(k, v []byte) error {
+	key := string(k)
+
+	c.lock.Lock()
+
+	if old, ok := c.dirty[key]; ok {
+		c.dirtySize -= entrySize(key, old)
+		delete(c.dirty, key)
+	}
+
+	c.generation++
+	c.addClean(key, copyBytes(v))
+	c.lock.Unlock()
+
+	return c.Storage.Put(k, v)
+}
+
+// SetCode writes the code directly to the database
+func (c *CachedStorage) SetCode(hash types.Hash, code []byte) error {
+	return c.Put(GetCodeKey(hash), code)
+}
+
+// GetCode reads the code through the caches, since it may still be in the dirty buffer
+func (c *CachedStorage) GetCode(hash types.Hash) ([]byte, bool) {
+	res, ok, err := c.Get(GetCodeKey(hash))
+	if err != nil {
+		return nil, false
+	}
+
+	return res, ok
+}
+
+// Batch returns a batch which writes to the dirty buffer
+func (c *CachedStorage) Batch() Batch {
+	return &cachedBatch{storage: c}
+}
+
+// Flush writes the dirty buffer to the database
+func (c *CachedStorage) Flush() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.flush()
+}
+
+// Close flushes the dirty buffer and closes the database
+func (c *CachedStorage) Close() error {
+	if err := c.Flush(); err != nil {
+		return err
+	}
+
+	return c.Storage.Close()
+}
+
+// Stats returns the current statistics of the caches
+func (c *CachedStorage) Stats() CacheStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.stats()
+}
+
+func (c *CachedStorage) stats() CacheStats {
+	return CacheStats{
+		CleanHits:   c.cleanHits.Load(),
+		CleanMisses: c.cleanMisses.Load(),
+		CleanSize:   c.cleanSize,
+		DirtyHits:   c.dirtyHits.Load(),
+		DirtySize:   c.dirtySize,
+		Flushes:     c.flushes.Load(),
+	}
+}
+
+// write adds the batch entries to the dirty buffer, flushing it once it is full.
+// Without a dirty buffer the entries are written to the database right away
+func (c *CachedStorage) write(entries [][2][]byte) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	defer c.reportMetrics()
+
+	c.generation++
+
+	for _, entry := range entries {
+		key := string(entry[0])
+
+		c.addClean(key, entry[1])
+
+		if c.dirtyLimit == 0 {
+			continue
+		}
+
+		if old, ok := c.dirty[key]; ok {
+			c.dirtySize -= entrySize(key, old)
+		}
+
+		c.dirty[key] = entry[1]
+		c.dirtySize += entrySize(key, entry[1])
+	}
+
+	if c.dirtyLimit == 0 {
+		batch := c.Storage.Batch()
+
+		for _, entry := range entries {
+			batch.Put(entry[0], entry[1])
+		}
+
+		return batch.Write()
+	}
+
+	if c.dirtySize >= c.dirtyLimit {
+		return c.flush()
+	}
+
+	return nil
+}
+
+// flush writes the dirty buffer to the database, it must be called with the lock held
+func (c *CachedStorage) flush() error {
+	if len(c.dirty) == 0 {
+		return nil
+	}
+
+	batch := c.Storage.Batch()
+
+	for key, value := range c.dirty {
+		batch.Put([]byte(key), value)
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	c.dirty = map[string][]byte{}
+	c.dirtySize = 0
+	c.flushes.Add(1)
+
+	return nil
+}
+
+// addClean adds the entry to the clean cache and evicts the least recently used
+// entries above the size limit, it must be called with the lock held
+func (c *CachedStorage) addClean(key string, value []byte) {
+	size := entrySize(key, value)
+	if size > c.cleanLimit {
+		// too big to be cached, it must not shadow the new value either
+		c.clean.Remove(key)
+
+		return
+	}
+
+	if old, ok := c.clean.Peek(key); ok {
+		c.cleanSize -= entrySize(key, old.([]byte)) //nolint:forcetypeassert
+	}
+
+	c.clean.Add(key, value)
+	c.cleanSize += size
+
+	for c.cleanSize > c.cleanLimit {
+		c.clean.RemoveOldest()
+	}
+}
+
+// reportMetrics publishes the cache metrics, it must be called with the lock held
+func (c *CachedStorage) reportMetrics() {
+	stats := c.stats()
+
+	metrics.IncrCounter([]string{trieCacheMetrics, "clean_hit"}, float32(stats.CleanHits-c.reported.CleanHits))
+	metrics.IncrCounter([]string{trieCacheMetrics, "clean_miss"}, float32(stats.CleanMisses-c.reported.CleanMisses))
+	metrics.IncrCounter([]string{trieCacheMetrics, "dirty_hit"}, float32(stats.DirtyHits-c.reported.DirtyHits))
+	metrics.IncrCounter([]string{trieCacheMetrics, "flush"}, float32(stats.Flushes-c.reported.Flushes))
+	metrics.SetGauge([]string{trieCacheMetrics, "clean_size_bytes"}, float32(stats.CleanSize))
+	metrics.SetGauge([]string{trieCacheMetrics, "dirty_size_bytes"}, float32(stats.DirtySize))
+
+	c.reported = stats
+}
+
+// cachedBatch collects the entries written to the cached storage
+type cachedBatch struct {
+	storage *CachedStorage
+	entries [][2][]byte
+}
+
+func (b *cachedBatch) Put(k, v []byte) {
+	b.entries = append(b.entries, [2][]byte{copyBytes(k), copyBytes(v)})
+}
+
+func (b *cachedBatch) Write() error {
+	return b.storage.write(b.entries)
+}
+
+func copyBytes(b []byte) []byte {
+	c := make([]byte, len(b))
+	copy(c, b)
+
+	return c
+}
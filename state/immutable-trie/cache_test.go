@@ -0,0 +1,239 @@
+package itrie
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestCachedStorage_CleanCache(t *testing.T) {
+	t.Parallel()
+
+	db := NewMemoryStorage()
+	value := bytes.Repeat([]byte{0x1}, 1024*1024/4)
+
+	for i := byte(0); i < 8; i++ {
+		require.NoError(t, db.Put([]byte{i}, value))
+	}
+
+	c := NewCachedStorage(db, CacheConfig{CleanCacheSize: 1})
+
+	for i := byte(0); i < 8; i++ {
+		v, ok, err := c.Get([]byte{i})
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, value, v)
+	}
+
+	stats := c.Stats()
+	require.Equal(t, uint64(8), stats.CleanMisses)
+	require.Equal(t, uint64(0), stats.CleanHits)
+	// only three entries fit into a megabyte together with their keys
+	require.Equal(t, 3*(len(value)+1), stats.CleanSize)
+
+	// the most recent entries are cached, the oldest ones were evicted
+	_, _, err := c.Get([]byte{7})
+	require.NoError(t, err)
+
+	_, _, err = c.Get([]byte{0})
+	require.NoError(t, err)
+
+	stats = c.Stats()
+	require.Equal(t, uint64(1), stats.CleanHits)
+	require.Equal(t, uint64(9), stats.CleanMisses)
+	require.LessOrEqual(t, stats.CleanSize, 1024*1024)
+
+	// missing entries are not cached
+	_, ok, err := c.Get([]byte{0xff})
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestCachedStorage_DirtyBuffer(t *testing.T) {
+	t.Parallel()
+
+	db := NewMemoryStorage()
+	c := NewCachedStorage(db, CacheConfig{CleanCacheSize: 1, DirtyBufferSize: 1})
+
+	half := bytes.Repeat([]byte{0x2}, 1024*1024/2)
+
+	batch := c.Batch()
+	batch.Put([]byte("a"), half)
+	batch.Put(GetCodeKey(types.Hash{0x1}), []byte{0x3})
+	require.NoError(t, batch.Write())
+
+	// buffered, but readable through the storage
+	_, ok, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	v, ok, err := c.Get([]byte("a"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, half, v)
+
+	code, ok := c.GetCode(types.Hash{0x1})
+	require.True(t, ok)
+	require.Equal(t, []byte{0x3}, code)
+
+	require.Equal(t, uint64(0), c.Stats().Flushes)
+
+	// the buffer is flushed once it is full
+	batch = c.Batch()
+	batch.Put([]byte("b"), half)
+	require.NoError(t, batch.Write())
+
+	stats := c.Stats()
+	require.Equal(t, uint64(1), stats.Flushes)
+	require.Equal(t, 0, stats.DirtySize)
+
+	for _, key := range [][]byte{[]byte("a"), []byte("b"), GetCodeKey(types.Hash{0x1})} {
+		_, ok, err := db.Get(key)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	// the rest is flushed on close
+	batch = c.Batch()
+	batch.Put([]byte("c"), []byte{0x4})
+	require.NoError(t, batch.Write())
+	require.NoError(t, c.Close())
+
+	v, ok, err = db.Get([]byte("c"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte{0x4}, v)
+}
+
+// blockingStorage blocks the reads after the value is read, until they are released
+type blockingStorage struct {
+	Storage
+
+	reading chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingStorage) Get(k []byte) ([]byte, bool, error) {
+	v, ok, err := s.Storage.Get(k)
+
+	s.reading <- struct{}{}
+	<-s.release
+
+	return v, ok, err
+}
+
+func TestCachedStorage_ConcurrentWrite(t *testing.T) {
+	t.Parallel()
+
+	db := NewMemoryStorage()
+	require.NoError(t, db.Put([]byte("a"), []byte{0x1}))
+
+	blocking := &blockingStorage{Storage: db, reading: make(chan struct{}), release: make(chan struct{})}
+	c := NewCachedStorage(blocking, CacheConfig{CleanCacheSize: 1})
+
+	read := make(chan []byte)
+
+	go func() {
+		v, _, _ := c.Get([]byte("a"))
+		read <- v
+	}()
+
+	// the key is written while the old value is being read from the database
+	<-blocking.reading
+	require.NoError(t, c.Put([]byte("a"), []byte{0x2}))
+
+	close(blocking.release)
+	require.Equal(t, []byte{0x1}, <-read)
+
+	// the stale value must not replace the written one in the cache
+	v, ok, err := c.Get([]byte("a"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte{0x2}, v)
+}
+
+func TestCachedStorage_WriteThrough(t *testing.T) {
+	t.Parallel()
+
+	db := NewMemoryStorage()
+	c := NewCachedStorage(db, CacheConfig{CleanCacheSize: 1})
+
+	batch := c.Batch()
+	batch.Put([]byte("a"), []byte{0x1})
+	require.NoError(t, batch.Write())
+
+	v, ok, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte{0x1}, v)
+
+	// written entries are served from the clean cache
+	_, _, err = c.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), c.Stats().CleanHits)
+
+	// direct writes replace the cached value
+	require.NoError(t, c.Put([]byte("a"), []byte{0x2}))
+
+	v, _, err = c.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x2}, v)
+}
+
+func TestCachedStorage_State(t *testing.T) {
+	t.Parallel()
+
+	c := NewCachedStorage(NewMemoryStorage(), CacheConfig{CleanCacheSize: 16, DirtyBufferSize: 16})
+	s := NewState(c)
+
+	snap, root := commitObjects(t, s.NewSnapshot(),
+		flatTestObject(types.Address{0x1}, 1, types.EmptyRootHash, map[byte]byte{1: 1}),
+	)
+
+	// the state is readable from the dirty buffer before it is flushed
+	snap, err := NewState(c).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	account := mustAccount(t, snap, types.Address{0x1})
+	require.Equal(t, uint64(1), account.Nonce)
+	require.Equal(t, types.BytesToHash([]byte{1}), snap.GetStorage(types.Address{0x1}, account.Root, types.BytesToHash([]byte{1})))
+	require.Greater(t, c.Stats().DirtyHits, uint64(0))
+
+	require.NoError(t, c.Flush())
+
+	// and from the database afterwards
+	snap, err = NewState(c.Storage).NewSnapshotAt(root)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), mustAccount(t, snap, types.Address{0x1}).Nonce)
+}
+
+func TestCachedStorage_UncleanShutdown(t *testing.T) {
+	t.Parallel()
+
+	db := NewMemoryStorage()
+	c := NewCachedStorage(db, CacheConfig{CleanCacheSize: 16, DirtyBufferSize: 16})
+	s := NewState(c)
+
+	snap, flushedRoot := commitObjects(t, s.NewSnapshot(),
+		flatTestObject(types.Address{0x1}, 1, types.EmptyRootHash, map[byte]byte{1: 1}),
+	)
+	require.NoError(t, c.Flush())
+
+	_, bufferedRoot := commitObjects(t, snap,
+		flatTestObject(types.Address{0x2}, 1, types.EmptyRootHash, map[byte]byte{1: 2}),
+	)
+	require.True(t, s.HasState(bufferedRoot))
+
+	// the buffered commit is lost without a flush, and its root is missing
+	// so the head is rewound to the flushed one on startup
+	restarted := NewState(db)
+	require.False(t, restarted.HasState(bufferedRoot))
+	require.True(t, restarted.HasState(flushedRoot))
+
+	snap, err := restarted.NewSnapshotAt(flushedRoot)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), mustAccount(t, snap, types.Address{0x1}).Nonce)
+}
@@ -0,0 +1,144 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var ErrMissingPreimage = errors.New("preimage of the trie key is missing")
+
+// DumpConfig is the configuration of a state dump
+type DumpConfig struct {
+	// AllowMissingPreimages skips the accounts and the storage slots whose preimages were not recorded
+	// instead of failing, the skipped entries are reported to OnMissingPreimage
+	AllowMissingPreimages bool
+
+	// OnMissingPreimage is called for every skipped entry
+	OnMissingPreimage func(MissingPreimage) error
+}
+
+// MissingPreimage is an account or a storage slot skipped by the dump as its preimage was not recorded
+type MissingPreimage struct {
+	// Account is the hashed address of the skipped account, or of the account of the skipped slot
+	Account types.Hash `json:"account"`
+
+	// Slot is the hashed storage slot, it is not set if the whole account is skipped
+	Slot *types.Hash `json:"slot,omitempty"`
+}
+
+// skip reports the entry whose preimage is missing, it fails if the missing preimages are not allowed
+func (c *DumpConfig) skip(missing MissingPreimage) error {
+	if !c.AllowMissingPreimages {
+		if missing.Slot != nil {
+			return fmt.Errorf("%w: storage slot %s of account %s", ErrMissingPreimage, missing.Slot, missing.Account)
+		}
+
+		return fmt.Errorf("%w: account %s", ErrMissingPreimage, missing.Account)
+	}
+
+	if c.OnMissingPreimage == nil {
+		return nil
+	}
+
+	return c.OnMissingPreimage(missing)
+}
+
+// DumpAlloc calls fn for every account in the state with the given root as a genesis account
+// with its code and storage. The accounts are visited in the order of their hashed addresses,
+// the addresses and the storage slots are resolved from the recorded preimages
+func DumpAlloc(
+	storage Storage,
+	root types.Hash,
+	config DumpConfig,
+	fn func(types.Address, *chain.GenesisAccount) error,
+) error {
+	s := NewState(storage)
+
+	t, err := s.newTrieAt(root)
+	if err != nil {
+		return err
+	}
+
+	_, err = walkTrieLeaves(t.root, storage, nil, nil, func(key, value []byte) (bool, error) {
+		accountHash := types.BytesToHash(key)
+
+		preimage, ok, err := ReadPreimage(storage, accountHash)
+		if err != nil {
+			return false, err
+		}
+
+		if !ok || len(preimage) != types.AddressLength {
+			return true, config.skip(MissingPreimage{Account: accountHash})
+		}
+
+		addr := types.BytesToAddress(preimage)
+
+		account, err := dumpAccount(s, &config, accountHash, value)
+		if err != nil {
+			return false, fmt.Errorf("failed to dump account %s: %w", addr, err)
+		}
+
+		return true, fn(addr, account)
+	})
+
+	return err
+}
+
+// dumpAccount returns the genesis account of the RLP encoded account
+func dumpAccount(s *State, config *DumpConfig, accountHash types.Hash, data []byte) (*chain.GenesisAccount, error) {
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return nil, err
+	}
+
+	res := &chain.GenesisAccount{
+		Balance: account.Balance,
+		Nonce:   account.Nonce,
+	}
+
+	if codeHash := types.BytesToHash(account.CodeHash); codeHash != types.EmptyCodeHash && codeHash != types.ZeroHash {
+		code, ok := s.GetCode(codeHash)
+		if !ok {
+			return nil, fmt.Errorf("code %s not found", codeHash)
+		}
+
+		res.Code = code
+	}
+
+	if account.Root == types.EmptyRootHash || account.Root == types.ZeroHash {
+		return res, nil
+	}
+
+	storageTrie, err := s.newTrieAt(account.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Storage = map[types.Hash]types.Hash{}
+
+	_, err = walkTrieLeaves(storageTrie.root, s.storage, nil, nil, func(key, value []byte) (bool, error) {
+		slotHash := types.BytesToHash(key)
+
+		preimage, ok, err := ReadPreimage(s.storage, slotHash)
+		if err != nil {
+			return false, err
+		}
+
+		if !ok || len(preimage) != types.HashLength {
+			return true, config.skip(MissingPreimage{Account: accountHash, Slot: &slotHash})
+		}
+
+		res.Storage[types.BytesToHash(preimage)] = decodeStorageValue(value)
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
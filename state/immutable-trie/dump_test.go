@@ -0,0 +1,143 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestDumpAlloc(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	s := NewState(storage)
+	s.EnablePreimages()
+
+	code := []byte{0x60, 0x01}
+	contract := flatTestObject(types.StringToAddress("2"), 1, types.EmptyRootHash, map[byte]byte{1: 10, 2: 20})
+	contract.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+	contract.Code = code
+	contract.DirtyCode = true
+
+	snap, _ := commitObjects(t, s.NewSnapshot(),
+		flatTestObject(types.StringToAddress("1"), 5, types.EmptyRootHash, nil),
+		contract,
+	)
+
+	// the cleared slot and the deleted account are not exported
+	contract = flatTestObject(types.StringToAddress("2"), 2, snapStorageRoot(t, snap, types.StringToAddress("2")),
+		map[byte]byte{2: 0, 3: 30})
+	contract.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+
+	_, root := commitObjects(t, snap,
+		contract,
+		&state.Object{Address: types.StringToAddress("1"), Deleted: true},
+		flatTestObject(types.StringToAddress("3"), 7, types.EmptyRootHash, nil),
+	)
+
+	alloc := map[types.Address]*chain.GenesisAccount{}
+
+	require.NoError(t, DumpAlloc(storage, root, DumpConfig{}, func(addr types.Address, account *chain.GenesisAccount) error {
+		alloc[addr] = account
+
+		return nil
+	}))
+
+	require.Equal(t, map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("2"): {
+			Code: code,
+			Storage: map[types.Hash]types.Hash{
+				types.BytesToHash([]byte{1}): types.BytesToHash([]byte{10}),
+				types.BytesToHash([]byte{3}): types.BytesToHash([]byte{30}),
+			},
+			Balance: big.NewInt(2),
+			Nonce:   2,
+		},
+		types.StringToAddress("3"): {
+			Balance: big.NewInt(7),
+			Nonce:   7,
+		},
+	}, alloc)
+
+	// the trie nodes are copied without the preimages
+	copied := NewMemoryStorage()
+	require.NoError(t, CopyTrie(root.Bytes(), storage, copied, nil, false))
+
+	err := DumpAlloc(copied, root, DumpConfig{}, func(types.Address, *chain.GenesisAccount) error { return nil })
+	require.ErrorIs(t, err, ErrMissingPreimage)
+}
+
+func TestDumpAlloc_MissingPreimages(t *testing.T) {
+	t.Parallel()
+
+	var (
+		eoa      = types.StringToAddress("1")
+		contract = types.StringToAddress("2")
+	)
+
+	// the preimages are not recorded by default
+	storage := NewMemoryStorage()
+	snap, root1 := commitObjects(t, NewState(storage).NewSnapshot(),
+		flatTestObject(eoa, 1, types.EmptyRootHash, nil),
+	)
+
+	s := NewState(storage)
+	s.EnablePreimages()
+
+	snap, err := s.NewSnapshotAt(root1)
+	require.NoError(t, err)
+
+	// the preimages are only recorded for the keys written once the recording is enabled
+	_, root := commitObjects(t, snap,
+		flatTestObject(contract, 1, types.EmptyRootHash, map[byte]byte{1: 10}),
+	)
+
+	noop := func(types.Address, *chain.GenesisAccount) error { return nil }
+
+	err = DumpAlloc(storage, root, DumpConfig{}, noop)
+	require.ErrorIs(t, err, ErrMissingPreimage)
+
+	var (
+		alloc   = map[types.Address]*chain.GenesisAccount{}
+		missing []MissingPreimage
+	)
+
+	require.NoError(t, DumpAlloc(storage, root, DumpConfig{
+		AllowMissingPreimages: true,
+		OnMissingPreimage: func(m MissingPreimage) error {
+			missing = append(missing, m)
+
+			return nil
+		},
+	}, func(addr types.Address, account *chain.GenesisAccount) error {
+		alloc[addr] = account
+
+		return nil
+	}))
+
+	require.Equal(t, []MissingPreimage{{Account: types.BytesToHash(crypto.Keccak256(eoa.Bytes()))}}, missing)
+	require.Equal(t, map[types.Address]*chain.GenesisAccount{
+		contract: {
+			Storage: map[types.Hash]types.Hash{
+				types.BytesToHash([]byte{1}): types.BytesToHash([]byte{10}),
+			},
+			Balance: big.NewInt(1),
+			Nonce:   1,
+		},
+	}, alloc)
+}
+
+func snapStorageRoot(t *testing.T, snap state.Snapshot, addr types.Address) types.Hash {
+	t.Helper()
+
+	account, err := snap.GetAccount(addr)
+	require.NoError(t, err)
+
+	return account.Root
+}
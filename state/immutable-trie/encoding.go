@@ -78,3 +78,14 @@ func decodeCompact(compact []byte) []byte {
 
 	return base
 }
+
+// hexNibblesToBytes packs a hex sequence of nibbles
+// (without terminator flag) back into bytes.
+func hexNibblesToBytes(hex []byte) []byte {
+	result := make([]byte, len(hex)/2)
+	for i := range result {
+		result[i] = hex[2*i]<<4 | hex[2*i+1]
+	}
+
+	return result
+}
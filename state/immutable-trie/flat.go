@@ -0,0 +1,272 @@
+package itrie
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// DefaultFlatDiffLayers is the default number of in-memory diff layers kept on top of the flat state on disk
+const DefaultFlatDiffLayers = 128
+
+// flatTree keeps the flat state of the latest state roots.
+// The oldest of them is persisted to the trie storage (disk layer), while the
+// changes of the following commits are kept in memory (diff layers) and
+// flattened into the disk layer once there are more than maxDiffLayers of them
+type flatTree struct {
+	logger        hclog.Logger
+	state         *State
+	maxDiffLayers int
+
+	lock   sync.RWMutex
+	disk   *flatDiskLayer
+	layers map[types.Hash]flatLayer
+}
+
+// newFlatTree loads the flat state from the trie storage. If it is missing or
+// does not belong to the given state root, it is regenerated in the background
+func newFlatTree(logger hclog.Logger, state *State, root types.Hash, maxDiffLayers int) (*flatTree, error) {
+	db := state.storage
+
+	diskRoot, ok, err := db.Get(flatRootKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flat state root: %w", err)
+	}
+
+	disk := &flatDiskLayer{
+		db:        db,
+		stateRoot: root,
+	}
+
+	if ok && types.BytesToHash(diskRoot) == root {
+		data, _, err := db.Get(flatGeneratorKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read flat state generator: %w", err)
+		}
+
+		disk.genMarker = decodeGeneratorMarker(data)
+	} else {
+		logger.Info("flat state is missing or outdated, regenerating it", "root", root)
+
+		disk.genMarker = []byte{}
+
+		batch := db.Batch()
+		batch.Put(flatRootKey, root.Bytes())
+		batch.Put(flatGeneratorKey, encodeGeneratorMarker(disk.genMarker))
+
+		if err := batch.Write(); err != nil {
+			return nil, fmt.Errorf("failed to reset flat state: %w", err)
+		}
+	}
+
+	t := &flatTree{
+		logger:        logger,
+		state:         state,
+		maxDiffLayers: maxDiffLayers,
+		disk:          disk,
+		layers:        map[types.Hash]flatLayer{root: disk},
+	}
+
+	if disk.genMarker != nil {
+		t.startGenerator(disk)
+	}
+
+	return t, nil
+}
+
+// get returns the flat layer of the given state root, or nil if there is none
+func (t *flatTree) get(root types.Hash) flatLayer {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.layers[root]
+}
+
+// update adds a diff layer with the changes of a commit on top of the parent state root
+// and flattens the layers which are too deep into the disk layer
+func (t *flatTree) update(
+	root, parentRoot types.Hash,
+	accounts map[types.Hash][]byte,
+	storageSlots map[types.Hash]map[types.Hash][]byte,
+	destructs map[types.Hash]struct{},
+) error {
+	if root == parentRoot {
+		return nil
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	parent, ok := t.layers[parentRoot]
+	if !ok {
+		// the parent is either too old or was dropped, so there is nothing to build on
+		return nil
+	}
+
+	if _, ok := t.layers[root]; ok {
+		return nil
+	}
+
+	t.layers[root] = newFlatDiffLayer(parent, root, accounts, storageSlots, destructs)
+
+	return t.flatten(root, t.maxDiffLayers)
+}
+
+// close flattens all the diff layers up to the given state root into the disk layer
+// and stops the generator, so the flat state is preserved across restarts
+func (t *flatTree) close(root types.Hash) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var err error
+
+	if _, ok := t.layers[root]; ok {
+		err = t.flatten(root, 0)
+	}
+
+	t.stopGenerator(t.disk)
+
+	return err
+}
+
+// flatten flattens the diff layers below the given state root into the disk layer,
+// keeping at most keep diff layers. Layers that do not descend from
+// the new disk layer are dropped. It must be called with the lock held
+func (t *flatTree) flatten(root types.Hash, keep int) error {
+	var diffs []*flatDiffLayer
+
+	for layer := t.layers[root]; layer != nil; {
+		diff, ok := layer.(*flatDiffLayer)
+		if !ok {
+			break
+		}
+
+		diffs = append(diffs, diff)
+		layer = diff.parentLayer()
+	}
+
+	if len(diffs) <= keep {
+		return nil
+	}
+
+	// flatten the oldest layers first
+	for i := len(diffs) - 1; i >= keep; i-- {
+		disk, err := t.flattenDiff(t.disk, diffs[i])
+		if err != nil {
+			return err
+		}
+
+		t.disk = disk
+	}
+
+	if keep > 0 {
+		diffs[keep-1].setParent(t.disk)
+	}
+
+	// rebuild the layers, dropping the flattened ones and the ones on other branches
+	layers := map[types.Hash]flatLayer{t.disk.stateRoot: t.disk}
+
+	for hash, layer := range t.layers {
+		if descendsFrom(layer, t.disk) {
+			layers[hash] = layer
+		} else if diff, ok := layer.(*flatDiffLayer); ok {
+			diff.markStale()
+		}
+	}
+
+	t.layers = layers
+
+	return nil
+}
+
+// flattenDiff writes the diff layer into the disk layer and returns the new disk layer,
+// the previous one becomes stale
+func (t *flatTree) flattenDiff(disk *flatDiskLayer, diff *flatDiffLayer) (*flatDiskLayer, error) {
+	t.stopGenerator(disk)
+
+	marker := disk.marker()
+	batch := disk.db.Batch()
+
+	hashes := make(map[types.Hash]struct{}, len(diff.accounts)+len(diff.destructs))
+	for hash := range diff.accounts {
+		hashes[hash] = struct{}{}
+	}
+
+	for hash := range diff.destructs {
+		hashes[hash] = struct{}{}
+	}
+
+	for hash := range diff.storageSlots {
+		hashes[hash] = struct{}{}
+	}
+
+	for hash := range hashes {
+		// the generator writes the accounts it has not reached yet from the new root
+		if !covered(marker, hash) {
+			continue
+		}
+
+		incarnation, account, exists, err := readFlatAccount(disk.db, hash)
+		if err != nil {
+			return nil, err
+		}
+
+		_, destructed := diff.destructs[hash]
+		if destructed && exists {
+			// the storage of the previous incarnation becomes unreachable
+			incarnation++
+		}
+
+		newAccount, modified := diff.accounts[hash]
+		if modified {
+			account = newAccount
+		}
+
+		if modified || destructed {
+			batch.Put(flatAccountKey(hash), encodeFlatAccount(incarnation, account))
+		}
+
+		for slot, value := range diff.storageSlots[hash] {
+			batch.Put(flatStorageKey(hash, incarnation, slot), value)
+		}
+	}
+
+	batch.Put(flatRootKey, diff.stateRoot.Bytes())
+	batch.Put(flatGeneratorKey, encodeGeneratorMarker(marker))
+
+	// readers of the previous disk layer must not see the flattened changes
+	disk.markStale()
+	diff.markStale()
+
+	if err := batch.Write(); err != nil {
+		return nil, fmt.Errorf("failed to flatten state %s: %w", diff.stateRoot, err)
+	}
+
+	newDisk := &flatDiskLayer{
+		db:        disk.db,
+		stateRoot: diff.stateRoot,
+		genMarker: marker,
+	}
+
+	if marker != nil {
+		t.startGenerator(newDisk)
+	}
+
+	return newDisk, nil
+}
+
+// descendsFrom checks if the disk layer is an ancestor of the given layer
+func descendsFrom(layer flatLayer, disk *flatDiskLayer) bool {
+	for {
+		switch l := layer.(type) {
+		case *flatDiskLayer:
+			return l == disk
+		case *flatDiffLayer:
+			layer = l.parentLayer()
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package itrie
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// flatGeneratorLogInterval is the interval between the generation progress logs
+	flatGeneratorLogInterval = 8 * time.Second
+
+	// flatGeneratorBatchAccounts is the number of accounts after which the generated entries are written
+	flatGeneratorBatchAccounts = 1000
+)
+
+func (t *flatTree) startGenerator(disk *flatDiskLayer) {
+	disk.genAbort = make(chan chan struct{})
+
+	go t.generate(disk)
+}
+
+// stopGenerator stops the generator of the disk layer and waits for it to persist its progress
+func (t *flatTree) stopGenerator(disk *flatDiskLayer) {
+	if disk.genAbort == nil {
+		return
+	}
+
+	done := make(chan struct{})
+	disk.genAbort <- done
+	<-done
+
+	disk.genAbort = nil
+}
+
+// generate iterates the account trie of the disk layer, starting after the generator marker,
+// and writes the flat account and storage entries. The generator marker is advanced
+// whenever the entries are written, so readers fall back to the trie for the accounts
+// which are not generated yet
+func (t *flatTree) generate(disk *flatDiskLayer) {
+	var (
+		abort    chan struct{}
+		batch    = disk.db.Batch()
+		marker   = disk.marker()
+		start    = time.Now()
+		logged   = time.Now()
+		accounts int
+	)
+
+	// commit writes the generated entries along with the generator marker
+	commit := func(marker []byte) error {
+		batch.Put(flatGeneratorKey, encodeGeneratorMarker(marker))
+
+		if err := batch.Write(); err != nil {
+			return err
+		}
+
+		disk.setMarker(marker)
+		batch = disk.db.Batch()
+
+		return nil
+	}
+
+	t.logger.Info("generating flat state", "root", disk.stateRoot, "marker", types.BytesToHash(marker))
+
+	err := func() error {
+		trie, err := t.state.newTrieAt(disk.stateRoot)
+		if err != nil {
+			return err
+		}
+
+		var after []byte
+		if len(marker) != 0 {
+			after = bytesToHexNibbles(marker)
+			after = after[:len(after)-1]
+		}
+
+		_, err = walkTrieLeaves(trie.root, disk.db, nil, after, func(key, value []byte) (bool, error) {
+			if err := t.generateAccount(batch, disk.db, types.BytesToHash(key), value); err != nil {
+				return false, err
+			}
+
+			accounts++
+
+			select {
+			case abort = <-disk.genAbort:
+				return false, commit(key)
+			default:
+			}
+
+			if accounts%flatGeneratorBatchAccounts == 0 {
+				if err := commit(key); err != nil {
+					return false, err
+				}
+			}
+
+			if time.Since(logged) > flatGeneratorLogInterval {
+				t.logger.Info("generating flat state", "accounts", accounts, "marker", types.BytesToHash(key))
+
+				logged = time.Now()
+			}
+
+			return true, nil
+		})
+
+		return err
+	}()
+
+	switch {
+	case err != nil:
+		// reads keep falling back to the trie for the accounts which are not generated
+		t.logger.Error("failed to generate flat state", "root", disk.stateRoot, "err", err)
+	case abort == nil:
+		if err := commit(nil); err != nil {
+			t.logger.Error("failed to complete flat state generation", "err", err)
+		} else {
+			t.logger.Info("flat state generated", "root", disk.stateRoot,
+				"accounts", accounts, "elapsed", time.Since(start))
+		}
+	}
+
+	if abort == nil {
+		abort = <-disk.genAbort
+	}
+
+	close(abort)
+}
+
+// generateAccount writes the flat entry of the account and its storage slots
+func (t *flatTree) generateAccount(batch Batch, db Storage, hash types.Hash, data []byte) error {
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return fmt.Errorf("failed to decode account %s: %w", hash, err)
+	}
+
+	// entries left from a previous generation must not be reused
+	incarnation, _, exists, err := readFlatAccount(db, hash)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		incarnation++
+	}
+
+	batch.Put(flatAccountKey(hash), encodeFlatAccount(incarnation, data))
+
+	if account.Root == types.EmptyRootHash {
+		return nil
+	}
+
+	storageTrie, err := t.state.newTrieAt(account.Root)
+	if err != nil {
+		return err
+	}
+
+	_, err = walkTrieLeaves(storageTrie.root, db, nil, nil, func(key, value []byte) (bool, error) {
+		batch.Put(flatStorageKey(hash, incarnation, types.BytesToHash(key)), value)
+
+		return true, nil
+	})
+
+	return err
+}
+
+// walkTrieLeaves calls fn for the key and value of every leaf of the trie in key order,
+// skipping the leaves whose keys (as nibbles) are not greater than after.
+// It returns false if the walk was stopped by fn
+func walkTrieLeaves(
+	node Node,
+	storage Storage,
+	path, after []byte,
+	fn func(key, value []byte) (bool, error),
+) (bool, error) {
+	if node == nil {
+		return true, nil
+	}
+
+	// skip the subtrees whose keys all precede the start position
+	if after != nil && len(path) <= len(after) {
+		switch bytes.Compare(path, after[:len(path)]) {
+		case -1:
+			return true, nil
+		case 1:
+			after = nil
+		}
+	}
+
+	switch n := node.(type) {
+	case *ValueNode:
+		if n.hash {
+			nc, ok, err := GetNode(n.buf, storage)
+			if err != nil {
+				return false, err
+			}
+
+			if !ok {
+				return false, fmt.Errorf("trie node %x not found", n.buf)
+			}
+
+			return walkTrieLeaves(nc, storage, path, after, fn)
+		}
+
+		if after != nil && bytes.Compare(path, after) <= 0 {
+			return true, nil
+		}
+
+		return fn(hexNibblesToBytes(path), n.buf)
+
+	case *ShortNode:
+		key := n.key
+		if hasTerminator(key) {
+			key = key[:len(key)-1]
+		}
+
+		return walkTrieLeaves(n.child, storage, append(path[:len(path):len(path)], key...), after, fn)
+
+	case *FullNode:
+		if ok, err := walkTrieLeaves(n.value, storage, path, after, fn); !ok || err != nil {
+			return ok, err
+		}
+
+		for i, child := range n.children {
+			ok, err := walkTrieLeaves(child, storage, append(path[:len(path):len(path)], byte(i)), after, fn)
+			if !ok || err != nil {
+				return ok, err
+			}
+		}
+
+		return true, nil
+
+	default:
+		return false, fmt.Errorf("unknown node type %T", n)
+	}
+}
@@ -0,0 +1,342 @@
+package itrie
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// flatAccountPrefix is the prefix of the flat account entries,
+	// key = prefix + account hash, value = incarnation + account RLP (empty if the account does not exist)
+	flatAccountPrefix = []byte("snap-a")
+
+	// flatStoragePrefix is the prefix of the flat storage entries,
+	// key = prefix + account hash + incarnation + slot hash, value = slot RLP (empty if the slot is cleared)
+	flatStoragePrefix = []byte("snap-s")
+
+	// flatRootKey is the key of the state root the flat entries on disk belong to
+	flatRootKey = []byte("snap-root")
+
+	// flatGeneratorKey is the key of the generation progress of the flat entries on disk
+	flatGeneratorKey = []byte("snap-generator")
+)
+
+var (
+	errFlatNotCovered = errors.New("flat state is not generated yet")
+	errFlatStale      = errors.New("flat state layer is stale")
+)
+
+const incarnationLength = 8
+
+// flatLayer is a flat view of the accounts and storage slots at the given state root
+type flatLayer interface {
+	// root returns the state root of the layer
+	root() types.Hash
+
+	// account returns the RLP encoded account with the given hash,
+	// or nil if the account does not exist
+	account(hash types.Hash) ([]byte, error)
+
+	// storage returns the RLP encoded value of the given storage slot,
+	// or nil if the slot is empty
+	storage(accountHash, slotHash types.Hash) ([]byte, error)
+}
+
+func flatAccountKey(hash types.Hash) []byte {
+	key := make([]byte, 0, len(flatAccountPrefix)+types.HashLength)
+	key = append(key, flatAccountPrefix...)
+
+	return append(key, hash.Bytes()...)
+}
+
+func flatStorageKey(accountHash types.Hash, incarnation uint64, slotHash types.Hash) []byte {
+	key := make([]byte, 0, len(flatStoragePrefix)+2*types.HashLength+incarnationLength)
+	key = append(key, flatStoragePrefix...)
+	key = append(key, accountHash.Bytes()...)
+	key = binary.BigEndian.AppendUint64(key, incarnation)
+
+	return append(key, slotHash.Bytes()...)
+}
+
+func encodeFlatAccount(incarnation uint64, account []byte) []byte {
+	data := make([]byte, incarnationLength, incarnationLength+len(account))
+	binary.BigEndian.PutUint64(data, incarnation)
+
+	return append(data, account...)
+}
+
+// readFlatAccount reads the flat account entry with the given hash.
+// Entries of deleted accounts are kept, so the incarnation of their storage is not reused
+func readFlatAccount(db Storage, hash types.Hash) (incarnation uint64, account []byte, exists bool, err error) {
+	data, ok, err := db.Get(flatAccountKey(hash))
+	if err != nil || !ok {
+		return 0, nil, false, err
+	}
+
+	if len(data) < incarnationLength {
+		return 0, nil, false, fmt.Errorf("invalid flat account entry %s", hash)
+	}
+
+	if len(data) > incarnationLength {
+		account = data[incarnationLength:]
+	}
+
+	return binary.BigEndian.Uint64(data[:incarnationLength]), account, true, nil
+}
+
+// encodeGeneratorMarker encodes the generation progress, nil marker means that the generation is done
+func encodeGeneratorMarker(marker []byte) []byte {
+	if marker == nil {
+		return []byte{1}
+	}
+
+	return append([]byte{0}, marker...)
+}
+
+func decodeGeneratorMarker(data []byte) []byte {
+	if len(data) == 0 || data[0] == 1 {
+		return nil
+	}
+
+	return append([]byte{}, data[1:]...)
+}
+
+// covered returns true if the flat entry of the given account has already been generated
+func covered(marker []byte, hash types.Hash) bool {
+	if marker == nil {
+		return true
+	}
+
+	return len(marker) != 0 && bytes.Compare(hash.Bytes(), marker) <= 0
+}
+
+// flatDiskLayer is the flat state persisted to the trie storage
+type flatDiskLayer struct {
+	db        Storage
+	stateRoot types.Hash
+
+	lock  sync.RWMutex
+	stale bool
+
+	// genMarker is the hash of the last generated account, nil once the generation is done
+	genMarker []byte
+	// genAbort is used to stop the generator and wait for it to persist its progress
+	genAbort chan chan struct{}
+}
+
+func (dl *flatDiskLayer) root() types.Hash {
+	return dl.stateRoot
+}
+
+func (dl *flatDiskLayer) account(hash types.Hash) ([]byte, error) {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	if dl.stale {
+		return nil, errFlatStale
+	}
+
+	if !covered(dl.genMarker, hash) {
+		return nil, errFlatNotCovered
+	}
+
+	_, account, _, err := readFlatAccount(dl.db, hash)
+
+	return account, err
+}
+
+func (dl *flatDiskLayer) storage(accountHash, slotHash types.Hash) ([]byte, error) {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	if dl.stale {
+		return nil, errFlatStale
+	}
+
+	if !covered(dl.genMarker, accountHash) {
+		return nil, errFlatNotCovered
+	}
+
+	incarnation, account, _, err := readFlatAccount(dl.db, accountHash)
+	if err != nil || account == nil {
+		return nil, err
+	}
+
+	data, ok, err := dl.db.Get(flatStorageKey(accountHash, incarnation, slotHash))
+	if err != nil || !ok || len(data) == 0 {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (dl *flatDiskLayer) marker() []byte {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	return dl.genMarker
+}
+
+func (dl *flatDiskLayer) setMarker(marker []byte) {
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+
+	dl.genMarker = marker
+}
+
+func (dl *flatDiskLayer) markStale() {
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+
+	dl.stale = true
+}
+
+// flatDiffLayer holds the accounts and storage slots modified by a single state commit
+type flatDiffLayer struct {
+	stateRoot types.Hash
+
+	// accounts are the modified accounts, nil value for deleted ones
+	accounts map[types.Hash][]byte
+	// storageSlots are the modified storage slots, nil value for cleared ones
+	storageSlots map[types.Hash]map[types.Hash][]byte
+	// destructs are the accounts whose storage prior to this layer is discarded
+	destructs map[types.Hash]struct{}
+
+	lock   sync.RWMutex
+	parent flatLayer
+	stale  bool
+}
+
+func newFlatDiffLayer(
+	parent flatLayer,
+	root types.Hash,
+	accounts map[types.Hash][]byte,
+	storageSlots map[types.Hash]map[types.Hash][]byte,
+	destructs map[types.Hash]struct{},
+) *flatDiffLayer {
+	return &flatDiffLayer{
+		stateRoot:    root,
+		parent:       parent,
+		accounts:     accounts,
+		storageSlots: storageSlots,
+		destructs:    destructs,
+	}
+}
+
+func (dl *flatDiffLayer) root() types.Hash {
+	return dl.stateRoot
+}
+
+func (dl *flatDiffLayer) account(hash types.Hash) ([]byte, error) {
+	dl.lock.RLock()
+
+	if dl.stale {
+		dl.lock.RUnlock()
+
+		return nil, errFlatStale
+	}
+
+	if account, ok := dl.accounts[hash]; ok {
+		dl.lock.RUnlock()
+
+		return account, nil
+	}
+
+	parent := dl.parent
+	dl.lock.RUnlock()
+
+	return parent.account(hash)
+}
+
+func (dl *flatDiffLayer) storage(accountHash, slotHash types.Hash) ([]byte, error) {
+	dl.lock.RLock()
+
+	if dl.stale {
+		dl.lock.RUnlock()
+
+		return nil, errFlatStale
+	}
+
+	if value, ok := dl.storageSlots[accountHash][slotHash]; ok {
+		dl.lock.RUnlock()
+
+		return value, nil
+	}
+
+	if _, ok := dl.destructs[accountHash]; ok {
+		dl.lock.RUnlock()
+
+		return nil, nil
+	}
+
+	parent := dl.parent
+	dl.lock.RUnlock()
+
+	return parent.storage(accountHash, slotHash)
+}
+
+func (dl *flatDiffLayer) parentLayer() flatLayer {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	return dl.parent
+}
+
+func (dl *flatDiffLayer) setParent(parent flatLayer) {
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+
+	dl.parent = parent
+}
+
+func (dl *flatDiffLayer) markStale() {
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+
+	dl.stale = true
+}
+
+// flatDiff collects the changes of a state commit
+type flatDiff struct {
+	accounts     map[types.Hash][]byte
+	storageSlots map[types.Hash]map[types.Hash][]byte
+	destructs    map[types.Hash]struct{}
+}
+
+func newFlatDiff() *flatDiff {
+	return &flatDiff{
+		accounts:     map[types.Hash][]byte{},
+		storageSlots: map[types.Hash]map[types.Hash][]byte{},
+		destructs:    map[types.Hash]struct{}{},
+	}
+}
+
+func (d *flatDiff) deleteAccount(hash types.Hash) {
+	d.accounts[hash] = nil
+	d.destructs[hash] = struct{}{}
+	delete(d.storageSlots, hash)
+}
+
+// updateAccount sets the account, destructed discards its storage prior to the commit
+func (d *flatDiff) updateAccount(hash types.Hash, account []byte, destructed bool) {
+	d.accounts[hash] = account
+
+	if destructed {
+		d.destructs[hash] = struct{}{}
+	}
+}
+
+func (d *flatDiff) updateStorage(hash, slot types.Hash, value []byte) {
+	slots, ok := d.storageSlots[hash]
+	if !ok {
+		slots = map[types.Hash][]byte{}
+		d.storageSlots[hash] = slots
+	}
+
+	slots[slot] = value
+}
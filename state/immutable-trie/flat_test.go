@@ -0,0 +1,348 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func flatTestObject(addr types.Address, nonce uint64, root types.Hash, slots map[byte]byte) *state.Object {
+	obj := &state.Object{
+		Address:  addr,
+		Nonce:    nonce,
+		Balance:  big.NewInt(int64(nonce)),
+		Root:     root,
+		CodeHash: types.EmptyCodeHash,
+	}
+
+	for k, v := range slots {
+		entry := &state.StorageObject{Key: types.BytesToHash([]byte{k}).Bytes()}
+		if v == 0 {
+			entry.Deleted = true
+		} else {
+			entry.Val = types.BytesToHash([]byte{v}).Bytes()
+		}
+
+		obj.Storage = append(obj.Storage, entry)
+	}
+
+	return obj
+}
+
+func commitObjects(t *testing.T, snap state.Snapshot, objs ...*state.Object) (state.Snapshot, types.Hash) {
+	t.Helper()
+
+	snap, root, err := snap.Commit(objs)
+	require.NoError(t, err)
+
+	return snap, types.BytesToHash(root)
+}
+
+func waitFlatGenerated(t *testing.T, s *State) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		s.flat.lock.RLock()
+		defer s.flat.lock.RUnlock()
+
+		return s.flat.disk.marker() == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// requireSameState checks that reading through the flat state returns the same values as reading the trie
+func requireSameState(t *testing.T, s *State, root types.Hash, addrs []types.Address) {
+	t.Helper()
+
+	flatSnap, err := s.NewSnapshotAt(root)
+	require.NoError(t, err)
+	require.NotNil(t, flatSnap.(*Snapshot).flat) //nolint:forcetypeassert
+
+	trieSnap, err := NewState(s.storage).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	for _, addr := range addrs {
+		expected, err := trieSnap.GetAccount(addr)
+		require.NoError(t, err)
+
+		account, err := flatSnap.GetAccount(addr)
+		require.NoError(t, err)
+		require.Equal(t, expected, account, addr)
+
+		if expected == nil {
+			continue
+		}
+
+		for k := byte(1); k < 8; k++ {
+			key := types.BytesToHash([]byte{k})
+			require.Equal(t,
+				trieSnap.GetStorage(addr, expected.Root, key),
+				flatSnap.GetStorage(addr, expected.Root, key),
+				"%s slot %d", addr, k,
+			)
+		}
+	}
+}
+
+func TestFlatState_Generate(t *testing.T) {
+	t.Parallel()
+
+	addrs := []types.Address{{0x1}, {0x2}, {0x3}, {0x4}}
+
+	s := NewState(NewMemoryStorage())
+
+	objs := make([]*state.Object, 0, len(addrs))
+	for i, addr := range addrs {
+		objs = append(objs, flatTestObject(addr, uint64(i+1), types.EmptyRootHash, map[byte]byte{1: byte(i + 1), 2: 7}))
+	}
+
+	_, root := commitObjects(t, s.NewSnapshot(), objs...)
+
+	require.NoError(t, s.EnableFlatState(hclog.NewNullLogger(), root, 2))
+	waitFlatGenerated(t, s)
+
+	requireSameState(t, s, root, append(addrs, types.Address{0x5}))
+
+	// flat entries are served without the trie
+	layer := s.getFlatLayer(root)
+
+	data, err := layer.account(types.BytesToHash(hashit(addrs[0].Bytes())))
+	require.NoError(t, err)
+	require.NotNil(t, data)
+
+	data, err = layer.account(types.BytesToHash(hashit(types.Address{0x5}.Bytes())))
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
+
+func TestFlatState_DiffLayers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		a = types.Address{0x1}
+		b = types.Address{0x2}
+		c = types.Address{0x3}
+
+		addrs = []types.Address{a, b, c}
+	)
+
+	s := NewState(NewMemoryStorage())
+
+	require.NoError(t, s.EnableFlatState(hclog.NewNullLogger(), types.EmptyRootHash, 2))
+	waitFlatGenerated(t, s)
+
+	snap, err := s.NewSnapshotAt(types.EmptyRootHash)
+	require.NoError(t, err)
+
+	// block 1: create accounts with storage
+	snap, root1 := commitObjects(t, snap,
+		flatTestObject(a, 1, types.EmptyRootHash, map[byte]byte{1: 1, 2: 2}),
+		flatTestObject(b, 1, types.EmptyRootHash, map[byte]byte{1: 3}),
+	)
+	requireSameState(t, s, root1, addrs)
+
+	accountB, err := snap.GetAccount(b)
+	require.NoError(t, err)
+
+	// block 2: clear a slot and delete an account
+	snap, root2 := commitObjects(t, snap,
+		flatTestObject(a, 2, mustAccount(t, snap, a).Root, map[byte]byte{1: 0, 3: 4}),
+		&state.Object{Address: b, Deleted: true},
+	)
+	requireSameState(t, s, root2, addrs)
+
+	// block 3: recreate the deleted account, its old storage must not come back
+	snap, root3 := commitObjects(t, snap,
+		flatTestObject(b, 5, types.EmptyRootHash, map[byte]byte{2: 5}),
+		flatTestObject(c, 1, types.EmptyRootHash, nil),
+	)
+	requireSameState(t, s, root3, addrs)
+	require.NotEqual(t, accountB.Root, mustAccount(t, snap, b).Root)
+
+	// block 4: only two diff layers are kept, so the first two blocks are flattened to disk
+	_, root4 := commitObjects(t, snap, flatTestObject(c, 2, types.EmptyRootHash, map[byte]byte{6: 6}))
+	requireSameState(t, s, root4, addrs)
+
+	require.Equal(t, root2, s.flat.disk.root())
+	require.Nil(t, s.getFlatLayer(root1))
+	require.Nil(t, s.getFlatLayer(types.EmptyRootHash))
+
+	diskRoot, ok, err := s.storage.Get(flatRootKey)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, root2.Bytes(), diskRoot)
+
+	// a fork from the disk root is built on, the other branch is dropped once it is flattened
+	forkSnap, err := s.NewSnapshotAt(root2)
+	require.NoError(t, err)
+
+	forkSnap, fork3 := commitObjects(t, forkSnap, flatTestObject(c, 9, types.EmptyRootHash, nil))
+	requireSameState(t, s, fork3, addrs)
+
+	_, fork4 := commitObjects(t, forkSnap, flatTestObject(a, 9, types.EmptyRootHash, nil))
+	_, fork5 := commitObjects(t, mustSnapshotAt(t, s, fork4), flatTestObject(b, 9, types.EmptyRootHash, nil))
+	requireSameState(t, s, fork5, addrs)
+
+	require.Equal(t, fork3, s.flat.disk.root())
+	require.Nil(t, s.getFlatLayer(root3))
+	require.Nil(t, s.getFlatLayer(root4))
+
+	// everything is persisted on close and reused after a restart
+	require.NoError(t, s.CloseFlatState(fork5))
+
+	restarted := NewState(s.storage)
+	require.NoError(t, restarted.EnableFlatState(hclog.NewNullLogger(), fork5, 2))
+	require.Nil(t, restarted.flat.disk.marker())
+
+	requireSameState(t, restarted, fork5, addrs)
+}
+
+func TestFlatState_RecreatedAccount(t *testing.T) {
+	t.Parallel()
+
+	var (
+		a = types.Address{0x1}
+		b = types.Address{0x2}
+
+		addrs = []types.Address{a, b}
+
+		slot1 = types.BytesToHash([]byte{1})
+		slot2 = types.BytesToHash([]byte{2})
+	)
+
+	s := NewState(NewMemoryStorage())
+
+	require.NoError(t, s.EnableFlatState(hclog.NewNullLogger(), types.EmptyRootHash, 8))
+	waitFlatGenerated(t, s)
+
+	snap := mustSnapshotAt(t, s, types.EmptyRootHash)
+
+	// block 1: create a contract with storage and an account without it
+	snap, root1 := commitObjects(t, snap,
+		flatTestObject(a, 1, types.EmptyRootHash, map[byte]byte{1: 1, 2: 2}),
+		flatTestObject(b, 1, types.EmptyRootHash, nil),
+	)
+	requireSameState(t, s, root1, addrs)
+
+	oldRoot := mustAccount(t, snap, a).Root
+
+	// block 2: recreate the contract within the block, and update the account without storage
+	recreated := flatTestObject(a, 1, types.EmptyRootHash, map[byte]byte{2: 5})
+	recreated.Recreated = true
+
+	snap, root2 := commitObjects(t, snap, recreated, flatTestObject(b, 2, types.EmptyRootHash, nil))
+	requireSameState(t, s, root2, addrs)
+
+	// only the recreated account discards its storage
+	layer, ok := s.getFlatLayer(root2).(*flatDiffLayer)
+	require.True(t, ok)
+	require.Equal(t, map[types.Hash]struct{}{types.BytesToHash(hashit(a.Bytes())): {}}, layer.destructs)
+
+	// the old slot is gone from the new account, and still readable at the old storage root
+	newRoot := mustAccount(t, snap, a).Root
+
+	require.Equal(t, types.Hash{}, snap.GetStorage(a, newRoot, slot1))
+	require.Equal(t, types.BytesToHash([]byte{5}), snap.GetStorage(a, newRoot, slot2))
+	require.Equal(t, types.BytesToHash([]byte{1}), snap.GetStorage(a, oldRoot, slot1))
+	require.Equal(t, types.BytesToHash([]byte{2}), snap.GetStorage(a, oldRoot, slot2))
+
+	// nothing is stored under the empty root
+	require.Equal(t, types.Hash{}, snap.GetStorage(a, types.EmptyRootHash, slot2))
+}
+
+func TestFlatState_Regenerate(t *testing.T) {
+	t.Parallel()
+
+	addrs := []types.Address{{0x1}, {0x2}}
+
+	s := NewState(NewMemoryStorage())
+
+	require.NoError(t, s.EnableFlatState(hclog.NewNullLogger(), types.EmptyRootHash, 8))
+
+	snap, err := s.NewSnapshotAt(types.EmptyRootHash)
+	require.NoError(t, err)
+
+	snap, _ = commitObjects(t, snap, flatTestObject(addrs[0], 1, types.EmptyRootHash, map[byte]byte{1: 1}))
+	_, root := commitObjects(t, snap,
+		&state.Object{Address: addrs[0], Deleted: true},
+		flatTestObject(addrs[1], 1, types.EmptyRootHash, map[byte]byte{1: 2}),
+	)
+
+	// the diff layers are lost without a close, so the flat state is regenerated on restart
+	restarted := NewState(s.storage)
+	require.NoError(t, restarted.EnableFlatState(hclog.NewNullLogger(), root, 8))
+	waitFlatGenerated(t, restarted)
+
+	requireSameState(t, restarted, root, addrs)
+}
+
+func TestWalkTrieLeaves(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	txn := NewTrie().Txn(storage)
+
+	keys := make([][]byte, 0, 64)
+	for i := 0; i < 64; i++ {
+		keys = append(keys, hashit([]byte{byte(i)}))
+		txn.Insert(keys[i], []byte{byte(i)})
+	}
+
+	batch := storage.Batch()
+	txn.batch = batch
+
+	root, err := txn.Hash()
+	require.NoError(t, err)
+	require.NoError(t, batch.Write())
+
+	tr, err := NewState(storage).newTrieAt(types.BytesToHash(root))
+	require.NoError(t, err)
+
+	walk := func(after []byte) [][]byte {
+		var visited [][]byte
+
+		ok, err := walkTrieLeaves(tr.root, storage, nil, after, func(key, _ []byte) (bool, error) {
+			visited = append(visited, key)
+
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		return visited
+	}
+
+	all := walk(nil)
+	require.Len(t, all, len(keys))
+
+	for i := 1; i < len(all); i++ {
+		require.Less(t, types.BytesToHash(all[i-1]).String(), types.BytesToHash(all[i]).String())
+	}
+
+	marker := bytesToHexNibbles(all[20])
+	require.Equal(t, all[21:], walk(marker[:len(marker)-1]))
+}
+
+func mustAccount(t *testing.T, snap state.Snapshot, addr types.Address) *state.Account {
+	t.Helper()
+
+	account, err := snap.GetAccount(addr)
+	require.NoError(t, err)
+	require.NotNil(t, account)
+
+	return account
+}
+
+func mustSnapshotAt(t *testing.T, s *State, root types.Hash) state.Snapshot {
+	t.Helper()
+
+	snap, err := s.NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	return snap
+}
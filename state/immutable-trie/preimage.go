@@ -0,0 +1,22 @@
+package itrie
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// preimagePrefix is the prefix of the preimage entries of the trie keys,
+// key = prefix + keccak hash, value = the hashed address or storage slot
+var preimagePrefix = []byte("preimage")
+
+func preimageKey(hash []byte) []byte {
+	key := make([]byte, 0, len(preimagePrefix)+types.HashLength)
+	key = append(key, preimagePrefix...)
+
+	return append(key, hash...)
+}
+
+// ReadPreimage returns the address or the storage slot hashed to the given trie key.
+// Preimages are recorded for the keys written by the state commits, if the state records them
+func ReadPreimage(storage Storage, hash types.Hash) ([]byte, bool, error) {
+	return storage.Get(preimageKey(hash.Bytes()))
+}
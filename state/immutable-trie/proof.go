@@ -0,0 +1,145 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var ErrMissingProofNode = errors.New("trie node missing from the proof")
+
+// Prove returns the encoded trie nodes on the path from the root to the given key,
+// which prove either the value of the key or its absence
+func Prove(storage Storage, root types.Hash, key []byte) ([][]byte, error) {
+	proof := [][]byte{}
+
+	_, err := provePath(storage, root, key, func(data []byte) {
+		proof = append(proof, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// VerifyProof returns the value of the key in the trie with the given root proven by the encoded
+// trie nodes of the proof, or nil if the proof shows that the key is not in the trie
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := NewMemoryStorage()
+
+	for _, data := range proof {
+		if err := nodes.Put(hashit(data), data); err != nil {
+			return nil, err
+		}
+	}
+
+	value, err := provePath(nodes, root, key, func([]byte) {})
+	if errors.Is(err, errMissingNode) {
+		return nil, fmt.Errorf("%w: %v", ErrMissingProofNode, err)
+	}
+
+	return value, err
+}
+
+var errMissingNode = errors.New("trie node not found")
+
+// provePath walks the trie with the given root along the path of the key. It calls fn with the
+// encoded form of every stored node on the path and returns the value of the key, if any
+func provePath(storage Storage, root types.Hash, key []byte, fn func([]byte)) ([]byte, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil
+	}
+
+	node, err := resolveProofNode(storage, root.Bytes(), fn)
+	if err != nil {
+		return nil, err
+	}
+
+	search := bytesToHexNibbles(key)
+
+	for {
+		switch n := node.(type) {
+		case nil:
+			return nil, nil
+
+		case *ValueNode:
+			if n.hash {
+				if node, err = resolveProofNode(storage, n.buf, fn); err != nil {
+					return nil, err
+				}
+
+				continue
+			}
+
+			if len(search) != 0 {
+				return nil, nil
+			}
+
+			return n.buf, nil
+
+		case *ShortNode:
+			if !bytes.HasPrefix(search, n.key) {
+				return nil, nil
+			}
+
+			search = search[len(n.key):]
+			node = n.child
+
+		case *FullNode:
+			if len(search) == 0 {
+				node = n.value
+
+				continue
+			}
+
+			node = n.getEdge(search[0])
+			search = search[1:]
+
+		default:
+			return nil, fmt.Errorf("unknown node type %T", n)
+		}
+	}
+}
+
+// resolveProofNode reads and decodes the stored node of the given hash and calls fn with its encoded form
+func resolveProofNode(storage Storage, hash []byte, fn func([]byte)) (Node, error) {
+	data, ok, err := storage.Get(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok || len(data) == 0 {
+		return nil, fmt.Errorf("%w: %x", errMissingNode, hash)
+	}
+
+	node, err := decodeStoredNode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	fn(data)
+
+	return node, nil
+}
+
+// decodeStoredNode decodes a node stored under its hash, the nodes embedded in it are decoded
+// as well, while the referenced ones are kept as hash value nodes
+func decodeStoredNode(data []byte) (Node, error) {
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Type() != fastrlp.TypeArray {
+		return nil, fmt.Errorf("storage item should be an array")
+	}
+
+	return decodeNode(v, nil)
+}
@@ -0,0 +1,200 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var ErrInvalidRange = errors.New("invalid trie range")
+
+// TrieRange is a range of consecutive leaves of a trie, sorted by their keys,
+// together with the encoded trie nodes proving its first and its last leaf
+type TrieRange struct {
+	Keys   [][]byte
+	Values [][]byte
+	Proof  [][]byte
+
+	// More is set if the trie has leaves following the range
+	More bool
+}
+
+// ReadTrieRange returns at most max leaves of the trie with the given root whose keys follow after,
+// or the first leaves of the trie if after is nil
+func ReadTrieRange(storage Storage, root types.Hash, after []byte, max int) (*TrieRange, error) {
+	res := &TrieRange{}
+
+	if root == types.EmptyRootHash {
+		return res, nil
+	}
+
+	n, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("state not found at hash %s", root)
+	}
+
+	var start []byte
+	if after != nil {
+		start = bytesToHexNibbles(after)
+		start = start[:len(start)-1]
+	}
+
+	_, err = walkTrieLeaves(n, storage, nil, start, func(key, value []byte) (bool, error) {
+		if len(res.Keys) == max {
+			res.More = true
+
+			return false, nil
+		}
+
+		res.Keys = append(res.Keys, key)
+		res.Values = append(res.Values, append([]byte{}, value...))
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Keys) == 0 {
+		return res, nil
+	}
+
+	// the nodes shared by the paths of both ends are sent once
+	seen := map[types.Hash]struct{}{}
+
+	for _, key := range [][]byte{res.Keys[0], res.Keys[len(res.Keys)-1]} {
+		_, err := provePath(storage, root, key, func(data []byte) {
+			hash := types.BytesToHash(hashit(data))
+			if _, ok := seen[hash]; !ok {
+				seen[hash] = struct{}{}
+				res.Proof = append(res.Proof, data)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// Verify checks that the keys of the range are sorted and follow after, and that its first and last
+// leaves are proven to be in the trie with the given root. The leaves in between are not proven,
+// a range with missing or altered leaves builds a trie with a different root
+func (r *TrieRange) Verify(root types.Hash, after []byte) error {
+	if len(r.Keys) != len(r.Values) {
+		return fmt.Errorf("%w: %d keys and %d values", ErrInvalidRange, len(r.Keys), len(r.Values))
+	}
+
+	for i, key := range r.Keys {
+		if len(key) != types.HashLength {
+			return fmt.Errorf("%w: key %x is not a hash", ErrInvalidRange, key)
+		}
+
+		prev := after
+		if i > 0 {
+			prev = r.Keys[i-1]
+		}
+
+		if prev != nil && bytes.Compare(prev, key) >= 0 {
+			return fmt.Errorf("%w: key %x does not follow %x", ErrInvalidRange, key, prev)
+		}
+	}
+
+	if len(r.Keys) == 0 {
+		return nil
+	}
+
+	for _, i := range []int{0, len(r.Keys) - 1} {
+		value, err := VerifyProof(root, r.Keys[i], r.Proof)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(value, r.Values[i]) {
+			return fmt.Errorf("%w: value of key %x is not proven", ErrInvalidRange, r.Keys[i])
+		}
+	}
+
+	return nil
+}
+
+// trieBuilderCommitSize is the number of leaves after which the trie builder
+// writes the nodes built so far and drops them from memory
+const trieBuilderCommitSize = 10000
+
+// TrieBuilder builds a trie from its leaves inserted in key order and writes its nodes to the storage.
+// The nodes are written bottom up, so every written node has its whole subtree written
+type TrieBuilder struct {
+	storage Storage
+	trie    *Trie
+	txn     *Txn
+	pending int
+}
+
+// NewTrieBuilder creates a builder of a trie written to the given storage
+func NewTrieBuilder(storage Storage) *TrieBuilder {
+	trie := NewTrie()
+
+	return &TrieBuilder{
+		storage: storage,
+		trie:    trie,
+		txn:     trie.Txn(storage),
+	}
+}
+
+// Insert adds a leaf to the trie
+func (b *TrieBuilder) Insert(key, value []byte) error {
+	b.txn.Insert(key, value)
+	b.pending++
+
+	if b.pending < trieBuilderCommitSize {
+		return nil
+	}
+
+	_, err := b.Commit()
+
+	return err
+}
+
+// Commit writes the nodes of the leaves inserted so far and returns the root of the trie
+func (b *TrieBuilder) Commit() (types.Hash, error) {
+	if b.txn.root == nil {
+		return types.EmptyRootHash, nil
+	}
+
+	batch := b.storage.Batch()
+	b.txn.batch = batch
+
+	root, err := b.txn.Hash()
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if err := batch.Write(); err != nil {
+		return types.ZeroHash, err
+	}
+
+	// continue from the written root, the nodes which are not modified anymore are released
+	// and only the right edge of the trie is loaded back by the following inserts
+	n, ok, err := GetNode(root, b.storage)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if !ok {
+		return types.ZeroHash, fmt.Errorf("built trie root %x not written", root)
+	}
+
+	b.trie = &Trie{root: n, epoch: b.txn.epoch}
+	b.txn = b.trie.Txn(b.storage)
+	b.pending = 0
+
+	return types.BytesToHash(root), nil
+}
@@ -0,0 +1,148 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// buildTestTrie writes a trie of n leaves with hashed keys and returns its root and its sorted leaves
+func buildTestTrie(t *testing.T, storage Storage, n int) (types.Hash, *TrieRange) {
+	t.Helper()
+
+	txn := NewTrie().Txn(storage)
+
+	for i := 0; i < n; i++ {
+		txn.Insert(hashit([]byte{byte(i), byte(i >> 8)}), []byte{byte(i), 0xff})
+	}
+
+	batch := storage.Batch()
+	txn.batch = batch
+
+	root, err := txn.Hash()
+	require.NoError(t, err)
+	require.NoError(t, batch.Write())
+
+	leaves, err := ReadTrieRange(storage, types.BytesToHash(root), nil, n)
+	require.NoError(t, err)
+	require.Len(t, leaves.Keys, n)
+
+	return types.BytesToHash(root), leaves
+}
+
+func TestProof(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	root, leaves := buildTestTrie(t, storage, 300)
+
+	for i, key := range leaves.Keys {
+		proof, err := Prove(storage, root, key)
+		require.NoError(t, err)
+
+		value, err := VerifyProof(root, key, proof)
+		require.NoError(t, err)
+		require.Equal(t, leaves.Values[i], value)
+	}
+
+	// the absence of a key is proven as well
+	absent := hashit([]byte("absent"))
+
+	proof, err := Prove(storage, root, absent)
+	require.NoError(t, err)
+
+	value, err := VerifyProof(root, absent, proof)
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// a proof without the root does not prove anything
+	proof, err = Prove(storage, root, leaves.Keys[0])
+	require.NoError(t, err)
+
+	_, err = VerifyProof(root, leaves.Keys[0], proof[1:])
+	require.ErrorIs(t, err, ErrMissingProofNode)
+}
+
+func TestTrieRange(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	root, leaves := buildTestTrie(t, storage, 1000)
+
+	t.Run("ranges rebuild the trie", func(t *testing.T) {
+		t.Parallel()
+
+		synced := NewMemoryStorage()
+		builder := NewTrieBuilder(synced)
+
+		var (
+			after  []byte
+			ranges int
+		)
+
+		for {
+			r, err := ReadTrieRange(storage, root, after, 128)
+			require.NoError(t, err)
+			require.NoError(t, r.Verify(root, after))
+
+			for i, key := range r.Keys {
+				require.NoError(t, builder.Insert(key, r.Values[i]))
+			}
+
+			ranges++
+
+			if !r.More {
+				break
+			}
+
+			after = r.Keys[len(r.Keys)-1]
+		}
+
+		require.Equal(t, 8, ranges)
+
+		built, err := builder.Commit()
+		require.NoError(t, err)
+		require.Equal(t, root, built)
+
+		_, ok, err := synced.Get(root.Bytes())
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("altered ranges", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := ReadTrieRange(storage, root, leaves.Keys[9], 10)
+		require.NoError(t, err)
+		require.Equal(t, leaves.Keys[10:20], r.Keys)
+
+		// the ends of the range are proven
+		altered := *r
+		altered.Values = append([][]byte{{0x1}}, r.Values[1:]...)
+		require.ErrorIs(t, altered.Verify(root, leaves.Keys[9]), ErrInvalidRange)
+
+		// the range has to follow the requested key
+		require.ErrorIs(t, r.Verify(root, leaves.Keys[15]), ErrInvalidRange)
+
+		// a leaf missing in the middle is not detected, but the built trie does not match the root
+		altered = *r
+		altered.Keys = append(append([][]byte{}, r.Keys[:4]...), r.Keys[5:]...)
+		altered.Values = append(append([][]byte{}, r.Values[:4]...), r.Values[5:]...)
+		require.NoError(t, altered.Verify(root, leaves.Keys[9]))
+	})
+
+	t.Run("empty trie", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := ReadTrieRange(storage, types.EmptyRootHash, nil, 10)
+		require.NoError(t, err)
+		require.Empty(t, r.Keys)
+		require.False(t, r.More)
+
+		built, err := NewTrieBuilder(NewMemoryStorage()).Commit()
+		require.NoError(t, err)
+		require.Equal(t, types.EmptyRootHash, built)
+	})
+}
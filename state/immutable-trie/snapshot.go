@@ -13,32 +13,63 @@ import (
 type Snapshot struct {
 	state *State
 	trie  *Trie
+	// flat is the flat view of the state, reads fall back to the trie if it is nil or can not serve them
+	flat flatLayer
 }
 
 var emptyStateHash = types.StringToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
 
 func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.Hash) types.Hash {
-	var (
-		err  error
-		trie *Trie
-	)
-
 	if root == emptyStateHash {
-		trie = s.state.newTrie()
-	} else {
-		trie, err = s.state.newTrieAt(root)
-		if err != nil {
-			return types.Hash{}
-		}
+		return types.Hash{}
 	}
 
 	key := crypto.Keccak256(rawkey.Bytes())
 
+	if s.flat != nil {
+		addrHash := types.BytesToHash(hashit(addr.Bytes()))
+
+		// the flat entries belong to the current account, a different root is served by the trie
+		if s.flatStorageRootIs(addrHash, root) {
+			if val, err := s.flat.storage(addrHash, types.BytesToHash(key)); err == nil {
+				if len(val) == 0 {
+					return types.Hash{}
+				}
+
+				return decodeStorageValue(val)
+			}
+		}
+	}
+
+	trie, err := s.state.newTrieAt(root)
+	if err != nil {
+		return types.Hash{}
+	}
+
 	val, ok := trie.Get(key, s.state.storage)
 	if !ok {
 		return types.Hash{}
 	}
 
+	return decodeStorageValue(val)
+}
+
+// flatStorageRootIs checks if the storage root of the account in the flat state is the given one
+func (s *Snapshot) flatStorageRootIs(addrHash types.Hash, root types.Hash) bool {
+	data, err := s.flat.account(addrHash)
+	if err != nil || data == nil {
+		return false
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return false
+	}
+
+	return account.Root == root
+}
+
+func decodeStorageValue(val []byte) types.Hash {
 	p := &fastrlp.Parser{}
 
 	v, err := p.Parse(val)
@@ -57,7 +88,7 @@ func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.
 func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
 	key := crypto.Keccak256(addr.Bytes())
 
-	data, ok := s.trie.Get(key, s.state.storage)
+	data, ok := s.getAccountData(key)
 	if !ok {
 		return nil, nil
 	}
@@ -70,6 +101,17 @@ func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
 	return &account, nil
 }
 
+// getAccountData returns the RLP encoded account with the given hash
+func (s *Snapshot) getAccountData(key []byte) ([]byte, bool) {
+	if s.flat != nil {
+		if data, err := s.flat.account(types.BytesToHash(key)); err == nil {
+			return data, data != nil
+		}
+	}
+
+	return s.trie.Get(key, s.state.storage)
+}
+
 func (s *Snapshot) GetCode(hash types.Hash) ([]byte, bool) {
 	return s.state.GetCode(hash)
 }
@@ -83,10 +125,26 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 	arena := stateArenaPool.Get()
 	defer stateArenaPool.Put(arena)
 
+	// changes for the flat state, collected only if there is a flat layer to build on
+	var diff *flatDiff
+	if s.flat != nil {
+		diff = newFlatDiff()
+	}
+
 	for _, obj := range objs {
+		addrHash := hashit(obj.Address.Bytes())
+
 		if obj.Deleted {
-			tt.Delete(hashit(obj.Address.Bytes()))
+			tt.Delete(addrHash)
+
+			if diff != nil {
+				diff.deleteAccount(types.BytesToHash(addrHash))
+			}
 		} else {
+			if s.state.preimages {
+				batch.Put(preimageKey(addrHash), obj.Address.Bytes())
+			}
+
 			account := state.Account{
 				Balance:  obj.Balance,
 				Nonce:    obj.Nonce,
@@ -107,9 +165,22 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 					k := hashit(entry.Key)
 					if entry.Deleted {
 						localTxn.Delete(k)
+
+						if diff != nil {
+							diff.updateStorage(types.BytesToHash(addrHash), types.BytesToHash(k), nil)
+						}
 					} else {
+						if s.state.preimages {
+							batch.Put(preimageKey(k), entry.Key)
+						}
+
 						vv := arena.NewBytes(bytes.TrimLeft(entry.Val, "\x00"))
-						localTxn.Insert(k, vv.MarshalTo(nil))
+						val := vv.MarshalTo(nil)
+						localTxn.Insert(k, val)
+
+						if diff != nil {
+							diff.updateStorage(types.BytesToHash(addrHash), types.BytesToHash(k), val)
+						}
 					}
 				}
 
@@ -129,8 +200,13 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 			vv := account.MarshalWith(arena)
 			data := vv.MarshalTo(nil)
 
-			tt.Insert(hashit(obj.Address.Bytes()), data)
+			tt.Insert(addrHash, data)
 			arena.Reset()
+
+			if diff != nil {
+				// the storage of a previous incarnation is gone if the account was recreated
+				diff.updateAccount(types.BytesToHash(addrHash), data, obj.Recreated)
+			}
 		}
 	}
 
@@ -148,5 +224,12 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 
 	s.state.AddState(types.BytesToHash(root), nTrie)
 
-	return &Snapshot{trie: nTrie, state: s.state}, root, nil
+	if diff != nil {
+		err := s.state.flat.update(types.BytesToHash(root), s.flat.root(), diff.accounts, diff.storageSlots, diff.destructs)
+		if err != nil {
+			return nil, types.ZeroHash[:], fmt.Errorf("snapshot commit flat state update error: %w", err)
+		}
+	}
+
+	return &Snapshot{trie: nTrie, state: s.state, flat: s.state.getFlatLayer(types.BytesToHash(root))}, root, nil
 }
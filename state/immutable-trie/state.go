@@ -3,6 +3,7 @@ package itrie
 import (
 	"fmt"
 
+	"github.com/hashicorp/go-hclog"
 	lru "github.com/hashicorp/golang-lru"
 
 	"github.com/0xPolygon/polygon-edge/state"
@@ -12,6 +13,10 @@ import (
 type State struct {
 	storage Storage
 	cache   *lru.Cache
+	flat    *flatTree
+
+	// preimages is set if the state commits record the preimages of the trie keys
+	preimages bool
 }
 
 func NewState(storage Storage) *State {
@@ -25,6 +30,17 @@ func NewState(storage Storage) *State {
 	return s
 }
 
+// HasState returns whether the state of the given root is available
+func (s *State) HasState(root types.Hash) bool {
+	if root == types.EmptyRootHash {
+		return true
+	}
+
+	_, ok, err := s.storage.Get(root.Bytes())
+
+	return err == nil && ok
+}
+
 func (s *State) NewSnapshot() state.Snapshot {
 	return &Snapshot{state: s, trie: s.newTrie()}
 }
@@ -35,7 +51,44 @@ func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
 		return nil, err
 	}
 
-	return &Snapshot{state: s, trie: t}, nil
+	return &Snapshot{state: s, trie: t, flat: s.getFlatLayer(root)}, nil
+}
+
+// EnablePreimages makes the state commits record the addresses and the storage slots
+// hashed to the trie keys, which are required to export the state as a genesis alloc
+func (s *State) EnablePreimages() {
+	s.preimages = true
+}
+
+// EnableFlatState enables reading the state through a flat key-value view of the accounts and
+// storage slots, which is kept for the given state root and the following commits.
+// If the flat state on disk is missing or belongs to another root, it is regenerated in the background
+func (s *State) EnableFlatState(logger hclog.Logger, root types.Hash, diffLayers int) error {
+	flat, err := newFlatTree(logger, s, root, diffLayers)
+	if err != nil {
+		return err
+	}
+
+	s.flat = flat
+
+	return nil
+}
+
+// CloseFlatState persists the flat state of the given state root, so it can be reused after a restart
+func (s *State) CloseFlatState(root types.Hash) error {
+	if s.flat == nil {
+		return nil
+	}
+
+	return s.flat.close(root)
+}
+
+func (s *State) getFlatLayer(root types.Hash) flatLayer {
+	if s.flat == nil {
+		return nil
+	}
+
+	return s.flat.get(root)
 }
 
 func (s *State) newTrie() *Trie {
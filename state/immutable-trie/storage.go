@@ -1,6 +1,7 @@
 package itrie
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/umbracle/fastrlp"
 )
 
@@ -98,7 +100,12 @@ func (kv *KVStorage) Close() error {
 }
 
 func NewLevelDBStorage(path string, logger hclog.Logger) (Storage, error) {
-	db, err := leveldb.OpenFile(path, nil)
+	return NewLevelDBStorageWithOpt(path, logger, nil)
+}
+
+// NewLevelDBStorageWithOpt creates the trie storage with leveldb with custom options
+func NewLevelDBStorageWithOpt(path string, logger hclog.Logger, options *opt.Options) (Storage, error) {
+	db, err := leveldb.OpenFile(path, options)
 	if err != nil {
 		return nil, err
 	}
@@ -159,7 +166,7 @@ func (m *memStorage) GetCode(hash types.Hash) ([]byte, bool) {
 }
 
 func (m *memStorage) Batch() Batch {
-	return &memBatch{db: &m.db, l: new(sync.Mutex)}
+	return &memBatch{db: &m.db, l: m.l}
 }
 
 func (m *memStorage) Close() error {
@@ -281,3 +288,23 @@ func decodeNode(v *fastrlp.Value, s Storage) (Node, error) {
 func GetCodeKey(hash types.Hash) []byte {
 	return append(codePrefix, hash.Bytes()...)
 }
+
+// KeyCategory returns the kind of the entry stored under the given key in the trie storage
+func KeyCategory(k []byte) string {
+	switch {
+	case len(k) == types.HashLength:
+		return "Node"
+	case len(k) == len(codePrefix)+types.HashLength && bytes.HasPrefix(k, codePrefix):
+		return "Code"
+	case bytes.HasPrefix(k, flatAccountPrefix):
+		return "FlatAccount"
+	case bytes.HasPrefix(k, flatStoragePrefix):
+		return "FlatStorage"
+	case bytes.Equal(k, flatRootKey), bytes.Equal(k, flatGeneratorKey):
+		return "FlatMeta"
+	case len(k) == len(preimagePrefix)+types.HashLength && bytes.HasPrefix(k, preimagePrefix):
+		return "Preimage"
+	default:
+		return "Other"
+	}
+}
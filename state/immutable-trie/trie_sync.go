@@ -0,0 +1,234 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var ErrUnrequestedTrieData = errors.New("unrequested trie node or code")
+
+// trieSyncRequest is a missing trie node or code. It is written once all the data it references
+// is written, so a stored node always has its whole subtree, storage tries and codes stored
+type trieSyncRequest struct {
+	hash    types.Hash
+	code    bool
+	storage bool // node of a storage trie, whose leaves are not accounts
+	data    []byte
+
+	parents []*trieSyncRequest
+	deps    int
+}
+
+// TrieSync schedules the retrieval of the nodes missing from a state trie, or a storage trie,
+// with the given root. The account leaves are followed into their storage tries and codes.
+// The caller fetches the data listed by Missing and hands it over to ProcessNode and ProcessCode
+// until nothing is pending
+type TrieSync struct {
+	storage Storage
+
+	requests map[types.Hash]*trieSyncRequest // nodes which are not written yet
+	codes    map[types.Hash]*trieSyncRequest // codes which are not written yet
+	queue    []*trieSyncRequest              // requests without data, in the order of their discovery
+}
+
+// NewTrieSync creates the sync of the trie with the given root, storage is set for a storage trie
+func NewTrieSync(storage Storage, root types.Hash, isStorage bool) (*TrieSync, error) {
+	s := &TrieSync{
+		storage:  storage,
+		requests: map[types.Hash]*trieSyncRequest{},
+		codes:    map[types.Hash]*trieSyncRequest{},
+	}
+
+	if err := s.schedule(nil, root, false, isStorage); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Pending returns the number of nodes and codes which are not written yet
+func (s *TrieSync) Pending() int {
+	return len(s.requests) + len(s.codes)
+}
+
+// Missing returns at most max hashes of the nodes and the codes which have to be fetched
+func (s *TrieSync) Missing(max int) (nodes []types.Hash, codes []types.Hash) {
+	// drop the requests whose data has arrived meanwhile
+	queue := s.queue[:0]
+
+	for _, req := range s.queue {
+		if req.data == nil {
+			queue = append(queue, req)
+		}
+	}
+
+	s.queue = queue
+
+	for _, req := range s.queue {
+		if len(nodes)+len(codes) == max {
+			break
+		}
+
+		if req.code {
+			codes = append(codes, req.hash)
+		} else {
+			nodes = append(nodes, req.hash)
+		}
+	}
+
+	return nodes, codes
+}
+
+// ProcessNode handles a fetched trie node and schedules the data it references
+func (s *TrieSync) ProcessNode(data []byte) error {
+	hash := types.BytesToHash(hashit(data))
+
+	req, ok := s.requests[hash]
+	if !ok || req.data != nil {
+		return fmt.Errorf("%w: node %s", ErrUnrequestedTrieData, hash)
+	}
+
+	node, err := decodeStoredNode(data)
+	if err != nil {
+		return fmt.Errorf("failed to decode trie node %s: %w", hash, err)
+	}
+
+	req.data = data
+
+	if err := s.scheduleChildren(req, node); err != nil {
+		return err
+	}
+
+	if req.deps == 0 {
+		return s.commit(req)
+	}
+
+	return nil
+}
+
+// ProcessCode handles a fetched code
+func (s *TrieSync) ProcessCode(code []byte) error {
+	hash := types.BytesToHash(hashit(code))
+
+	req, ok := s.codes[hash]
+	if !ok {
+		return fmt.Errorf("%w: code %s", ErrUnrequestedTrieData, hash)
+	}
+
+	req.data = code
+
+	return s.commit(req)
+}
+
+// scheduleChildren schedules the missing nodes referenced by the node and, for the account leaves,
+// the missing storage tries and codes
+func (s *TrieSync) scheduleChildren(req *trieSyncRequest, node Node) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			return s.schedule(req, types.BytesToHash(n.buf), false, req.storage)
+		}
+
+		if req.storage {
+			return nil
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(n.buf); err != nil {
+			return fmt.Errorf("failed to decode account of trie node %s: %w", req.hash, err)
+		}
+
+		if err := s.schedule(req, account.Root, false, true); err != nil {
+			return err
+		}
+
+		return s.schedule(req, types.BytesToHash(account.CodeHash), true, false)
+
+	case *ShortNode:
+		return s.scheduleChildren(req, n.child)
+
+	case *FullNode:
+		for _, child := range n.children {
+			if err := s.scheduleChildren(req, child); err != nil {
+				return err
+			}
+		}
+
+		return s.scheduleChildren(req, n.value)
+
+	default:
+		return fmt.Errorf("unknown node type %T", n)
+	}
+}
+
+// schedule adds a request for the node or the code of the hash, unless it is already stored,
+// and makes the parent wait for it
+func (s *TrieSync) schedule(parent *trieSyncRequest, hash types.Hash, code, storage bool) error {
+	requests := s.requests
+	if code {
+		requests = s.codes
+
+		if hash == types.EmptyCodeHash || hash == types.ZeroHash {
+			return nil
+		}
+
+		if _, ok := s.storage.GetCode(hash); ok {
+			return nil
+		}
+	} else {
+		if hash == types.EmptyRootHash || hash == types.ZeroHash {
+			return nil
+		}
+
+		if _, ok, err := s.storage.Get(hash.Bytes()); err != nil || ok {
+			return err
+		}
+	}
+
+	req, ok := requests[hash]
+	if !ok {
+		req = &trieSyncRequest{hash: hash, code: code, storage: storage}
+		requests[hash] = req
+		s.queue = append(s.queue, req)
+	}
+
+	if parent != nil {
+		req.parents = append(req.parents, parent)
+		parent.deps++
+	}
+
+	return nil
+}
+
+// commit writes the data of the request and then the parents which do not wait for other data
+func (s *TrieSync) commit(req *trieSyncRequest) error {
+	if req.code {
+		if err := s.storage.SetCode(req.hash, req.data); err != nil {
+			return err
+		}
+
+		delete(s.codes, req.hash)
+	} else {
+		if err := s.storage.Put(req.hash.Bytes(), req.data); err != nil {
+			return err
+		}
+
+		delete(s.requests, req.hash)
+	}
+
+	for _, parent := range req.parents {
+		if parent.deps--; parent.deps == 0 {
+			if err := s.commit(parent); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
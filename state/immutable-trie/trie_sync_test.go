@@ -0,0 +1,132 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// healTrie runs the sync of the trie with the given root, serving the requests from the source storage
+func healTrie(t *testing.T, source, synced Storage, root types.Hash) {
+	t.Helper()
+
+	sched, err := NewTrieSync(synced, root, false)
+	require.NoError(t, err)
+
+	for sched.Pending() > 0 {
+		nodes, codes := sched.Missing(16)
+		require.NotEmpty(t, append(nodes, codes...))
+
+		for _, hash := range nodes {
+			data, ok, err := source.Get(hash.Bytes())
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.NoError(t, sched.ProcessNode(data))
+		}
+
+		for _, hash := range codes {
+			code, ok := source.GetCode(hash)
+			require.True(t, ok)
+			require.NoError(t, sched.ProcessCode(code))
+		}
+	}
+}
+
+func TestTrieSync(t *testing.T) {
+	t.Parallel()
+
+	source := NewMemoryStorage()
+
+	code := []byte{0x60, 0x02}
+	objs := make([]*state.Object, 0, 100)
+
+	for i := 0; i < 100; i++ {
+		obj := flatTestObject(types.BytesToAddress([]byte{byte(i + 1)}), uint64(i+1), types.EmptyRootHash,
+			map[byte]byte{byte(i): byte(i + 1), 0xff: 1})
+
+		if i%10 == 0 {
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+			obj.Code = code
+			obj.DirtyCode = true
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, root := commitObjects(t, NewState(source).NewSnapshot(), objs...)
+
+	requireSynced := func(t *testing.T, synced Storage) {
+		t.Helper()
+
+		snap, err := NewState(synced).NewSnapshotAt(root)
+		require.NoError(t, err)
+
+		for i, obj := range objs {
+			account, err := snap.GetAccount(obj.Address)
+			require.NoError(t, err)
+			require.Equal(t, obj.Nonce, account.Nonce)
+
+			value := snap.GetStorage(obj.Address, account.Root, types.BytesToHash([]byte{byte(i)}))
+			require.Equal(t, types.BytesToHash([]byte{byte(i + 1)}), value)
+
+			_, ok := snap.GetCode(types.BytesToHash(account.CodeHash))
+			require.True(t, ok)
+		}
+	}
+
+	t.Run("empty storage", func(t *testing.T) {
+		t.Parallel()
+
+		synced := NewMemoryStorage()
+		healTrie(t, source, synced, root)
+		requireSynced(t, synced)
+
+		// a stored root is complete, so there is nothing left to sync
+		sched, err := NewTrieSync(synced, root, false)
+		require.NoError(t, err)
+		require.Zero(t, sched.Pending())
+	})
+
+	t.Run("incomplete ranges", func(t *testing.T) {
+		t.Parallel()
+
+		synced := NewMemoryStorage()
+		leaves, err := ReadTrieRange(source, root, nil, len(objs))
+		require.NoError(t, err)
+
+		// the accounts are written with their storage tries, skipping some of them
+		builder := NewTrieBuilder(synced)
+
+		for i, key := range leaves.Keys {
+			if i%7 == 3 {
+				continue
+			}
+
+			var account state.Account
+			require.NoError(t, account.UnmarshalRlp(leaves.Values[i]))
+			require.NoError(t, CopyTrie(account.Root.Bytes(), source, synced, nil, true))
+			require.NoError(t, builder.Insert(key, leaves.Values[i]))
+		}
+
+		built, err := builder.Commit()
+		require.NoError(t, err)
+		require.NotEqual(t, root, built)
+
+		healTrie(t, source, synced, root)
+		requireSynced(t, synced)
+	})
+
+	t.Run("unrequested data", func(t *testing.T) {
+		t.Parallel()
+
+		sched, err := NewTrieSync(NewMemoryStorage(), root, false)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, sched.ProcessNode([]byte{0xc0}), ErrUnrequestedTrieData)
+		require.ErrorIs(t, sched.ProcessCode(code), ErrUnrequestedTrieData)
+	})
+}
@@ -1,11 +1,26 @@
 package evm
 
-import "github.com/0xPolygon/polygon-edge/helper/common"
+import (
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+)
 
-const bitmapSize = 8
+const (
+	bitmapSize = 8
+
+	evmMetrics = "evm"
+)
+
+// codeAnalysisCache holds the jumpdest bitmaps of the recently executed code, keyed by code hash.
+// It's shared by all the EVM instances, so the cached bitmaps must not be modified
+var codeAnalysisCache = newCodeAnalysisLRU(codeAnalysisCacheMaxBytes)
 
 type bitmap struct {
 	buf []byte
+
+	// shared is set if buf is owned by the code analysis cache
+	shared bool
 }
 
 func (b *bitmap) isSet(i uint64) bool {
@@ -17,6 +32,13 @@ func (b *bitmap) set(i uint64) {
 }
 
 func (b *bitmap) reset() {
+	if b.shared {
+		b.buf = nil
+		b.shared = false
+
+		return
+	}
+
 	for i := range b.buf {
 		b.buf[i] = 0
 	}
@@ -45,6 +67,36 @@ func (b *bitmap) setCode(code []byte) {
 	}
 }
 
+// setCodeWithHash sets the bitmap of the code, reusing the analysis from the cache
+// if the code with the same hash has already been analysed
+func (b *bitmap) setCodeWithHash(code []byte, codeHash types.Hash) {
+	// the hash is unknown for the init code of contract creations
+	if codeHash == types.ZeroHash || codeHash == types.EmptyCodeHash {
+		b.setCode(code)
+
+		return
+	}
+
+	if buf, ok := codeAnalysisCache.Get(codeHash); ok {
+		metrics.IncrCounter([]string{evmMetrics, "code_analysis_cache_hit"}, 1)
+
+		b.buf = buf
+		b.shared = true
+
+		return
+	}
+
+	metrics.IncrCounter([]string{evmMetrics, "code_analysis_cache_miss"}, 1)
+
+	analysis := &bitmap{}
+	analysis.setCode(code)
+
+	codeAnalysisCache.Add(codeHash, analysis.buf)
+
+	b.buf = analysis.buf
+	b.shared = true
+}
+
 func isPushOp(i byte) bool {
 	// From PUSH1 (0x60) to PUSH32(0x7F)
 	return i>>5 == 3
@@ -3,6 +3,10 @@ package evm
 import (
 	"strings"
 	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsPush(t *testing.T) {
@@ -22,3 +26,43 @@ func TestIsPush(t *testing.T) {
 		t.Fatal("bad")
 	}
 }
+
+func TestBitmap_SetCodeWithHash(t *testing.T) {
+	t.Parallel()
+
+	// PUSH1 0x5b, JUMPDEST, JUMPDEST
+	code := []byte{PUSH1, JUMPDEST, JUMPDEST, JUMPDEST}
+	codeHash := crypto.Keccak256Hash(code)
+
+	expected := &bitmap{}
+	expected.setCode(code)
+
+	first := &bitmap{}
+	first.setCodeWithHash(code, codeHash)
+	require.True(t, first.shared)
+	require.Equal(t, expected.buf, first.buf)
+
+	cached, ok := codeAnalysisCache.Get(codeHash)
+	require.True(t, ok)
+	require.Equal(t, expected.buf, cached)
+
+	// the analysis is reused and is not cleared on reset
+	second := &bitmap{}
+	second.setCodeWithHash(code, codeHash)
+	require.Equal(t, &first.buf[0], &second.buf[0])
+
+	first.reset()
+	require.Nil(t, first.buf)
+	require.False(t, first.shared)
+	require.Equal(t, expected.buf, second.buf)
+
+	require.False(t, second.isSet(1))
+	require.True(t, second.isSet(2))
+	require.True(t, second.isSet(3))
+
+	// the init code of contract creations is not cached
+	creation := &bitmap{}
+	creation.setCodeWithHash(code, types.ZeroHash)
+	require.False(t, creation.shared)
+	require.False(t, codeAnalysisCache.Contains(types.ZeroHash))
+}
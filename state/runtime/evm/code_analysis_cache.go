@@ -0,0 +1,121 @@
+package evm
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// codeAnalysisCacheMaxBytes is the max total size of the code analysis results kept in the cache
+	codeAnalysisCacheMaxBytes = 16 * 1024 * 1024
+
+	// codeAnalysisEntryOverhead is the approximate size of the bookkeeping of a cached entry,
+	// the key, the list element and the map slot
+	codeAnalysisEntryOverhead = 128
+)
+
+// codeAnalysisEntry is the analysis of the code with the hash
+type codeAnalysisEntry struct {
+	codeHash types.Hash
+	buf      []byte
+}
+
+// size returns the approximate memory held by the entry
+func (e *codeAnalysisEntry) size() int {
+	return cap(e.buf) + codeAnalysisEntryOverhead
+}
+
+// codeAnalysisLRU is an LRU cache of code analysis results bounded by their total size in bytes,
+// so a few large contracts can't hold more memory than many small ones
+type codeAnalysisLRU struct {
+	lock     sync.Mutex
+	maxBytes int
+	bytes    int
+	entries  map[types.Hash]*list.Element
+	order    *list.List
+}
+
+// newCodeAnalysisLRU creates the cache holding at most maxBytes of analysis results
+func newCodeAnalysisLRU(maxBytes int) *codeAnalysisLRU {
+	return &codeAnalysisLRU{
+		maxBytes: maxBytes,
+		entries:  make(map[types.Hash]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the analysis of the code with the hash, and marks it as recently used
+func (c *codeAnalysisLRU) Get(codeHash types.Hash) ([]byte, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, ok := c.entries[codeHash]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	entry, _ := elem.Value.(*codeAnalysisEntry)
+
+	return entry.buf, true
+}
+
+// Contains checks if the analysis of the code with the hash is cached, without marking it as used
+func (c *codeAnalysisLRU) Contains(codeHash types.Hash) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	_, ok := c.entries[codeHash]
+
+	return ok
+}
+
+// Add caches the analysis of the code with the hash, evicting the least recently used
+// results until the cache fits in its size. Results larger than the cache are not kept
+func (c *codeAnalysisLRU) Add(codeHash types.Hash, buf []byte) {
+	entry := &codeAnalysisEntry{codeHash: codeHash, buf: buf}
+	if entry.size() > c.maxBytes {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if elem, ok := c.entries[codeHash]; ok {
+		c.removeElement(elem)
+	}
+
+	c.entries[codeHash] = c.order.PushFront(entry)
+	c.bytes += entry.size()
+
+	for c.bytes > c.maxBytes {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// Len returns the number of cached results
+func (c *codeAnalysisLRU) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.order.Len()
+}
+
+// Bytes returns the approximate total size of the cached results
+func (c *codeAnalysisLRU) Bytes() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.bytes
+}
+
+// removeElement removes the entry from the cache, the lock must be held
+func (c *codeAnalysisLRU) removeElement(elem *list.Element) {
+	entry, _ := c.order.Remove(elem).(*codeAnalysisEntry)
+
+	delete(c.entries, entry.codeHash)
+	c.bytes -= entry.size()
+}
@@ -0,0 +1,53 @@
+package evm
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeAnalysisLRU_BoundedBySize(t *testing.T) {
+	t.Parallel()
+
+	entrySize := 1024 + codeAnalysisEntryOverhead
+	cache := newCodeAnalysisLRU(3 * entrySize)
+
+	hashes := []types.Hash{{0x1}, {0x2}, {0x3}, {0x4}}
+
+	for _, hash := range hashes[:3] {
+		cache.Add(hash, make([]byte, 1024))
+	}
+
+	require.Equal(t, 3, cache.Len())
+	require.Equal(t, 3*entrySize, cache.Bytes())
+
+	// the first entry is used, so the second one is evicted
+	_, ok := cache.Get(hashes[0])
+	require.True(t, ok)
+
+	cache.Add(hashes[3], make([]byte, 1024))
+
+	require.Equal(t, 3, cache.Len())
+	require.False(t, cache.Contains(hashes[1]))
+	require.True(t, cache.Contains(hashes[0]))
+	require.True(t, cache.Contains(hashes[3]))
+
+	// a large entry evicts several small ones
+	large := types.Hash{0x5}
+	cache.Add(large, make([]byte, 2*1024))
+
+	require.Equal(t, 2, cache.Len())
+	require.True(t, cache.Contains(large))
+	require.True(t, cache.Contains(hashes[3]))
+	require.LessOrEqual(t, cache.Bytes(), 3*entrySize)
+
+	// the entry larger than the cache is not kept
+	cache.Add(types.Hash{0x6}, make([]byte, 4*1024))
+	require.False(t, cache.Contains(types.Hash{0x6}))
+	require.Equal(t, 2, cache.Len())
+
+	// replacing the entry doesn't count it twice
+	cache.Add(large, make([]byte, 1024))
+	require.Equal(t, 2*entrySize, cache.Bytes())
+}
@@ -39,7 +39,7 @@ func (e *EVM) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInT
 	contract.host = host
 	contract.config = config
 
-	contract.bitmap.setCode(c.Code)
+	contract.bitmap.setCodeWithHash(c.Code, c.CodeHash)
 
 	ret, err := contract.Run()
 
@@ -0,0 +1,138 @@
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+)
+
+const (
+	benchmarkGas = 1_000_000_000
+
+	// benchmarkCodeSize is the max size of the deployed code (EIP-170)
+	benchmarkCodeSize = 24576
+
+	push2 = PUSH1 + 1
+	dup2  = DUP1 + 1
+)
+
+var (
+	benchmarkWord1 = []byte{
+		0x7f, 0x3c, 0x44, 0xcd, 0xdd, 0xb6, 0xa9, 0x00, 0xfa, 0x2b, 0x58, 0x5d, 0xd2, 0x99, 0xe0, 0x3d,
+		0x12, 0xfa, 0x42, 0x93, 0xbc, 0x7f, 0x3c, 0x44, 0xcd, 0xdd, 0xb6, 0xa9, 0x00, 0xfa, 0x2b, 0x58,
+	}
+	benchmarkWord2 = []byte{
+		0x01, 0x95, 0x33, 0x42, 0x7e, 0x11, 0xc4, 0x5f, 0x96, 0x1a, 0x55, 0x0c, 0x3d, 0x2b, 0x9e, 0x81,
+		0x6a, 0x3c, 0x0d, 0x77, 0xf2, 0x58, 0x13, 0xe4, 0x21, 0xa0, 0x6b, 0xc9, 0x3f, 0x08, 0xd7, 0x45,
+	}
+)
+
+// push32 returns the code pushing the word on the stack
+func push32(word []byte) []byte {
+	return append([]byte{PUSH32}, word...)
+}
+
+// loopCode returns the code which executes the body the given number of times.
+// The body must leave the stack unchanged
+func loopCode(iterations uint16, body []byte) []byte {
+	code := []byte{push2, byte(iterations >> 8), byte(iterations)}
+
+	loop := len(code)
+
+	code = append(code, JUMPDEST)
+	code = append(code, body...)
+
+	// counter = counter - 1, jump back to the loop while it is not zero
+	return append(code, PUSH1, 1, SWAP1, SUB, DUP1, push2, byte(loop>>8), byte(loop), JUMPI, byte(STOP))
+}
+
+func benchmarkCode(b *testing.B, code []byte) {
+	b.Helper()
+
+	var (
+		evm    = NewEVM()
+		host   = &mockHost{}
+		config = chain.AllForksEnabled.At(0)
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		result := evm.Run(newMockContract(big.NewInt(0), benchmarkGas, code), host, &config)
+		if result.Err != nil {
+			b.Fatal(result.Err)
+		}
+	}
+}
+
+func BenchmarkEVM_Arithmetic(b *testing.B) {
+	var body []byte
+
+	body = append(body, push32(benchmarkWord1)...)
+	body = append(body, push32(benchmarkWord2)...)
+	body = append(body, MUL, DUP1)
+	body = append(body, push32(benchmarkWord2)...)
+	body = append(body, ADD, DUP1, PUSH1, 3, EXP, SWAP1)
+	body = append(body, push32(benchmarkWord2)...)
+	body = append(body, SWAP1, DIV, PUSH1, 7, SHL, ADD)
+	body = append(body, push32(benchmarkWord1)...)
+	body = append(body, push32(benchmarkWord2)...)
+	body = append(body, MULMOD, POP, POP)
+
+	benchmarkCode(b, loopCode(1000, body))
+}
+
+func BenchmarkEVM_Comparison(b *testing.B) {
+	var body []byte
+
+	body = append(body, push32(benchmarkWord1)...)
+	body = append(body, push32(benchmarkWord2)...)
+	body = append(body, dup2, dup2, LT, POP, dup2, dup2, SGT, POP, EQ, ISZERO, NOT, PUSH1, 5, BYTE, POP)
+
+	benchmarkCode(b, loopCode(1000, body))
+}
+
+func BenchmarkEVM_MemoryAndHash(b *testing.B) {
+	var body []byte
+
+	body = append(body, push32(benchmarkWord1)...)
+	body = append(body, PUSH1, 0, MSTORE, PUSH1, 64, PUSH1, 0, SHA3, PUSH1, 32, MSTORE, PUSH1, 32, MLOAD, POP)
+
+	benchmarkCode(b, loopCode(1000, body))
+}
+
+func BenchmarkEVM_CodeAnalysis(b *testing.B) {
+	// the large contract returns right away, the run is dominated by the jumpdest analysis
+	code := make([]byte, benchmarkCodeSize)
+	code[0] = byte(STOP)
+
+	for i := 1; i < len(code); i++ {
+		if i%3 == 0 {
+			code[i] = PUSH1
+		} else {
+			code[i] = JUMPDEST
+		}
+	}
+
+	var (
+		evm    = NewEVM()
+		host   = &mockHost{}
+		config = chain.AllForksEnabled.At(0)
+		hash   = crypto.Keccak256Hash(code)
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		contract := newMockContract(big.NewInt(0), benchmarkGas, code)
+		contract.CodeHash = hash
+
+		if result := evm.Run(contract, host, &config); result.Err != nil {
+			b.Fatal(result.Err)
+		}
+	}
+}
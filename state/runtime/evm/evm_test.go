@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -259,7 +260,7 @@ type mockTracer struct {
 
 func (m *mockTracer) CaptureState(
 	memory []byte,
-	stack []*big.Int,
+	stack []uint256.Int,
 	opCode int,
 	contractAddress types.Address,
 	sp int,
@@ -330,7 +331,7 @@ func TestRunWithTracer(t *testing.T) {
 					name: "CaptureState",
 					args: map[string]interface{}{
 						"memory":          []byte{},
-						"stack":           []*big.Int{},
+						"stack":           []uint256.Int{},
 						"opCode":          int(PUSH1),
 						"contractAddress": contractAddress,
 						"sp":              0,
@@ -353,8 +354,8 @@ func TestRunWithTracer(t *testing.T) {
 					name: "CaptureState",
 					args: map[string]interface{}{
 						"memory": []byte{},
-						"stack": []*big.Int{
-							big.NewInt(1),
+						"stack": []uint256.Int{
+							*uint256.NewInt(1),
 						},
 						"opCode":          int(0),
 						"contractAddress": contractAddress,
@@ -375,7 +376,7 @@ func TestRunWithTracer(t *testing.T) {
 					name: "CaptureState",
 					args: map[string]interface{}{
 						"memory":          []byte{},
-						"stack":           []*big.Int{},
+						"stack":           []uint256.Int{},
 						"opCode":          int(POP),
 						"contractAddress": contractAddress,
 						"sp":              0,
@@ -425,7 +426,7 @@ func TestRunWithTracer(t *testing.T) {
 			state.config = config
 
 			// make sure stack, memory, and returnData are empty
-			state.stack = make([]*big.Int, 0)
+			state.stack = make([]uint256.Int, 0)
 			state.memory = make([]byte, 0)
 			state.returnData = make([]byte, 0)
 
@@ -3,8 +3,8 @@ package evm
 
 import (
 	"errors"
+	"math"
 	"math/big"
-	"math/bits"
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/crypto"
@@ -12,6 +12,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/keccak"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 )
 
 type instruction func(c *state)
@@ -23,9 +24,9 @@ const (
 )
 
 var (
-	zero     = big.NewInt(0)
-	one      = big.NewInt(1)
-	wordSize = big.NewInt(32)
+	zero     = uint256.NewInt(0)
+	one      = uint256.NewInt(1)
+	wordSize = uint256.NewInt(32)
 )
 
 func (c *state) calculateGasForEIP2929(addr types.Address) uint64 {
@@ -46,7 +47,6 @@ func opAdd(c *state) {
 	b := c.top()
 
 	b.Add(a, b)
-	toU256(b)
 }
 
 func opMul(c *state) {
@@ -54,7 +54,6 @@ func opMul(c *state) {
 	b := c.top()
 
 	b.Mul(a, b)
-	toU256(b)
 }
 
 func opSub(c *state) {
@@ -62,85 +61,38 @@ func opSub(c *state) {
 	b := c.top()
 
 	b.Sub(a, b)
-	toU256(b)
 }
 
 func opDiv(c *state) {
 	a := c.pop()
 	b := c.top()
 
-	if b.Sign() == 0 {
-		// division by zero
-		b.Set(zero)
-	} else {
-		b.Div(a, b)
-		toU256(b)
-	}
+	// division by zero results in zero
+	b.Div(a, b)
 }
 
 func opSDiv(c *state) {
-	a := to256(c.pop())
-	b := to256(c.top())
-
-	if b.Sign() == 0 {
-		// division by zero
-		b.Set(zero)
-	} else {
-		neg := a.Sign() != b.Sign()
-		b.Div(a.Abs(a), b.Abs(b))
-
-		if neg {
-			b.Neg(b)
-		}
+	a := c.pop()
+	b := c.top()
 
-		toU256(b)
-	}
+	// division by zero results in zero
+	b.SDiv(a, b)
 }
 
 func opMod(c *state) {
 	a := c.pop()
 	b := c.top()
 
-	if b.Sign() == 0 {
-		// division by zero
-		b.Set(zero)
-	} else {
-		b.Mod(a, b)
-		toU256(b)
-	}
+	// division by zero results in zero
+	b.Mod(a, b)
 }
 
 func opSMod(c *state) {
-	a := to256(c.pop())
-	b := to256(c.top())
-
-	if b.Sign() == 0 {
-		// division by zero
-		b.Set(zero)
-	} else {
-		neg := a.Sign() < 0
-		b.Mod(a.Abs(a), b.Abs(b))
-
-		if neg {
-			b.Neg(b)
-		}
-
-		toU256(b)
-	}
-}
-
-var bigPool = sync.Pool{
-	New: func() interface{} {
-		return new(big.Int)
-	},
-}
-
-func acquireBig() *big.Int {
-	return bigPool.Get().(*big.Int)
-}
+	a := c.pop()
+	b := c.top()
 
-func releaseBig(b *big.Int) {
-	bigPool.Put(b)
+	// division by zero results in zero
+	b.SMod(a, b)
 }
 
 func opExp(c *state) {
@@ -159,23 +111,7 @@ func opExp(c *state) {
 		return
 	}
 
-	z := acquireBig().Set(one)
-
-	// https://www.programminglogic.com/fast-exponentiation-algorithms/
-	for _, d := range y.Bits() {
-		for i := 0; i < _W; i++ {
-			if d&1 == 1 {
-				toU256(z.Mul(z, x))
-			}
-
-			d >>= 1
-
-			toU256(x.Mul(x, x))
-		}
-	}
-
-	y.Set(z)
-	releaseBig(z)
+	y.Exp(x, y)
 }
 
 func opAddMod(c *state) {
@@ -183,14 +119,8 @@ func opAddMod(c *state) {
 	b := c.pop()
 	z := c.top()
 
-	if z.Sign() == 0 {
-		// division by zero
-		z.Set(zero)
-	} else {
-		a = a.Add(a, b)
-		z = z.Mod(a, z)
-		toU256(z)
-	}
+	// division by zero results in zero
+	z.AddMod(a, b, z)
 }
 
 func opMulMod(c *state) {
@@ -198,14 +128,8 @@ func opMulMod(c *state) {
 	b := c.pop()
 	z := c.top()
 
-	if z.Sign() == 0 {
-		// division by zero
-		z.Set(zero)
-	} else {
-		a = a.Mul(a, b)
-		z = z.Mod(a, z)
-		toU256(z)
-	}
+	// division by zero results in zero
+	z.MulMod(a, b, z)
 }
 
 func opAnd(c *state) {
@@ -229,36 +153,26 @@ func opXor(c *state) {
 	b.Xor(a, b)
 }
 
-var opByteMask = big.NewInt(255)
-
 func opByte(c *state) {
 	x := c.pop()
 	y := c.top()
 
-	indx := x.Int64()
-	if indx > 31 {
-		y.Set(zero)
-	} else {
-		sh := (31 - indx) * 8
-		y.Rsh(y, uint(sh))
-		y.And(y, opByteMask)
-	}
+	y.Byte(x)
 }
 
 func opNot(c *state) {
 	a := c.top()
 
 	a.Not(a)
-	toU256(a)
 }
 
 func opIsZero(c *state) {
 	a := c.top()
 
-	if a.Sign() == 0 {
-		a.Set(one)
+	if a.IsZero() {
+		a.SetOne()
 	} else {
-		a.Set(zero)
+		a.Clear()
 	}
 }
 
@@ -266,10 +180,10 @@ func opEq(c *state) {
 	a := c.pop()
 	b := c.top()
 
-	if a.Cmp(b) == 0 {
-		b.Set(one)
+	if a.Eq(b) {
+		b.SetOne()
 	} else {
-		b.Set(zero)
+		b.Clear()
 	}
 }
 
@@ -277,10 +191,10 @@ func opLt(c *state) {
 	a := c.pop()
 	b := c.top()
 
-	if a.Cmp(b) < 0 {
-		b.Set(one)
+	if a.Lt(b) {
+		b.SetOne()
 	} else {
-		b.Set(zero)
+		b.Clear()
 	}
 }
 
@@ -288,32 +202,32 @@ func opGt(c *state) {
 	a := c.pop()
 	b := c.top()
 
-	if a.Cmp(b) > 0 {
-		b.Set(one)
+	if a.Gt(b) {
+		b.SetOne()
 	} else {
-		b.Set(zero)
+		b.Clear()
 	}
 }
 
 func opSlt(c *state) {
-	a := to256(c.pop())
-	b := to256(c.top())
+	a := c.pop()
+	b := c.top()
 
-	if a.Cmp(b) < 0 {
-		b.Set(one)
+	if a.Slt(b) {
+		b.SetOne()
 	} else {
-		b.Set(zero)
+		b.Clear()
 	}
 }
 
 func opSgt(c *state) {
-	a := to256(c.pop())
-	b := to256(c.top())
+	a := c.pop()
+	b := c.top()
 
-	if a.Cmp(b) > 0 {
-		b.Set(one)
+	if a.Sgt(b) {
+		b.SetOne()
 	} else {
-		b.Set(zero)
+		b.Clear()
 	}
 }
 
@@ -321,33 +235,11 @@ func opSignExtension(c *state) {
 	ext := c.pop()
 	x := c.top()
 
-	if ext.Cmp(wordSize) > 0 {
-		return
-	}
-
-	if x == nil {
-		return
-	}
-
-	bit := uint(ext.Uint64()*8 + 7)
-
-	mask := acquireBig().Set(one)
-	mask.Lsh(mask, bit)
-	mask.Sub(mask, one)
-
-	if x.Bit(int(bit)) > 0 {
-		mask.Not(mask)
-		x.Or(x, mask)
-	} else {
-		x.And(x, mask)
-	}
-
-	toU256(x)
-	releaseBig(mask)
+	x.ExtendSign(x, ext)
 }
 
-func equalOrOverflowsUint256(b *big.Int) bool {
-	return b.BitLen() > 8
+func equalOrOverflowsUint256(b *uint256.Int) bool {
+	return !b.LtUint64(256)
 }
 
 func opShl(c *state) {
@@ -361,10 +253,9 @@ func opShl(c *state) {
 	value := c.top()
 
 	if equalOrOverflowsUint256(shift) {
-		value.Set(zero)
+		value.Clear()
 	} else {
 		value.Lsh(value, uint(shift.Uint64()))
-		toU256(value)
 	}
 }
 
@@ -379,10 +270,9 @@ func opShr(c *state) {
 	value := c.top()
 
 	if equalOrOverflowsUint256(shift) {
-		value.Set(zero)
+		value.Clear()
 	} else {
 		value.Rsh(value, uint(shift.Uint64()))
-		toU256(value)
 	}
 }
 
@@ -394,17 +284,16 @@ func opSar(c *state) {
 	}
 
 	shift := c.pop()
-	value := to256(c.top())
+	value := c.top()
 
 	if equalOrOverflowsUint256(shift) {
 		if value.Sign() >= 0 {
-			value.Set(zero)
+			value.Clear()
 		} else {
-			value.Set(tt256m1)
+			value.SetAllOne()
 		}
 	} else {
-		value.Rsh(value, uint(shift.Uint64()))
-		toU256(value)
+		value.SRsh(value, uint(shift.Uint64()))
 	}
 }
 
@@ -430,14 +319,9 @@ func opMLoad(c *state) {
 		return
 	}
 
-	c.push1().SetBytes(c.tmp)
+	c.push1().SetBytes32(c.tmp)
 }
 
-var (
-	_W = bits.UintSize
-	_S = _W / 8
-)
-
 func opMStore(c *state) {
 	offset := c.pop()
 	val := c.pop()
@@ -447,28 +331,7 @@ func opMStore(c *state) {
 	}
 
 	o := offset.Uint64()
-	buf := c.memory[o : o+32]
-
-	i := 32
-
-	// convert big.int to bytes
-	// https://golang.org/src/math/big/nat.go#L1284
-	for _, d := range val.Bits() {
-		for j := 0; j < _S; j++ {
-			i--
-
-			buf[i] = byte(d)
-
-			d >>= 8
-		}
-	}
-
-	// fill the rest of the slot with zeros
-	for i > 0 {
-		i--
-
-		buf[i] = 0
-	}
+	val.WriteToSlice(c.memory[o : o+32])
 }
 
 func opMStore8(c *state) {
@@ -479,7 +342,7 @@ func opMStore8(c *state) {
 		return
 	}
 
-	c.memory[offset.Uint64()] = byte(val.Uint64() & 0xff)
+	c.memory[offset.Uint64()] = byte(val.Uint64())
 }
 
 // --- storage ---
@@ -490,10 +353,10 @@ func opSload(c *state) {
 	var gas uint64
 
 	if c.config.Berlin {
-		if _, slotPresent := c.host.ContainsAccessListSlot(c.msg.Address, bigToHash(loc)); !slotPresent {
+		if _, slotPresent := c.host.ContainsAccessListSlot(c.msg.Address, toHash(loc)); !slotPresent {
 			gas = ColdStorageReadCostEIP2929
 
-			c.host.AddSlotToAccessList(c.msg.Address, bigToHash(loc))
+			c.host.AddSlotToAccessList(c.msg.Address, toHash(loc))
 		} else {
 			gas = WarmStorageReadCostEIP2929
 		}
@@ -510,8 +373,8 @@ func opSload(c *state) {
 		return
 	}
 
-	val := c.host.GetStorage(c.msg.Address, bigToHash(loc))
-	loc.SetBytes(val.Bytes())
+	val := c.host.GetStorage(c.msg.Address, toHash(loc))
+	loc.SetBytes32(val.Bytes())
 }
 
 func opSStore(c *state) {
@@ -607,8 +470,7 @@ func opSha3(c *state) {
 
 	c.tmp = keccak.Keccak256(c.tmp[:0], c.tmp)
 
-	v := c.push1()
-	v.SetBytes(c.tmp)
+	c.push1().SetBytes32(c.tmp)
 }
 
 func opPop(c *state) {
@@ -618,7 +480,7 @@ func opPop(c *state) {
 // context operations
 
 func opAddress(c *state) {
-	c.push1().SetBytes(c.msg.Address.Bytes())
+	c.push1().SetBytes20(c.msg.Address.Bytes())
 }
 
 func opBalance(c *state) {
@@ -640,7 +502,7 @@ func opBalance(c *state) {
 		return
 	}
 
-	c.push1().Set(c.host.GetBalance(addr))
+	c.push1().SetFromBig(c.host.GetBalance(addr))
 }
 
 func opSelfBalance(c *state) {
@@ -650,7 +512,7 @@ func opSelfBalance(c *state) {
 		return
 	}
 
-	c.push1().Set(c.host.GetBalance(c.msg.Address))
+	c.push1().SetFromBig(c.host.GetBalance(c.msg.Address))
 }
 
 func opChainID(c *state) {
@@ -664,19 +526,19 @@ func opChainID(c *state) {
 }
 
 func opOrigin(c *state) {
-	c.push1().SetBytes(c.host.GetTxContext().Origin.Bytes())
+	c.push1().SetBytes20(c.host.GetTxContext().Origin.Bytes())
 }
 
 func opCaller(c *state) {
-	c.push1().SetBytes(c.msg.Caller.Bytes())
+	c.push1().SetBytes20(c.msg.Caller.Bytes())
 }
 
 func opCallValue(c *state) {
 	v := c.push1()
 	if value := c.msg.Value; value != nil {
-		v.Set(value)
+		v.SetFromBig(value)
 	} else {
-		v.Set(zero)
+		v.Clear()
 	}
 }
 
@@ -694,7 +556,7 @@ func opCallDataLoad(c *state) {
 	bufPtr := bufPool.Get().(*[]byte)
 	buf := *bufPtr
 	c.setBytes(buf[:32], c.msg.Input, 32, offset)
-	offset.SetBytes(buf[:32])
+	offset.SetBytes32(buf[:32])
 	bufPool.Put(bufPtr)
 }
 
@@ -761,9 +623,9 @@ func opExtCodeHash(c *state) {
 
 	v := c.push1()
 	if c.host.Empty(address) {
-		v.Set(zero)
+		v.Clear()
 	} else {
-		v.SetBytes(c.host.GetCodeHash(address).Bytes())
+		v.SetBytes32(c.host.GetCodeHash(address).Bytes())
 	}
 }
 
@@ -779,7 +641,7 @@ func opGas(c *state) {
 	c.push1().SetUint64(c.gas)
 }
 
-func (c *state) setBytes(dst, input []byte, size uint64, dataOffset *big.Int) {
+func (c *state) setBytes(dst, input []byte, size uint64, dataOffset *uint256.Int) {
 	if !dataOffset.IsUint64() {
 		// overflow, copy 'size' 0 bytes to dst
 		for i := uint64(0); i < size; i++ {
@@ -877,10 +739,13 @@ func opReturnDataCopy(c *state) {
 	// 1. the dataOffset is uint64 (overflow check)
 	// 2. the sum of dataOffset and length overflows uint64
 	// 3. the length of return data has enough space to receive offset + length bytes
-	end := new(big.Int).Add(dataOffset, length)
+	var end uint256.Int
+
+	_, overflow := end.AddOverflow(dataOffset, length)
 	endAddress := end.Uint64()
 
 	if !dataOffset.IsUint64() ||
+		overflow ||
 		!end.IsUint64() ||
 		uint64(len(c.returnData)) < endAddress {
 		c.exit(errReturnDataOutOfBounds)
@@ -889,7 +754,7 @@ func opReturnDataCopy(c *state) {
 	}
 
 	// if length is 0, return immediately since no need for the data copying nor memory allocation
-	if length.Sign() == 0 {
+	if length.IsZero() {
 		return
 	}
 
@@ -936,32 +801,32 @@ func opCodeCopy(c *state) {
 func opBlockHash(c *state) {
 	num := c.top()
 
-	if !num.IsInt64() {
-		num.Set(zero)
+	if !num.IsUint64() || num.Uint64() > math.MaxInt64 {
+		num.Clear()
 
 		return
 	}
 
-	n := num.Int64()
+	n := int64(num.Uint64())
 	lastBlock := c.host.GetTxContext().Number
 
 	if lastBlock-257 < n && n < lastBlock {
-		num.SetBytes(c.host.GetBlockHash(n).Bytes())
+		num.SetBytes32(c.host.GetBlockHash(n).Bytes())
 	} else {
-		num.Set(zero)
+		num.Clear()
 	}
 }
 
 func opCoinbase(c *state) {
-	c.push1().SetBytes(c.host.GetTxContext().Coinbase.Bytes())
+	c.push1().SetBytes20(c.host.GetTxContext().Coinbase.Bytes())
 }
 
 func opTimestamp(c *state) {
-	c.push1().SetInt64(c.host.GetTxContext().Timestamp)
+	c.push1().SetUint64(uint64(c.host.GetTxContext().Timestamp))
 }
 
 func opNumber(c *state) {
-	c.push1().SetInt64(c.host.GetTxContext().Number)
+	c.push1().SetUint64(uint64(c.host.GetTxContext().Number))
 }
 
 func opDifficulty(c *state) {
@@ -969,7 +834,7 @@ func opDifficulty(c *state) {
 }
 
 func opGasLimit(c *state) {
-	c.push1().SetInt64(c.host.GetTxContext().GasLimit)
+	c.push1().SetUint64(uint64(c.host.GetTxContext().GasLimit))
 }
 
 func opBaseFee(c *state) {
@@ -979,7 +844,7 @@ func opBaseFee(c *state) {
 		return
 	}
 
-	c.push(c.host.GetTxContext().BaseFee)
+	c.push1().SetFromBig(c.host.GetTxContext().BaseFee)
 }
 
 func opSelfDestruct(c *state) {
@@ -1035,7 +900,7 @@ func opJumpi(c *state) {
 	dest := c.pop()
 	cond := c.pop()
 
-	if cond.Sign() != 0 {
+	if !cond.IsZero() {
 		if c.validJumpdest(dest) {
 			c.ip = int(dest.Uint64() - 1)
 		} else {
@@ -1054,7 +919,7 @@ func opPush0(c *state) {
 		return
 	}
 
-	c.push(zero)
+	c.push1().Clear()
 }
 
 func opPush(n int) instruction {
@@ -1115,7 +980,7 @@ func opLog(size int) instruction {
 
 		topics := make([]types.Hash, size)
 		for i := 0; i < size; i++ {
-			topics[i] = bigToHash(c.pop())
+			topics[i] = toHash(c.pop())
 		}
 
 		var ok bool
@@ -1162,7 +1027,7 @@ func opCreate(op OpCode) instruction {
 
 		contract, err := c.buildCreateContract(op)
 		if err != nil {
-			c.push1().Set(zero)
+			c.push1().Clear()
 
 			if contract != nil {
 				c.gas += contract.Gas
@@ -1182,13 +1047,13 @@ func opCreate(op OpCode) instruction {
 
 		v := c.push1()
 		if op == CREATE && c.config.Homestead && errors.Is(result.Err, runtime.ErrCodeStoreOutOfGas) {
-			v.Set(zero)
+			v.Clear()
 		} else if op == CREATE && result.Failed() && !errors.Is(result.Err, runtime.ErrCodeStoreOutOfGas) {
-			v.Set(zero)
+			v.Clear()
 		} else if op == CREATE2 && result.Failed() {
-			v.Set(zero)
+			v.Clear()
 		} else {
-			v.SetBytes(contract.Address.Bytes())
+			v.SetBytes20(contract.Address.Bytes())
 		}
 
 		c.gas += result.GasLeft
@@ -1204,7 +1069,7 @@ func opCall(op OpCode) instruction {
 		c.resetReturnData()
 
 		if op == CALL && c.inStaticCall() {
-			if val := c.peekAt(3); val != nil && val.BitLen() > 0 {
+			if val := c.peekAt(3); val != nil && !val.IsZero() {
 				c.exit(errWriteProtection)
 
 				return
@@ -1244,7 +1109,7 @@ func opCall(op OpCode) instruction {
 
 		contract, offset, size, err := c.buildCallContract(op)
 		if err != nil {
-			c.push1().Set(zero)
+			c.push1().Clear()
 
 			if contract != nil {
 				c.gas += contract.Gas
@@ -1263,9 +1128,9 @@ func opCall(op OpCode) instruction {
 
 		v := c.push1()
 		if result.Succeeded() {
-			v.Set(one)
+			v.SetOne()
 		} else {
-			v.Set(zero)
+			v.Clear()
 		}
 
 		if result.Succeeded() || result.Reverted() {
@@ -1286,7 +1151,7 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 
 	var value *big.Int
 	if op == CALL || op == CALLCODE {
-		value = c.pop()
+		value = c.pop().ToBig()
 	}
 
 	// input range
@@ -1385,6 +1250,7 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 		c.host.GetCode(addr),
 		args,
 	)
+	contract.CodeHash = c.host.GetCodeHash(addr)
 
 	if op == STATICCALL || parent.msg.Static {
 		contract.Static = true
@@ -1409,17 +1275,17 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 
 func (c *state) buildCreateContract(op OpCode) (*runtime.Contract, error) {
 	// Pop input arguments
-	value := c.pop()
+	value := c.pop().ToBig()
 	offset := c.pop()
 	length := c.pop()
 
-	var salt *big.Int
+	var salt *uint256.Int
 	if op == CREATE2 {
 		salt = c.pop()
 	}
 
 	// check if the value can be transferred
-	hasTransfer := value != nil && value.Sign() != 0
+	hasTransfer := value.Sign() != 0
 
 	// Calculate and consume gas cost
 
@@ -1464,7 +1330,7 @@ func (c *state) buildCreateContract(op OpCode) (*runtime.Contract, error) {
 	if op == CREATE {
 		address = crypto.CreateAddress(c.msg.Address, c.host.GetNonce(c.msg.Address))
 	} else {
-		address = crypto.CreateAddress2(c.msg.Address, bigToHash(salt), input)
+		address = crypto.CreateAddress2(c.msg.Address, toHash(salt), input)
 	}
 
 	contract := runtime.NewContractCreation(
@@ -1505,24 +1371,3 @@ func opHalt(op OpCode) instruction {
 		}
 	}
 }
-
-var (
-	tt256   = new(big.Int).Lsh(big.NewInt(1), 256)   // 2 ** 256
-	tt256m1 = new(big.Int).Sub(tt256, big.NewInt(1)) // 2 ** 256 - 1
-)
-
-func toU256(x *big.Int) *big.Int {
-	if x.Sign() < 0 || x.BitLen() > 256 {
-		x.And(x, tt256m1)
-	}
-
-	return x
-}
-
-func to256(x *big.Int) *big.Int {
-	if x.BitLen() > 255 {
-		x.Sub(x, tt256)
-	}
-
-	return x
-}
@@ -8,24 +8,26 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
 var (
-	two   = big.NewInt(2)
-	three = big.NewInt(3)
-	four  = big.NewInt(4)
-	five  = big.NewInt(5)
+	two   = uint256.NewInt(2)
+	three = uint256.NewInt(3)
+	four  = uint256.NewInt(4)
+	five  = uint256.NewInt(5)
 
 	allEnabledForks = chain.AllForksEnabled.At(0)
 )
 
 type OperandsLogical struct {
-	operands       []*big.Int
+	operands       []*uint256.Int
 	expectedResult bool
 }
 
@@ -46,8 +48,8 @@ func testLogicalOperation(t *testing.T, f instruction, test OperandsLogical, s *
 }
 
 type OperandsArithmetic struct {
-	operands       []*big.Int
-	expectedResult *big.Int
+	operands       []*uint256.Int
+	expectedResult *uint256.Int
 }
 
 func testArithmeticOperation(t *testing.T, f instruction, test OperandsArithmetic, s *state) {
@@ -67,10 +69,10 @@ func TestAdd(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{one, one}, two},
-		{[]*big.Int{zero, one}, one},
-		{[]*big.Int{three, two}, five},
-		{[]*big.Int{zero, zero}, zero},
+		{[]*uint256.Int{one, one}, two},
+		{[]*uint256.Int{zero, one}, one},
+		{[]*uint256.Int{three, two}, five},
+		{[]*uint256.Int{zero, zero}, zero},
 	}
 
 	for _, testOperand := range testOperands {
@@ -83,10 +85,10 @@ func TestMul(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{two, two}, four},
-		{[]*big.Int{three, two}, big.NewInt(6)},
-		{[]*big.Int{three, one}, three},
-		{[]*big.Int{zero, one}, zero},
+		{[]*uint256.Int{two, two}, four},
+		{[]*uint256.Int{three, two}, uint256.NewInt(6)},
+		{[]*uint256.Int{three, one}, three},
+		{[]*uint256.Int{zero, one}, zero},
 	}
 
 	for _, testOperand := range testOperands {
@@ -99,9 +101,9 @@ func TestSub(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{one, two}, one},
-		{[]*big.Int{zero, two}, two},
-		{[]*big.Int{two, two}, zero},
+		{[]*uint256.Int{one, two}, one},
+		{[]*uint256.Int{zero, two}, two},
+		{[]*uint256.Int{two, two}, zero},
 	}
 
 	for _, testOperand := range testOperands {
@@ -114,10 +116,10 @@ func TestDiv(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{two, two}, one},
-		{[]*big.Int{one, two}, two},
-		{[]*big.Int{one, zero}, zero},
-		{[]*big.Int{zero, one}, zero},
+		{[]*uint256.Int{two, two}, one},
+		{[]*uint256.Int{one, two}, two},
+		{[]*uint256.Int{one, zero}, zero},
+		{[]*uint256.Int{zero, one}, zero},
 	}
 
 	for _, testOperand := range testOperands {
@@ -130,10 +132,10 @@ func TestSDiv(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{two, two}, one},
-		{[]*big.Int{one, two}, two},
-		{[]*big.Int{zero, one}, zero},
-		{[]*big.Int{one, zero}, zero},
+		{[]*uint256.Int{two, two}, one},
+		{[]*uint256.Int{one, two}, two},
+		{[]*uint256.Int{zero, one}, zero},
+		{[]*uint256.Int{one, zero}, zero},
 	}
 
 	for _, testOperand := range testOperands {
@@ -146,11 +148,11 @@ func TestMod(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{two, three}, one},
-		{[]*big.Int{two, two}, zero},
-		{[]*big.Int{one, three}, zero},
-		{[]*big.Int{zero, one}, zero},
-		{[]*big.Int{three, five}, two},
+		{[]*uint256.Int{two, three}, one},
+		{[]*uint256.Int{two, two}, zero},
+		{[]*uint256.Int{one, three}, zero},
+		{[]*uint256.Int{zero, one}, zero},
+		{[]*uint256.Int{three, five}, two},
 	}
 	for _, testOperand := range testOperands {
 		testArithmeticOperation(t, opMod, testOperand, s)
@@ -162,11 +164,11 @@ func TestSMod(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{two, three}, one},
-		{[]*big.Int{two, two}, zero},
-		{[]*big.Int{one, three}, zero},
-		{[]*big.Int{zero, one}, zero},
-		{[]*big.Int{three, five}, two},
+		{[]*uint256.Int{two, three}, one},
+		{[]*uint256.Int{two, two}, zero},
+		{[]*uint256.Int{one, three}, zero},
+		{[]*uint256.Int{zero, one}, zero},
+		{[]*uint256.Int{three, five}, two},
 	}
 
 	for _, testOperand := range testOperands {
@@ -183,10 +185,10 @@ func TestExp(t *testing.T) {
 		defer cancelFn()
 
 		testOperands := []OperandsArithmetic{
-			{[]*big.Int{one, one}, one},
-			{[]*big.Int{two, two}, four},
-			{[]*big.Int{two, three}, big.NewInt(9)},
-			{[]*big.Int{four, two}, big.NewInt(16)},
+			{[]*uint256.Int{one, one}, one},
+			{[]*uint256.Int{two, two}, four},
+			{[]*uint256.Int{two, three}, uint256.NewInt(9)},
+			{[]*uint256.Int{four, two}, uint256.NewInt(16)},
 		}
 
 		for i, testOperand := range testOperands {
@@ -203,10 +205,10 @@ func TestExp(t *testing.T) {
 		defer cancelFn()
 
 		testOperands := []OperandsArithmetic{
-			{[]*big.Int{one, one}, one},
-			{[]*big.Int{two, two}, four},
-			{[]*big.Int{two, three}, big.NewInt(9)},
-			{[]*big.Int{four, two}, big.NewInt(16)},
+			{[]*uint256.Int{one, one}, one},
+			{[]*uint256.Int{two, two}, four},
+			{[]*uint256.Int{two, three}, uint256.NewInt(9)},
+			{[]*uint256.Int{four, two}, uint256.NewInt(16)},
 		}
 
 		for i, testOperand := range testOperands {
@@ -221,9 +223,9 @@ func TestAddMod(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{three, one, two}, zero},
-		{[]*big.Int{two, one, two}, one},
-		{[]*big.Int{zero, one, one}, zero},
+		{[]*uint256.Int{three, one, two}, zero},
+		{[]*uint256.Int{two, one, two}, one},
+		{[]*uint256.Int{zero, one, one}, zero},
 	}
 
 	for _, testOperand := range testOperands {
@@ -236,9 +238,9 @@ func TestMulMod(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{three, two, four}, two},
-		{[]*big.Int{two, two, four}, zero},
-		{[]*big.Int{zero, one, one}, zero},
+		{[]*uint256.Int{three, two, four}, two},
+		{[]*uint256.Int{two, two, four}, zero},
+		{[]*uint256.Int{zero, one, one}, zero},
 	}
 
 	for _, testOperand := range testOperands {
@@ -251,10 +253,10 @@ func TestAnd(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsLogical{
-		{[]*big.Int{one, one}, true},
-		{[]*big.Int{one, zero}, false},
-		{[]*big.Int{zero, one}, false},
-		{[]*big.Int{zero, zero}, false},
+		{[]*uint256.Int{one, one}, true},
+		{[]*uint256.Int{one, zero}, false},
+		{[]*uint256.Int{zero, one}, false},
+		{[]*uint256.Int{zero, zero}, false},
 	}
 	for _, testOperand := range testOperands {
 		testLogicalOperation(t, opAnd, testOperand, s)
@@ -266,10 +268,10 @@ func TestOr(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsLogical{
-		{[]*big.Int{one, one}, true},
-		{[]*big.Int{one, zero}, true},
-		{[]*big.Int{zero, one}, true},
-		{[]*big.Int{zero, zero}, false},
+		{[]*uint256.Int{one, one}, true},
+		{[]*uint256.Int{one, zero}, true},
+		{[]*uint256.Int{zero, one}, true},
+		{[]*uint256.Int{zero, zero}, false},
 	}
 	for _, testOperand := range testOperands {
 		testLogicalOperation(t, opOr, testOperand, s)
@@ -281,10 +283,10 @@ func TestXor(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsLogical{
-		{[]*big.Int{one, one}, false},
-		{[]*big.Int{one, zero}, true},
-		{[]*big.Int{zero, one}, true},
-		{[]*big.Int{zero, zero}, false},
+		{[]*uint256.Int{one, one}, false},
+		{[]*uint256.Int{one, zero}, true},
+		{[]*uint256.Int{zero, one}, true},
+		{[]*uint256.Int{zero, zero}, false},
 	}
 	for _, testOperand := range testOperands {
 		testLogicalOperation(t, opXor, testOperand, s)
@@ -296,10 +298,10 @@ func TestByte(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{one, big.NewInt(31)}, one},
-		{[]*big.Int{five, big.NewInt(31)}, five},
-		{[]*big.Int{two, big.NewInt(32)}, zero},
-		{[]*big.Int{one, big.NewInt(30)}, zero},
+		{[]*uint256.Int{one, uint256.NewInt(31)}, one},
+		{[]*uint256.Int{five, uint256.NewInt(31)}, five},
+		{[]*uint256.Int{two, uint256.NewInt(32)}, zero},
+		{[]*uint256.Int{one, uint256.NewInt(30)}, zero},
 	}
 
 	for _, testOperand := range testOperands {
@@ -312,8 +314,8 @@ func TestShl(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{three, one}, big.NewInt(6)},
-		{[]*big.Int{three, zero}, three},
+		{[]*uint256.Int{three, one}, uint256.NewInt(6)},
+		{[]*uint256.Int{three, zero}, three},
 	}
 
 	for _, testOperand := range testOperands {
@@ -326,9 +328,9 @@ func TestShr(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{five, one}, two},
-		{[]*big.Int{five, two}, one},
-		{[]*big.Int{five, zero}, five},
+		{[]*uint256.Int{five, one}, two},
+		{[]*uint256.Int{five, two}, one},
+		{[]*uint256.Int{five, zero}, five},
 	}
 
 	for _, testOperand := range testOperands {
@@ -341,9 +343,9 @@ func TestSar(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{five, one}, two},
-		{[]*big.Int{five, two}, one},
-		{[]*big.Int{five, zero}, five},
+		{[]*uint256.Int{five, one}, two},
+		{[]*uint256.Int{five, two}, one},
+		{[]*uint256.Int{five, zero}, five},
 	}
 
 	for _, testOperand := range testOperands {
@@ -390,9 +392,9 @@ func TestGt(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsLogical{
-		{[]*big.Int{one, one}, false},
-		{[]*big.Int{two, one}, false},
-		{[]*big.Int{one, two}, true},
+		{[]*uint256.Int{one, one}, false},
+		{[]*uint256.Int{two, one}, false},
+		{[]*uint256.Int{one, two}, true},
 	}
 
 	for _, testOperand := range testOperands {
@@ -405,9 +407,9 @@ func TestLt(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsLogical{
-		{[]*big.Int{one, one}, false},
-		{[]*big.Int{two, one}, true},
-		{[]*big.Int{one, two}, false},
+		{[]*uint256.Int{one, one}, false},
+		{[]*uint256.Int{two, one}, true},
+		{[]*uint256.Int{one, two}, false},
 	}
 
 	for _, testOperand := range testOperands {
@@ -420,10 +422,10 @@ func TestEq(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsLogical{
-		{[]*big.Int{zero, zero}, true},
-		{[]*big.Int{one, zero}, false},
-		{[]*big.Int{zero, one}, false},
-		{[]*big.Int{one, one}, true},
+		{[]*uint256.Int{zero, zero}, true},
+		{[]*uint256.Int{one, zero}, false},
+		{[]*uint256.Int{zero, one}, false},
+		{[]*uint256.Int{one, one}, true},
 	}
 
 	for _, testOperand := range testOperands {
@@ -436,9 +438,9 @@ func TestSlt(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsLogical{
-		{[]*big.Int{one, one}, false},
-		{[]*big.Int{zero, one}, false},
-		{[]*big.Int{one, zero}, true},
+		{[]*uint256.Int{one, one}, false},
+		{[]*uint256.Int{zero, one}, false},
+		{[]*uint256.Int{one, zero}, true},
 	}
 
 	for _, testOperand := range testOperands {
@@ -451,9 +453,9 @@ func TestSgt(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsLogical{
-		{[]*big.Int{one, one}, false},
-		{[]*big.Int{zero, one}, true},
-		{[]*big.Int{one, zero}, false},
+		{[]*uint256.Int{one, one}, false},
+		{[]*uint256.Int{zero, one}, true},
+		{[]*uint256.Int{one, zero}, false},
 	}
 
 	for _, testOperand := range testOperands {
@@ -466,17 +468,17 @@ func TestSignExtension(t *testing.T) {
 		s, cancelFn := getState(&chain.ForksInTime{})
 		defer cancelFn()
 
-		firstValue, ok := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639808", 10)
-		require.True(t, ok)
-		secondValue, ok := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129607168", 10)
-		require.True(t, ok)
-		thirdValue, ok := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913121251328", 10)
-		require.True(t, ok)
+		firstValue, err := uint256.FromDecimal("115792089237316195423570985008687907853269984665640564039457584007913129639808")
+		require.NoError(t, err)
+		secondValue, err := uint256.FromDecimal("115792089237316195423570985008687907853269984665640564039457584007913129607168")
+		require.NoError(t, err)
+		thirdValue, err := uint256.FromDecimal("115792089237316195423570985008687907853269984665640564039457584007913121251328")
+		require.NoError(t, err)
 
 		testOperands := []OperandsArithmetic{
-			{[]*big.Int{big.NewInt(128), zero}, firstValue},
-			{[]*big.Int{big.NewInt(32768), one}, secondValue},
-			{[]*big.Int{big.NewInt(8388608), two}, thirdValue},
+			{[]*uint256.Int{uint256.NewInt(128), zero}, firstValue},
+			{[]*uint256.Int{uint256.NewInt(32768), one}, secondValue},
+			{[]*uint256.Int{uint256.NewInt(8388608), two}, thirdValue},
 		}
 
 		for _, testOperand := range testOperands {
@@ -488,9 +490,9 @@ func TestSignExtension(t *testing.T) {
 		defer cancelFn()
 
 		testOperands := []OperandsArithmetic{
-			{[]*big.Int{one, two}, one},
-			{[]*big.Int{two, one}, two},
-			{[]*big.Int{two, zero}, two},
+			{[]*uint256.Int{one, two}, one},
+			{[]*uint256.Int{two, one}, two},
+			{[]*uint256.Int{two, zero}, two},
 		}
 
 		for _, testOperand := range testOperands {
@@ -504,10 +506,10 @@ func TestNot(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsArithmetic{
-		{[]*big.Int{big.NewInt(-1)}, zero},
-		{[]*big.Int{zero}, tt256m1},
-		{[]*big.Int{one}, new(big.Int).Sub(tt256m1, big.NewInt(1))},
-		{[]*big.Int{big.NewInt(10)}, new(big.Int).Sub(tt256m1, big.NewInt(10))},
+		{[]*uint256.Int{new(uint256.Int).SetAllOne()}, zero},
+		{[]*uint256.Int{zero}, new(uint256.Int).SetAllOne()},
+		{[]*uint256.Int{one}, new(uint256.Int).Sub(new(uint256.Int).SetAllOne(), uint256.NewInt(1))},
+		{[]*uint256.Int{uint256.NewInt(10)}, new(uint256.Int).Sub(new(uint256.Int).SetAllOne(), uint256.NewInt(10))},
 	}
 	for _, testOperand := range testOperands {
 		t.Log(testOperand.expectedResult)
@@ -520,9 +522,9 @@ func TestIsZero(t *testing.T) {
 	defer closeFn()
 
 	testOperands := []OperandsLogical{
-		{[]*big.Int{one, one}, false},
-		{[]*big.Int{zero, zero}, true},
-		{[]*big.Int{two, two}, false},
+		{[]*uint256.Int{one, one}, false},
+		{[]*uint256.Int{zero, zero}, true},
+		{[]*uint256.Int{two, two}, false},
 	}
 
 	for _, testOperand := range testOperands {
@@ -531,7 +533,7 @@ func TestIsZero(t *testing.T) {
 }
 
 func TestMStore(t *testing.T) {
-	offset := big.NewInt(62)
+	offset := uint256.NewInt(62)
 
 	s, closeFn := getState(&chain.ForksInTime{})
 	defer closeFn()
@@ -549,8 +551,8 @@ func TestMStore(t *testing.T) {
 }
 
 func TestMStore8(t *testing.T) {
-	offsetStore := big.NewInt(62)
-	offsetLoad := big.NewInt(31)
+	offsetStore := uint256.NewInt(62)
+	offsetLoad := uint256.NewInt(31)
 
 	s, closeFn := getState(&chain.ForksInTime{})
 	defer closeFn()
@@ -573,14 +575,14 @@ func TestSload(t *testing.T) {
 		defer closeFn()
 
 		mockHost := &mockHost{}
-		mockHost.On("GetStorage", mock.Anything, mock.Anything).Return(bigToHash(one)).Once()
+		mockHost.On("GetStorage", mock.Anything, mock.Anything).Return(toHash(one)).Once()
 		s.host = mockHost
 
 		s.push(one)
 
 		opSload(s)
 		assert.Equal(t, uint64(200), s.gas)
-		assert.Equal(t, bigToHash(one), bigToHash(s.pop()))
+		assert.Equal(t, toHash(one), toHash(s.pop()))
 	})
 
 	t.Run("EIP150", func(t *testing.T) {
@@ -588,14 +590,14 @@ func TestSload(t *testing.T) {
 		defer closeFn()
 
 		mockHost := &mockHost{}
-		mockHost.On("GetStorage", mock.Anything, mock.Anything).Return(bigToHash(one)).Once()
+		mockHost.On("GetStorage", mock.Anything, mock.Anything).Return(toHash(one)).Once()
 		s.host = mockHost
 
 		s.push(one)
 
 		opSload(s)
 		assert.Equal(t, uint64(800), s.gas)
-		assert.Equal(t, bigToHash(one), bigToHash(s.pop()))
+		assert.Equal(t, toHash(one), toHash(s.pop()))
 	})
 
 	t.Run("NoForks", func(t *testing.T) {
@@ -603,14 +605,14 @@ func TestSload(t *testing.T) {
 		defer closeFn()
 
 		mockHost := &mockHost{}
-		mockHost.On("GetStorage", mock.Anything, mock.Anything).Return(bigToHash(one)).Once()
+		mockHost.On("GetStorage", mock.Anything, mock.Anything).Return(toHash(one)).Once()
 		s.host = mockHost
 
 		s.push(one)
 
 		opSload(s)
 		assert.Equal(t, uint64(950), s.gas)
-		assert.Equal(t, bigToHash(one), bigToHash(s.pop()))
+		assert.Equal(t, toHash(one), toHash(s.pop()))
 	})
 }
 
@@ -730,7 +732,7 @@ func TestBalance(t *testing.T) {
 
 		opBalance(s)
 
-		assert.Equal(t, balance, s.pop())
+		assert.Equal(t, uint256.MustFromBig(balance), s.pop())
 		assert.Equal(t, gasLeft, s.gas)
 	})
 
@@ -744,7 +746,7 @@ func TestBalance(t *testing.T) {
 
 		opBalance(s)
 
-		assert.Equal(t, big.NewInt(100), s.pop())
+		assert.Equal(t, uint256.NewInt(100), s.pop())
 		assert.Equal(t, gasLeft, s.gas)
 	})
 
@@ -758,7 +760,7 @@ func TestBalance(t *testing.T) {
 
 		opBalance(s)
 
-		assert.Equal(t, balance, s.pop())
+		assert.Equal(t, uint256.MustFromBig(balance), s.pop())
 		assert.Equal(t, gasLeft, s.gas)
 	})
 }
@@ -776,7 +778,7 @@ func TestSelfBalance(t *testing.T) {
 
 		opSelfBalance(s)
 
-		assert.Equal(t, big.NewInt(100), s.pop())
+		assert.Equal(t, uint256.NewInt(100), s.pop())
 	})
 
 	t.Run("NoForkErrorExpected", func(t *testing.T) {
@@ -807,7 +809,7 @@ func TestChainID(t *testing.T) {
 
 		opChainID(s)
 
-		assert.Equal(t, big.NewInt(chainID), s.pop())
+		assert.Equal(t, uint256.NewInt(uint64(chainID)), s.pop())
 	})
 	t.Run("NoForksErrorExpected", func(t *testing.T) {
 		s, cancelFn := getState(&chain.ForksInTime{})
@@ -881,7 +883,7 @@ func TestCallDataLoad(t *testing.T) {
 
 	s.push(one)
 
-	s.msg = &runtime.Contract{Input: big.NewInt(7).Bytes()}
+	s.msg = &runtime.Contract{Input: uint256.NewInt(7).Bytes()}
 
 	opCallDataLoad(s)
 	assert.Equal(t, zero.Uint64(), s.pop().Uint64())
@@ -894,7 +896,7 @@ func TestCallDataSize(t *testing.T) {
 	s.msg.Input = make([]byte, 10)
 
 	opCallDataSize(s)
-	assert.Equal(t, big.NewInt(10), s.pop())
+	assert.Equal(t, uint256.NewInt(10), s.pop())
 }
 
 func TestCodeSize(t *testing.T) {
@@ -904,7 +906,7 @@ func TestCodeSize(t *testing.T) {
 	s.code = make([]byte, 10)
 
 	opCodeSize(s)
-	assert.Equal(t, big.NewInt(10), s.pop())
+	assert.Equal(t, uint256.NewInt(10), s.pop())
 }
 
 func TestExtCodeSize(t *testing.T) {
@@ -924,7 +926,7 @@ func TestExtCodeSize(t *testing.T) {
 		opExtCodeSize(s)
 
 		assert.Equal(t, gasLeft, s.gas)
-		assert.Equal(t, big.NewInt(int64(codeSize)), s.pop())
+		assert.Equal(t, uint256.NewInt(uint64(codeSize)), s.pop())
 	})
 	t.Run("NoForks", func(t *testing.T) {
 		gasLeft := uint64(980)
@@ -941,7 +943,7 @@ func TestExtCodeSize(t *testing.T) {
 		opExtCodeSize(s)
 
 		assert.Equal(t, gasLeft, s.gas)
-		assert.Equal(t, big.NewInt(int64(codeSize)), s.pop())
+		assert.Equal(t, uint256.NewInt(uint64(codeSize)), s.pop())
 	})
 }
 
@@ -952,12 +954,12 @@ func TestGasPrice(t *testing.T) {
 	defer cancelFn()
 
 	mockHost := &mockHost{}
-	mockHost.On("GetTxContext").Return(runtime.TxContext{GasPrice: bigToHash(big.NewInt(gasPrice))}).Once()
+	mockHost.On("GetTxContext").Return(runtime.TxContext{GasPrice: toHash(uint256.NewInt(uint64(gasPrice)))}).Once()
 	s.host = mockHost
 
 	opGasPrice(s)
 
-	assert.Equal(t, bigToHash(big.NewInt(gasPrice)), s.popHash())
+	assert.Equal(t, toHash(uint256.NewInt(uint64(gasPrice))), s.popHash())
 }
 
 func TestReturnDataSize(t *testing.T) {
@@ -971,7 +973,7 @@ func TestReturnDataSize(t *testing.T) {
 
 		opReturnDataSize(s)
 
-		assert.Equal(t, big.NewInt(dataSize), s.pop())
+		assert.Equal(t, uint256.NewInt(uint64(dataSize)), s.pop())
 	})
 	t.Run("NoForks", func(t *testing.T) {
 		s, cancelFn := getState(&chain.ForksInTime{})
@@ -1063,13 +1065,13 @@ func TestPCMSizeGas(t *testing.T) {
 
 		opMSize(s)
 
-		assert.Equal(t, new(big.Int).SetUint64(memorySize), s.pop())
+		assert.Equal(t, new(uint256.Int).SetUint64(memorySize), s.pop())
 	})
 
 	t.Run("Gas", func(t *testing.T) {
 		opGas(s)
 
-		assert.Equal(t, new(big.Int).SetUint64(gasLeft), s.pop())
+		assert.Equal(t, new(uint256.Int).SetUint64(gasLeft), s.pop())
 	})
 }
 
@@ -1086,13 +1088,13 @@ func TestExtCodeCopy(t *testing.T) {
 
 		s.push(one)
 		s.push(zero)
-		s.push(big.NewInt(31))
-		s.push(big.NewInt(32))
+		s.push(uint256.NewInt(31))
+		s.push(uint256.NewInt(32))
 
 		opExtCodeCopy(s)
 
 		assert.Equal(t, leftGas, s.gas)
-		assert.Equal(t, big.NewInt(1).FillBytes(make([]byte, 32)), s.memory)
+		assert.Equal(t, common.PadLeftOrTrim([]byte{1}, 32), s.memory)
 	})
 
 	t.Run("NonEIP150Fork", func(t *testing.T) {
@@ -1107,13 +1109,13 @@ func TestExtCodeCopy(t *testing.T) {
 
 		s.push(one)
 		s.push(zero)
-		s.push(big.NewInt(31))
-		s.push(big.NewInt(32))
+		s.push(uint256.NewInt(31))
+		s.push(uint256.NewInt(32))
 
 		opExtCodeCopy(s)
 
 		assert.Equal(t, leftGas, s.gas)
-		assert.Equal(t, big.NewInt(1).FillBytes(make([]byte, 32)), s.memory)
+		assert.Equal(t, common.PadLeftOrTrim([]byte{1}, 32), s.memory)
 	})
 }
 
@@ -1125,14 +1127,14 @@ func TestCallDataCopy(t *testing.T) {
 
 	s.msg.Input = one.Bytes()
 
-	s.push(big.NewInt(1))
+	s.push(uint256.NewInt(1))
 	s.push(zero)
-	s.push(big.NewInt(31))
+	s.push(uint256.NewInt(31))
 
 	opCallDataCopy(s)
 
 	assert.Equal(t, gasLeft, s.gas)
-	assert.Equal(t, big.NewInt(1).FillBytes(make([]byte, 32)), s.memory)
+	assert.Equal(t, common.PadLeftOrTrim([]byte{1}, 32), s.memory)
 }
 
 func TestCodeCopyLenZero(t *testing.T) {
@@ -1141,9 +1143,9 @@ func TestCodeCopyLenZero(t *testing.T) {
 
 	var expectedGas = s.gas
 
-	s.push(big.NewInt(0)) //length
-	s.push(big.NewInt(0)) //dataOffset
-	s.push(big.NewInt(0)) //memOffset
+	s.push(uint256.NewInt(0)) //length
+	s.push(uint256.NewInt(0)) //dataOffset
+	s.push(uint256.NewInt(0)) //memOffset
 
 	opCodeCopy(s)
 
@@ -1156,14 +1158,14 @@ func TestCodeCopy(t *testing.T) {
 	s, cancelFn := getState(&chain.ForksInTime{})
 	defer cancelFn()
 
-	s.push(big.NewInt(1))  //length
-	s.push(zero)           //dataOffset
-	s.push(big.NewInt(31)) //memOffset
+	s.push(uint256.NewInt(1))  //length
+	s.push(zero)               //dataOffset
+	s.push(uint256.NewInt(31)) //memOffset
 
 	s.code = one.Bytes()
 
 	opCodeCopy(s)
-	assert.Equal(t, one.FillBytes(make([]byte, 32)), s.memory)
+	assert.Equal(t, common.PadLeftOrTrim([]byte{1}, 32), s.memory)
 }
 
 func TestBlockHash(t *testing.T) {
@@ -1174,12 +1176,12 @@ func TestBlockHash(t *testing.T) {
 
 	mockHost := &mockHost{}
 	mockHost.On("GetTxContext").Return(runtime.TxContext{Number: 5}).Once()
-	mockHost.On("GetBlockHash", mock.Anything).Return(bigToHash(three)).Once()
+	mockHost.On("GetBlockHash", mock.Anything).Return(toHash(three)).Once()
 	s.host = mockHost
 
 	opBlockHash(s)
 
-	assert.Equal(t, bigToHash(three), bigToHash(s.pop()))
+	assert.Equal(t, toHash(three), toHash(s.pop()))
 }
 
 func TestCoinBase(t *testing.T) {
@@ -1192,7 +1194,7 @@ func TestCoinBase(t *testing.T) {
 
 	opCoinbase(s)
 
-	assert.Equal(t, types.StringToAddress("0x1").Bytes(), s.pop().FillBytes(make([]byte, 20)))
+	assert.Equal(t, types.StringToAddress("0x1").Bytes(), s.pop().PaddedBytes(20))
 }
 
 func TestTimeStamp(t *testing.T) {
@@ -1205,7 +1207,7 @@ func TestTimeStamp(t *testing.T) {
 
 	opTimestamp(s)
 
-	assert.Equal(t, big.NewInt(335), s.pop())
+	assert.Equal(t, uint256.NewInt(335), s.pop())
 }
 
 func TestNumber(t *testing.T) {
@@ -1226,12 +1228,12 @@ func TestDifficulty(t *testing.T) {
 	defer cancelFn()
 
 	mockHost := &mockHost{}
-	mockHost.On("GetTxContext").Return(runtime.TxContext{Difficulty: bigToHash(five)}).Once()
+	mockHost.On("GetTxContext").Return(runtime.TxContext{Difficulty: toHash(five)}).Once()
 	s.host = mockHost
 
 	opDifficulty(s)
 
-	assert.Equal(t, bigToHash(five), bigToHash(s.pop()))
+	assert.Equal(t, toHash(five), toHash(s.pop()))
 }
 
 func TestGasLimit(t *testing.T) {
@@ -1259,7 +1261,7 @@ func TestGasLimit(t *testing.T) {
 
 		opBaseFee(s)
 
-		assert.Equal(t, new(big.Int).SetUint64(baseFee), s.pop())
+		assert.Equal(t, new(uint256.Int).SetUint64(baseFee), s.pop())
 	})
 }
 
@@ -1293,7 +1295,7 @@ func TestJump(t *testing.T) {
 	defer cancelFn()
 
 	s.code = make([]byte, 10)
-	s.bitmap = bitmap{big.NewInt(255).Bytes()}
+	s.bitmap = bitmap{buf: uint256.NewInt(255).Bytes()}
 	s.push(five)
 
 	opJump(s)
@@ -1306,7 +1308,7 @@ func TestJumpI(t *testing.T) {
 	defer cancelFn()
 
 	s.code = make([]byte, 10)
-	s.bitmap = bitmap{big.NewInt(255).Bytes()}
+	s.bitmap = bitmap{buf: uint256.NewInt(255).Bytes()}
 	s.push(one)
 	s.push(five)
 
@@ -1322,7 +1324,7 @@ func TestDup(t *testing.T) {
 	s.sp = 6
 
 	for i := 0; i < 10; i++ {
-		s.stack = append(s.stack, big.NewInt(int64(i)))
+		s.stack = append(s.stack, *uint256.NewInt(uint64(i)))
 	}
 
 	instr := opDup(4)
@@ -1338,14 +1340,14 @@ func TestSwap(t *testing.T) {
 	s.sp = 6
 
 	for i := 0; i < 10; i++ {
-		s.stack = append(s.stack, big.NewInt(int64(i)))
+		s.stack = append(s.stack, *uint256.NewInt(uint64(i)))
 	}
 
 	instr := opSwap(4)
 	instr(s)
 
-	assert.Equal(t, five, s.stack[1])
-	assert.Equal(t, one, s.stack[6-1])
+	assert.Equal(t, five, &s.stack[1])
+	assert.Equal(t, one, &s.stack[6-1])
 }
 
 func TestLog(t *testing.T) {
@@ -1356,11 +1358,11 @@ func TestLog(t *testing.T) {
 		s.msg.Static = true
 		s.sp = 1
 
-		s.push(big.NewInt(3))
-		s.push(big.NewInt(20))
+		s.push(uint256.NewInt(3))
+		s.push(uint256.NewInt(20))
 
 		for i := 0; i < 20; i++ {
-			s.push(big.NewInt(int64(i)))
+			s.push(uint256.NewInt(uint64(i)))
 		}
 
 		instr := opLog(10)
@@ -1375,11 +1377,11 @@ func TestLog(t *testing.T) {
 
 		s.sp = 1
 
-		s.push(big.NewInt(3))
-		s.push(big.NewInt(20))
+		s.push(uint256.NewInt(3))
+		s.push(uint256.NewInt(20))
 
 		for i := 0; i < 20; i++ {
-			s.push(big.NewInt(int64(i)))
+			s.push(uint256.NewInt(uint64(i)))
 		}
 
 		instr := opLog(35)
@@ -1394,15 +1396,15 @@ func TestLog(t *testing.T) {
 
 		s.gas = 25000
 
-		s.push(big.NewInt(3))
-		s.push(big.NewInt(20))
+		s.push(uint256.NewInt(3))
+		s.push(uint256.NewInt(20))
 
 		mockHost := &mockHost{}
 		mockHost.On("EmitLog", mock.Anything, mock.Anything, mock.Anything).Once()
 		s.host = mockHost
 
 		for i := 0; i < 20; i++ {
-			s.push(big.NewInt(int64(i)))
+			s.push(uint256.NewInt(uint64(i)))
 		}
 
 		instr := opLog(10)
@@ -1433,6 +1435,10 @@ func (m *mockHostForInstructions) GetCode(addr types.Address) []byte {
 	return m.code
 }
 
+func (m *mockHostForInstructions) GetCodeHash(addr types.Address) types.Hash {
+	return crypto.Keccak256Hash(m.code)
+}
+
 func (m *mockHostForInstructions) GetStorage(addr types.Address, key types.Hash) types.Hash {
 	idx, ok := m.addresses[addr]
 	if !ok {
@@ -1457,7 +1463,7 @@ func Test_opSload(t *testing.T) {
 	type state struct {
 		gas        uint64
 		sp         int
-		stack      []*big.Int
+		stack      []uint256.Int
 		memory     []byte
 		accessList *runtime.AccessList
 		stop       bool
@@ -1488,8 +1494,8 @@ func Test_opSload(t *testing.T) {
 			initState: &state{
 				gas: 10000,
 				sp:  1,
-				stack: []*big.Int{
-					new(big.Int).SetBytes(key1.Bytes()),
+				stack: []uint256.Int{
+					*new(uint256.Int).SetBytes(key1.Bytes()),
 				},
 				memory:     []byte{0x01},
 				accessList: runtime.NewAccessList(),
@@ -1497,8 +1503,8 @@ func Test_opSload(t *testing.T) {
 			resultState: &state{
 				gas: 7900,
 				sp:  1,
-				stack: []*big.Int{
-					new(big.Int).SetBytes(val1.Bytes()),
+				stack: []uint256.Int{
+					*new(uint256.Int).SetBytes(val1.Bytes()),
 				},
 				memory: []byte{0x01},
 				stop:   false,
@@ -1535,8 +1541,8 @@ func Test_opSload(t *testing.T) {
 			initState: &state{
 				gas: 10000,
 				sp:  1,
-				stack: []*big.Int{
-					new(big.Int).SetBytes(key1.Bytes()),
+				stack: []uint256.Int{
+					*new(uint256.Int).SetBytes(key1.Bytes()),
 				},
 				memory: []byte{0x01},
 				accessList: &runtime.AccessList{
@@ -1548,8 +1554,8 @@ func Test_opSload(t *testing.T) {
 			resultState: &state{
 				gas: 9900,
 				sp:  1,
-				stack: []*big.Int{
-					new(big.Int).SetBytes(val1.Bytes()),
+				stack: []uint256.Int{
+					*new(uint256.Int).SetBytes(val1.Bytes()),
 				},
 				memory: []byte{0x01},
 				stop:   false,
@@ -1587,8 +1593,8 @@ func Test_opSload(t *testing.T) {
 			initState: &state{
 				gas: 10000,
 				sp:  1,
-				stack: []*big.Int{
-					new(big.Int).SetBytes(key1.Bytes()),
+				stack: []uint256.Int{
+					*new(uint256.Int).SetBytes(key1.Bytes()),
 				},
 				memory:     []byte{0x01},
 				accessList: nil,
@@ -1596,8 +1602,8 @@ func Test_opSload(t *testing.T) {
 			resultState: &state{
 				gas: 9200,
 				sp:  1,
-				stack: []*big.Int{
-					new(big.Int).SetBytes(val1.Bytes()),
+				stack: []uint256.Int{
+					*new(uint256.Int).SetBytes(val1.Bytes()),
 				},
 				memory:     []byte{0x01},
 				stop:       false,
@@ -1654,14 +1660,14 @@ func TestCreate(t *testing.T) {
 	type state struct {
 		gas    uint64
 		sp     int
-		stack  []*big.Int
+		stack  []uint256.Int
 		memory []byte
 		stop   bool
 		err    error
 	}
 
-	addressToBigInt := func(addr types.Address) *big.Int {
-		return new(big.Int).SetBytes(addr[:])
+	addressToBigInt := func(addr types.Address) *uint256.Int {
+		return new(uint256.Int).SetBytes(addr[:])
 	}
 
 	tests := []struct {
@@ -1684,10 +1690,10 @@ func TestCreate(t *testing.T) {
 			initState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
+				stack: []uint256.Int{
+					*uint256.NewInt(0x01), // length
+					*uint256.NewInt(0x00), // offset
+					*uint256.NewInt(0x00), // value
 				},
 				memory: []byte{
 					byte(REVERT),
@@ -1696,10 +1702,10 @@ func TestCreate(t *testing.T) {
 			resultState: &state{
 				gas: 500,
 				sp:  1,
-				stack: []*big.Int{
-					addressToBigInt(crypto.CreateAddress(addr1, 0)), // contract address
-					big.NewInt(0x00),
-					big.NewInt(0x00),
+				stack: []uint256.Int{
+					*addressToBigInt(crypto.CreateAddress(addr1, 0)), // contract address
+					*uint256.NewInt(0x00),
+					*uint256.NewInt(0x00),
 				},
 				memory: []byte{
 					byte(REVERT),
@@ -1726,10 +1732,10 @@ func TestCreate(t *testing.T) {
 			initState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
+				stack: []uint256.Int{
+					*uint256.NewInt(0x01), // length
+					*uint256.NewInt(0x00), // offset
+					*uint256.NewInt(0x00), // value
 				},
 				memory: []byte{
 					byte(REVERT),
@@ -1741,10 +1747,10 @@ func TestCreate(t *testing.T) {
 			resultState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
+				stack: []uint256.Int{
+					*uint256.NewInt(0x01), // length
+					*uint256.NewInt(0x00), // offset
+					*uint256.NewInt(0x00), // value
 				},
 				memory: []byte{
 					byte(REVERT),
@@ -1768,10 +1774,10 @@ func TestCreate(t *testing.T) {
 			initState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
+				stack: []uint256.Int{
+					*uint256.NewInt(0x01), // length
+					*uint256.NewInt(0x00), // offset
+					*uint256.NewInt(0x00), // value
 				},
 				memory: []byte{
 					byte(REVERT),
@@ -1783,10 +1789,10 @@ func TestCreate(t *testing.T) {
 			resultState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
+				stack: []uint256.Int{
+					*uint256.NewInt(0x01), // length
+					*uint256.NewInt(0x00), // offset
+					*uint256.NewInt(0x00), // value
 				},
 				memory: []byte{
 					byte(REVERT),
@@ -1813,10 +1819,10 @@ func TestCreate(t *testing.T) {
 			initState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
+				stack: []uint256.Int{
+					*uint256.NewInt(0x01), // length
+					*uint256.NewInt(0x00), // offset
+					*uint256.NewInt(0x00), // value
 				},
 				memory: []byte{
 					byte(REVERT),
@@ -1828,11 +1834,11 @@ func TestCreate(t *testing.T) {
 			resultState: &state{
 				gas: 1000,
 				sp:  1,
-				stack: []*big.Int{
+				stack: []uint256.Int{
 					// need to init with 0x01 to add abs field in big.Int
-					big.NewInt(0x01).SetInt64(0x00),
-					big.NewInt(0x00),
-					big.NewInt(0x00),
+					*uint256.NewInt(0x01).SetUint64(0x00),
+					*uint256.NewInt(0x00),
+					*uint256.NewInt(0x00),
 				},
 				memory: []byte{
 					byte(REVERT),
@@ -1864,10 +1870,10 @@ func TestCreate(t *testing.T) {
 			initState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
+				stack: []uint256.Int{
+					*uint256.NewInt(0x01), // length
+					*uint256.NewInt(0x00), // offset
+					*uint256.NewInt(0x00), // value
 				},
 				memory: []byte{
 					byte(REVERT),
@@ -1879,11 +1885,11 @@ func TestCreate(t *testing.T) {
 			resultState: &state{
 				gas: 1000,
 				sp:  1,
-				stack: []*big.Int{
+				stack: []uint256.Int{
 					// need to init with 0x01 to add abs field in big.Int
-					big.NewInt(0x01).SetInt64(0x00),
-					big.NewInt(0x00),
-					big.NewInt(0x00),
+					*uint256.NewInt(0x01).SetUint64(0x00),
+					*uint256.NewInt(0x00),
+					*uint256.NewInt(0x00),
 				},
 				memory: []byte{
 					byte(REVERT),
@@ -1916,11 +1922,11 @@ func TestCreate(t *testing.T) {
 			initState: &state{
 				gas: 1000,
 				sp:  4,
-				stack: []*big.Int{
-					big.NewInt(0x01), // salt
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
+				stack: []uint256.Int{
+					*uint256.NewInt(0x01), // salt
+					*uint256.NewInt(0x01), // length
+					*uint256.NewInt(0x00), // offset
+					*uint256.NewInt(0x00), // value
 				},
 				memory: []byte{
 					byte(REVERT),
@@ -1932,11 +1938,11 @@ func TestCreate(t *testing.T) {
 			resultState: &state{
 				gas: 15,
 				sp:  1,
-				stack: []*big.Int{
-					big.NewInt(0x01).SetInt64(0x00),
-					big.NewInt(0x01),
-					big.NewInt(0x00),
-					big.NewInt(0x00),
+				stack: []uint256.Int{
+					*uint256.NewInt(0x01).SetUint64(0x00),
+					*uint256.NewInt(0x01),
+					*uint256.NewInt(0x00),
+					*uint256.NewInt(0x00),
 				},
 				memory: []byte{
 					byte(REVERT),
@@ -1988,13 +1994,11 @@ func Test_opReturnDataCopy(t *testing.T) {
 
 	// Positive number that does not fit in uint64 (math.MaxUint64 + 1)
 	largeNumber := "18446744073709551616"
-	bigIntValue := new(big.Int)
-	bigIntValue.SetString(largeNumber, 10)
+	bigIntValue := uint256.MustFromDecimal(largeNumber)
 
 	// Positive number that does fit in uint64 but multiplied by two does not
 	largeNumber2 := "18446744073709551615"
-	bigIntValue2 := new(big.Int)
-	bigIntValue2.SetString(largeNumber2, 10)
+	bigIntValue2 := uint256.MustFromDecimal(largeNumber2)
 
 	tests := []struct {
 		name        string
@@ -2020,20 +2024,20 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should return error if memOffset is negative",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(1),  // length
-					big.NewInt(0),  // dataOffset
-					big.NewInt(-1), // memOffset
+				stack: []uint256.Int{
+					*uint256.NewInt(1),            // length
+					*uint256.NewInt(0),            // dataOffset
+					*new(uint256.Int).SetAllOne(), // memOffset
 				},
 				sp:         3,
 				returnData: []byte{0xff},
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(1),
-					big.NewInt(0),
-					big.NewInt(-1),
+				stack: []uint256.Int{
+					*uint256.NewInt(1),
+					*uint256.NewInt(0),
+					*new(uint256.Int).SetAllOne(),
 				},
 				sp:         0,
 				returnData: []byte{0xff},
@@ -2045,20 +2049,20 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should return error if dataOffset is negative",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(1),  // length
-					big.NewInt(-1), // dataOffset
-					big.NewInt(0),  // memOffset
+				stack: []uint256.Int{
+					*uint256.NewInt(1),            // length
+					*new(uint256.Int).SetAllOne(), // dataOffset
+					*uint256.NewInt(0),            // memOffset
 				},
 				sp:     3,
 				memory: make([]byte, 1),
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(1),
-					big.NewInt(-1),
-					big.NewInt(0),
+				stack: []uint256.Int{
+					*uint256.NewInt(1),
+					*new(uint256.Int).SetAllOne(),
+					*uint256.NewInt(0),
 				},
 				sp:     0,
 				memory: make([]byte, 1),
@@ -2070,20 +2074,20 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should return error if length is negative",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(-1), // length
-					big.NewInt(2),  // dataOffset
-					big.NewInt(0),  // memOffset
+				stack: []uint256.Int{
+					*new(uint256.Int).SetAllOne(), // length
+					*uint256.NewInt(2),            // dataOffset
+					*uint256.NewInt(0),            // memOffset
 				},
 				sp:         3,
 				returnData: []byte{0xff},
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(-1),
-					big.NewInt(2),
-					big.NewInt(0),
+				stack: []uint256.Int{
+					*new(uint256.Int).SetAllOne(),
+					*uint256.NewInt(2),
+					*uint256.NewInt(0),
 				},
 				sp:         0,
 				returnData: []byte{0xff},
@@ -2095,10 +2099,10 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should copy data from returnData to memory",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(1), // length
-					big.NewInt(0), // dataOffset
-					big.NewInt(0), // memOffset
+				stack: []uint256.Int{
+					*uint256.NewInt(1), // length
+					*uint256.NewInt(0), // dataOffset
+					*uint256.NewInt(0), // memOffset
 				},
 				sp:         3,
 				returnData: []byte{0xff},
@@ -2107,10 +2111,10 @@ func Test_opReturnDataCopy(t *testing.T) {
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(1),
-					big.NewInt(0),
-					big.NewInt(0),
+				stack: []uint256.Int{
+					*uint256.NewInt(1),
+					*uint256.NewInt(0),
+					*uint256.NewInt(0),
 				},
 				sp:                 0,
 				returnData:         []byte{0xff},
@@ -2127,10 +2131,10 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should not copy data if length is zero",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(0), // length
-					big.NewInt(0), // dataOffset
-					big.NewInt(4), // memOffset
+				stack: []uint256.Int{
+					*uint256.NewInt(0), // length
+					*uint256.NewInt(0), // dataOffset
+					*uint256.NewInt(4), // memOffset
 				},
 				sp:         3,
 				returnData: []byte{0x01},
@@ -2138,10 +2142,10 @@ func Test_opReturnDataCopy(t *testing.T) {
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(0),
-					big.NewInt(0),
-					big.NewInt(4),
+				stack: []uint256.Int{
+					*uint256.NewInt(0),
+					*uint256.NewInt(0),
+					*uint256.NewInt(4),
 				},
 				sp:         0,
 				returnData: []byte{0x01},
@@ -2154,19 +2158,19 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should return error if data offset overflows uint64",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(1),  // length
-					bigIntValue,    // dataOffset
-					big.NewInt(-1), // memOffset
+				stack: []uint256.Int{
+					*uint256.NewInt(1),            // length
+					*bigIntValue,                  // dataOffset
+					*new(uint256.Int).SetAllOne(), // memOffset
 				},
 				sp: 3,
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(1),
-					bigIntValue,
-					big.NewInt(-1),
+				stack: []uint256.Int{
+					*uint256.NewInt(1),
+					*bigIntValue,
+					*new(uint256.Int).SetAllOne(),
 				},
 				sp:   0,
 				stop: true,
@@ -2177,19 +2181,19 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should return error if sum of data offset and length overflows uint64",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					bigIntValue2,   // length
-					bigIntValue2,   // dataOffset
-					big.NewInt(-1), // memOffset
+				stack: []uint256.Int{
+					*bigIntValue2,                 // length
+					*bigIntValue2,                 // dataOffset
+					*new(uint256.Int).SetAllOne(), // memOffset
 				},
 				sp: 3,
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					bigIntValue2,
-					bigIntValue2,
-					big.NewInt(-1),
+				stack: []uint256.Int{
+					*bigIntValue2,
+					*bigIntValue2,
+					*new(uint256.Int).SetAllOne(),
 				},
 				sp:   0,
 				stop: true,
@@ -2200,10 +2204,10 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should return error if the length of return data does not have enough space to receive offset + length bytes",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(2), // length
-					big.NewInt(0), // dataOffset
-					big.NewInt(0), // memOffset
+				stack: []uint256.Int{
+					*uint256.NewInt(2), // length
+					*uint256.NewInt(0), // dataOffset
+					*uint256.NewInt(0), // memOffset
 				},
 				sp:         3,
 				returnData: []byte{0xff},
@@ -2211,10 +2215,10 @@ func Test_opReturnDataCopy(t *testing.T) {
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(2),
-					big.NewInt(0),
-					big.NewInt(0),
+				stack: []uint256.Int{
+					*uint256.NewInt(2),
+					*uint256.NewInt(0),
+					*uint256.NewInt(0),
 				},
 				sp:         0,
 				returnData: []byte{0xff},
@@ -2227,10 +2231,10 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should return error if there is no gas",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(1), // length
-					big.NewInt(0), // dataOffset
-					big.NewInt(0), // memOffset
+				stack: []uint256.Int{
+					*uint256.NewInt(1), // length
+					*uint256.NewInt(0), // dataOffset
+					*uint256.NewInt(0), // memOffset
 				},
 				sp:         3,
 				returnData: []byte{0xff},
@@ -2239,10 +2243,10 @@ func Test_opReturnDataCopy(t *testing.T) {
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(1),
-					big.NewInt(0),
-					big.NewInt(0),
+				stack: []uint256.Int{
+					*uint256.NewInt(1),
+					*uint256.NewInt(0),
+					*uint256.NewInt(0),
 				},
 				sp:                 0,
 				returnData:         []byte{0xff},
@@ -2307,7 +2311,7 @@ func CompareStates(a *state, b *state) bool {
 	}
 
 	for i := range a.stack {
-		if a.stack[i].Cmp(b.stack[i]) != 0 {
+		if a.stack[i].Cmp(&b.stack[i]) != 0 {
 			return false
 		}
 	}
@@ -2338,13 +2342,13 @@ func Test_opCall(t *testing.T) {
 			initState: &state{
 				gas: 2600,
 				sp:  6,
-				stack: []*big.Int{
-					big.NewInt(0x00), // outSize
-					big.NewInt(0x02), // outOffset
-					big.NewInt(0x00), // inSize
-					big.NewInt(0x00), // inOffset
-					big.NewInt(0x00), // address
-					big.NewInt(0x00), // initialGas
+				stack: []uint256.Int{
+					*uint256.NewInt(0x00), // outSize
+					*uint256.NewInt(0x02), // outOffset
+					*uint256.NewInt(0x00), // inSize
+					*uint256.NewInt(0x00), // inOffset
+					*uint256.NewInt(0x00), // address
+					*uint256.NewInt(0x00), // initialGas
 				},
 				memory: []byte{0x01},
 			},
@@ -2372,14 +2376,14 @@ func Test_opCall(t *testing.T) {
 		// 	initState: &state{
 		// 		gas: 6640,
 		// 		sp:  7,
-		// 		stack: []*big.Int{
-		// 			big.NewInt(0x00),                        // outSize
-		// 			big.NewInt(0x00),                        // outOffset
-		// 			big.NewInt(0x00),                        // inSize
-		// 			big.NewInt(0x00),                        // inOffset
-		// 			big.NewInt(0x01),                        // value
-		// 			big.NewInt(0x03),                        // address
-		// 			big.NewInt(0).SetUint64(math.MaxUint64), // initialGas
+		// 		stack: []*uint256.Int{
+		// 			uint256.NewInt(0x00),                        // outSize
+		// 			uint256.NewInt(0x00),                        // outOffset
+		// 			uint256.NewInt(0x00),                        // inSize
+		// 			uint256.NewInt(0x00),                        // inOffset
+		// 			uint256.NewInt(0x01),                        // value
+		// 			uint256.NewInt(0x03),                        // address
+		// 			uint256.NewInt(0).SetUint64(math.MaxUint64), // initialGas
 		// 		},
 		// 		memory:     []byte{0x01},
 		// 		accessList: runtime.NewAccessList(),
@@ -2406,14 +2410,14 @@ func Test_opCall(t *testing.T) {
 		// 	initState: &state{
 		// 		gas: 6640,
 		// 		sp:  7,
-		// 		stack: []*big.Int{
-		// 			big.NewInt(0x00),                        // outSize
-		// 			big.NewInt(0x00),                        // outOffset
-		// 			big.NewInt(0x00),                        // inSize
-		// 			big.NewInt(0x00),                        // inOffset
-		// 			big.NewInt(0x01),                        // value
-		// 			big.NewInt(0x03),                        // address
-		// 			big.NewInt(0).SetUint64(math.MaxUint64), // initialGas
+		// 		stack: []*uint256.Int{
+		// 			uint256.NewInt(0x00),                        // outSize
+		// 			uint256.NewInt(0x00),                        // outOffset
+		// 			uint256.NewInt(0x00),                        // inSize
+		// 			uint256.NewInt(0x00),                        // inOffset
+		// 			uint256.NewInt(0x01),                        // value
+		// 			uint256.NewInt(0x03),                        // address
+		// 			uint256.NewInt(0).SetUint64(math.MaxUint64), // initialGas
 		// 		},
 		// 		memory:     []byte{0x01},
 		// 		accessList: runtime.NewAccessList(),
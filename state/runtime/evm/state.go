@@ -2,7 +2,6 @@ package evm
 
 import (
 	"errors"
-	"math/big"
 	"strings"
 
 	"sync"
@@ -12,6 +11,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 )
 
 var statePool = sync.Pool{
@@ -62,7 +62,7 @@ type state struct {
 	lastGasCost uint64
 
 	// stack
-	stack []*big.Int
+	stack []uint256.Int
 	sp    int
 
 	err  error
@@ -76,8 +76,6 @@ type state struct {
 
 	returnData []byte
 	ret        []byte
-
-	unsafepool common.UnsafePool[*big.Int]
 }
 
 func (c *state) reset() {
@@ -97,14 +95,6 @@ func (c *state) reset() {
 		c.memory[i] = 0
 	}
 
-	// Before stack cleanup, return instances of big.Int to the pool
-	// for the future usage
-	for i := range c.stack {
-		c.unsafepool.Put(func(x *big.Int) *big.Int {
-			return x.SetInt64(0)
-		}, c.stack[i])
-	}
-
 	c.stack = c.stack[:0]
 	c.tmp = c.tmp[:0]
 	c.ret = c.ret[:0]
@@ -113,9 +103,9 @@ func (c *state) reset() {
 	c.memory = c.memory[:0]
 }
 
-func (c *state) validJumpdest(dest *big.Int) bool {
-	udest := dest.Uint64()
-	if dest.BitLen() >= 63 || udest >= uint64(len(c.code)) {
+func (c *state) validJumpdest(dest *uint256.Int) bool {
+	udest, overflow := dest.Uint64WithOverflow()
+	if overflow || udest >= uint64(len(c.code)) {
 		return false
 	}
 
@@ -135,25 +125,23 @@ func (c *state) exit(err error) {
 	c.err = err
 }
 
-func (c *state) push(val *big.Int) {
+func (c *state) push(val *uint256.Int) {
 	c.push1().Set(val)
 }
 
-func (c *state) push1() *big.Int {
+// push1 grows the stack by one element and returns it,
+// the returned element might hold a stale value and has to be set by the caller
+func (c *state) push1() *uint256.Int {
 	if len(c.stack) > c.sp {
 		c.sp++
 
-		return c.stack[c.sp-1]
+		return &c.stack[c.sp-1]
 	}
 
-	v := c.unsafepool.Get(func() *big.Int {
-		return big.NewInt(0)
-	})
-
-	c.stack = append(c.stack, v)
+	c.stack = append(c.stack, uint256.Int{})
 	c.sp++
 
-	return v
+	return &c.stack[len(c.stack)-1]
 }
 
 func (c *state) stackAtLeast(n int) bool {
@@ -161,7 +149,7 @@ func (c *state) stackAtLeast(n int) bool {
 }
 
 func (c *state) popHash() types.Hash {
-	return types.BytesToHash(c.pop().Bytes())
+	return c.pop().Bytes32()
 }
 
 func (c *state) popAddr() (types.Address, bool) {
@@ -170,34 +158,34 @@ func (c *state) popAddr() (types.Address, bool) {
 		return types.Address{}, false
 	}
 
-	return types.BytesToAddress(b.Bytes()), true
+	return b.Bytes20(), true
 }
 
 func (c *state) stackSize() int {
 	return c.sp
 }
 
-func (c *state) top() *big.Int {
+func (c *state) top() *uint256.Int {
 	if c.sp == 0 {
 		return nil
 	}
 
-	return c.stack[c.sp-1]
+	return &c.stack[c.sp-1]
 }
 
-func (c *state) pop() *big.Int {
+func (c *state) pop() *uint256.Int {
 	if c.sp == 0 {
 		return nil
 	}
 
-	o := c.stack[c.sp-1]
+	o := &c.stack[c.sp-1]
 	c.sp--
 
 	return o
 }
 
-func (c *state) peekAt(n int) *big.Int {
-	return c.stack[c.sp-n]
+func (c *state) peekAt(n int) *uint256.Int {
+	return &c.stack[c.sp-n]
 }
 
 func (c *state) swap(n int) {
@@ -293,8 +281,8 @@ func (c *state) inStaticCall() bool {
 	return c.msg.Static
 }
 
-func bigToHash(b *big.Int) types.Hash {
-	return types.BytesToHash(b.Bytes())
+func toHash(b *uint256.Int) types.Hash {
+	return b.Bytes32()
 }
 
 func (c *state) Len() int {
@@ -304,14 +292,14 @@ func (c *state) Len() int {
 // allocateMemory allocates memory to enable accessing in the range of [offset, offset+size]
 // throws error if the given offset and size are negative
 // consumes gas if memory needs to be expanded
-func (c *state) allocateMemory(offset, size *big.Int) bool {
+func (c *state) allocateMemory(offset, size *uint256.Int) bool {
 	if !offset.IsUint64() || !size.IsUint64() {
 		c.exit(errReturnDataOutOfBounds)
 
 		return false
 	}
 
-	if size.Sign() == 0 {
+	if size.IsZero() {
 		return true
 	}
 
@@ -341,8 +329,8 @@ func (c *state) allocateMemory(offset, size *big.Int) bool {
 	return true
 }
 
-func (c *state) get2(dst []byte, offset, length *big.Int) ([]byte, bool) {
-	if length.Sign() == 0 {
+func (c *state) get2(dst []byte, offset, length *uint256.Int) ([]byte, bool) {
+	if length.IsZero() {
 		return nil, true
 	}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 )
 
 // TxContext is the context of the transaction
@@ -91,7 +92,7 @@ type Host interface {
 type VMTracer interface {
 	CaptureState(
 		memory []byte,
-		stack []*big.Int,
+		stack []uint256.Int,
 		opCode int,
 		contractAddress types.Address,
 		sp int,
@@ -196,6 +197,7 @@ type Runtime interface {
 // Contract is the instance being called
 type Contract struct {
 	Code        []byte
+	CodeHash    types.Hash // not set for the init code of contract creations
 	Type        CallType
 	CodeAddress types.Address
 	Address     types.Address
@@ -1,12 +1,16 @@
 package calltracer
 
 import (
+	"errors"
 	"math/big"
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
+	"github.com/umbracle/ethgo/abi"
 )
 
 var (
@@ -20,32 +24,64 @@ var (
 	}
 )
 
+// Config is the configuration of the call tracer
+type Config struct {
+	// WithLog attaches the logs emitted by each call to its frame
+	WithLog bool
+	// OnlyTopCall skips tracing of the nested calls
+	OnlyTopCall bool
+}
+
 type Call struct {
-	Type    string  `json:"type"`
-	From    string  `json:"from"`
-	To      string  `json:"to"`
-	Value   string  `json:"value,omitempty"`
-	Gas     string  `json:"gas"`
-	GasUsed string  `json:"gasUsed"`
-	Input   string  `json:"input"`
-	Output  string  `json:"output"`
-	Calls   []*Call `json:"calls,omitempty"`
+	Type         string     `json:"type"`
+	From         string     `json:"from"`
+	To           string     `json:"to"`
+	Value        string     `json:"value,omitempty"`
+	Gas          string     `json:"gas"`
+	GasUsed      string     `json:"gasUsed"`
+	Input        string     `json:"input"`
+	Output       string     `json:"output"`
+	Error        string     `json:"error,omitempty"`
+	RevertReason string     `json:"revertReason,omitempty"`
+	Logs         []*CallLog `json:"logs,omitempty"`
+	Calls        []*Call    `json:"calls,omitempty"`
 
 	parent   *Call
 	startGas uint64
 }
 
+// CallLog is a log emitted during the execution of a call
+type CallLog struct {
+	Address types.Address `json:"address"`
+	Topics  []types.Hash  `json:"topics"`
+	Data    string        `json:"data"`
+	// Position is the number of the sub calls made before the log was emitted
+	Position string `json:"position"`
+}
+
 type CallTracer struct {
+	Config Config
+
 	call               *Call
 	activeCall         *Call
 	activeGas          uint64
 	activeAvailableGas uint64
 
+	// depth is the depth of the call currently being executed,
+	// it's tracked separately since nested calls might not be recorded
+	depth int
+
 	cancelLock sync.RWMutex
 	reason     error
 	stop       bool
 }
 
+func NewCallTracer(config Config) *CallTracer {
+	return &CallTracer{
+		Config: config,
+	}
+}
+
 func (c *CallTracer) Cancel(err error) {
 	c.cancelLock.Lock()
 	defer c.cancelLock.Unlock()
@@ -64,6 +100,7 @@ func (c *CallTracer) cancelled() bool {
 func (c *CallTracer) Clear() {
 	c.call = nil
 	c.activeCall = nil
+	c.depth = 0
 }
 
 func (c *CallTracer) GetResult() (interface{}, error) {
@@ -85,7 +122,9 @@ func (c *CallTracer) TxEnd(gasLeft uint64) {
 
 func (c *CallTracer) CallStart(depth int, from, to types.Address, callType int,
 	gas uint64, value *big.Int, input []byte) {
-	if c.cancelled() {
+	c.depth = depth
+
+	if c.cancelled() || c.skipped(depth) {
 		return
 	}
 
@@ -123,6 +162,12 @@ func (c *CallTracer) CallStart(depth int, from, to types.Address, callType int,
 }
 
 func (c *CallTracer) CallEnd(depth int, output []byte, err error) {
+	c.depth = depth - 1
+
+	if c.activeCall == nil || c.skipped(depth) {
+		return
+	}
+
 	c.activeCall.Output = hex.EncodeToHex(output)
 
 	gasUsed := uint64(0)
@@ -134,24 +179,60 @@ func (c *CallTracer) CallEnd(depth int, output []byte, err error) {
 	c.activeCall.GasUsed = hex.EncodeUint64(gasUsed)
 	c.activeGas = 0
 
+	if err != nil {
+		c.activeCall.Error = err.Error()
+
+		if errors.Is(err, runtime.ErrExecutionReverted) {
+			if reason, unpackErr := abi.UnpackRevertError(output); unpackErr == nil {
+				c.activeCall.RevertReason = reason
+			}
+		}
+
+		// logs of the failed call and its sub calls are reverted
+		c.activeCall.clearLogs()
+	}
+
 	if depth > 1 {
 		c.activeCall = c.activeCall.parent
 	}
+}
 
-	if err != nil {
-		c.Cancel(err)
+func (c *CallTracer) CaptureLog(contractAddress types.Address, topics []types.Hash, data []byte) {
+	if !c.Config.WithLog || c.activeCall == nil || c.cancelled() || c.skipped(c.depth) {
+		return
 	}
+
+	c.activeCall.Logs = append(c.activeCall.Logs, &CallLog{
+		Address:  contractAddress,
+		Topics:   append([]types.Hash{}, topics...),
+		Data:     hex.EncodeToHex(data),
+		Position: hex.EncodeUint64(uint64(len(c.activeCall.Calls))),
+	})
 }
 
-func (c *CallTracer) CaptureState(memory []byte, stack []*big.Int, opCode int,
+func (c *CallTracer) CaptureState(memory []byte, stack []uint256.Int, opCode int,
 	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
 	if c.cancelled() {
 		state.Halt()
 	}
 }
 
+// skipped returns true if the call at the given depth is not recorded
+func (c *CallTracer) skipped(depth int) bool {
+	return c.Config.OnlyTopCall && depth > 1
+}
+
 func (c *CallTracer) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
 	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
 	c.activeGas += cost
 	c.activeAvailableGas = availableGas
 }
+
+// clearLogs removes the logs of the call and all of its sub calls
+func (c *Call) clearLogs() {
+	c.Logs = nil
+
+	for _, call := range c.Calls {
+		call.clearLogs()
+	}
+}
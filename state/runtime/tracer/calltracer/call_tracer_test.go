@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/require"
 )
@@ -226,8 +227,8 @@ func TestCallTracer_CallEnd(t *testing.T) {
 		require.Equal(t, uint64(0), tracer.activeGas)
 		require.Equal(t, hex.EncodeToHex(output), tracer.activeCall.Output)
 		require.Equal(t, "0x0", tracer.activeCall.GasUsed)
-		require.True(t, tracer.stop)
-		require.Equal(t, err, tracer.reason)
+		require.Equal(t, err.Error(), tracer.activeCall.Error)
+		require.False(t, tracer.cancelled())
 	})
 
 	t.Run("call_end_when_depth_is_1_no_error_activeAvailableGas_lower_than_start_gas", func(t *testing.T) {
@@ -260,8 +261,8 @@ func TestCallTracer_CallEnd(t *testing.T) {
 		require.Equal(t, uint64(0), tracer.activeGas)
 		require.Equal(t, hex.EncodeToHex(output), tracer.activeCall.Output)
 		require.Equal(t, hex.EncodeUint64(1000), tracer.activeCall.GasUsed)
-		require.True(t, tracer.stop)
-		require.Equal(t, err, tracer.reason)
+		require.Equal(t, err.Error(), tracer.activeCall.Error)
+		require.False(t, tracer.cancelled())
 	})
 
 	t.Run("call_end_when_depth_is_2_no_error", func(t *testing.T) {
@@ -286,3 +287,118 @@ func TestCallTracer_CallEnd(t *testing.T) {
 		require.Equal(t, uint64(500), tracer.activeCall.startGas)
 	})
 }
+
+func TestCallTracer_RevertReason(t *testing.T) {
+	t.Parallel()
+
+	// abi encoded Error("insufficient balance")
+	output, err := hex.DecodeHex("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000014" +
+		"696e73756666696369656e742062616c616e6365000000000000000000000000")
+	require.NoError(t, err)
+
+	c := NewCallTracer(Config{})
+
+	c.CallStart(1, types.ZeroAddress, types.ZeroAddress, 0, 1000, nil, nil)
+	c.CallEnd(1, output, runtime.ErrExecutionReverted)
+
+	result, err := c.GetResult()
+	require.NoError(t, err)
+
+	call, ok := result.(*Call)
+	require.True(t, ok)
+	require.Equal(t, runtime.ErrExecutionReverted.Error(), call.Error)
+	require.Equal(t, "insufficient balance", call.RevertReason)
+}
+
+func TestCallTracer_WithLog(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1  = types.StringToAddress("1")
+		addr2  = types.StringToAddress("2")
+		addr3  = types.StringToAddress("3")
+		topics = []types.Hash{types.StringToHash("1")}
+		data   = []byte{0x1}
+	)
+
+	t.Run("logs_are_attached_to_call_frames", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewCallTracer(Config{WithLog: true})
+
+		c.CallStart(1, types.ZeroAddress, addr1, 0, 1000, nil, nil)
+		c.CaptureLog(addr1, topics, data)
+		c.CallStart(2, addr1, addr2, 0, 500, nil, nil)
+		c.CaptureLog(addr2, topics, data)
+		c.CallEnd(2, nil, nil)
+		c.CaptureLog(addr1, topics, data)
+		c.CallEnd(1, nil, nil)
+
+		require.Equal(t, []*CallLog{
+			{Address: addr1, Topics: topics, Data: "0x01", Position: "0x0"},
+			{Address: addr1, Topics: topics, Data: "0x01", Position: "0x1"},
+		}, c.call.Logs)
+		require.Equal(t, []*CallLog{
+			{Address: addr2, Topics: topics, Data: "0x01", Position: "0x0"},
+		}, c.call.Calls[0].Logs)
+	})
+
+	t.Run("logs_of_failed_calls_are_dropped", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewCallTracer(Config{WithLog: true})
+
+		c.CallStart(1, types.ZeroAddress, addr1, 0, 1000, nil, nil)
+		c.CallStart(2, addr1, addr2, 0, 500, nil, nil)
+		c.CallStart(3, addr2, addr3, 0, 200, nil, nil)
+		c.CaptureLog(addr3, topics, data)
+		c.CallEnd(3, nil, nil)
+		c.CaptureLog(addr2, topics, data)
+		c.CallEnd(2, nil, runtime.ErrExecutionReverted)
+		c.CaptureLog(addr1, topics, data)
+		c.CallEnd(1, nil, nil)
+
+		require.Len(t, c.call.Logs, 1)
+		require.Nil(t, c.call.Calls[0].Logs)
+		require.Nil(t, c.call.Calls[0].Calls[0].Logs)
+	})
+
+	t.Run("logs_are_ignored_when_disabled", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewCallTracer(Config{})
+
+		c.CallStart(1, types.ZeroAddress, addr1, 0, 1000, nil, nil)
+		c.CaptureLog(addr1, topics, data)
+		c.CallEnd(1, nil, nil)
+
+		require.Nil(t, c.call.Logs)
+	})
+}
+
+func TestCallTracer_OnlyTopCall(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1  = types.StringToAddress("1")
+		addr2  = types.StringToAddress("2")
+		topics = []types.Hash{types.StringToHash("1")}
+	)
+
+	c := NewCallTracer(Config{WithLog: true, OnlyTopCall: true})
+
+	c.CallStart(1, types.ZeroAddress, addr1, 0, 1000, nil, nil)
+	c.CallStart(2, addr1, addr2, 0, 500, nil, nil)
+	c.CaptureLog(addr2, topics, nil)
+	c.CallEnd(2, []byte{0x2}, nil)
+	c.CaptureLog(addr1, topics, nil)
+	c.CallEnd(1, []byte{0x1}, nil)
+
+	require.Nil(t, c.call.Calls)
+	require.Equal(t, "0x01", c.call.Output)
+	require.Equal(t, []*CallLog{
+		{Address: addr1, Topics: topics, Data: "0x", Position: "0x0"},
+	}, c.call.Logs)
+}
@@ -0,0 +1,493 @@
+package gasprofiler
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
+)
+
+const (
+	// selectorLength is the length of the function selector in call input
+	selectorLength = 4
+
+	// fallbackSelector is used for calls which input is too short to contain a function selector
+	fallbackSelector = "fallback"
+	// constructorSelector is used for contract creations
+	constructorSelector = "constructor"
+)
+
+var (
+	// createTypes are the call types which create a contract
+	createTypes = map[int]bool{
+		int(runtime.Create):  true,
+		int(runtime.Create2): true,
+	}
+
+	errInvalidFoldedLine = errors.New("invalid folded stack line")
+)
+
+// Config is the configuration of the gas profiler
+type Config struct {
+	// Folded enables output of the folded stacks which can be rendered by flamegraph tools
+	Folded bool
+}
+
+// Result is a gas profile of the traced execution
+type Result struct {
+	// GasUsed is the gas used by the traced transactions
+	GasUsed uint64 `json:"gasUsed"`
+	// ExecutionGas is the gas consumed by the executed opcodes
+	ExecutionGas uint64         `json:"executionGas"`
+	Contracts    []*ContractGas `json:"contracts"`
+	Functions    []*FunctionGas `json:"functions"`
+	Opcodes      []*OpcodeGas   `json:"opcodes"`
+	Folded       []string       `json:"folded,omitempty"`
+}
+
+// ContractGas is the gas consumed by the code of a contract
+type ContractGas struct {
+	Address types.Address `json:"address"`
+	Gas     uint64        `json:"gas"`
+	Calls   uint64        `json:"calls"`
+}
+
+// FunctionGas is the gas consumed by a function of a contract
+type FunctionGas struct {
+	Address  types.Address `json:"address"`
+	Selector string        `json:"selector"`
+	Gas      uint64        `json:"gas"`
+	Calls    uint64        `json:"calls"`
+}
+
+// OpcodeGas is the gas consumed by an opcode
+type OpcodeGas struct {
+	Opcode string `json:"opcode"`
+	Gas    uint64 `json:"gas"`
+	Count  uint64 `json:"count"`
+}
+
+type functionKey struct {
+	address  types.Address
+	selector string
+}
+
+// frame is a call being executed
+type frame struct {
+	address  types.Address
+	selector string
+	stack    string
+	startGas uint64
+
+	// gasLeft is the gas left after the last executed opcode
+	gasLeft uint64
+	// executed is set once the call executes an opcode, the precompiled contracts and the calls
+	// of the accounts without code execute none
+	executed bool
+	// ended is set once the gas left by the call is known
+	ended bool
+	// childGas is the gas consumed by the sub call made by the current opcode
+	childGas uint64
+}
+
+type GasProfiler struct {
+	Config Config
+
+	gasLimit     uint64
+	gasUsed      uint64
+	executionGas uint64
+
+	contracts map[types.Address]*ContractGas
+	functions map[functionKey]*FunctionGas
+	opcodes   map[string]*OpcodeGas
+	folded    map[string]uint64
+
+	frames []*frame
+
+	cancelLock sync.RWMutex
+	reason     error
+	stop       bool
+}
+
+func NewGasProfiler(config Config) *GasProfiler {
+	p := &GasProfiler{
+		Config: config,
+	}
+
+	p.Clear()
+
+	return p
+}
+
+func (p *GasProfiler) Cancel(err error) {
+	p.cancelLock.Lock()
+	defer p.cancelLock.Unlock()
+
+	p.reason = err
+	p.stop = true
+}
+
+func (p *GasProfiler) cancelled() bool {
+	p.cancelLock.RLock()
+	defer p.cancelLock.RUnlock()
+
+	return p.stop
+}
+
+func (p *GasProfiler) Clear() {
+	p.gasLimit = 0
+	p.gasUsed = 0
+	p.executionGas = 0
+	p.contracts = make(map[types.Address]*ContractGas)
+	p.functions = make(map[functionKey]*FunctionGas)
+	p.opcodes = make(map[string]*OpcodeGas)
+	p.folded = make(map[string]uint64)
+	p.frames = nil
+}
+
+func (p *GasProfiler) GetResult() (interface{}, error) {
+	p.cancelLock.RLock()
+	defer p.cancelLock.RUnlock()
+
+	if p.reason != nil {
+		return nil, p.reason
+	}
+
+	result := &Result{
+		GasUsed:      p.gasUsed,
+		ExecutionGas: p.executionGas,
+		Contracts:    make([]*ContractGas, 0, len(p.contracts)),
+		Functions:    make([]*FunctionGas, 0, len(p.functions)),
+		Opcodes:      make([]*OpcodeGas, 0, len(p.opcodes)),
+	}
+
+	for _, c := range p.contracts {
+		cp := *c
+		result.Contracts = append(result.Contracts, &cp)
+	}
+
+	for _, f := range p.functions {
+		fp := *f
+		result.Functions = append(result.Functions, &fp)
+	}
+
+	for _, o := range p.opcodes {
+		op := *o
+		result.Opcodes = append(result.Opcodes, &op)
+	}
+
+	if p.Config.Folded {
+		result.Folded = foldedLines(p.folded)
+	}
+
+	result.sort()
+
+	return result, nil
+}
+
+func (p *GasProfiler) TxStart(gasLimit uint64) {
+	p.gasLimit = gasLimit
+}
+
+func (p *GasProfiler) TxEnd(gasLeft uint64) {
+	if p.gasLimit > gasLeft {
+		p.gasUsed += p.gasLimit - gasLeft
+	}
+}
+
+func (p *GasProfiler) CallStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+	selector := fallbackSelector
+
+	switch {
+	case createTypes[callType]:
+		selector = constructorSelector
+	case len(input) >= selectorLength:
+		selector = hex.EncodeToHex(input[:selectorLength])
+	}
+
+	label := fmt.Sprintf("%s:%s", to, selector)
+
+	if parent := p.activeFrame(); parent != nil {
+		label = parent.stack + ";" + label
+	}
+
+	p.frames = append(p.frames, &frame{
+		address:  to,
+		selector: selector,
+		stack:    label,
+		startGas: gas,
+		gasLeft:  gas,
+	})
+
+	contract, ok := p.contracts[to]
+	if !ok {
+		contract = &ContractGas{Address: to}
+		p.contracts[to] = contract
+	}
+
+	contract.Calls++
+
+	key := functionKey{address: to, selector: selector}
+
+	function, ok := p.functions[key]
+	if !ok {
+		function = &FunctionGas{Address: to, Selector: selector}
+		p.functions[key] = function
+	}
+
+	function.Calls++
+}
+
+// CallGasLeft records the gas left by the call being ended
+func (p *GasProfiler) CallGasLeft(depth int, gasLeft uint64) {
+	if active := p.activeFrame(); active != nil {
+		active.gasLeft = gasLeft
+		active.ended = true
+	}
+}
+
+func (p *GasProfiler) CallEnd(depth int, output []byte, err error) {
+	child := p.activeFrame()
+	if child == nil {
+		return
+	}
+
+	p.frames = p.frames[:len(p.frames)-1]
+
+	// exceptional halts consume all the gas given to the call
+	if !child.ended && err != nil && !errors.Is(err, runtime.ErrExecutionReverted) {
+		child.gasLeft = 0
+	}
+
+	var consumed uint64
+	if child.startGas > child.gasLeft {
+		consumed = child.startGas - child.gasLeft
+	}
+
+	// the gas of the calls which execute no opcode is accounted to their own frame,
+	// rather than to the call opcode of the parent
+	if !child.executed && consumed > 0 {
+		p.executionGas += consumed
+
+		p.contracts[child.address].Gas += consumed
+		p.functions[functionKey{address: child.address, selector: child.selector}].Gas += consumed
+
+		if p.Config.Folded {
+			p.folded[child.stack] += consumed
+		}
+	}
+
+	if parent := p.activeFrame(); parent != nil {
+		parent.childGas += consumed
+	}
+}
+
+func (p *GasProfiler) CaptureLog(contractAddress types.Address, topics []types.Hash, data []byte) {
+}
+
+func (p *GasProfiler) CaptureState(memory []byte, stack []uint256.Int, opCode int,
+	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
+	if p.cancelled() {
+		state.Halt()
+	}
+}
+
+func (p *GasProfiler) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
+	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
+	active := p.activeFrame()
+	if active == nil {
+		return
+	}
+
+	active.executed = true
+
+	if availableGas > cost {
+		active.gasLeft = availableGas - cost
+	} else {
+		active.gasLeft = 0
+	}
+
+	// the cost of the call and create opcodes includes the gas consumed by the sub call,
+	// which is already accounted for in the frame of the sub call
+	if active.childGas > cost {
+		cost = 0
+	} else {
+		cost -= active.childGas
+	}
+
+	active.childGas = 0
+
+	p.executionGas += cost
+
+	p.contracts[active.address].Gas += cost
+	p.functions[functionKey{address: active.address, selector: active.selector}].Gas += cost
+
+	op, ok := p.opcodes[opcode]
+	if !ok {
+		op = &OpcodeGas{Opcode: opcode}
+		p.opcodes[opcode] = op
+	}
+
+	op.Gas += cost
+	op.Count++
+
+	if p.Config.Folded && cost > 0 {
+		p.folded[active.stack+";"+opcode] += cost
+	}
+}
+
+// activeFrame returns the frame of the call being executed
+func (p *GasProfiler) activeFrame() *frame {
+	if len(p.frames) == 0 {
+		return nil
+	}
+
+	return p.frames[len(p.frames)-1]
+}
+
+// MergeResults aggregates the gas profiles of several transactions into a single profile
+func MergeResults(results []interface{}) (*Result, error) {
+	var (
+		merged    = &Result{}
+		contracts = make(map[types.Address]*ContractGas)
+		functions = make(map[functionKey]*FunctionGas)
+		opcodes   = make(map[string]*OpcodeGas)
+		folded    = make(map[string]uint64)
+	)
+
+	for _, res := range results {
+		result, ok := res.(*Result)
+		if !ok {
+			return nil, fmt.Errorf("unexpected gas profile type %T", res)
+		}
+
+		merged.GasUsed += result.GasUsed
+		merged.ExecutionGas += result.ExecutionGas
+
+		for _, c := range result.Contracts {
+			contract, ok := contracts[c.Address]
+			if !ok {
+				contract = &ContractGas{Address: c.Address}
+				contracts[c.Address] = contract
+			}
+
+			contract.Gas += c.Gas
+			contract.Calls += c.Calls
+		}
+
+		for _, f := range result.Functions {
+			key := functionKey{address: f.Address, selector: f.Selector}
+
+			function, ok := functions[key]
+			if !ok {
+				function = &FunctionGas{Address: f.Address, Selector: f.Selector}
+				functions[key] = function
+			}
+
+			function.Gas += f.Gas
+			function.Calls += f.Calls
+		}
+
+		for _, o := range result.Opcodes {
+			op, ok := opcodes[o.Opcode]
+			if !ok {
+				op = &OpcodeGas{Opcode: o.Opcode}
+				opcodes[o.Opcode] = op
+			}
+
+			op.Gas += o.Gas
+			op.Count += o.Count
+		}
+
+		for _, line := range result.Folded {
+			idx := strings.LastIndex(line, " ")
+			if idx < 0 {
+				return nil, errInvalidFoldedLine
+			}
+
+			gas, err := strconv.ParseUint(line[idx+1:], 10, 64)
+			if err != nil {
+				return nil, errInvalidFoldedLine
+			}
+
+			folded[line[:idx]] += gas
+		}
+	}
+
+	merged.Contracts = make([]*ContractGas, 0, len(contracts))
+	for _, c := range contracts {
+		merged.Contracts = append(merged.Contracts, c)
+	}
+
+	merged.Functions = make([]*FunctionGas, 0, len(functions))
+	for _, f := range functions {
+		merged.Functions = append(merged.Functions, f)
+	}
+
+	merged.Opcodes = make([]*OpcodeGas, 0, len(opcodes))
+	for _, o := range opcodes {
+		merged.Opcodes = append(merged.Opcodes, o)
+	}
+
+	if len(folded) > 0 {
+		merged.Folded = foldedLines(folded)
+	}
+
+	merged.sort()
+
+	return merged, nil
+}
+
+// sort orders the entries of the profile by consumed gas, descending
+func (r *Result) sort() {
+	sort.SliceStable(r.Contracts, func(i, j int) bool {
+		if r.Contracts[i].Gas != r.Contracts[j].Gas {
+			return r.Contracts[i].Gas > r.Contracts[j].Gas
+		}
+
+		return r.Contracts[i].Address.String() < r.Contracts[j].Address.String()
+	})
+
+	sort.SliceStable(r.Functions, func(i, j int) bool {
+		if r.Functions[i].Gas != r.Functions[j].Gas {
+			return r.Functions[i].Gas > r.Functions[j].Gas
+		}
+
+		if r.Functions[i].Address != r.Functions[j].Address {
+			return r.Functions[i].Address.String() < r.Functions[j].Address.String()
+		}
+
+		return r.Functions[i].Selector < r.Functions[j].Selector
+	})
+
+	sort.SliceStable(r.Opcodes, func(i, j int) bool {
+		if r.Opcodes[i].Gas != r.Opcodes[j].Gas {
+			return r.Opcodes[i].Gas > r.Opcodes[j].Gas
+		}
+
+		return r.Opcodes[i].Opcode < r.Opcodes[j].Opcode
+	})
+}
+
+// foldedLines converts the folded stacks to lines in the "frame;frame;opcode gas" format
+func foldedLines(folded map[string]uint64) []string {
+	lines := make([]string, 0, len(folded))
+
+	for stack, gas := range folded {
+		lines = append(lines, fmt.Sprintf("%s %d", stack, gas))
+	}
+
+	sort.Strings(lines)
+
+	return lines
+}
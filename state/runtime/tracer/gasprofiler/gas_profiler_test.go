@@ -0,0 +1,248 @@
+package gasprofiler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+
+	transferInput = []byte{0xa9, 0x05, 0x9c, 0xbb, 0x1}
+)
+
+// simulateCall traces a call from addr1 which calls addr2:
+//
+//	addr1: PUSH1 (3), CALL (100 + sub call), STOP (0)
+//	addr2: PUSH1 (3), SSTORE (20000)
+func simulateCall(p *GasProfiler, subCallErr error) {
+	p.TxStart(100000)
+	p.CallStart(1, types.ZeroAddress, addr1, 0, 50000, nil, transferInput)
+	p.ExecuteState(addr1, 0, "PUSH1", 50000, 3, nil, 1, nil, nil)
+
+	p.CallStart(2, addr1, addr2, 0, 30000, nil, nil)
+	p.ExecuteState(addr2, 0, "PUSH1", 30000, 3, nil, 2, nil, nil)
+	p.ExecuteState(addr2, 2, "SSTORE", 29997, 20000, nil, 2, nil, nil)
+	p.CallEnd(2, nil, subCallErr)
+
+	// CALL cost includes the gas consumed by the sub call
+	subCallGas := uint64(20003)
+	if subCallErr != nil && !errors.Is(subCallErr, runtime.ErrExecutionReverted) {
+		subCallGas = 30000
+	}
+
+	p.ExecuteState(addr1, 2, "CALL", 49997, 100+subCallGas, nil, 1, nil, nil)
+	p.ExecuteState(addr1, 3, "STOP", 49997-100-subCallGas, 0, nil, 1, nil, nil)
+	p.CallEnd(1, nil, nil)
+	p.TxEnd(100000 - 21000 - 100 - subCallGas - 3)
+}
+
+func TestGasProfiler_Breakdown(t *testing.T) {
+	t.Parallel()
+
+	p := NewGasProfiler(Config{})
+
+	simulateCall(p, nil)
+
+	res, err := p.GetResult()
+	require.NoError(t, err)
+
+	result, ok := res.(*Result)
+	require.True(t, ok)
+
+	require.Equal(t, uint64(21000+100+20003+3), result.GasUsed)
+	require.Equal(t, uint64(3+100+3+20000), result.ExecutionGas)
+
+	require.Equal(t, []*ContractGas{
+		{Address: addr2, Gas: 20003, Calls: 1},
+		{Address: addr1, Gas: 103, Calls: 1},
+	}, result.Contracts)
+
+	require.Equal(t, []*FunctionGas{
+		{Address: addr2, Selector: fallbackSelector, Gas: 20003, Calls: 1},
+		{Address: addr1, Selector: "0xa9059cbb", Gas: 103, Calls: 1},
+	}, result.Functions)
+
+	require.Equal(t, []*OpcodeGas{
+		{Opcode: "SSTORE", Gas: 20000, Count: 1},
+		{Opcode: "CALL", Gas: 100, Count: 1},
+		{Opcode: "PUSH1", Gas: 6, Count: 2},
+		{Opcode: "STOP", Gas: 0, Count: 1},
+	}, result.Opcodes)
+
+	require.Nil(t, result.Folded)
+}
+
+func TestGasProfiler_ExceptionalHalt(t *testing.T) {
+	t.Parallel()
+
+	p := NewGasProfiler(Config{})
+
+	simulateCall(p, runtime.ErrOutOfGas)
+
+	res, err := p.GetResult()
+	require.NoError(t, err)
+
+	result, ok := res.(*Result)
+	require.True(t, ok)
+
+	// the gas burnt by the sub call is not attributed to the CALL opcode of the caller
+	require.Equal(t, []*ContractGas{
+		{Address: addr2, Gas: 20003, Calls: 1},
+		{Address: addr1, Gas: 103, Calls: 1},
+	}, result.Contracts)
+}
+
+func TestGasProfiler_Folded(t *testing.T) {
+	t.Parallel()
+
+	p := NewGasProfiler(Config{Folded: true})
+
+	simulateCall(p, nil)
+
+	res, err := p.GetResult()
+	require.NoError(t, err)
+
+	result, ok := res.(*Result)
+	require.True(t, ok)
+
+	var (
+		frame1 = addr1.String() + ":0xa9059cbb"
+		frame2 = addr2.String() + ":" + fallbackSelector
+	)
+
+	require.Equal(t, []string{
+		frame1 + ";" + frame2 + ";PUSH1 3",
+		frame1 + ";" + frame2 + ";SSTORE 20000",
+		frame1 + ";CALL 100",
+		frame1 + ";PUSH1 3",
+	}, result.Folded)
+}
+
+func TestGasProfiler_Clear(t *testing.T) {
+	t.Parallel()
+
+	p := NewGasProfiler(Config{Folded: true})
+
+	simulateCall(p, nil)
+	p.Clear()
+
+	res, err := p.GetResult()
+	require.NoError(t, err)
+
+	require.Equal(t, &Result{
+		Contracts: []*ContractGas{},
+		Functions: []*FunctionGas{},
+		Opcodes:   []*OpcodeGas{},
+		Folded:    []string{},
+	}, res)
+}
+
+func TestGasProfiler_Cancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+
+	p := NewGasProfiler(Config{})
+	p.Cancel(err)
+
+	require.True(t, p.cancelled())
+
+	res, resErr := p.GetResult()
+	require.Nil(t, res)
+	require.Equal(t, err, resErr)
+}
+
+func TestMergeResults(t *testing.T) {
+	t.Parallel()
+
+	results := make([]interface{}, 2)
+
+	for i := range results {
+		p := NewGasProfiler(Config{Folded: true})
+
+		simulateCall(p, nil)
+
+		res, err := p.GetResult()
+		require.NoError(t, err)
+
+		results[i] = res
+	}
+
+	merged, err := MergeResults(results)
+	require.NoError(t, err)
+
+	require.Equal(t, 2*uint64(21000+100+20003+3), merged.GasUsed)
+	require.Equal(t, []*ContractGas{
+		{Address: addr2, Gas: 40006, Calls: 2},
+		{Address: addr1, Gas: 206, Calls: 2},
+	}, merged.Contracts)
+	require.Equal(t, &OpcodeGas{Opcode: "SSTORE", Gas: 40000, Count: 2}, merged.Opcodes[0])
+	require.Contains(t, merged.Folded, addr1.String()+":0xa9059cbb;CALL 200")
+
+	_, err = MergeResults([]interface{}{"invalid"})
+	require.Error(t, err)
+}
+
+func TestGasProfiler_PrecompileCall(t *testing.T) {
+	t.Parallel()
+
+	var (
+		precompile = types.StringToAddress("2")
+		created    = types.StringToAddress("3")
+	)
+
+	p := NewGasProfiler(Config{Folded: true})
+
+	p.TxStart(100000)
+	p.CallStart(1, types.ZeroAddress, addr1, int(runtime.Call), 50000, nil, transferInput)
+
+	// the precompiled contract executes no opcode, its gas is known once it ends
+	p.CallStart(2, addr1, precompile, int(runtime.StaticCall), 3000, nil, []byte{0x1})
+	p.CallGasLeft(2, 2940)
+	p.CallEnd(2, nil, nil)
+	p.ExecuteState(addr1, 0, "STATICCALL", 50000, 100+60, nil, 1, nil, nil)
+
+	// the created contract is labeled as a constructor
+	p.CallStart(2, addr1, created, int(runtime.Create2), 10000, nil, []byte{0x60})
+	p.ExecuteState(created, 0, "PUSH1", 10000, 3, nil, 2, nil, nil)
+	p.CallGasLeft(2, 9997)
+	p.CallEnd(2, nil, nil)
+	p.ExecuteState(addr1, 1, "CREATE2", 49840, 32000+3, nil, 1, nil, nil)
+
+	p.CallGasLeft(1, 49840-32003)
+	p.CallEnd(1, nil, nil)
+	p.TxEnd(100000 - 21000 - 32163)
+
+	res, err := p.GetResult()
+	require.NoError(t, err)
+
+	result, ok := res.(*Result)
+	require.True(t, ok)
+
+	require.Equal(t, []*FunctionGas{
+		{Address: addr1, Selector: "0xa9059cbb", Gas: 32100, Calls: 1},
+		{Address: precompile, Selector: fallbackSelector, Gas: 60, Calls: 1},
+		{Address: created, Selector: constructorSelector, Gas: 3, Calls: 1},
+	}, result.Functions)
+
+	require.Equal(t, []*OpcodeGas{
+		{Opcode: "CREATE2", Gas: 32000, Count: 1},
+		{Opcode: "STATICCALL", Gas: 100, Count: 1},
+		{Opcode: "PUSH1", Gas: 3, Count: 1},
+	}, result.Opcodes)
+
+	frame1 := addr1.String() + ":0xa9059cbb"
+
+	require.Equal(t, []string{
+		frame1 + ";" + precompile.String() + ":" + fallbackSelector + " 60",
+		frame1 + ";" + created.String() + ":" + constructorSelector + ";PUSH1 3",
+		frame1 + ";CREATE2 32000",
+		frame1 + ";STATICCALL 100",
+	}, result.Folded)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 )
 
 type Config struct {
@@ -127,9 +128,16 @@ func (t *StructTracer) CallEnd(
 	}
 }
 
+func (t *StructTracer) CaptureLog(
+	contractAddress types.Address,
+	topics []types.Hash,
+	data []byte,
+) {
+}
+
 func (t *StructTracer) CaptureState(
 	memory []byte,
-	stack []*big.Int,
+	stack []uint256.Int,
 	opCode int,
 	contractAddress types.Address,
 	sp int,
@@ -173,7 +181,7 @@ func (t *StructTracer) captureMemory(
 }
 
 func (t *StructTracer) captureStack(
-	stack []*big.Int,
+	stack []uint256.Int,
 	sp int,
 	opCode int,
 ) {
@@ -183,8 +191,8 @@ func (t *StructTracer) captureStack(
 
 	currentStack := make([]*big.Int, sp)
 
-	for i, v := range stack[:sp] {
-		currentStack[i] = new(big.Int).Set(v)
+	for i := range stack[:sp] {
+		currentStack[i] = stack[i].ToBig()
 	}
 
 	t.currentStack[len(t.currentStack)-1] = currentStack
@@ -195,7 +203,7 @@ func (t *StructTracer) captureStack(
 }
 
 func (t *StructTracer) captureStorage(
-	stack []*big.Int,
+	stack []uint256.Int,
 	opCode int,
 	contractAddress types.Address,
 	sp int,
@@ -218,7 +226,7 @@ func (t *StructTracer) captureStorage(
 	switch opCode {
 	case evm.SLOAD:
 		if sp >= 1 {
-			slot := types.BytesToHash(stack[sp-1].Bytes())
+			slot := types.Hash(stack[sp-1].Bytes32())
 			value := host.GetStorage(contractAddress, slot)
 
 			addToStorage(slot, value)
@@ -226,8 +234,8 @@ func (t *StructTracer) captureStorage(
 
 	case evm.SSTORE:
 		if sp >= 2 {
-			slot := types.BytesToHash(stack[sp-1].Bytes())
-			value := types.BytesToHash(stack[sp-2].Bytes())
+			slot := types.Hash(stack[sp-1].Bytes32())
+			value := types.Hash(stack[sp-2].Bytes32())
 
 			addToStorage(slot, value)
 		}
@@ -13,6 +13,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 )
 
 var (
@@ -328,6 +329,10 @@ func TestStructTracerCaptureState(t *testing.T) {
 			big.NewInt(1), /* value */
 			big.NewInt(2), /* key */
 		}}
+		vmStack = []uint256.Int{
+			*uint256.NewInt(1), /* value */
+			*uint256.NewInt(2), /* key */
+		}
 		contractAddress = types.StringToAddress("3")
 		storageValue    = types.StringToHash("4")
 	)
@@ -340,7 +345,7 @@ func TestStructTracerCaptureState(t *testing.T) {
 
 		// input
 		memory          [][]byte
-		stack           [][]*big.Int
+		stack           []uint256.Int
 		opCode          int
 		contractAddress types.Address
 		sp              int
@@ -361,7 +366,7 @@ func TestStructTracerCaptureState(t *testing.T) {
 				currentMemory: make([]([]byte), 1),
 			},
 			memory:          memory,
-			stack:           stack,
+			stack:           vmStack,
 			opCode:          1,
 			contractAddress: contractAddress,
 			sp:              2,
@@ -388,7 +393,7 @@ func TestStructTracerCaptureState(t *testing.T) {
 				currentStack: make([]([]*big.Int), 1),
 			},
 			memory:          memory,
-			stack:           stack,
+			stack:           vmStack,
 			opCode:          1,
 			contractAddress: contractAddress,
 			sp:              2,
@@ -417,7 +422,7 @@ func TestStructTracerCaptureState(t *testing.T) {
 				},
 			},
 			memory:          memory,
-			stack:           stack,
+			stack:           vmStack,
 			opCode:          evm.SLOAD,
 			contractAddress: contractAddress,
 			sp:              2,
@@ -463,7 +468,7 @@ func TestStructTracerCaptureState(t *testing.T) {
 				},
 			},
 			memory:          memory,
-			stack:           stack,
+			stack:           vmStack,
 			opCode:          evm.SSTORE,
 			contractAddress: contractAddress,
 			sp:              2,
@@ -493,7 +498,7 @@ func TestStructTracerCaptureState(t *testing.T) {
 				interrupt: true,
 			},
 			memory:          memory,
-			stack:           stack,
+			stack:           vmStack,
 			opCode:          1,
 			contractAddress: contractAddress,
 			sp:              2,
@@ -521,7 +526,7 @@ func TestStructTracerCaptureState(t *testing.T) {
 				},
 			},
 			memory:          memory,
-			stack:           stack,
+			stack:           vmStack,
 			opCode:          evm.SLOAD,
 			contractAddress: contractAddress,
 			sp:              0,
@@ -552,7 +557,7 @@ func TestStructTracerCaptureState(t *testing.T) {
 				},
 			},
 			memory:          memory,
-			stack:           stack,
+			stack:           vmStack,
 			opCode:          evm.SSTORE,
 			contractAddress: contractAddress,
 			sp:              1,
@@ -581,7 +586,7 @@ func TestStructTracerCaptureState(t *testing.T) {
 
 			test.tracer.CaptureState(
 				test.memory[0],
-				test.stack[0],
+				test.stack,
 				test.opCode,
 				test.contractAddress,
 				test.sp,
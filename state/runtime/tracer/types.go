@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 )
 
 // RuntimeHost is the interface defining the methods for accessing state by tracer
@@ -46,10 +47,17 @@ type Tracer interface {
 		err error,
 	)
 
+	// Log-level
+	CaptureLog(
+		contractAddress types.Address,
+		topics []types.Hash,
+		data []byte,
+	)
+
 	// Op-level
 	CaptureState(
 		memory []byte,
-		stack []*big.Int,
+		stack []uint256.Int,
 		opCode int,
 		contractAddress types.Address,
 		sp int,
@@ -68,3 +76,9 @@ type Tracer interface {
 		host RuntimeHost,
 	)
 }
+
+// CallGasTracer is implemented by the tracers which account the gas of each call.
+// CallGasLeft is called right before CallEnd, with the gas left by the call
+type CallGasTracer interface {
+	CallGasLeft(depth int, gasLeft uint64)
+}
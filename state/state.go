@@ -110,6 +110,10 @@ type StateObject struct {
 	DirtyCode bool
 	Txn       *iradix.Txn
 
+	// Recreated is set if the account existed before and was created again,
+	// the storage of the previous account is discarded
+	Recreated bool
+
 	// withFakeStorage signals whether the state object
 	// is using the override full state
 	withFakeStorage bool
@@ -131,6 +135,7 @@ func (s *StateObject) Copy() *StateObject {
 	ss.Suicide = s.Suicide
 	ss.Deleted = s.Deleted
 	ss.DirtyCode = s.DirtyCode
+	ss.Recreated = s.Recreated
 	ss.Code = s.Code
 	ss.withFakeStorage = s.withFakeStorage
 
@@ -150,6 +155,9 @@ type Object struct {
 	Nonce    uint64
 	Deleted  bool
 
+	// Recreated is set if the account replaced a previous one, whose storage is discarded
+	Recreated bool
+
 	//nolint:godox
 	// TODO: Move this to executor (to be fixed in EVM-527)
 	DirtyCode bool
@@ -136,6 +136,8 @@ func (txn *Txn) upsertAccount(addr types.Address, create bool, f func(object *St
 				CodeHash: types.EmptyCodeHash.Bytes(),
 				Root:     emptyStateHash,
 			},
+			// the account was deleted earlier in the block
+			Recreated: txn.isDeleted(addr),
 		}
 	}
 
@@ -152,6 +154,7 @@ func (txn *Txn) AddSealingReward(addr types.Address, balance *big.Int) {
 		if object.Suicide {
 			*object = *newStateObject()
 			object.Account.Balance.SetBytes(balance.Bytes())
+			object.Recreated = true
 		} else {
 			object.Account.Balance.Add(object.Account.Balance, balance)
 		}
@@ -573,9 +576,23 @@ func (txn *Txn) CreateAccount(addr types.Address) {
 		obj.Account.Balance.SetBytes(prev.Account.Balance.Bytes())
 	}
 
+	obj.Recreated = ok || txn.isDeleted(addr)
+
 	txn.txn.Insert(addr.Bytes(), obj)
 }
 
+// isDeleted checks if the account was deleted earlier in the block
+func (txn *Txn) isDeleted(addr types.Address) bool {
+	val, exists := txn.txn.Get(addr.Bytes())
+	if !exists {
+		return false
+	}
+
+	obj, ok := val.(*StateObject)
+
+	return ok && obj.Deleted
+}
+
 func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) error {
 	remove := [][]byte{}
 
@@ -639,6 +656,7 @@ func (txn *Txn) Commit(deleteEmptyObjects bool) ([]*Object, error) {
 			CodeHash:  types.BytesToHash(a.Account.CodeHash),
 			DirtyCode: a.DirtyCode,
 			Code:      a.Code,
+			Recreated: a.Recreated,
 		}
 		if a.Deleted {
 			obj.Deleted = true
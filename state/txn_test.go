@@ -92,3 +92,42 @@ func TestIncrNonce(t *testing.T) {
 	require.NoError(t, txn.IncrNonce(address1))
 	require.Equal(t, nonMaxUint64NonceValue+1, txn.GetNonce(address1))
 }
+
+func TestCommit_Recreated(t *testing.T) {
+	t.Parallel()
+
+	var (
+		address3 = types.StringToAddress("3")
+		address4 = types.StringToAddress("4")
+	)
+
+	txn := newTestTxn(defaultPreState)
+
+	// an existing account created again
+	txn.CreateAccount(addr1)
+
+	// a new account and an update of an account created in the block
+	txn.CreateAccount(addr2)
+	txn.AddBalance(address3, big.NewInt(1))
+
+	// an account deleted and created again within the block
+	txn.AddBalance(address4, big.NewInt(1))
+	require.True(t, txn.Suicide(address4))
+	require.NoError(t, txn.CleanDeleteObjects(true))
+	txn.AddBalance(address4, big.NewInt(2))
+
+	objs, err := txn.Commit(false)
+	require.NoError(t, err)
+
+	recreated := map[types.Address]bool{}
+	for _, obj := range objs {
+		recreated[obj.Address] = obj.Recreated
+	}
+
+	require.Equal(t, map[types.Address]bool{
+		addr1:    true,
+		addr2:    false,
+		address3: false,
+		address4: true,
+	}, recreated)
+}
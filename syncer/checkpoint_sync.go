@@ -0,0 +1,250 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// checkpointAncestors is the number of the blocks preceding the checkpoint which are synced with it,
+	// they are the blocks whose hashes are available to the following blocks through the BLOCKHASH opcode
+	checkpointAncestors = 256
+
+	// backfillRetryInterval is the interval the backfill is retried at when no peer serves the blocks
+	backfillRetryInterval = 10 * time.Second
+)
+
+var (
+	errInvalidCheckpoint  = errors.New("invalid checkpoint, expected <number>:<hash>")
+	errCheckpointMismatch = errors.New("block does not match the checkpoint")
+	errNoBackfillPeer     = errors.New("no peer serves the blocks preceding the checkpoint")
+)
+
+// Checkpoint is a trusted block a new node starts syncing from, instead of the genesis
+type Checkpoint struct {
+	Number uint64
+	Hash   types.Hash
+}
+
+// ParseCheckpoint parses a checkpoint given as <number>:<hash>
+func ParseCheckpoint(raw string) (*Checkpoint, error) {
+	number, hash, ok := strings.Cut(raw, ":")
+	if !ok {
+		return nil, errInvalidCheckpoint
+	}
+
+	n, err := strconv.ParseUint(number, 10, 64)
+	if err != nil || n == 0 {
+		return nil, fmt.Errorf("%w: invalid block number %q", errInvalidCheckpoint, number)
+	}
+
+	h := types.StringToHash(hash)
+	if h == types.ZeroHash {
+		return nil, fmt.Errorf("%w: invalid block hash %q", errInvalidCheckpoint, hash)
+	}
+
+	return &Checkpoint{Number: n, Hash: h}, nil
+}
+
+// CheckpointVerifier is implemented by the consensus which verifies the seals of a checkpoint
+type CheckpointVerifier interface {
+	// ValidatorSnapshot returns the encoded validator set which seals the block of the given number
+	ValidatorSnapshot(blockNumber uint64) ([]byte, error)
+	// VerifyCheckpoint verifies the seals of the checkpoint header against the encoded validator set,
+	// which must match the one the header carries, and makes it the validator set the following blocks
+	// are verified against
+	VerifyCheckpoint(header *types.Header, snapshot []byte) error
+}
+
+// CheckpointSyncConfig is the configuration of the checkpoint sync
+type CheckpointSyncConfig struct {
+	// Checkpoint is the trusted block a new node starts syncing from, the sync starts
+	// from the genesis if it is not set
+	Checkpoint *Checkpoint
+
+	// Backfill makes the node download the blocks preceding the checkpoint in the background
+	Backfill bool
+
+	// Verifier verifies the seals of the checkpoint, and serves the validator sets to the peers
+	Verifier CheckpointVerifier
+}
+
+// shouldCheckpointSync returns whether the checkpoint is synced before the following blocks are,
+// which is the case for a node with only the genesis block, or for a node whose checkpoint
+// was written without the ancestors which are synced with it
+func (s *syncer) shouldCheckpointSync() bool {
+	if s.checkpoint.Checkpoint == nil || s.stateSyncer == nil {
+		return false
+	}
+
+	if s.blockchain.Header().Number == 0 {
+		return true
+	}
+
+	header, ok := s.blockchain.Checkpoint()
+
+	return ok && header.Number > s.checkpointAncestorsFrom()
+}
+
+// checkpointAncestorsFrom returns the number of the lowest block synced with the checkpoint
+func (s *syncer) checkpointAncestorsFrom() uint64 {
+	if n := s.checkpoint.Checkpoint.Number; n > checkpointAncestors {
+		return n - checkpointAncestors
+	}
+
+	return 1
+}
+
+// checkpointSyncWithPeer downloads the checkpoint block from the peer, verifies its seals
+// and downloads its state, then writes it as the head of the chain together with its ancestors
+func (s *syncer) checkpointSyncWithPeer(peer *NoForkPeer) error {
+	var (
+		checkpoint = s.checkpoint.Checkpoint
+		from       = s.checkpointAncestorsFrom()
+	)
+
+	// the checkpoint was written before, only its ancestors are missing
+	if header, ok := s.blockchain.Checkpoint(); ok {
+		return s.backfillWithPeer(peer.ID, from, header.Number-1)
+	}
+
+	s.logger.Info("syncing checkpoint", "number", checkpoint.Number, "hash", checkpoint.Hash, "peer", peer.ID)
+
+	blocks, err := s.fetchBlockRange(peer.ID, from, checkpoint.Number)
+	if err != nil {
+		return err
+	}
+
+	block := blocks[len(blocks)-1]
+	if block.Header.ComputeHash().Hash != checkpoint.Hash {
+		return fmt.Errorf("%w: block %d has hash %s", errCheckpointMismatch, block.Number(), block.Hash())
+	}
+
+	snapshot, err := s.syncPeerClient.GetValidatorSnapshot(peer.ID, checkpoint.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get validator snapshot: %w", err)
+	}
+
+	if err := s.checkpoint.Verifier.VerifyCheckpoint(block.Header, snapshot); err != nil {
+		metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+		return fmt.Errorf("%w, failed to verify checkpoint: %w", errUnverifiableBlock, err)
+	}
+
+	if err := s.stateSyncer.sync(peer.ID, block.Header.StateRoot); err != nil {
+		return err
+	}
+
+	if err := s.blockchain.WriteCheckpointBlock(block, syncerName); err != nil {
+		return fmt.Errorf("failed to write checkpoint block: %w", err)
+	}
+
+	if err := s.blockchain.BackfillBlocks(blocks[:len(blocks)-1]); err != nil {
+		return fmt.Errorf("failed to write checkpoint ancestors: %w", err)
+	}
+
+	s.logger.Info("checkpoint synced", "number", checkpoint.Number, "hash", checkpoint.Hash)
+
+	return nil
+}
+
+// backfillWithPeer downloads the given blocks preceding the checkpoint from the peer and writes them
+func (s *syncer) backfillWithPeer(peerID peer.ID, from, to uint64) error {
+	blocks, err := s.fetchBlockRange(peerID, from, to)
+	if err != nil {
+		return err
+	}
+
+	if err := s.blockchain.BackfillBlocks(blocks); err != nil {
+		metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+		return err
+	}
+
+	metrics.SetGauge([]string{syncerMetrics, "backfill_lowest_block"}, float32(from))
+
+	return nil
+}
+
+// fetchBlockRange downloads all the blocks of the range from the peer
+func (s *syncer) fetchBlockRange(peerID peer.ID, from, to uint64) ([]*types.Block, error) {
+	blockCh, err := s.syncPeerClient.GetBlockRange(peerID, from, to, s.blockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+			s.logger.Error("Failed to close stream: ", err)
+		}
+	}()
+
+	blocks := make([]*types.Block, 0, to-from+1)
+
+	for block := range blockCh {
+		if expected := from + uint64(len(blocks)); block.Number() != expected {
+			return nil, fmt.Errorf("%w: expected block %d, got %d", errUnexpectedBlock, expected, block.Number())
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	if len(blocks) != cap(blocks) {
+		return nil, fmt.Errorf("%w: got %d of %d blocks from %d", errIncompleteChunk, len(blocks), cap(blocks), from)
+	}
+
+	return blocks, nil
+}
+
+// runBackfill writes the blocks preceding the checkpoint down to the genesis, downloading them
+// in chunks from the best peers serving them
+func (s *syncer) runBackfill() {
+	skipList := make(map[peer.ID]bool)
+
+	for {
+		header, ok := s.blockchain.Checkpoint()
+		if !ok && s.blockchain.Header().Number > 0 {
+			s.logger.Info("blocks preceding the checkpoint are backfilled")
+
+			return
+		}
+
+		err := errNoBackfillPeer
+
+		// there is no checkpoint before the checkpoint sync completes
+		if ok {
+			from := uint64(1)
+			if header.Number > downloadChunkSize {
+				from = header.Number - downloadChunkSize
+			}
+
+			if bestPeer := s.peerMap.BestPeerFrom(from, skipList); bestPeer != nil {
+				if err = s.backfillWithPeer(bestPeer.ID, from, header.Number-1); err != nil {
+					s.logger.Warn("failed to backfill blocks with peer", "peer ID", bestPeer.ID, "error", err)
+
+					skipList[bestPeer.ID] = true
+				}
+			} else {
+				skipList = make(map[peer.ID]bool)
+			}
+		}
+
+		wait := time.Duration(0)
+		if err != nil {
+			wait = backfillRetryInterval
+		}
+
+		select {
+		case <-s.closeCh:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package syncer
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockCheckpointVerifier struct {
+	snapshot []byte
+}
+
+func (m *mockCheckpointVerifier) ValidatorSnapshot(uint64) ([]byte, error) {
+	return m.snapshot, nil
+}
+
+func (m *mockCheckpointVerifier) VerifyCheckpoint(_ *types.Header, snapshot []byte) error {
+	if !bytes.Equal(m.snapshot, snapshot) {
+		return fmt.Errorf("%w: quorum not reached", blockchain.ErrInvalidSeal)
+	}
+
+	return nil
+}
+
+func TestParseCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	hash := types.StringToHash("0x1234")
+
+	checkpoint, err := ParseCheckpoint("100:" + hash.String())
+	require.NoError(t, err)
+	assert.Equal(t, &Checkpoint{Number: 100, Hash: hash}, checkpoint)
+
+	for _, raw := range []string{"", "100", "0:" + hash.String(), "x:" + hash.String(), "100:0x"} {
+		_, err := ParseCheckpoint(raw)
+		assert.ErrorIs(t, err, errInvalidCheckpoint, raw)
+	}
+}
+
+func Test_syncer_checkpointSyncWithPeer(t *testing.T) {
+	t.Parallel()
+
+	blocks := createMockBlocks(300)
+
+	for i, b := range blocks {
+		b.Header.StateRoot = types.EmptyRootHash
+
+		if i > 0 {
+			b.Header.ParentHash = blocks[i-1].Hash()
+		}
+
+		b.Header.ComputeHash()
+	}
+
+	tests := []struct {
+		name       string
+		checkpoint types.Hash
+		snapshot   []byte
+		err        bool
+	}{
+		{
+			name:       "should write the checkpoint and its ancestors",
+			checkpoint: blocks[299].Hash(),
+			snapshot:   []byte("validators"),
+		},
+		{
+			name:       "should return error if the block does not match the checkpoint",
+			checkpoint: blocks[298].Hash(),
+			snapshot:   []byte("validators"),
+			err:        true,
+		},
+		{
+			name:       "should return error if the seals are not verified",
+			checkpoint: blocks[299].Hash(),
+			snapshot:   []byte("other validators"),
+			err:        true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				peerID     = peer.ID("A")
+				head       = &types.Header{Number: 0}
+				checkpoint *types.Header
+				backfilled []*types.Block
+			)
+
+			syncer := NewTestSyncer(
+				nil,
+				&mockBlockchain{
+					headerHandler: func() *types.Header {
+						return head
+					},
+					writeCheckpointBlockHandler: func(b *types.Block) error {
+						head, checkpoint = b.Header, b.Header
+
+						return nil
+					},
+					checkpointHandler: func() (*types.Header, bool) {
+						return checkpoint, checkpoint != nil
+					},
+					backfillBlocksHandler: func(blocks []*types.Block) error {
+						checkpoint, backfilled = blocks[0].Header, blocks
+
+						return nil
+					},
+				},
+				time.Second,
+				&mockSyncPeerClient{
+					getBlockRangeHandler: func(id peer.ID, from, to uint64, _ time.Duration) (<-chan *types.Block, error) {
+						assert.Equal(t, uint64(300-checkpointAncestors), from)
+						assert.Equal(t, uint64(300), to)
+
+						return blocksToCh(blocks[from-1:to], 0), nil
+					},
+					getValidatorSnapshotHandler: func(id peer.ID, number uint64) ([]byte, error) {
+						assert.Equal(t, uint64(300), number)
+
+						return test.snapshot, nil
+					},
+				},
+				&mockProgression{},
+			)
+
+			syncer.stateSyncer = newStateSyncer(hclog.NewNullLogger(), newTestNetwork(t), itrie.NewMemoryStorage())
+			syncer.checkpoint = CheckpointSyncConfig{
+				Checkpoint: &Checkpoint{Number: 300, Hash: test.checkpoint},
+				Verifier:   &mockCheckpointVerifier{snapshot: []byte("validators")},
+			}
+
+			require.True(t, syncer.shouldCheckpointSync())
+
+			err := syncer.checkpointSyncWithPeer(&NoForkPeer{ID: peerID, Number: 300})
+			if test.err {
+				require.Error(t, err)
+				assert.Zero(t, head.Number)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, blocks[299].Hash(), head.Hash)
+			assert.Equal(t, blocks[300-checkpointAncestors-1:299], backfilled)
+
+			// the sync continues from the checkpoint
+			assert.False(t, syncer.shouldCheckpointSync())
+		})
+	}
+}
@@ -24,6 +24,7 @@ const (
 	SyncPeerClientLoggerName = "sync-peer-client"
 	statusTopicName          = "syncer/status/0.1"
 	defaultTimeoutForStatus  = 10 * time.Second
+	defaultTimeoutForData    = 30 * time.Second
 )
 
 type syncPeerClient struct {
@@ -133,9 +134,10 @@ func (m *syncPeerClient) GetPeerStatus(peerID peer.ID) (*NoForkPeer, error) {
 	}
 
 	return &NoForkPeer{
-		ID:       peerID,
-		Number:   status.Number,
-		Distance: m.network.GetPeerDistance(peerID),
+		ID:          peerID,
+		Number:      status.Number,
+		HistoryTail: status.HistoryTail,
+		Distance:    m.network.GetPeerDistance(peerID),
 	}, nil
 }
 
@@ -226,9 +228,10 @@ func (m *syncPeerClient) handleStatusUpdate(obj interface{}, from peer.ID) {
 
 	if !m.peerStatusUpdateChClosed {
 		m.peerStatusUpdateCh <- &NoForkPeer{
-			ID:       from,
-			Number:   status.Number,
-			Distance: m.network.GetPeerDistance(from),
+			ID:          from,
+			Number:      status.Number,
+			HistoryTail: status.HistoryTail,
+			Distance:    m.network.GetPeerDistance(from),
 		}
 	}
 }
@@ -255,7 +258,8 @@ func (m *syncPeerClient) startNewBlockProcess() {
 			latest := event.NewChain[l-1]
 			// Publish status
 			if err := m.topic.Publish(&proto.SyncPeerStatus{
-				Number: latest.Number,
+				Number:      latest.Number,
+				HistoryTail: m.blockchain.HistoryTail(),
 			}); err != nil {
 				m.logger.Warn("failed to publish status", "err", err)
 			}
@@ -297,6 +301,112 @@ func (m *syncPeerClient) GetBlocks(
 	peerID peer.ID,
 	from uint64,
 	timeoutPerBlock time.Duration,
+) (<-chan *types.Block, error) {
+	return m.getBlocks(peerID, &proto.GetBlocksRequest{From: from}, timeoutPerBlock)
+}
+
+// GetBlockRange returns a stream of blocks from given height up to the given last block.
+// The peers which do not support the last block stream the blocks up to their latest
+func (m *syncPeerClient) GetBlockRange(
+	peerID peer.ID,
+	from uint64,
+	to uint64,
+	timeoutPerBlock time.Duration,
+) (<-chan *types.Block, error) {
+	return m.getBlocks(peerID, &proto.GetBlocksRequest{From: from, To: to}, timeoutPerBlock)
+}
+
+// GetValidatorSnapshot fetches the encoded validator set which seals the given block from the peer
+func (m *syncPeerClient) GetValidatorSnapshot(peerID peer.ID, number uint64) ([]byte, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForStatus)
+	defer cancel()
+
+	snapshot, err := clt.GetValidatorSnapshot(timeoutCtx, &proto.ValidatorSnapshotRequest{Number: number})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.Snapshot, nil
+}
+
+// GetHeaders fetches the headers from given height up to the given last header from the peer,
+// the peer returns fewer headers if it does not have all of them or if there are too many of them
+func (m *syncPeerClient) GetHeaders(peerID peer.ID, from, to uint64) ([]*types.Header, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForData)
+	defer cancel()
+
+	res, err := clt.GetHeaders(timeoutCtx, &proto.GetBlocksRequest{From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]*types.Header, len(res.Headers))
+
+	for i, raw := range res.Headers {
+		header := &types.Header{}
+		if err := header.UnmarshalRLP(raw); err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_message"}, 1)
+
+			return nil, err
+		}
+
+		headers[i] = header
+	}
+
+	return headers, nil
+}
+
+// GetBodies fetches the bodies of the blocks of the given hashes from the peer,
+// the peer returns a prefix of them if it does not have all of them or if they are too large
+func (m *syncPeerClient) GetBodies(peerID peer.ID, hashes []types.Hash) ([]*types.Body, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForData)
+	defer cancel()
+
+	req := &proto.GetBodiesRequest{Hashes: make([][]byte, len(hashes))}
+	for i, hash := range hashes {
+		req.Hashes[i] = hash.Bytes()
+	}
+
+	res, err := clt.GetBodies(timeoutCtx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	bodies := make([]*types.Body, len(res.Bodies))
+
+	for i, protoBody := range res.Bodies {
+		body, err := fromProtoBody(protoBody)
+		if err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_message"}, 1)
+
+			return nil, err
+		}
+
+		bodies[i] = body
+	}
+
+	return bodies, nil
+}
+
+func (m *syncPeerClient) getBlocks(
+	peerID peer.ID,
+	req *proto.GetBlocksRequest,
+	timeoutPerBlock time.Duration,
 ) (<-chan *types.Block, error) {
 	clt, err := m.newSyncPeerClient(peerID)
 	if err != nil {
@@ -305,9 +415,7 @@ func (m *syncPeerClient) GetBlocks(
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	stream, err := clt.GetBlocks(ctx, &proto.GetBlocksRequest{
-		From: from,
-	})
+	stream, err := clt.GetBlocks(ctx, req)
 	if err != nil {
 		cancel()
 
@@ -369,6 +477,34 @@ func fromProto(protoBlock *proto.Block) (*types.Block, error) {
 	return block, nil
 }
 
+// fromProtoBody gets block body from gRPC response data
+func fromProtoBody(protoBody *proto.Body) (*types.Body, error) {
+	body := &types.Body{
+		Transactions: make([]*types.Transaction, len(protoBody.Transactions)),
+		Uncles:       make([]*types.Header, len(protoBody.Uncles)),
+	}
+
+	for i, raw := range protoBody.Transactions {
+		tx := &types.Transaction{}
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			return nil, err
+		}
+
+		body.Transactions[i] = tx.ComputeHash()
+	}
+
+	for i, raw := range protoBody.Uncles {
+		uncle := &types.Header{}
+		if err := uncle.UnmarshalRLP(raw); err != nil {
+			return nil, err
+		}
+
+		body.Uncles[i] = uncle
+	}
+
+	return body, nil
+}
+
 func blockStreamToChannel(stream proto.SyncPeer_GetBlocksClient) (<-chan *types.Block, <-chan error) {
 	blockCh := make(chan *types.Block)
 	errorCh := make(chan error, 1)
@@ -0,0 +1,637 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// downloadChunkSize is the number of blocks requested from a peer at once
+	downloadChunkSize = 64
+
+	// maxDownloadPeers is the maximum number of peers the blocks are downloaded from at once
+	maxDownloadPeers = 8
+
+	// maxDownloadChunksAhead is the maximum number of chunks downloaded ahead of the imported blocks
+	maxDownloadChunksAhead = 4 * maxDownloadPeers
+
+	// maxDownloadFailures is the number of failed chunks after which a peer is dropped from the download
+	maxDownloadFailures = 3
+
+	// slowChunkFactor is how many times slower than the fastest peer a chunk which blocks the import
+	// can be downloaded before it is reassigned to another peer
+	slowChunkFactor = 3
+
+	// throughputWeight is the weight of the latest chunk in the throughput of a peer
+	throughputWeight = 0.3
+)
+
+var (
+	errNoDownloadPeers = errors.New("no peer left to download the blocks from")
+	errUnexpectedBlock = errors.New("unexpected block in chunk")
+	errIncompleteChunk = errors.New("chunk ended before its last block")
+	errChunkAborted    = errors.New("chunk download aborted")
+	errBodyMismatch    = errors.New("body does not match the header")
+)
+
+// blockChunk is a range of blocks downloaded from a single peer
+type blockChunk struct {
+	from uint64
+	to   uint64
+
+	// the peer which was too slow to download the chunk, it is downloaded from another peer
+	avoid peer.ID
+}
+
+// chunkResult is the outcome of a chunk download, the blocks are the downloaded prefix of the chunk
+type chunkResult struct {
+	peerID  peer.ID
+	chunk   *blockChunk
+	blocks  []*types.Block
+	elapsed time.Duration
+	err     error
+}
+
+// downloadPeer is a peer the blocks are downloaded from, scored by its throughput and failures
+type downloadPeer struct {
+	*NoForkPeer
+
+	throughput float64 // blocks per second, weighted towards the latest chunks
+	failures   int
+	dropped    bool
+
+	// chunk in download, nil if the peer is idle
+	active  *blockChunk
+	started time.Time
+	aborted bool // the download of the chunk was aborted, and the peer penalized for it
+	abortCh chan struct{}
+}
+
+// blockDownloader downloads the chunks of the missing blocks concurrently from several peers,
+// and imports the blocks in order as the chunks arrive
+type blockDownloader struct {
+	logger       hclog.Logger
+	blockchain   Blockchain
+	client       SyncPeerClient
+	reputations  *peerReputations
+	blockTimeout time.Duration
+
+	// verified headers whose bodies are downloaded, the whole blocks are downloaded if there are none
+	headers []*types.Header
+
+	peers   []*downloadPeer
+	queue   []*blockChunk           // chunks to download again, ordered by their first block
+	results map[uint64]*chunkResult // downloaded chunks by their first block
+
+	nextChunk uint64 // the first block of the next new chunk
+	next      uint64 // the next block to import
+	target    uint64 // the last block to import
+
+	resultCh chan *chunkResult
+	wg       sync.WaitGroup
+
+	started    time.Time
+	downloaded uint64
+	imported   uint64
+}
+
+func newBlockDownloader(s *syncer, peers []*NoForkPeer, from uint64) *blockDownloader {
+	if len(peers) > maxDownloadPeers {
+		peers = peers[:maxDownloadPeers]
+	}
+
+	d := &blockDownloader{
+		logger:       s.logger,
+		blockchain:   s.blockchain,
+		client:       s.syncPeerClient,
+		reputations:  s.reputations,
+		blockTimeout: s.blockTimeout,
+		peers:        make([]*downloadPeer, len(peers)),
+		results:      make(map[uint64]*chunkResult),
+		nextChunk:    from,
+		next:         from,
+		resultCh:     make(chan *chunkResult, len(peers)),
+		started:      time.Now(),
+	}
+
+	for i, p := range peers {
+		d.peers[i] = &downloadPeer{NoForkPeer: p}
+
+		if p.Number > d.target {
+			d.target = p.Number
+		}
+	}
+
+	return d
+}
+
+// newBodyDownloader creates a downloader of the bodies of the verified headers, the blocks are assembled
+// from the headers and the downloaded bodies
+func newBodyDownloader(s *syncer, peers []*NoForkPeer, headers []*types.Header) *blockDownloader {
+	d := newBlockDownloader(s, peers, headers[0].Number)
+	d.headers = headers
+	d.target = headers[len(headers)-1].Number
+
+	return d
+}
+
+// parallelSyncWithPeers downloads the blocks following the local head from several peers at once
+// and imports them until the highest block of the peers, or until the callback returns true.
+// It returns the last imported block and the peers dropped for failing or misbehaving
+func (s *syncer) parallelSyncWithPeers(
+	peers []*NoForkPeer,
+	newBlockCallback func(*types.FullBlock) bool,
+) (uint64, bool, []peer.ID, error) {
+	localLatest := s.blockchain.Header().Number
+	d := newBlockDownloader(s, peers, localLatest+1)
+
+	s.logger.Info("downloading blocks from multiple peers", "from", d.next, "to", d.target, "peers", len(d.peers))
+	metrics.SetGauge([]string{syncerMetrics, "download_peers"}, float32(len(d.peers)))
+
+	subscription := s.blockchain.SubscribeEvents()
+	s.syncProgression.StartProgression(localLatest+1, subscription)
+	s.syncProgression.UpdateHighestProgression(d.target)
+
+	defer func() {
+		d.stop()
+
+		s.syncProgression.StopProgression()
+		s.blockchain.UnsubscribeEvents(subscription)
+	}()
+
+	shouldTerminate, err := d.run(newBlockCallback)
+
+	return d.next - 1, shouldTerminate, d.droppedPeers(), err
+}
+
+// run downloads and imports the blocks up to the target
+func (d *blockDownloader) run(newBlockCallback func(*types.FullBlock) bool) (bool, error) {
+	ticker := time.NewTicker(d.blockTimeout / 4)
+	defer ticker.Stop()
+
+	for d.next <= d.target {
+		if d.assign() == 0 {
+			return false, errNoDownloadPeers
+		}
+
+		select {
+		case res := <-d.resultCh:
+			d.handleResult(res)
+
+			shouldTerminate, err := d.importReady(newBlockCallback)
+			if err != nil || shouldTerminate {
+				return shouldTerminate, err
+			}
+		case <-ticker.C:
+			d.reassignSlowChunk()
+		}
+	}
+
+	return false, nil
+}
+
+// assign starts downloading chunks on the idle peers, and returns the number of chunks in download
+func (d *blockDownloader) assign() int {
+	active := 0
+
+	// the fastest peers take the lowest chunks, the import waits for them first
+	peers := make([]*downloadPeer, 0, len(d.peers))
+
+	for _, p := range d.peers {
+		if p.active != nil {
+			active++
+		} else if !p.dropped {
+			peers = append(peers, p)
+		}
+	}
+
+	sort.SliceStable(peers, func(i, j int) bool {
+		return peers[i].throughput > peers[j].throughput
+	})
+
+	for _, p := range peers {
+		if chunk := d.takeChunk(p); chunk != nil {
+			d.start(p, chunk)
+
+			active++
+		}
+	}
+
+	if active == 0 && len(d.queue) > 0 {
+		// the peers to avoid are the only ones left to download the chunks from
+		for _, chunk := range d.queue {
+			chunk.avoid = ""
+		}
+
+		for _, p := range peers {
+			if chunk := d.takeChunk(p); chunk != nil {
+				d.start(p, chunk)
+
+				active++
+			}
+		}
+	}
+
+	return active
+}
+
+// takeChunk returns the next chunk the peer can download, nil if there is none
+func (d *blockDownloader) takeChunk(p *downloadPeer) *blockChunk {
+	for i, chunk := range d.queue {
+		if chunk.avoid != p.ID && p.Number >= chunk.to && p.Serves(chunk.from) {
+			d.queue = append(d.queue[:i], d.queue[i+1:]...)
+
+			return chunk
+		}
+	}
+
+	if d.nextChunk > d.target || d.nextChunk >= d.next+maxDownloadChunksAhead*downloadChunkSize ||
+		p.Number < d.nextChunk || !p.Serves(d.nextChunk) {
+		return nil
+	}
+
+	chunk := &blockChunk{
+		from: d.nextChunk,
+		to:   min(d.nextChunk+downloadChunkSize-1, p.Number, d.target),
+	}
+
+	d.nextChunk = chunk.to + 1
+
+	return chunk
+}
+
+// requeue schedules the given blocks to be downloaded again
+func (d *blockDownloader) requeue(from, to uint64, avoid peer.ID) {
+	chunk := &blockChunk{from: from, to: to, avoid: avoid}
+
+	i := sort.Search(len(d.queue), func(i int) bool {
+		return d.queue[i].from > from
+	})
+
+	d.queue = append(d.queue[:i], append([]*blockChunk{chunk}, d.queue[i:]...)...)
+}
+
+// start downloads the chunk from the peer in the background
+func (d *blockDownloader) start(p *downloadPeer, chunk *blockChunk) {
+	p.active = chunk
+	p.started = time.Now()
+	p.aborted = false
+	p.abortCh = make(chan struct{})
+
+	d.wg.Add(1)
+
+	go d.fetchChunk(p.ID, chunk, p.abortCh)
+}
+
+// fetchChunk downloads the blocks of the chunk from the peer, and sends the result to the downloader
+func (d *blockDownloader) fetchChunk(peerID peer.ID, chunk *blockChunk, abortCh <-chan struct{}) {
+	defer d.wg.Done()
+
+	var (
+		started = time.Now()
+		res     = &chunkResult{peerID: peerID, chunk: chunk}
+	)
+
+	defer func() {
+		res.elapsed = time.Since(started)
+		d.resultCh <- res
+	}()
+
+	if d.headers != nil {
+		res.blocks, res.err = d.fetchBodies(peerID, chunk, abortCh)
+
+		return
+	}
+
+	blockCh, err := d.client.GetBlockRange(peerID, chunk.from, chunk.to, d.blockTimeout)
+	if err != nil {
+		res.err = err
+
+		return
+	}
+
+	defer func() {
+		if err := d.client.CloseStream(peerID); err != nil {
+			d.logger.Error("Failed to close stream: ", err)
+		}
+
+		// release the stream goroutine of the blocks following the chunk, without waiting for the stream to end
+		go func() {
+			for range blockCh {
+			}
+		}()
+	}()
+
+	for next := chunk.from; next <= chunk.to; next++ {
+		select {
+		case block, ok := <-blockCh:
+			if !ok {
+				res.err = errIncompleteChunk
+
+				return
+			}
+
+			if block.Number() != next {
+				res.err = fmt.Errorf("%w: block %d, expected %d", errUnexpectedBlock, block.Number(), next)
+
+				return
+			}
+
+			res.blocks = append(res.blocks, block)
+		case <-abortCh:
+			res.err = errChunkAborted
+
+			return
+		}
+	}
+}
+
+// fetchBodies downloads the bodies of the chunk from the peer, and assembles the blocks from them
+// and the verified headers. The peer may return a prefix of the bodies, the rest is downloaded again
+func (d *blockDownloader) fetchBodies(
+	peerID peer.ID,
+	chunk *blockChunk,
+	abortCh <-chan struct{},
+) ([]*types.Block, error) {
+	headers := d.headers[chunk.from-d.headers[0].Number : chunk.to-d.headers[0].Number+1]
+	hashes := make([]types.Hash, len(headers))
+
+	for i, header := range headers {
+		hashes[i] = header.Hash
+	}
+
+	bodies, err := d.client.GetBodies(peerID, hashes)
+
+	if err := d.client.CloseStream(peerID); err != nil {
+		d.logger.Error("Failed to close stream: ", err)
+	}
+
+	select {
+	case <-abortCh:
+		return nil, errChunkAborted
+	default:
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bodies) == 0 {
+		return nil, errIncompleteChunk
+	}
+
+	if len(bodies) > len(headers) {
+		return nil, fmt.Errorf("%w: got %d bodies for %d headers", errUnexpectedBlock, len(bodies), len(headers))
+	}
+
+	blocks := make([]*types.Block, 0, len(bodies))
+
+	for i, body := range bodies {
+		block := &types.Block{
+			Header:       headers[i],
+			Transactions: body.Transactions,
+			Uncles:       body.Uncles,
+		}
+
+		if err := verifyBody(block); err != nil {
+			return blocks, err
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// verifyBody verifies the transactions and the uncles of the block against the roots of its header
+func verifyBody(block *types.Block) error {
+	if hash := buildroot.CalculateUncleRoot(block.Uncles); hash != block.Header.Sha3Uncles {
+		return fmt.Errorf("%w: block %d has uncle root %s, expected %s",
+			errBodyMismatch, block.Number(), hash, block.Header.Sha3Uncles)
+	}
+
+	if hash := buildroot.CalculateTransactionsRoot(block.Transactions, block.Number()); hash != block.Header.TxRoot {
+		return fmt.Errorf("%w: block %d has transactions root %s, expected %s",
+			errBodyMismatch, block.Number(), hash, block.Header.TxRoot)
+	}
+
+	return nil
+}
+
+// handleResult stores the downloaded blocks of the chunk and schedules the missing ones again
+func (d *blockDownloader) handleResult(res *chunkResult) {
+	p := d.peer(res.peerID)
+	p.active = nil
+
+	if n := len(res.blocks); n > 0 {
+		d.results[res.chunk.from] = res
+
+		if res.elapsed > 0 {
+			p.updateThroughput(float64(n) / res.elapsed.Seconds())
+		}
+
+		d.downloaded += uint64(n)
+
+		metrics.IncrCounter([]string{syncerMetrics, "downloaded_blocks"}, float32(n))
+		metrics.SetGauge([]string{syncerMetrics, "download_blocks_per_second"},
+			float32(float64(d.downloaded)/time.Since(d.started).Seconds()))
+	}
+
+	if last := res.chunk.from + uint64(len(res.blocks)); last <= res.chunk.to {
+		var avoid peer.ID
+		if p.aborted {
+			avoid = p.ID
+		}
+
+		d.requeue(last, res.chunk.to, avoid)
+	}
+
+	switch {
+	case res.err == nil, p.aborted:
+		// the peer was penalized when its chunk was aborted
+	case errors.Is(res.err, errUnexpectedBlock), errors.Is(res.err, errBodyMismatch):
+		d.logger.Warn("peer sent unexpected blocks, dropping it", "peer", p.ID, "err", res.err)
+		d.reputations.recordInvalidBlock(p.ID)
+		d.drop(p)
+	default:
+		d.logger.Debug("failed to download chunk", "peer", p.ID, "from", res.chunk.from, "err", res.err)
+		d.reputations.recordFailure(p.ID)
+		d.penalize(p)
+	}
+}
+
+// importReady verifies and writes the downloaded blocks following the local head
+func (d *blockDownloader) importReady(newBlockCallback func(*types.FullBlock) bool) (bool, error) {
+	for {
+		res, ok := d.results[d.next]
+		if !ok {
+			return false, nil
+		}
+
+		delete(d.results, d.next)
+
+		for _, block := range res.blocks {
+			fullBlock, err := d.blockchain.VerifyFinalizedBlock(block)
+			if err != nil {
+				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+				// the block may fail the verification because of the local node, the other peers can't help then
+				if !isPeerFault(err) {
+					return false, fmt.Errorf("%w, %w", errUnverifiableBlock, err)
+				}
+
+				// the peer which sent the block is misbehaving, the rest of its chunk is downloaded again
+				d.logger.Warn("peer sent invalid block, dropping it", "peer", res.peerID, "block", block.Number(), "err", err)
+				d.reputations.recordInvalidBlock(res.peerID)
+				d.drop(d.peer(res.peerID))
+				d.requeue(block.Number(), res.blocks[len(res.blocks)-1].Number(), "")
+
+				return false, nil
+			}
+
+			if err := d.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
+				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+				return false, fmt.Errorf("failed to write block while bulk syncing: %w", err)
+			}
+
+			updateMetrics(fullBlock)
+
+			d.next++
+			d.imported++
+
+			metrics.SetGauge([]string{syncerMetrics, "import_blocks_per_second"},
+				float32(float64(d.imported)/time.Since(d.started).Seconds()))
+
+			if newBlockCallback(fullBlock) {
+				return true, nil
+			}
+		}
+	}
+}
+
+// reassignSlowChunk aborts the chunk the import waits for if its peer is much slower than the fastest
+// other peer, so the rest of the chunk is downloaded from another peer
+func (d *blockDownloader) reassignSlowChunk() {
+	var (
+		blocking *downloadPeer
+		fastest  float64
+		idle     bool
+	)
+
+	for _, p := range d.peers {
+		switch {
+		case p.dropped:
+		case p.active != nil && p.active.from == d.next:
+			blocking = p
+		case p.active == nil:
+			idle = true
+
+			fallthrough
+		default:
+			fastest = max(fastest, p.throughput)
+		}
+	}
+
+	if blocking == nil || blocking.aborted || !idle || fastest == 0 {
+		return
+	}
+
+	expected := time.Duration(float64(blocking.active.to-blocking.active.from+1) / fastest * float64(time.Second))
+	if time.Since(blocking.started) < slowChunkFactor*expected {
+		return
+	}
+
+	d.logger.Debug("reassigning chunk of slow peer", "peer", blocking.ID, "from", blocking.active.from)
+	metrics.IncrCounter([]string{syncerMetrics, "reassigned_chunks"}, 1)
+
+	d.reputations.recordStall(blocking.ID)
+	d.abort(blocking)
+	d.penalize(blocking)
+}
+
+// penalize counts a failure of the peer, and drops it from the download after too many failures
+func (d *blockDownloader) penalize(p *downloadPeer) {
+	p.failures++
+
+	if p.failures >= maxDownloadFailures {
+		d.logger.Warn("peer failed too many chunks, dropping it", "peer", p.ID)
+		d.drop(p)
+	}
+}
+
+// drop stops downloading from the peer
+func (d *blockDownloader) drop(p *downloadPeer) {
+	if p.dropped {
+		return
+	}
+
+	p.dropped = true
+
+	d.abort(p)
+
+	metrics.SetGauge([]string{syncerMetrics, "download_peers"}, float32(len(d.peers)-len(d.droppedPeers())))
+}
+
+// stop aborts the downloads in progress and waits for them to finish
+func (d *blockDownloader) stop() {
+	for _, p := range d.peers {
+		d.abort(p)
+	}
+
+	d.wg.Wait()
+}
+
+// abort aborts the chunk in download from the peer, if any
+func (d *blockDownloader) abort(p *downloadPeer) {
+	if p.active == nil || p.aborted {
+		return
+	}
+
+	p.aborted = true
+	close(p.abortCh)
+}
+
+// peer returns the download peer of the given ID
+func (d *blockDownloader) peer(peerID peer.ID) *downloadPeer {
+	for _, p := range d.peers {
+		if p.ID == peerID {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// droppedPeers returns the IDs of the peers dropped from the download
+func (d *blockDownloader) droppedPeers() []peer.ID {
+	dropped := make([]peer.ID, 0)
+
+	for _, p := range d.peers {
+		if p.dropped {
+			dropped = append(dropped, p.ID)
+		}
+	}
+
+	return dropped
+}
+
+// updateThroughput weights the throughput of the latest chunk into the throughput of the peer
+func (p *downloadPeer) updateThroughput(blocksPerSecond float64) {
+	if p.throughput == 0 {
+		p.throughput = blocksPerSecond
+
+		return
+	}
+
+	p.throughput = (1-throughputWeight)*p.throughput + throughputWeight*blocksPerSecond
+}
@@ -0,0 +1,232 @@
+package syncer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	invalidBlockExtra      = []byte("invalid")
+	unverifiableBlockExtra = []byte("unverifiable")
+
+	errLocalFailure = errors.New("validators not found")
+)
+
+// testDownloadPeer serves the blocks of a chain for the download tests
+type testDownloadPeer struct {
+	id       peer.ID
+	number   uint64
+	delay    time.Duration // delay before each block
+	offset   uint64        // the peer sends the blocks shifted by the offset
+	invalid  bool          // the peer sends blocks which fail the verification
+	local    bool          // the blocks of the peer fail the verification because of the local node
+	requests int
+}
+
+type blockRangeHandler func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error)
+
+// newTestDownloadPeers returns the statuses of the peers and the handler serving their blocks
+func newTestDownloadPeers(peers ...*testDownloadPeer) ([]*NoForkPeer, blockRangeHandler) {
+	var (
+		statuses = make([]*NoForkPeer, len(peers))
+		byID     = make(map[peer.ID]*testDownloadPeer, len(peers))
+		lock     sync.Mutex
+	)
+
+	for i, p := range peers {
+		statuses[i] = &NoForkPeer{ID: p.id, Number: p.number, Distance: big.NewInt(int64(i))}
+		byID[p.id] = p
+	}
+
+	handler := func(id peer.ID, from, to uint64, _ time.Duration) (<-chan *types.Block, error) {
+		lock.Lock()
+		p := byID[id]
+		p.requests++
+		lock.Unlock()
+
+		ch := make(chan *types.Block)
+
+		go func() {
+			defer close(ch)
+
+			for i := from; i <= to && i <= p.number; i++ {
+				time.Sleep(p.delay)
+
+				header := &types.Header{Number: i + p.offset}
+				if p.invalid {
+					header.ExtraData = invalidBlockExtra
+				} else if p.local {
+					header.ExtraData = unverifiableBlockExtra
+				}
+
+				ch <- &types.Block{Header: header}
+			}
+		}()
+
+		return ch, nil
+	}
+
+	return statuses, handler
+}
+
+func Test_parallelSyncWithPeers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		peers       []*testDownloadPeer
+		terminateAt uint64
+
+		// results
+		synced          uint64
+		shouldTerminate bool
+		dropped         []peer.ID
+		err             error
+	}{
+		{
+			name: "should download the blocks from all the peers",
+			peers: []*testDownloadPeer{
+				{id: "A", number: 500},
+				{id: "B", number: 500},
+				{id: "C", number: 400},
+			},
+			synced:  500,
+			dropped: []peer.ID{},
+		},
+		{
+			name: "should reassign the chunk of a slow peer",
+			peers: []*testDownloadPeer{
+				{id: "A", number: 300, delay: 100 * time.Millisecond},
+				{id: "B", number: 300},
+				{id: "C", number: 300},
+			},
+			synced:  300,
+			dropped: []peer.ID{},
+		},
+		{
+			name: "should drop the peer sending unexpected blocks",
+			peers: []*testDownloadPeer{
+				{id: "A", number: 300},
+				{id: "B", number: 300, offset: 1},
+			},
+			synced:  300,
+			dropped: []peer.ID{"B"},
+		},
+		{
+			name: "should drop the peer sending invalid blocks",
+			peers: []*testDownloadPeer{
+				{id: "A", number: 300, invalid: true},
+				{id: "B", number: 300},
+			},
+			synced:  300,
+			dropped: []peer.ID{"A"},
+		},
+		{
+			name: "should not drop the peers if the blocks fail the local verification",
+			peers: []*testDownloadPeer{
+				{id: "A", number: 300, local: true},
+				{id: "B", number: 300, local: true},
+			},
+			dropped: []peer.ID{},
+			err:     errLocalFailure,
+		},
+		{
+			name: "should stop when the callback returns true",
+			peers: []*testDownloadPeer{
+				{id: "A", number: 300},
+				{id: "B", number: 300},
+			},
+			terminateAt:     100,
+			synced:          100,
+			shouldTerminate: true,
+			dropped:         []peer.ID{},
+		},
+		{
+			name: "should return error if all the peers are dropped",
+			peers: []*testDownloadPeer{
+				{id: "A", number: 300, offset: 1},
+				{id: "B", number: 300, invalid: true},
+			},
+			dropped: []peer.ID{"A", "B"},
+			err:     errNoDownloadPeers,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				statuses, rangeHandler = newTestDownloadPeers(test.peers...)
+				latest                 uint64
+			)
+
+			syncer := NewTestSyncer(
+				nil,
+				&mockBlockchain{
+					headerHandler: func() *types.Header {
+						return &types.Header{Number: latest}
+					},
+					verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+						if bytes.Equal(b.Header.ExtraData, invalidBlockExtra) {
+							return nil, fmt.Errorf("%w: quorum not reached", blockchain.ErrInvalidSeal)
+						}
+
+						if bytes.Equal(b.Header.ExtraData, unverifiableBlockExtra) {
+							return nil, fmt.Errorf("failed to verify the header: %w", errLocalFailure)
+						}
+
+						return &types.FullBlock{Block: b}, nil
+					},
+					writeFullBlockHandler: func(b *types.FullBlock) error {
+						require.Equal(t, latest+1, b.Block.Number())
+
+						latest = b.Block.Number()
+
+						return nil
+					},
+				},
+				time.Second,
+				&mockSyncPeerClient{getBlockRangeHandler: rangeHandler},
+				&mockProgression{},
+			)
+
+			started := time.Now()
+
+			callback := func(b *types.FullBlock) bool {
+				return test.terminateAt != 0 && b.Block.Number() >= test.terminateAt
+			}
+
+			synced, shouldTerminate, dropped, err := syncer.parallelSyncWithPeers(statuses, callback)
+
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.synced, synced)
+			assert.Equal(t, test.synced, latest)
+			assert.Equal(t, test.shouldTerminate, shouldTerminate)
+			assert.ElementsMatch(t, test.dropped, dropped)
+
+			// downloading everything from the slow peer alone takes 30 seconds
+			assert.Less(t, time.Since(started), 10*time.Second)
+
+			if test.err == nil && !test.shouldTerminate {
+				for _, p := range test.peers {
+					assert.NotZero(t, p.requests, "peer %s", p.id)
+				}
+			}
+		})
+	}
+}
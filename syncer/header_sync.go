@@ -0,0 +1,128 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// headerBatchSize is the number of headers downloaded and verified before the bodies of their blocks are
+const headerBatchSize = 1024
+
+var errInvalidHeaders = errors.New("invalid header chain")
+
+// HeaderVerifier is implemented by the consensus which verifies the seals of the headers
+// before the bodies of their blocks are downloaded
+type HeaderVerifier interface {
+	// VerifyHeaders verifies the consecutive headers, in ascending order, following the local head.
+	// The headers are verified without their bodies, so the peers serving invalid chains are rejected cheaply
+	VerifyHeaders(headers []*types.Header) error
+}
+
+// HeaderSyncConfig is the configuration of the header-first sync
+type HeaderSyncConfig struct {
+	// Enabled makes the node download and verify the headers before the bodies of their blocks
+	Enabled bool
+
+	// Verifier verifies the seals of the headers
+	Verifier HeaderVerifier
+}
+
+// shouldHeaderSync returns whether the blocks are synced headers first
+func (s *syncer) shouldHeaderSync() bool {
+	return s.headerSync.Enabled && s.headerSync.Verifier != nil
+}
+
+// headerSyncWithPeers syncs the blocks up to the latest block of the best peer in batches. The headers of
+// a batch are downloaded from the best peer and verified first, then the bodies of their blocks are downloaded
+// from several peers at once. The peers which fail or misbehave are added to the skip list
+func (s *syncer) headerSyncWithPeers(
+	bestPeer *NoForkPeer,
+	skipList map[peer.ID]bool,
+	newBlockCallback func(*types.FullBlock) bool,
+) (bool, error) {
+	localLatest := s.blockchain.Header().Number
+
+	subscription := s.blockchain.SubscribeEvents()
+	s.syncProgression.StartProgression(localLatest+1, subscription)
+	s.syncProgression.UpdateHighestProgression(bestPeer.Number)
+
+	defer func() {
+		s.syncProgression.StopProgression()
+		s.blockchain.UnsubscribeEvents(subscription)
+	}()
+
+	for localLatest < bestPeer.Number {
+		headers, err := s.fetchVerifiedHeaders(bestPeer.ID, localLatest+1,
+			min(localLatest+headerBatchSize, bestPeer.Number))
+		if err != nil {
+			s.recordSyncFailure(bestPeer.ID, err, skipList)
+
+			return false, err
+		}
+
+		d := newBodyDownloader(s, s.peerMap.PeersFrom(localLatest+1, skipList), headers)
+
+		s.logger.Debug("downloading bodies of verified headers", "from", d.next, "to", d.target, "peers", len(d.peers))
+
+		shouldTerminate, err := d.run(newBlockCallback)
+		d.stop()
+
+		for _, peerID := range d.droppedPeers() {
+			skipList[peerID] = true
+		}
+
+		if err != nil || shouldTerminate {
+			return shouldTerminate, err
+		}
+
+		localLatest = d.target
+	}
+
+	return false, nil
+}
+
+// fetchVerifiedHeaders downloads the headers of the range from the peer and verifies their seals
+func (s *syncer) fetchVerifiedHeaders(peerID peer.ID, from, to uint64) ([]*types.Header, error) {
+	headers := make([]*types.Header, 0, to-from+1)
+
+	defer func() {
+		if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+			s.logger.Error("Failed to close stream: ", err)
+		}
+	}()
+
+	// the peer serves a limited number of headers at once
+	for next := from; next <= to; {
+		res, err := s.syncPeerClient.GetHeaders(peerID, next, to)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(res) == 0 {
+			return nil, fmt.Errorf("%w: got %d of %d headers from %d", errIncompleteChunk, len(headers), cap(headers), from)
+		}
+
+		for _, header := range res {
+			if header.Number != next || next > to {
+				return nil, fmt.Errorf("%w: expected header %d, got %d", errUnexpectedBlock, next, header.Number)
+			}
+
+			headers = append(headers, header)
+			next++
+		}
+	}
+
+	if err := s.headerSync.Verifier.VerifyHeaders(headers); err != nil {
+		metrics.IncrCounter([]string{syncerMetrics, "bad_header"}, 1)
+
+		return nil, fmt.Errorf("%w: %w", errInvalidHeaders, err)
+	}
+
+	metrics.IncrCounter([]string{syncerMetrics, "verified_headers"}, float32(len(headers)))
+
+	return headers, nil
+}
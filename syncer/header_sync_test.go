@@ -0,0 +1,172 @@
+package syncer
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockHeaderVerifier struct {
+	invalid uint64 // the number of the header failing the verification
+}
+
+func (m *mockHeaderVerifier) VerifyHeaders(headers []*types.Header) error {
+	for _, header := range headers {
+		if header.Number == m.invalid {
+			return fmt.Errorf("%w: quorum not reached", blockchain.ErrInvalidSeal)
+		}
+	}
+
+	return nil
+}
+
+func Test_syncer_headerSyncWithPeers(t *testing.T) {
+	t.Parallel()
+
+	const latest = 1500
+
+	headers := make([]*types.Header, latest+1)
+	for i := range headers {
+		headers[i] = (&types.Header{
+			Number:     uint64(i),
+			TxRoot:     types.EmptyRootHash,
+			Sha3Uncles: types.EmptyUncleHash,
+		}).ComputeHash()
+	}
+
+	tests := []struct {
+		name          string
+		invalidHeader uint64
+		badBodies     peer.ID // the peer sending the bodies which do not match the headers
+
+		// results
+		synced  uint64
+		skipped []peer.ID
+		err     error
+	}{
+		{
+			name:    "should download the bodies of the verified headers from all the peers",
+			synced:  latest,
+			skipped: []peer.ID{},
+		},
+		{
+			name:      "should drop the peer sending bodies which do not match the headers",
+			badBodies: "B",
+			synced:    latest,
+			skipped:   []peer.ID{"B"},
+		},
+		{
+			name:          "should reject the peer serving invalid headers before downloading the bodies",
+			invalidHeader: 100,
+			skipped:       []peer.ID{"A"},
+			err:           errInvalidHeaders,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				local        uint64
+				bodyRequests = make(map[peer.ID]int)
+				lock         sync.Mutex
+				skipList     = make(map[peer.ID]bool)
+			)
+
+			syncer := NewTestSyncer(
+				nil,
+				&mockBlockchain{
+					headerHandler: func() *types.Header {
+						return headers[local]
+					},
+					verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+						return &types.FullBlock{Block: b}, nil
+					},
+					writeFullBlockHandler: func(b *types.FullBlock) error {
+						require.Equal(t, local+1, b.Block.Number())
+
+						local = b.Block.Number()
+
+						return nil
+					},
+				},
+				time.Second,
+				&mockSyncPeerClient{
+					getHeadersHandler: func(id peer.ID, from, to uint64) ([]*types.Header, error) {
+						assert.Equal(t, peer.ID("A"), id)
+
+						// the peer serves a limited number of headers at once
+						return headers[from : min(to, from+299)+1], nil
+					},
+					getBodiesHandler: func(id peer.ID, hashes []types.Hash) ([]*types.Body, error) {
+						lock.Lock()
+						bodyRequests[id]++
+						lock.Unlock()
+
+						bodies := make([]*types.Body, len(hashes))
+						for i := range bodies {
+							bodies[i] = &types.Body{}
+
+							if id == test.badBodies {
+								bodies[i].Transactions = []*types.Transaction{
+									types.NewTx(types.NewLegacyTx(types.WithValue(big.NewInt(1)))),
+								}
+							}
+						}
+
+						return bodies, nil
+					},
+				},
+				&mockProgression{},
+			)
+
+			syncer.headerSync = HeaderSyncConfig{
+				Enabled:  true,
+				Verifier: &mockHeaderVerifier{invalid: test.invalidHeader},
+			}
+
+			syncer.peerMap.Put(
+				&NoForkPeer{ID: "A", Number: latest, Distance: big.NewInt(0)},
+				&NoForkPeer{ID: "B", Number: latest, Distance: big.NewInt(1)},
+			)
+
+			bestPeer := syncer.peerMap.BestPeerFrom(1, skipList)
+			require.Equal(t, peer.ID("A"), bestPeer.ID)
+
+			shouldTerminate, err := syncer.headerSyncWithPeers(bestPeer, skipList, func(*types.FullBlock) bool {
+				return false
+			})
+
+			assert.ErrorIs(t, err, test.err)
+			assert.False(t, shouldTerminate)
+			assert.Equal(t, test.synced, local)
+
+			skipped := make([]peer.ID, 0)
+			for id := range skipList {
+				skipped = append(skipped, id)
+			}
+
+			assert.ElementsMatch(t, test.skipped, skipped)
+
+			if test.err != nil {
+				// no body is downloaded for the invalid headers
+				assert.Empty(t, bodyRequests)
+			} else {
+				assert.NotZero(t, bodyRequests["A"])
+				assert.NotZero(t, bodyRequests["B"])
+			}
+		})
+	}
+}
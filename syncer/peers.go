@@ -2,6 +2,7 @@ package syncer
 
 import (
 	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -12,10 +13,17 @@ type NoForkPeer struct {
 	ID peer.ID
 	// peer's latest block number
 	Number uint64
+	// the lowest block number the peer serves, the older history is pruned
+	HistoryTail uint64
 	// peer's distance
 	Distance *big.Int
 }
 
+// Serves returns whether the peer serves the blocks from the given height
+func (p *NoForkPeer) Serves(from uint64) bool {
+	return p.HistoryTail <= from
+}
+
 func (p *NoForkPeer) IsBetter(t *NoForkPeer) bool {
 	if p.Number != t.Number {
 		return p.Number > t.Number
@@ -49,6 +57,12 @@ func (m *PeerMap) Remove(peerID peer.ID) {
 
 // BestPeer returns the top of heap
 func (m *PeerMap) BestPeer(skipMap map[peer.ID]bool) *NoForkPeer {
+	return m.BestPeerFrom(0, skipMap)
+}
+
+// BestPeerFrom returns the best peer serving the blocks from the given height,
+// the peers which pruned the history beyond it are skipped
+func (m *PeerMap) BestPeerFrom(from uint64, skipMap map[peer.ID]bool) *NoForkPeer {
 	var bestPeer *NoForkPeer
 
 	m.Range(func(key, value interface{}) bool {
@@ -58,6 +72,10 @@ func (m *PeerMap) BestPeer(skipMap map[peer.ID]bool) *NoForkPeer {
 			return true
 		}
 
+		if from > 0 && !peer.Serves(from) {
+			return true
+		}
+
 		if bestPeer == nil || peer.IsBetter(bestPeer) {
 			bestPeer = peer
 		}
@@ -67,3 +85,27 @@ func (m *PeerMap) BestPeer(skipMap map[peer.ID]bool) *NoForkPeer {
 
 	return bestPeer
 }
+
+// PeersFrom returns the peers serving the blocks from the given height which have the block of that
+// height, ordered from the best one
+func (m *PeerMap) PeersFrom(from uint64, skipMap map[peer.ID]bool) []*NoForkPeer {
+	peers := make([]*NoForkPeer, 0)
+
+	m.Range(func(key, value interface{}) bool {
+		peer, _ := value.(*NoForkPeer)
+
+		if (skipMap != nil && skipMap[peer.ID]) || !peer.Serves(from) || peer.Number < from {
+			return true
+		}
+
+		peers = append(peers, peer)
+
+		return true
+	})
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].IsBetter(peers[j])
+	})
+
+	return peers
+}
@@ -160,3 +160,34 @@ func TestBestPeer(t *testing.T) {
 		})
 	}
 }
+
+func TestBestPeerFrom(t *testing.T) {
+	t.Parallel()
+
+	allPeers := getAllTestPeers()
+
+	// the best peer pruned the history before block 15
+	allPeers[2].HistoryTail = 15
+
+	peerMap := NewPeerMap(allPeers)
+
+	assert.Equal(t, allPeers[1], peerMap.BestPeerFrom(11, nil))
+	assert.Equal(t, allPeers[2], peerMap.BestPeerFrom(15, nil))
+	assert.Equal(t, allPeers[2], peerMap.BestPeer(nil))
+}
+
+func TestPeersFrom(t *testing.T) {
+	t.Parallel()
+
+	allPeers := getAllTestPeers()
+
+	// the best peer pruned the history before block 15
+	allPeers[2].HistoryTail = 15
+
+	peerMap := NewPeerMap(allPeers)
+
+	assert.Equal(t, []*NoForkPeer{allPeers[1], allPeers[0]}, peerMap.PeersFrom(5, nil))
+	assert.Equal(t, []*NoForkPeer{allPeers[1]}, peerMap.PeersFrom(11, nil))
+	assert.Equal(t, []*NoForkPeer{allPeers[2], allPeers[1]}, peerMap.PeersFrom(15, nil))
+	assert.Equal(t, []*NoForkPeer{allPeers[1]}, peerMap.PeersFrom(15, map[peer.ID]bool{allPeers[2].ID: true}))
+}
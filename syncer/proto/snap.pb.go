@@ -0,0 +1,408 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.19.4
+// source: syncer/proto/snap.proto
+
+package proto
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// TrieRangeRequest is a request for GetTrieRange
+type TrieRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Root hash of the trie
+	Root []byte `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	// The key the range follows, the range starts with the first leaf if empty
+	After []byte `protobuf:"bytes,2,opt,name=after,proto3" json:"after,omitempty"`
+	// Maximum number of leaves
+	Max uint64 `protobuf:"varint,3,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *TrieRangeRequest) Reset() {
+	*x = TrieRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_snap_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrieRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrieRangeRequest) ProtoMessage() {}
+
+func (x *TrieRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_snap_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrieRangeRequest.ProtoReflect.Descriptor instead.
+func (*TrieRangeRequest) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_snap_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TrieRangeRequest) GetRoot() []byte {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *TrieRangeRequest) GetAfter() []byte {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *TrieRangeRequest) GetMax() uint64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+// TrieRange contains consecutive leaves of a trie
+type TrieRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Keys of the leaves, sorted
+	Keys [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Values of the leaves
+	Values [][]byte `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	// Encoded trie nodes proving the first and the last leaf
+	Proof [][]byte `protobuf:"bytes,3,rep,name=proof,proto3" json:"proof,omitempty"`
+	// Whether the trie has leaves following the range
+	More bool `protobuf:"varint,4,opt,name=more,proto3" json:"more,omitempty"`
+}
+
+func (x *TrieRange) Reset() {
+	*x = TrieRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_snap_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrieRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrieRange) ProtoMessage() {}
+
+func (x *TrieRange) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_snap_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrieRange.ProtoReflect.Descriptor instead.
+func (*TrieRange) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_snap_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TrieRange) GetKeys() [][]byte {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *TrieRange) GetValues() [][]byte {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *TrieRange) GetProof() [][]byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *TrieRange) GetMore() bool {
+	if x != nil {
+		return x.More
+	}
+	return false
+}
+
+// HashesRequest is a request for the trie nodes or codes of the given hashes
+type HashesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hashes [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+}
+
+func (x *HashesRequest) Reset() {
+	*x = HashesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_snap_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HashesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashesRequest) ProtoMessage() {}
+
+func (x *HashesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_snap_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashesRequest.ProtoReflect.Descriptor instead.
+func (*HashesRequest) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_snap_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HashesRequest) GetHashes() [][]byte {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+// TrieData contains the found trie nodes or codes, the ones not found are omitted
+type TrieData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data [][]byte `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *TrieData) Reset() {
+	*x = TrieData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_snap_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrieData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrieData) ProtoMessage() {}
+
+func (x *TrieData) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_snap_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrieData.ProtoReflect.Descriptor instead.
+func (*TrieData) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_snap_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TrieData) GetData() [][]byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_syncer_proto_snap_proto protoreflect.FileDescriptor
+
+var file_syncer_proto_snap_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73,
+	0x6e, 0x61, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x22, 0x4e, 0x0a,
+	0x10, 0x54, 0x72, 0x69, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x72, 0x6f, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x61, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x61, 0x0a,
+	0x09, 0x54, 0x72, 0x69, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65,
+	0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x12, 0x0a, 0x04,
+	0x6d, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x6f, 0x72, 0x65,
+	0x22, 0x27, 0x0a, 0x0d, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x1e, 0x0a, 0x08, 0x54, 0x72, 0x69,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0x9d, 0x01, 0x0a, 0x08, 0x53, 0x6e,
+	0x61, 0x70, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x33, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x54, 0x72, 0x69,
+	0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x69, 0x65,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x72, 0x69, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x2f, 0x0a, 0x0c, 0x47,
+	0x65, 0x74, 0x54, 0x72, 0x69, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x11, 0x2e, 0x76, 0x31,
+	0x2e, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x69, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2b, 0x0a, 0x08,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x61,
+	0x73, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x72, 0x69, 0x65, 0x44, 0x61, 0x74, 0x61, 0x42, 0x0f, 0x5a, 0x0d, 0x2f, 0x73, 0x79,
+	0x6e, 0x63, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_syncer_proto_snap_proto_rawDescOnce sync.Once
+	file_syncer_proto_snap_proto_rawDescData = file_syncer_proto_snap_proto_rawDesc
+)
+
+func file_syncer_proto_snap_proto_rawDescGZIP() []byte {
+	file_syncer_proto_snap_proto_rawDescOnce.Do(func() {
+		file_syncer_proto_snap_proto_rawDescData = protoimpl.X.CompressGZIP(file_syncer_proto_snap_proto_rawDescData)
+	})
+	return file_syncer_proto_snap_proto_rawDescData
+}
+
+var file_syncer_proto_snap_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_syncer_proto_snap_proto_goTypes = []interface{}{
+	(*TrieRangeRequest)(nil), // 0: v1.TrieRangeRequest
+	(*TrieRange)(nil),        // 1: v1.TrieRange
+	(*HashesRequest)(nil),    // 2: v1.HashesRequest
+	(*TrieData)(nil),         // 3: v1.TrieData
+}
+var file_syncer_proto_snap_proto_depIdxs = []int32{
+	0, // 0: v1.SnapSync.GetTrieRange:input_type -> v1.TrieRangeRequest
+	2, // 1: v1.SnapSync.GetTrieNodes:input_type -> v1.HashesRequest
+	2, // 2: v1.SnapSync.GetCodes:input_type -> v1.HashesRequest
+	1, // 3: v1.SnapSync.GetTrieRange:output_type -> v1.TrieRange
+	3, // 4: v1.SnapSync.GetTrieNodes:output_type -> v1.TrieData
+	3, // 5: v1.SnapSync.GetCodes:output_type -> v1.TrieData
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_syncer_proto_snap_proto_init() }
+func file_syncer_proto_snap_proto_init() {
+	if File_syncer_proto_snap_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_syncer_proto_snap_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrieRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_syncer_proto_snap_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrieRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_syncer_proto_snap_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HashesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_syncer_proto_snap_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrieData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_syncer_proto_snap_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_syncer_proto_snap_proto_goTypes,
+		DependencyIndexes: file_syncer_proto_snap_proto_depIdxs,
+		MessageInfos:      file_syncer_proto_snap_proto_msgTypes,
+	}.Build()
+	File_syncer_proto_snap_proto = out.File
+	file_syncer_proto_snap_proto_rawDesc = nil
+	file_syncer_proto_snap_proto_goTypes = nil
+	file_syncer_proto_snap_proto_depIdxs = nil
+}
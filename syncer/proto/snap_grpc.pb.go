@@ -0,0 +1,183 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: syncer/proto/snap.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SnapSyncClient is the client API for SnapSync service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SnapSyncClient interface {
+	// Returns the leaves of a trie following a key, with the proofs of the first and the last leaf
+	GetTrieRange(ctx context.Context, in *TrieRangeRequest, opts ...grpc.CallOption) (*TrieRange, error)
+	// Returns the encoded trie nodes of the given hashes
+	GetTrieNodes(ctx context.Context, in *HashesRequest, opts ...grpc.CallOption) (*TrieData, error)
+	// Returns the contract codes of the given hashes
+	GetCodes(ctx context.Context, in *HashesRequest, opts ...grpc.CallOption) (*TrieData, error)
+}
+
+type snapSyncClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSnapSyncClient(cc grpc.ClientConnInterface) SnapSyncClient {
+	return &snapSyncClient{cc}
+}
+
+func (c *snapSyncClient) GetTrieRange(ctx context.Context, in *TrieRangeRequest, opts ...grpc.CallOption) (*TrieRange, error) {
+	out := new(TrieRange)
+	err := c.cc.Invoke(ctx, "/v1.SnapSync/GetTrieRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapSyncClient) GetTrieNodes(ctx context.Context, in *HashesRequest, opts ...grpc.CallOption) (*TrieData, error) {
+	out := new(TrieData)
+	err := c.cc.Invoke(ctx, "/v1.SnapSync/GetTrieNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapSyncClient) GetCodes(ctx context.Context, in *HashesRequest, opts ...grpc.CallOption) (*TrieData, error) {
+	out := new(TrieData)
+	err := c.cc.Invoke(ctx, "/v1.SnapSync/GetCodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SnapSyncServer is the server API for SnapSync service.
+// All implementations must embed UnimplementedSnapSyncServer
+// for forward compatibility
+type SnapSyncServer interface {
+	// Returns the leaves of a trie following a key, with the proofs of the first and the last leaf
+	GetTrieRange(context.Context, *TrieRangeRequest) (*TrieRange, error)
+	// Returns the encoded trie nodes of the given hashes
+	GetTrieNodes(context.Context, *HashesRequest) (*TrieData, error)
+	// Returns the contract codes of the given hashes
+	GetCodes(context.Context, *HashesRequest) (*TrieData, error)
+	mustEmbedUnimplementedSnapSyncServer()
+}
+
+// UnimplementedSnapSyncServer must be embedded to have forward compatible implementations.
+type UnimplementedSnapSyncServer struct {
+}
+
+func (UnimplementedSnapSyncServer) GetTrieRange(context.Context, *TrieRangeRequest) (*TrieRange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrieRange not implemented")
+}
+func (UnimplementedSnapSyncServer) GetTrieNodes(context.Context, *HashesRequest) (*TrieData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrieNodes not implemented")
+}
+func (UnimplementedSnapSyncServer) GetCodes(context.Context, *HashesRequest) (*TrieData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCodes not implemented")
+}
+func (UnimplementedSnapSyncServer) mustEmbedUnimplementedSnapSyncServer() {}
+
+// UnsafeSnapSyncServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SnapSyncServer will
+// result in compilation errors.
+type UnsafeSnapSyncServer interface {
+	mustEmbedUnimplementedSnapSyncServer()
+}
+
+func RegisterSnapSyncServer(s grpc.ServiceRegistrar, srv SnapSyncServer) {
+	s.RegisterService(&SnapSync_ServiceDesc, srv)
+}
+
+func _SnapSync_GetTrieRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrieRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapSyncServer).GetTrieRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SnapSync/GetTrieRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapSyncServer).GetTrieRange(ctx, req.(*TrieRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnapSync_GetTrieNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapSyncServer).GetTrieNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SnapSync/GetTrieNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapSyncServer).GetTrieNodes(ctx, req.(*HashesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnapSync_GetCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapSyncServer).GetCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SnapSync/GetCodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapSyncServer).GetCodes(ctx, req.(*HashesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SnapSync_ServiceDesc is the grpc.ServiceDesc for SnapSync service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SnapSync_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.SnapSync",
+	HandlerType: (*SnapSyncServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTrieRange",
+			Handler:    _SnapSync_GetTrieRange_Handler,
+		},
+		{
+			MethodName: "GetTrieNodes",
+			Handler:    _SnapSync_GetTrieNodes_Handler,
+		},
+		{
+			MethodName: "GetCodes",
+			Handler:    _SnapSync_GetCodes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "syncer/proto/snap.proto",
+}
@@ -34,6 +34,8 @@ type GetBlocksRequest struct {
 
 	// The height of beginning block to sync
 	From uint64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	// The height of the last block to sync, the stream ends with the latest block if it is 0
+	To uint64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
 }
 
 func (x *GetBlocksRequest) Reset() {
@@ -75,6 +77,13 @@ func (x *GetBlocksRequest) GetFrom() uint64 {
 	return 0
 }
 
+func (x *GetBlocksRequest) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
 // Block contains a block data
 type Block struct {
 	state         protoimpl.MessageState
@@ -132,6 +141,8 @@ type SyncPeerStatus struct {
 
 	// Latest block height
 	Number uint64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// The lowest block height served with its body, the older history is pruned
+	HistoryTail uint64 `protobuf:"varint,2,opt,name=historyTail,proto3" json:"historyTail,omitempty"`
 }
 
 func (x *SyncPeerStatus) Reset() {
@@ -173,29 +184,371 @@ func (x *SyncPeerStatus) GetNumber() uint64 {
 	return 0
 }
 
+func (x *SyncPeerStatus) GetHistoryTail() uint64 {
+	if x != nil {
+		return x.HistoryTail
+	}
+	return 0
+}
+
+// ValidatorSnapshotRequest is a request for GetValidatorSnapshot
+type ValidatorSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The height of the block sealed by the validators
+	Number uint64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+func (x *ValidatorSnapshotRequest) Reset() {
+	*x = ValidatorSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_syncer_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorSnapshotRequest) ProtoMessage() {}
+
+func (x *ValidatorSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_syncer_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*ValidatorSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_syncer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ValidatorSnapshotRequest) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+// ValidatorSnapshot contains a validator set
+type ValidatorSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Consensus specific encoding of the validator set
+	Snapshot []byte `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *ValidatorSnapshot) Reset() {
+	*x = ValidatorSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_syncer_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorSnapshot) ProtoMessage() {}
+
+func (x *ValidatorSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_syncer_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorSnapshot.ProtoReflect.Descriptor instead.
+func (*ValidatorSnapshot) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_syncer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ValidatorSnapshot) GetSnapshot() []byte {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+// Headers contains block headers
+type Headers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RLP encoded headers
+	Headers [][]byte `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
+}
+
+func (x *Headers) Reset() {
+	*x = Headers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_syncer_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Headers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Headers) ProtoMessage() {}
+
+func (x *Headers) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_syncer_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Headers.ProtoReflect.Descriptor instead.
+func (*Headers) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_syncer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Headers) GetHeaders() [][]byte {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+// GetBodiesRequest is a request for GetBodies
+type GetBodiesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The hashes of the blocks whose bodies are requested
+	Hashes [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+}
+
+func (x *GetBodiesRequest) Reset() {
+	*x = GetBodiesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_syncer_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBodiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBodiesRequest) ProtoMessage() {}
+
+func (x *GetBodiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_syncer_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBodiesRequest.ProtoReflect.Descriptor instead.
+func (*GetBodiesRequest) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_syncer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetBodiesRequest) GetHashes() [][]byte {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+// Body contains the body of a block
+type Body struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RLP encoded transactions
+	Transactions [][]byte `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// RLP encoded uncle headers
+	Uncles [][]byte `protobuf:"bytes,2,rep,name=uncles,proto3" json:"uncles,omitempty"`
+}
+
+func (x *Body) Reset() {
+	*x = Body{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_syncer_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Body) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Body) ProtoMessage() {}
+
+func (x *Body) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_syncer_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Body.ProtoReflect.Descriptor instead.
+func (*Body) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_syncer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Body) GetTransactions() [][]byte {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *Body) GetUncles() [][]byte {
+	if x != nil {
+		return x.Uncles
+	}
+	return nil
+}
+
+// Bodies contains the bodies of the requested blocks, in the order of the request
+type Bodies struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bodies []*Body `protobuf:"bytes,1,rep,name=bodies,proto3" json:"bodies,omitempty"`
+}
+
+func (x *Bodies) Reset() {
+	*x = Bodies{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_syncer_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Bodies) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bodies) ProtoMessage() {}
+
+func (x *Bodies) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_syncer_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bodies.ProtoReflect.Descriptor instead.
+func (*Bodies) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_syncer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Bodies) GetBodies() []*Body {
+	if x != nil {
+		return x.Bodies
+	}
+	return nil
+}
+
 var File_syncer_proto_syncer_proto protoreflect.FileDescriptor
 
 var file_syncer_proto_syncer_proto_rawDesc = []byte{
 	0x0a, 0x19, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73,
 	0x79, 0x6e, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x1a,
 	0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x26, 0x0a, 0x10,
+	0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x36, 0x0a, 0x10,
 	0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
-	0x66, 0x72, 0x6f, 0x6d, 0x22, 0x1d, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x14, 0x0a,
+	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x02, 0x74, 0x6f, 0x22, 0x1d, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x14, 0x0a,
 	0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x6c,
-	0x6f, 0x63, 0x6b, 0x22, 0x28, 0x0a, 0x0e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x65, 0x65, 0x72, 0x53,
+	0x6f, 0x63, 0x6b, 0x22, 0x4a, 0x0a, 0x0e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x65, 0x65, 0x72, 0x53,
 	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x32, 0x73, 0x0a,
-	0x08, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x65, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x09, 0x47, 0x65, 0x74,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x76,
-	0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x09, 0x47, 0x65, 0x74,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x42, 0x0f, 0x5a, 0x0d, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x72, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x20, 0x0a,
+	0x0b, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x54, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x54, 0x61, 0x69, 0x6c, 0x22,
+	0x32, 0x0a, 0x18, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x22, 0x2f, 0x0a, 0x11, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x22, 0x23, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x22, 0x2a, 0x0a, 0x10, 0x47, 0x65, 0x74,
+	0x42, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x68,
+	0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x42, 0x0a, 0x04, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x22, 0x0a,
+	0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x06, 0x75, 0x6e, 0x63, 0x6c, 0x65, 0x73, 0x22, 0x2a, 0x0a, 0x06, 0x42, 0x6f, 0x64,
+	0x69, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x06, 0x62,
+	0x6f, 0x64, 0x69, 0x65, 0x73, 0x32, 0xa0, 0x02, 0x0a, 0x08, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x65,
+	0x65, 0x72, 0x12, 0x2e, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12,
+	0x14, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x30, 0x01, 0x12, 0x37, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e,
+	0x63, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4b, 0x0a, 0x14, 0x47,
+	0x65, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x12, 0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x2f, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x76,
+	0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x2d, 0x0a, 0x09, 0x47, 0x65, 0x74,
+	0x42, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x12, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42,
+	0x6f, 0x64, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x42, 0x0f, 0x5a, 0x0d, 0x2f, 0x73, 0x79, 0x6e,
+	0x63, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -210,23 +563,36 @@ func file_syncer_proto_syncer_proto_rawDescGZIP() []byte {
 	return file_syncer_proto_syncer_proto_rawDescData
 }
 
-var file_syncer_proto_syncer_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_syncer_proto_syncer_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_syncer_proto_syncer_proto_goTypes = []interface{}{
-	(*GetBlocksRequest)(nil), // 0: v1.GetBlocksRequest
-	(*Block)(nil),            // 1: v1.Block
-	(*SyncPeerStatus)(nil),   // 2: v1.SyncPeerStatus
-	(*emptypb.Empty)(nil),    // 3: google.protobuf.Empty
+	(*GetBlocksRequest)(nil),         // 0: v1.GetBlocksRequest
+	(*Block)(nil),                    // 1: v1.Block
+	(*SyncPeerStatus)(nil),           // 2: v1.SyncPeerStatus
+	(*ValidatorSnapshotRequest)(nil), // 3: v1.ValidatorSnapshotRequest
+	(*ValidatorSnapshot)(nil),        // 4: v1.ValidatorSnapshot
+	(*Headers)(nil),                  // 5: v1.Headers
+	(*GetBodiesRequest)(nil),         // 6: v1.GetBodiesRequest
+	(*Body)(nil),                     // 7: v1.Body
+	(*Bodies)(nil),                   // 8: v1.Bodies
+	(*emptypb.Empty)(nil),            // 9: google.protobuf.Empty
 }
 var file_syncer_proto_syncer_proto_depIdxs = []int32{
-	0, // 0: v1.SyncPeer.GetBlocks:input_type -> v1.GetBlocksRequest
-	3, // 1: v1.SyncPeer.GetStatus:input_type -> google.protobuf.Empty
-	1, // 2: v1.SyncPeer.GetBlocks:output_type -> v1.Block
-	2, // 3: v1.SyncPeer.GetStatus:output_type -> v1.SyncPeerStatus
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	7, // 0: v1.Bodies.bodies:type_name -> v1.Body
+	0, // 1: v1.SyncPeer.GetBlocks:input_type -> v1.GetBlocksRequest
+	9, // 2: v1.SyncPeer.GetStatus:input_type -> google.protobuf.Empty
+	3, // 3: v1.SyncPeer.GetValidatorSnapshot:input_type -> v1.ValidatorSnapshotRequest
+	0, // 4: v1.SyncPeer.GetHeaders:input_type -> v1.GetBlocksRequest
+	6, // 5: v1.SyncPeer.GetBodies:input_type -> v1.GetBodiesRequest
+	1, // 6: v1.SyncPeer.GetBlocks:output_type -> v1.Block
+	2, // 7: v1.SyncPeer.GetStatus:output_type -> v1.SyncPeerStatus
+	4, // 8: v1.SyncPeer.GetValidatorSnapshot:output_type -> v1.ValidatorSnapshot
+	5, // 9: v1.SyncPeer.GetHeaders:output_type -> v1.Headers
+	8, // 10: v1.SyncPeer.GetBodies:output_type -> v1.Bodies
+	6, // [6:11] is the sub-list for method output_type
+	1, // [1:6] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_syncer_proto_syncer_proto_init() }
@@ -271,6 +637,78 @@ func file_syncer_proto_syncer_proto_init() {
 				return nil
 			}
 		}
+		file_syncer_proto_syncer_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_syncer_proto_syncer_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_syncer_proto_syncer_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Headers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_syncer_proto_syncer_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBodiesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_syncer_proto_syncer_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Body); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_syncer_proto_syncer_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Bodies); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -278,7 +716,7 @@ func file_syncer_proto_syncer_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_syncer_proto_syncer_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -18,10 +18,16 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SyncPeerClient interface {
-	// Returns stream of blocks beginning specified from
+	// Returns stream of blocks beginning specified from, up to the specified last block
 	GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (SyncPeer_GetBlocksClient, error)
 	// Returns server's status
 	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SyncPeerStatus, error)
+	// Returns the validator set which seals the specified block
+	GetValidatorSnapshot(ctx context.Context, in *ValidatorSnapshotRequest, opts ...grpc.CallOption) (*ValidatorSnapshot, error)
+	// Returns the headers beginning from the specified block, up to the specified last block
+	GetHeaders(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (*Headers, error)
+	// Returns the bodies of the blocks of the specified hashes
+	GetBodies(ctx context.Context, in *GetBodiesRequest, opts ...grpc.CallOption) (*Bodies, error)
 }
 
 type syncPeerClient struct {
@@ -73,14 +79,47 @@ func (c *syncPeerClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *syncPeerClient) GetValidatorSnapshot(ctx context.Context, in *ValidatorSnapshotRequest, opts ...grpc.CallOption) (*ValidatorSnapshot, error) {
+	out := new(ValidatorSnapshot)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetValidatorSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncPeerClient) GetHeaders(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (*Headers, error) {
+	out := new(Headers)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetHeaders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncPeerClient) GetBodies(ctx context.Context, in *GetBodiesRequest, opts ...grpc.CallOption) (*Bodies, error) {
+	out := new(Bodies)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetBodies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SyncPeerServer is the server API for SyncPeer service.
 // All implementations must embed UnimplementedSyncPeerServer
 // for forward compatibility
 type SyncPeerServer interface {
-	// Returns stream of blocks beginning specified from
+	// Returns stream of blocks beginning specified from, up to the specified last block
 	GetBlocks(*GetBlocksRequest, SyncPeer_GetBlocksServer) error
 	// Returns server's status
 	GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error)
+	// Returns the validator set which seals the specified block
+	GetValidatorSnapshot(context.Context, *ValidatorSnapshotRequest) (*ValidatorSnapshot, error)
+	// Returns the headers beginning from the specified block, up to the specified last block
+	GetHeaders(context.Context, *GetBlocksRequest) (*Headers, error)
+	// Returns the bodies of the blocks of the specified hashes
+	GetBodies(context.Context, *GetBodiesRequest) (*Bodies, error)
 	mustEmbedUnimplementedSyncPeerServer()
 }
 
@@ -94,6 +133,15 @@ func (UnimplementedSyncPeerServer) GetBlocks(*GetBlocksRequest, SyncPeer_GetBloc
 func (UnimplementedSyncPeerServer) GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedSyncPeerServer) GetValidatorSnapshot(context.Context, *ValidatorSnapshotRequest) (*ValidatorSnapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidatorSnapshot not implemented")
+}
+func (UnimplementedSyncPeerServer) GetHeaders(context.Context, *GetBlocksRequest) (*Headers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeaders not implemented")
+}
+func (UnimplementedSyncPeerServer) GetBodies(context.Context, *GetBodiesRequest) (*Bodies, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBodies not implemented")
+}
 func (UnimplementedSyncPeerServer) mustEmbedUnimplementedSyncPeerServer() {}
 
 // UnsafeSyncPeerServer may be embedded to opt out of forward compatibility for this service.
@@ -146,6 +194,60 @@ func _SyncPeer_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SyncPeer_GetValidatorSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatorSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetValidatorSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetValidatorSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetValidatorSnapshot(ctx, req.(*ValidatorSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SyncPeer_GetHeaders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetHeaders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetHeaders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetHeaders(ctx, req.(*GetBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SyncPeer_GetBodies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBodiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetBodies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetBodies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetBodies(ctx, req.(*GetBodiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.SyncPeer",
 	HandlerType: (*SyncPeerServer)(nil),
@@ -154,6 +256,18 @@ var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatus",
 			Handler:    _SyncPeer_GetStatus_Handler,
 		},
+		{
+			MethodName: "GetValidatorSnapshot",
+			Handler:    _SyncPeer_GetValidatorSnapshot_Handler,
+		},
+		{
+			MethodName: "GetHeaders",
+			Handler:    _SyncPeer_GetHeaders_Handler,
+		},
+		{
+			MethodName: "GetBodies",
+			Handler:    _SyncPeer_GetBodies_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,207 @@
+package syncer
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// the score changes of the sync outcomes with a peer
+	syncSuccessScore = 1
+	syncFailureScore = -2
+	syncStallScore   = -5
+
+	// minSyncScore is the score at which a peer is banned from the sync
+	minSyncScore = -20
+
+	// maxSyncScore caps the score a peer recovers with successful syncs
+	maxSyncScore = 20
+
+	// peerBanDuration is the duration of the first ban of a peer, it doubles with each following ban
+	peerBanDuration = 10 * time.Minute
+
+	// maxPeerBanDuration is the maximum duration of a ban
+	maxPeerBanDuration = 24 * time.Hour
+)
+
+// peerFaults are the errors which prove that a peer served an invalid block: a block whose seal fails
+// the verification, whose hash or body does not match the expected one, or which does not follow its parent
+var peerFaults = []error{
+	blockchain.ErrInvalidSeal,
+	blockchain.ErrParentNotFound,
+	blockchain.ErrParentHashMismatch,
+	blockchain.ErrInvalidBlockSequence,
+	blockchain.ErrInvalidSha3Uncles,
+	blockchain.ErrInvalidTxRoot,
+	errCheckpointMismatch,
+	errUnexpectedBlock,
+	errBodyMismatch,
+}
+
+// isPeerFault checks if the error proves that the peer served an invalid block. The other verification
+// errors may be caused by the local node, so they are not held against the peer
+func isPeerFault(err error) bool {
+	for _, fault := range peerFaults {
+		if errors.Is(err, fault) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PeerReputation is the sync reputation of a peer
+type PeerReputation struct {
+	ID            peer.ID
+	Score         int64
+	Failures      uint64
+	Stalls        uint64
+	InvalidBlocks uint64
+	Bans          uint64
+
+	// BannedUntil is the time the ban of the peer expires at, zero if the peer was never banned
+	BannedUntil time.Time
+}
+
+// IsBanned returns whether the peer is banned from the sync at the given time
+func (r *PeerReputation) IsBanned(now time.Time) bool {
+	return now.Before(r.BannedUntil)
+}
+
+// peerReputations keeps the sync reputation of the peers, it outlives their connections,
+// so the peers are penalized across the sync attempts and the reconnections
+type peerReputations struct {
+	lock  sync.Mutex
+	peers map[peer.ID]*PeerReputation
+
+	// reportPeer reports the peers serving invalid blocks to the reputation of the networking server,
+	// so they are disconnected and banned from the network as well, nil if there is none
+	reportPeer func(peerID peer.ID, penalty common.PeerPenalty, reason string)
+
+	now func() time.Time
+}
+
+func newPeerReputations() *peerReputations {
+	return &peerReputations{
+		peers: make(map[peer.ID]*PeerReputation),
+		now:   time.Now,
+	}
+}
+
+// recordSuccess rewards the peer for a completed sync
+func (r *peerReputations) recordSuccess(peerID peer.ID) {
+	r.update(peerID, func(rep *PeerReputation) {
+		rep.Score = min(rep.Score+syncSuccessScore, maxSyncScore)
+	})
+}
+
+// recordFailure penalizes the peer for a failed sync
+func (r *peerReputations) recordFailure(peerID peer.ID) {
+	r.update(peerID, func(rep *PeerReputation) {
+		rep.Failures++
+		rep.Score += syncFailureScore
+	})
+}
+
+// recordStall penalizes the peer for streaming the blocks too slowly, or for not streaming them at all
+func (r *peerReputations) recordStall(peerID peer.ID) {
+	metrics.IncrCounter([]string{syncerMetrics, "stalled_peers"}, 1)
+
+	r.update(peerID, func(rep *PeerReputation) {
+		rep.Stalls++
+		rep.Score += syncStallScore
+	})
+}
+
+// recordInvalidBlock bans the peer from the sync for serving an invalid block, and reports it to the networking
+// server. It must be called only for the proven peer faults, see isPeerFault
+func (r *peerReputations) recordInvalidBlock(peerID peer.ID) {
+	r.update(peerID, func(rep *PeerReputation) {
+		rep.InvalidBlocks++
+		rep.Score = min(rep.Score, minSyncScore)
+	})
+
+	if r.reportPeer != nil {
+		r.reportPeer(peerID, common.PenaltyInvalidBlock, "served invalid block")
+	}
+}
+
+// update applies the change to the reputation of the peer, and bans the peer if its score
+// drops to the minimum. The score of a banned peer is reset, so it starts over once the ban expires
+func (r *peerReputations) update(peerID peer.ID, change func(*PeerReputation)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	rep, ok := r.peers[peerID]
+	if !ok {
+		rep = &PeerReputation{ID: peerID}
+		r.peers[peerID] = rep
+	}
+
+	change(rep)
+
+	if rep.Score > minSyncScore {
+		return
+	}
+
+	// the ban doubles with each repeated offense
+	duration := peerBanDuration << min(rep.Bans, 8)
+	if duration > maxPeerBanDuration {
+		duration = maxPeerBanDuration
+	}
+
+	rep.Bans++
+	rep.Score = 0
+	rep.BannedUntil = r.now().Add(duration)
+
+	metrics.IncrCounter([]string{syncerMetrics, "banned_peers"}, 1)
+}
+
+// bannedPeers returns the set of the currently banned peers
+func (r *peerReputations) bannedPeers() map[peer.ID]bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var (
+		now    = r.now()
+		banned = make(map[peer.ID]bool)
+	)
+
+	for id, rep := range r.peers {
+		if rep.IsBanned(now) {
+			banned[id] = true
+		}
+	}
+
+	return banned
+}
+
+// list returns copies of the reputations of the peers, ordered by their scores
+func (r *peerReputations) list() []*PeerReputation {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	list := make([]*PeerReputation, 0, len(r.peers))
+
+	for _, rep := range r.peers {
+		repCopy := *rep
+		list = append(list, &repCopy)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Score != list[j].Score {
+			return list[i].Score > list[j].Score
+		}
+
+		return list[i].ID < list[j].ID
+	})
+
+	return list
+}
@@ -0,0 +1,94 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+)
+
+func Test_peerReputations(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	reputations := newPeerReputations()
+	reputations.now = func() time.Time {
+		return now
+	}
+
+	// the failures lower the score until the peer is banned
+	for i := 0; i < -minSyncScore/-syncFailureScore-1; i++ {
+		reputations.recordFailure("A")
+	}
+
+	assert.Empty(t, reputations.bannedPeers())
+
+	reputations.recordFailure("A")
+	assert.Equal(t, map[peer.ID]bool{"A": true}, reputations.bannedPeers())
+
+	// the peer serving an invalid block is banned at once, for longer on a repeated offense
+	reputations.recordSuccess("B")
+	reputations.recordInvalidBlock("B")
+	reputations.recordInvalidBlock("B")
+	assert.Equal(t, map[peer.ID]bool{"A": true, "B": true}, reputations.bannedPeers())
+
+	reputations.recordStall("C")
+
+	list := reputations.list()
+	require.Len(t, list, 3)
+
+	assert.Equal(t, peer.ID("A"), list[0].ID)
+	assert.Equal(t, uint64(-minSyncScore/-syncFailureScore), list[0].Failures)
+	assert.Equal(t, uint64(1), list[0].Bans)
+	assert.Equal(t, now.Add(peerBanDuration), list[0].BannedUntil)
+
+	assert.Equal(t, peer.ID("B"), list[1].ID)
+	assert.Equal(t, uint64(2), list[1].InvalidBlocks)
+	assert.Equal(t, uint64(2), list[1].Bans)
+	assert.Equal(t, now.Add(2*peerBanDuration), list[1].BannedUntil)
+
+	assert.Equal(t, peer.ID("C"), list[2].ID)
+	assert.Equal(t, int64(syncStallScore), list[2].Score)
+	assert.Equal(t, uint64(1), list[2].Stalls)
+
+	// the bans expire
+	now = now.Add(peerBanDuration)
+	assert.Equal(t, map[peer.ID]bool{"B": true}, reputations.bannedPeers())
+
+	now = now.Add(peerBanDuration)
+	assert.Empty(t, reputations.bannedPeers())
+
+	// the ban duration is capped
+	for i := 0; i < 20; i++ {
+		reputations.recordInvalidBlock("D")
+	}
+
+	for _, rep := range reputations.list() {
+		if rep.ID == "D" {
+			assert.Equal(t, now.Add(maxPeerBanDuration), rep.BannedUntil)
+		}
+	}
+}
+
+func Test_isPeerFault(t *testing.T) {
+	t.Parallel()
+
+	// the proven faults of the peer
+	assert.True(t, isPeerFault(fmt.Errorf("%w, %w", errUnverifiableBlock,
+		fmt.Errorf("failed to verify the header: %w", blockchain.ErrInvalidSeal))))
+	assert.True(t, isPeerFault(fmt.Errorf("%w, %w", errUnverifiableBlock, blockchain.ErrParentHashMismatch)))
+	assert.True(t, isPeerFault(fmt.Errorf("%w: %w", errInvalidHeaders, blockchain.ErrInvalidSeal)))
+	assert.True(t, isPeerFault(fmt.Errorf("%w: block 10 has hash 0x1", errCheckpointMismatch)))
+
+	// the verification errors which may be caused by the local node
+	assert.False(t, isPeerFault(fmt.Errorf("%w, %w", errUnverifiableBlock, blockchain.ErrInvalidStateRoot)))
+	assert.False(t, isPeerFault(fmt.Errorf("%w: %w", errInvalidHeaders, errors.New("validators not found"))))
+	assert.False(t, isPeerFault(errTimeout))
+}
@@ -3,6 +3,7 @@ package syncer
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/network/grpc"
 	"github.com/0xPolygon/polygon-edge/syncer/proto"
@@ -11,25 +12,40 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 )
 
+const (
+	// maxHeadersServed is the maximum number of headers served in a response
+	maxHeadersServed = 512
+	// maxBodiesServed is the maximum number of bodies served in a response
+	maxBodiesServed = 128
+	// maxBodiesResponseSize is the size after which no more bodies are added to a response,
+	// it keeps the responses below the gRPC message size limit
+	maxBodiesResponseSize = 2 * 1024 * 1024
+)
+
 var (
-	ErrBlockNotFound = errors.New("block not found")
+	ErrBlockNotFound      = errors.New("block not found")
+	ErrHistoryPruned      = errors.New("history pruned")
+	ErrSnapshotsNotServed = errors.New("validator snapshots are not served")
 )
 
 type syncPeerService struct {
 	proto.UnimplementedSyncPeerServer
 
-	blockchain Blockchain       // reference to the blockchain module
-	network    Network          // reference to the network module
-	stream     *grpc.GrpcStream // reference to the grpc stream
+	blockchain Blockchain         // reference to the blockchain module
+	network    Network            // reference to the network module
+	verifier   CheckpointVerifier // serves the validator snapshots, nil if they are not served
+	stream     *grpc.GrpcStream   // reference to the grpc stream
 }
 
 func NewSyncPeerService(
 	network Network,
 	blockchain Blockchain,
+	verifier CheckpointVerifier,
 ) SyncPeerService {
 	return &syncPeerService{
 		blockchain: blockchain,
 		network:    network,
+		verifier:   verifier,
 	}
 }
 
@@ -57,8 +73,13 @@ func (s *syncPeerService) GetBlocks(
 	req *proto.GetBlocksRequest,
 	stream proto.SyncPeer_GetBlocksServer,
 ) error {
-	// from to latest
-	for i := req.From; i <= s.blockchain.Header().Number; i++ {
+	// the pruned blocks can't be served in full
+	if tail := s.blockchain.HistoryTail(); req.From < tail {
+		return fmt.Errorf("%w: the lowest block served is %d", ErrHistoryPruned, tail)
+	}
+
+	// from to latest, or to the requested last block
+	for i := req.From; i <= s.blockchain.Header().Number && (req.To == 0 || i <= req.To); i++ {
 		block, ok := s.blockchain.GetBlockByNumber(i, true)
 		if !ok {
 			return ErrBlockNotFound
@@ -87,13 +108,112 @@ func (s *syncPeerService) GetStatus(
 	}
 
 	return &proto.SyncPeerStatus{
-		Number: number,
+		Number:      number,
+		HistoryTail: s.blockchain.HistoryTail(),
 	}, nil
 }
 
+// GetValidatorSnapshot is a gRPC endpoint to return the validator set which seals the requested block
+func (s *syncPeerService) GetValidatorSnapshot(
+	ctx context.Context,
+	req *proto.ValidatorSnapshotRequest,
+) (*proto.ValidatorSnapshot, error) {
+	if s.verifier == nil {
+		return nil, ErrSnapshotsNotServed
+	}
+
+	snapshot, err := s.verifier.ValidatorSnapshot(req.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ValidatorSnapshot{Snapshot: snapshot}, nil
+}
+
+// GetHeaders is a gRPC endpoint to return the headers from the specific height up to the requested last block.
+// The headers of the pruned blocks are kept, so they are served regardless of the history tail
+func (s *syncPeerService) GetHeaders(
+	ctx context.Context,
+	req *proto.GetBlocksRequest,
+) (*proto.Headers, error) {
+	to := s.blockchain.Header().Number
+	if req.To != 0 && req.To < to {
+		to = req.To
+	}
+
+	if req.From+maxHeadersServed-1 < to {
+		to = req.From + maxHeadersServed - 1
+	}
+
+	res := &proto.Headers{}
+
+	for i := req.From; i <= to; i++ {
+		header, ok := s.blockchain.GetHeaderByNumber(i)
+		if !ok {
+			return nil, ErrBlockNotFound
+		}
+
+		res.Headers = append(res.Headers, header.MarshalRLP())
+	}
+
+	return res, nil
+}
+
+// GetBodies is a gRPC endpoint to return the bodies of the requested blocks. The response ends
+// at the first body which is not found, or once it is large enough
+func (s *syncPeerService) GetBodies(
+	ctx context.Context,
+	req *proto.GetBodiesRequest,
+) (*proto.Bodies, error) {
+	var (
+		res  = &proto.Bodies{}
+		size = 0
+	)
+
+	for i, hash := range req.Hashes {
+		if i == maxBodiesServed || size > maxBodiesResponseSize {
+			break
+		}
+
+		body, ok := s.blockchain.GetBodyByHash(types.BytesToHash(hash))
+		if !ok {
+			break
+		}
+
+		protoBody := toProtoBody(body)
+		res.Bodies = append(res.Bodies, protoBody)
+
+		for _, tx := range protoBody.Transactions {
+			size += len(tx)
+		}
+	}
+
+	metrics.SetGauge([]string{syncerMetrics, "egress_bytes"}, float32(size))
+
+	return res, nil
+}
+
 // toProtoBlock converts type.Block -> proto.Block
 func toProtoBlock(block *types.Block) *proto.Block {
 	return &proto.Block{
 		Block: block.MarshalRLP(),
 	}
 }
+
+// toProtoBody converts types.Body -> proto.Body
+func toProtoBody(body *types.Body) *proto.Body {
+	protoBody := &proto.Body{
+		Transactions: make([][]byte, len(body.Transactions)),
+		Uncles:       make([][]byte, len(body.Uncles)),
+	}
+
+	for i, tx := range body.Transactions {
+		protoBody.Transactions[i] = tx.MarshalRLP()
+	}
+
+	for i, uncle := range body.Uncles {
+		protoBody.Uncles[i] = uncle.MarshalRLP()
+	}
+
+	return protoBody
+}
@@ -60,7 +60,9 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 	tests := []struct {
 		name           string
 		from           uint64
+		to             uint64
 		latest         uint64
+		historyTail    uint64
 		blocks         []*types.Block
 		receivedBlocks []*types.Block
 		err            error
@@ -73,6 +75,15 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 			receivedBlocks: blocks[4:], // from 5
 			err:            io.EOF,
 		},
+		{
+			name:           "should send the blocks to the last requested",
+			from:           5,
+			to:             7,
+			latest:         10,
+			blocks:         blocks,
+			receivedBlocks: blocks[4:7], // from 5 to 7
+			err:            io.EOF,
+		},
 		{
 			name:           "should return ErrBlockNotFound",
 			from:           5,
@@ -81,6 +92,15 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 			receivedBlocks: blocks[4:8], // from 5
 			err:            ErrBlockNotFound,
 		},
+		{
+			name:           "should return ErrHistoryPruned",
+			from:           5,
+			latest:         10,
+			historyTail:    6,
+			blocks:         blocks,
+			receivedBlocks: nil,
+			err:            ErrHistoryPruned,
+		},
 	}
 
 	for _, test := range tests {
@@ -97,6 +117,7 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 			service := &syncPeerService{
 				blockchain: &mockBlockchain{
 					headerHandler: newSimpleHeaderHandler(test.latest),
+					historyTail:   test.historyTail,
 					getBlockByNumberHandler: func(u uint64, _ bool) (*types.Block, bool) {
 						block, ok := blockMap[u]
 						if !ok {
@@ -112,6 +133,7 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 
 			stream, err := client.GetBlocks(context.Background(), &proto.GetBlocksRequest{
 				From: test.from,
+				To:   test.to,
 			})
 
 			assert.NoError(t, err)
@@ -144,6 +166,7 @@ func TestGetStatus(t *testing.T) {
 	service := &syncPeerService{
 		blockchain: &mockBlockchain{
 			headerHandler: newSimpleHeaderHandler(headerNumber),
+			historyTail:   4,
 		},
 	}
 
@@ -153,4 +176,51 @@ func TestGetStatus(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.Equal(t, headerNumber, status.Number)
+	assert.Equal(t, uint64(4), status.HistoryTail)
+}
+
+func Test_syncPeerService_GetHeadersAndBodies(t *testing.T) {
+	t.Parallel()
+
+	blocks := createMockBlocks(10)
+	for _, b := range blocks {
+		b.Header.ComputeHash()
+	}
+
+	service := &syncPeerService{
+		blockchain: &mockBlockchain{
+			headerHandler: newSimpleHeaderHandler(10),
+			// the bodies of the pruned blocks are missing
+			historyTail: 5,
+			getHeaderByNumberHandler: func(n uint64) (*types.Header, bool) {
+				return blocks[n-1].Header, true
+			},
+			getBodyByHashHandler: func(hash types.Hash) (*types.Body, bool) {
+				for _, b := range blocks[4:] {
+					if b.Hash() == hash {
+						return b.Body(), true
+					}
+				}
+
+				return nil, false
+			},
+		},
+	}
+
+	client := newMockGrpcClient(t, service)
+
+	headers, err := client.GetHeaders(context.Background(), &proto.GetBlocksRequest{From: 3, To: 20})
+	assert.NoError(t, err)
+	assert.Len(t, headers.Headers, 8) // from 3 to the latest
+
+	for i, raw := range headers.Headers {
+		assert.Equal(t, blocks[i+2].Header.MarshalRLP(), raw)
+	}
+
+	// the bodies end at the first missing one
+	bodies, err := client.GetBodies(context.Background(), &proto.GetBodiesRequest{
+		Hashes: [][]byte{blocks[5].Hash().Bytes(), blocks[6].Hash().Bytes(), blocks[1].Hash().Bytes()},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, bodies.Bodies, 2)
 }
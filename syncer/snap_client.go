@@ -0,0 +1,141 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const defaultTimeoutForSnap = 30 * time.Second
+
+// snapClient requests the state tries from the peers
+type snapClient struct {
+	network Network // reference to the network module
+
+	// the connections are kept open for the requests of a sync until the stream is closed
+	clients     map[peer.ID]proto.SnapSyncClient
+	clientsLock sync.Mutex
+}
+
+func newSnapClient(network Network) *snapClient {
+	return &snapClient{
+		network: network,
+		clients: map[peer.ID]proto.SnapSyncClient{},
+	}
+}
+
+// GetTrieRange fetches at most max leaves following after of the trie with the given root
+func (c *snapClient) GetTrieRange(
+	peerID peer.ID,
+	root types.Hash,
+	after []byte,
+	max uint64,
+) (*itrie.TrieRange, error) {
+	clt, err := c.newSnapSyncClient(peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForSnap)
+	defer cancel()
+
+	res, err := clt.GetTrieRange(ctx, &proto.TrieRangeRequest{
+		Root:  root.Bytes(),
+		After: after,
+		Max:   max,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &itrie.TrieRange{
+		Keys:   res.Keys,
+		Values: res.Values,
+		Proof:  res.Proof,
+		More:   res.More,
+	}, nil
+}
+
+// GetTrieNodes fetches the encoded trie nodes of the given hashes, the peer omits the unknown ones
+func (c *snapClient) GetTrieNodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error) {
+	clt, err := c.newSnapSyncClient(peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForSnap)
+	defer cancel()
+
+	res, err := clt.GetTrieNodes(ctx, toHashesRequest(hashes))
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// GetCodes fetches the contract codes of the given hashes, the peer omits the unknown ones
+func (c *snapClient) GetCodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error) {
+	clt, err := c.newSnapSyncClient(peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForSnap)
+	defer cancel()
+
+	res, err := clt.GetCodes(ctx, toHashesRequest(hashes))
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// CloseStream closes the stream to the peer
+func (c *snapClient) CloseStream(peerID peer.ID) error {
+	c.clientsLock.Lock()
+	defer c.clientsLock.Unlock()
+
+	delete(c.clients, peerID)
+
+	return c.network.CloseProtocolStream(snapProto, peerID)
+}
+
+// newSnapSyncClient returns the gRPC client of the peer, opening a stream if there is none
+func (c *snapClient) newSnapSyncClient(peerID peer.ID) (proto.SnapSyncClient, error) {
+	c.clientsLock.Lock()
+	defer c.clientsLock.Unlock()
+
+	if clt, ok := c.clients[peerID]; ok {
+		return clt, nil
+	}
+
+	conn, err := c.network.NewProtoConnection(snapProto, peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a stream, err %w", err)
+	}
+
+	c.network.SaveProtocolStream(snapProto, conn, peerID)
+
+	clt := proto.NewSnapSyncClient(conn)
+	c.clients[peerID] = clt
+
+	return clt, nil
+}
+
+func toHashesRequest(hashes []types.Hash) *proto.HashesRequest {
+	req := &proto.HashesRequest{Hashes: make([][]byte, len(hashes))}
+
+	for i, hash := range hashes {
+		req.Hashes[i] = hash.Bytes()
+	}
+
+	return req
+}
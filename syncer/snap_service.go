@@ -0,0 +1,131 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	snapProto = "/snap/0.1"
+
+	// maxTrieRangeLeaves is the maximum number of leaves served in a trie range
+	maxTrieRangeLeaves = 4096
+	// maxTrieDataItems is the maximum number of trie nodes or codes served in a response
+	maxTrieDataItems = 1024
+)
+
+// snapService serves the state tries to the peers which sync the state
+type snapService struct {
+	proto.UnimplementedSnapSyncServer
+
+	storage itrie.Storage    // reference to the state storage
+	network Network          // reference to the network module
+	stream  *grpc.GrpcStream // reference to the grpc stream
+}
+
+func newSnapService(network Network, storage itrie.Storage) *snapService {
+	return &snapService{
+		storage: storage,
+		network: network,
+	}
+}
+
+// Start starts snapService
+func (s *snapService) Start() {
+	s.stream = grpc.NewGrpcStream()
+
+	proto.RegisterSnapSyncServer(s.stream.GrpcServer(), s)
+	s.stream.Serve()
+	s.network.RegisterProtocol(snapProto, s.stream)
+}
+
+// Close closes snapService
+func (s *snapService) Close() error {
+	return s.stream.Close()
+}
+
+// GetTrieRange is a gRPC endpoint to return the leaves of a trie following a key
+func (s *snapService) GetTrieRange(
+	ctx context.Context,
+	req *proto.TrieRangeRequest,
+) (*proto.TrieRange, error) {
+	if len(req.Root) != types.HashLength || (len(req.After) != 0 && len(req.After) != types.HashLength) {
+		return nil, fmt.Errorf("%w: invalid root or key", itrie.ErrInvalidRange)
+	}
+
+	max := req.Max
+	if max == 0 || max > maxTrieRangeLeaves {
+		max = maxTrieRangeLeaves
+	}
+
+	var after []byte
+	if len(req.After) != 0 {
+		after = req.After
+	}
+
+	res, err := itrie.ReadTrieRange(s.storage, types.BytesToHash(req.Root), after, int(max))
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.TrieRange{
+		Keys:   res.Keys,
+		Values: res.Values,
+		Proof:  res.Proof,
+		More:   res.More,
+	}, nil
+}
+
+// GetTrieNodes is a gRPC endpoint to return the encoded trie nodes of the given hashes
+func (s *snapService) GetTrieNodes(
+	ctx context.Context,
+	req *proto.HashesRequest,
+) (*proto.TrieData, error) {
+	res := &proto.TrieData{}
+
+	for _, hash := range limitHashes(req.Hashes) {
+		if len(hash) != types.HashLength {
+			continue
+		}
+
+		data, ok, err := s.storage.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			res.Data = append(res.Data, data)
+		}
+	}
+
+	return res, nil
+}
+
+// GetCodes is a gRPC endpoint to return the contract codes of the given hashes
+func (s *snapService) GetCodes(
+	ctx context.Context,
+	req *proto.HashesRequest,
+) (*proto.TrieData, error) {
+	res := &proto.TrieData{}
+
+	for _, hash := range limitHashes(req.Hashes) {
+		if code, ok := s.storage.GetCode(types.BytesToHash(hash)); ok {
+			res.Data = append(res.Data, code)
+		}
+	}
+
+	return res, nil
+}
+
+func limitHashes(hashes [][]byte) [][]byte {
+	if len(hashes) > maxTrieDataItems {
+		return hashes[:maxTrieDataItems]
+	}
+
+	return hashes
+}
@@ -0,0 +1,415 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// stateSyncPivotDistance is the number of blocks the pivot block of the state sync is below the
+	// head of the peer, so the peer still has its state when the sync completes
+	stateSyncPivotDistance = 64
+
+	// stateSyncRangeSize is the number of leaves requested in a trie range
+	stateSyncRangeSize = 1024
+
+	// stateSyncHealBatch is the number of trie nodes or codes requested at once
+	stateSyncHealBatch = 256
+)
+
+var (
+	errMissingTrieData = errors.New("peer does not have the requested trie nodes or codes")
+)
+
+// StateSyncConfig is the configuration of the state sync
+type StateSyncConfig struct {
+	// Storage is the state storage the tries are served from and synced into.
+	// The tries are not served if it is not set
+	Storage itrie.Storage
+
+	// Enabled makes a node without a synced state download the state of a recent pivot block
+	// from its peers, instead of executing all the blocks from genesis
+	Enabled bool
+}
+
+// stateSyncer downloads the state tries of a state root from a peer. The account and storage tries
+// are downloaded as ranges of leaves from which the tries are rebuilt locally. The ranges only have
+// their ends proven, so the tries which do not match their roots afterwards are healed by
+// downloading their missing nodes. The storage tries and the codes of the accounts are synced
+// before the accounts are written, so a stored trie node always has its whole subtree stored
+type stateSyncer struct {
+	logger  hclog.Logger
+	storage itrie.Storage
+	client  *snapClient
+}
+
+func newStateSyncer(logger hclog.Logger, network Network, storage itrie.Storage) *stateSyncer {
+	return &stateSyncer{
+		logger:  logger,
+		storage: storage,
+		client:  newSnapClient(network),
+	}
+}
+
+// hasState returns whether the state of the given root is stored
+func (s *stateSyncer) hasState(root types.Hash) (bool, error) {
+	if root == types.EmptyRootHash {
+		return true, nil
+	}
+
+	_, ok, err := s.storage.Get(root.Bytes())
+
+	return ok, err
+}
+
+// sync downloads the state of the given root from the peer
+func (s *stateSyncer) sync(peerID peer.ID, root types.Hash) error {
+	defer func() {
+		if err := s.client.CloseStream(peerID); err != nil {
+			s.logger.Error("Failed to close stream: ", err)
+		}
+	}()
+
+	if ok, err := s.hasState(root); err != nil || ok {
+		return err
+	}
+
+	s.logger.Info("syncing state", "root", root, "peer", peerID)
+
+	var (
+		builder  = itrie.NewTrieBuilder(s.storage)
+		after    []byte
+		accounts uint64
+	)
+
+	for {
+		rng, err := s.fetchRange(peerID, root, after)
+		if err != nil {
+			return err
+		}
+
+		codes := make([]types.Hash, 0, len(rng.Keys))
+
+		for _, value := range rng.Values {
+			var account state.Account
+			if err := account.UnmarshalRlp(value); err != nil {
+				return fmt.Errorf("failed to decode account: %w", err)
+			}
+
+			if err := s.syncStorage(peerID, account.Root); err != nil {
+				return err
+			}
+
+			codes = append(codes, types.BytesToHash(account.CodeHash))
+		}
+
+		if err := s.syncCodes(peerID, codes); err != nil {
+			return err
+		}
+
+		// the accounts are written only once their storage and code are
+		for i, key := range rng.Keys {
+			if err := builder.Insert(key, rng.Values[i]); err != nil {
+				return err
+			}
+		}
+
+		accounts += uint64(len(rng.Keys))
+		metrics.SetGauge([]string{syncerMetrics, "state_sync_accounts"}, float32(accounts))
+
+		if !rng.More {
+			break
+		}
+
+		after = rng.Keys[len(rng.Keys)-1]
+
+		s.logger.Debug("synced accounts", "accounts", accounts, "last", types.BytesToHash(after))
+	}
+
+	built, err := builder.Commit()
+	if err != nil {
+		return err
+	}
+
+	if built != root {
+		s.logger.Info("healing state trie", "root", root, "built root", built)
+
+		if err := s.heal(peerID, root, false); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("state synced", "root", root, "accounts", accounts)
+
+	return nil
+}
+
+// syncStorage downloads the storage trie of the given root from the peer
+func (s *stateSyncer) syncStorage(peerID peer.ID, root types.Hash) error {
+	if ok, err := s.hasState(root); err != nil || ok {
+		return err
+	}
+
+	var (
+		builder = itrie.NewTrieBuilder(s.storage)
+		after   []byte
+	)
+
+	for {
+		rng, err := s.fetchRange(peerID, root, after)
+		if err != nil {
+			return err
+		}
+
+		for i, key := range rng.Keys {
+			if err := builder.Insert(key, rng.Values[i]); err != nil {
+				return err
+			}
+		}
+
+		if !rng.More {
+			break
+		}
+
+		after = rng.Keys[len(rng.Keys)-1]
+	}
+
+	built, err := builder.Commit()
+	if err != nil {
+		return err
+	}
+
+	if built != root {
+		return s.heal(peerID, root, true)
+	}
+
+	return nil
+}
+
+// fetchRange fetches and verifies the range of the trie leaves following after
+func (s *stateSyncer) fetchRange(peerID peer.ID, root types.Hash, after []byte) (*itrie.TrieRange, error) {
+	rng, err := s.client.GetTrieRange(peerID, root, after, stateSyncRangeSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trie range of root %s: %w", root, err)
+	}
+
+	if err := rng.Verify(root, after); err != nil {
+		metrics.IncrCounter([]string{syncerMetrics, "bad_trie_range"}, 1)
+
+		return nil, fmt.Errorf("invalid trie range of root %s: %w", root, err)
+	}
+
+	if rng.More && len(rng.Keys) == 0 {
+		return nil, fmt.Errorf("empty trie range of root %s with more leaves", root)
+	}
+
+	return rng, nil
+}
+
+// syncCodes downloads the missing codes of the given hashes from the peer
+func (s *stateSyncer) syncCodes(peerID peer.ID, hashes []types.Hash) error {
+	missing := make(map[types.Hash]struct{})
+
+	for _, hash := range hashes {
+		if hash == types.EmptyCodeHash || hash == types.ZeroHash {
+			continue
+		}
+
+		if _, ok := s.storage.GetCode(hash); !ok {
+			missing[hash] = struct{}{}
+		}
+	}
+
+	request := make([]types.Hash, 0, len(missing))
+	for hash := range missing {
+		request = append(request, hash)
+	}
+
+	for len(request) > 0 {
+		batch := request
+		if len(batch) > stateSyncHealBatch {
+			batch = batch[:stateSyncHealBatch]
+		}
+
+		request = request[len(batch):]
+
+		codes, err := s.client.GetCodes(peerID, batch)
+		if err != nil {
+			return fmt.Errorf("failed to get codes: %w", err)
+		}
+
+		for _, code := range codes {
+			hash := types.BytesToHash(crypto.Keccak256(code))
+			if _, ok := missing[hash]; !ok {
+				continue
+			}
+
+			if err := s.storage.SetCode(hash, code); err != nil {
+				return err
+			}
+
+			delete(missing, hash)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %d codes", errMissingTrieData, len(missing))
+	}
+
+	return nil
+}
+
+// heal downloads the nodes missing from the trie of the given root from the peer,
+// following the account leaves of a state trie into their storage tries and codes
+func (s *stateSyncer) heal(peerID peer.ID, root types.Hash, isStorage bool) error {
+	sched, err := itrie.NewTrieSync(s.storage, root, isStorage)
+	if err != nil {
+		return err
+	}
+
+	for sched.Pending() > 0 {
+		nodes, codes := sched.Missing(stateSyncHealBatch)
+		delivered := 0
+
+		if len(nodes) > 0 {
+			data, err := s.client.GetTrieNodes(peerID, nodes)
+			if err != nil {
+				return fmt.Errorf("failed to get trie nodes: %w", err)
+			}
+
+			for _, node := range data {
+				if err := sched.ProcessNode(node); err != nil {
+					return err
+				}
+			}
+
+			delivered += len(data)
+		}
+
+		if len(codes) > 0 {
+			data, err := s.client.GetCodes(peerID, codes)
+			if err != nil {
+				return fmt.Errorf("failed to get codes: %w", err)
+			}
+
+			for _, code := range data {
+				if err := sched.ProcessCode(code); err != nil {
+					return err
+				}
+			}
+
+			delivered += len(data)
+		}
+
+		if delivered == 0 {
+			return fmt.Errorf("%w: %d pending", errMissingTrieData, sched.Pending())
+		}
+
+		metrics.IncrCounter([]string{syncerMetrics, "state_sync_healed"}, float32(delivered))
+	}
+
+	return nil
+}
+
+// shouldStateSync returns whether the state is synced from the peer before the blocks are,
+// which is the case for a node with only the genesis block far behind the peer,
+// or for a node whose head block was written by a state sync which did not complete
+func (s *syncer) shouldStateSync(peer *NoForkPeer) (bool, error) {
+	if s.stateSyncer == nil {
+		return false, nil
+	}
+
+	head := s.blockchain.Header()
+
+	ok, err := s.stateSyncer.hasState(head.StateRoot)
+	if err != nil || !ok {
+		return err == nil, err
+	}
+
+	return head.Number == 0 && peer.Number > stateSyncPivotDistance, nil
+}
+
+// stateSyncWithPeer writes the blocks up to a pivot block below the head of the peer
+// without executing them, and downloads the state of the pivot block from the peer
+func (s *syncer) stateSyncWithPeer(peer *NoForkPeer) error {
+	head := s.blockchain.Header()
+
+	ok, err := s.stateSyncer.hasState(head.StateRoot)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		if err := s.syncPivotChain(peer.ID, peer.Number-stateSyncPivotDistance); err != nil {
+			return err
+		}
+
+		head = s.blockchain.Header()
+	}
+
+	return s.stateSyncer.sync(peer.ID, head.StateRoot)
+}
+
+// syncPivotChain writes the blocks following the local head up to the pivot block, verifying
+// their headers and bodies without executing them
+func (s *syncer) syncPivotChain(peerID peer.ID, pivot uint64) error {
+	localLatest := s.blockchain.Header().Number
+
+	blockCh, err := s.syncPeerClient.GetBlocks(peerID, localLatest+1, s.blockTimeout)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("syncing blocks up to the state sync pivot", "from", localLatest+1, "pivot", pivot, "peer", peerID)
+
+	subscription := s.blockchain.SubscribeEvents()
+	s.syncProgression.StartProgression(localLatest+1, subscription)
+	s.syncProgression.UpdateHighestProgression(pivot)
+
+	defer func() {
+		if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+			s.logger.Error("Failed to close stream: ", err)
+		}
+
+		// release the stream goroutine of the blocks following the pivot
+		for range blockCh {
+		}
+
+		s.syncProgression.StopProgression()
+		s.blockchain.UnsubscribeEvents(subscription)
+	}()
+
+	lastNumber := localLatest
+
+	for {
+		select {
+		case block, ok := <-blockCh:
+			if !ok {
+				return fmt.Errorf("blocks ended with block %d before pivot block %d", lastNumber, pivot)
+			}
+
+			if err := s.blockchain.WriteSyncedBlock(block, syncerName); err != nil {
+				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+				return fmt.Errorf("failed to write block %d while syncing to the pivot: %w", block.Number(), err)
+			}
+
+			lastNumber = block.Number()
+
+			if lastNumber >= pivot {
+				return nil
+			}
+		case <-time.After(s.blockTimeout):
+			return errTimeout
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package syncer
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// createTestState writes a state of n accounts with storage, some of them with code
+func createTestState(t *testing.T, storage itrie.Storage, n int) (types.Hash, []*state.Object) {
+	t.Helper()
+
+	code := []byte{0x60, 0x01, 0x60, 0x02}
+	objs := make([]*state.Object, 0, n)
+
+	for i := 0; i < n; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress([]byte{byte(i), byte(i >> 8), 0x1}),
+			Nonce:    uint64(i + 1),
+			Balance:  big.NewInt(int64(i)),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash,
+			Storage: []*state.StorageObject{
+				{
+					Key: types.BytesToHash([]byte{byte(i)}).Bytes(),
+					Val: types.BytesToHash([]byte{byte(i + 1)}).Bytes(),
+				},
+			},
+		}
+
+		if i%10 == 0 {
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+			obj.Code = code
+			obj.DirtyCode = true
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, root, err := itrie.NewState(storage).NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	return types.BytesToHash(root), objs
+}
+
+func createTestSnapService(t *testing.T, storage itrie.Storage) *network.Server {
+	t.Helper()
+
+	srv := newTestNetwork(t)
+	newSnapService(srv, storage).Start()
+
+	return srv
+}
+
+func TestStateSync(t *testing.T) {
+	t.Parallel()
+
+	source := itrie.NewMemoryStorage()
+	root, objs := createTestState(t, source, 3000)
+
+	requireSynced := func(t *testing.T, synced itrie.Storage) {
+		t.Helper()
+
+		snap, err := itrie.NewState(synced).NewSnapshotAt(root)
+		require.NoError(t, err)
+
+		for i, obj := range objs {
+			account, err := snap.GetAccount(obj.Address)
+			require.NoError(t, err)
+			require.Equal(t, obj.Nonce, account.Nonce)
+
+			value := snap.GetStorage(obj.Address, account.Root, types.BytesToHash([]byte{byte(i)}))
+			require.Equal(t, types.BytesToHash([]byte{byte(i + 1)}), value)
+
+			_, ok := snap.GetCode(types.BytesToHash(account.CodeHash))
+			require.True(t, ok)
+		}
+	}
+
+	syncFromPeer := func(t *testing.T, peerStorage, synced itrie.Storage) error {
+		t.Helper()
+
+		// the nodes syncing the state serve their own state as well
+		peerSrv := createTestSnapService(t, peerStorage)
+		clientSrv := createTestSnapService(t, synced)
+
+		t.Cleanup(func() {
+			clientSrv.Close()
+			peerSrv.Close()
+		})
+
+		require.NoError(t, network.JoinAndWait(
+			clientSrv,
+			peerSrv,
+			network.DefaultBufferTimeout,
+			network.DefaultJoinTimeout,
+		))
+
+		return newStateSyncer(hclog.NewNullLogger(), clientSrv, synced).sync(peerSrv.AddrInfo().ID, root)
+	}
+
+	t.Run("should sync the state into an empty storage", func(t *testing.T) {
+		t.Parallel()
+
+		synced := itrie.NewMemoryStorage()
+
+		require.NoError(t, syncFromPeer(t, source, synced))
+		requireSynced(t, synced)
+	})
+
+	t.Run("should reuse the state of an interrupted sync", func(t *testing.T) {
+		t.Parallel()
+
+		// the state of a previous sync has a part of the accounts of the root
+		synced := itrie.NewMemoryStorage()
+		partial, _ := createTestState(t, synced, 1500)
+		require.NotEqual(t, root, partial)
+
+		require.NoError(t, syncFromPeer(t, source, synced))
+		requireSynced(t, synced)
+	})
+
+	t.Run("should return error if the peer does not have the state", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Error(t, syncFromPeer(t, itrie.NewMemoryStorage(), itrie.NewMemoryStorage()))
+	})
+}
+
+func Test_syncer_stateSyncWithPeer(t *testing.T) {
+	t.Parallel()
+
+	source := itrie.NewMemoryStorage()
+	root, _ := createTestState(t, source, 10)
+
+	synced := itrie.NewMemoryStorage()
+	peerSrv := createTestSnapService(t, source)
+	clientSrv := createTestSnapService(t, synced)
+
+	t.Cleanup(func() {
+		clientSrv.Close()
+		peerSrv.Close()
+	})
+
+	require.NoError(t, network.JoinAndWait(
+		clientSrv,
+		peerSrv,
+		network.DefaultBufferTimeout,
+		network.DefaultJoinTimeout,
+	))
+
+	var (
+		peerID = peerSrv.AddrInfo().ID
+		blocks = createMockBlocks(100)
+		head   = &types.Header{Number: 0, StateRoot: types.EmptyRootHash}
+	)
+
+	for _, b := range blocks {
+		b.Header.StateRoot = root
+	}
+
+	syncer := NewTestSyncer(
+		nil,
+		&mockBlockchain{
+			headerHandler: func() *types.Header {
+				return head
+			},
+			writeSyncedBlockHandler: func(b *types.Block) error {
+				head = b.Header
+
+				return nil
+			},
+		},
+		time.Second,
+		&mockSyncPeerClient{
+			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
+				assert.Equal(t, peerID, id)
+				assert.Equal(t, uint64(1), start)
+
+				return blocksToCh(blocks, 0), nil
+			},
+		},
+		&mockProgression{},
+	)
+	syncer.stateSyncer = newStateSyncer(hclog.NewNullLogger(), clientSrv, synced)
+
+	peerStatus := &NoForkPeer{ID: peerID, Number: uint64(len(blocks))}
+
+	ok, err := syncer.shouldStateSync(peerStatus)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, syncer.stateSyncWithPeer(peerStatus))
+
+	// the blocks are written up to the pivot, whose state is synced
+	assert.Equal(t, uint64(len(blocks)-stateSyncPivotDistance), head.Number)
+
+	ok, err = syncer.stateSyncer.hasState(root)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = syncer.shouldStateSync(peerStatus)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -16,10 +16,19 @@ import (
 const (
 	syncerName  = "syncer"
 	syncerProto = "/syncer/0.2"
+
+	// stallWindowTimeouts is the number of block timeouts in the window the delivery rate is checked over
+	stallWindowTimeouts = 10
+
+	// minDeliveredBlocksPerTimeout is the minimum delivery rate of a peer which is ahead,
+	// the block timeout spans several blocks, so a slower peer does not keep up with the chain
+	minDeliveredBlocksPerTimeout = 2
 )
 
 var (
-	errTimeout = errors.New("timeout awaiting block from peer")
+	errTimeout           = errors.New("timeout awaiting block from peer")
+	errStalled           = errors.New("sync with peer stalled")
+	errUnverifiableBlock = errors.New("unable to verify block")
 )
 
 // XXX: Don't use this syncer for the consensus that may cause fork.
@@ -30,14 +39,23 @@ type syncer struct {
 	syncProgression Progression
 
 	peerMap         *PeerMap
+	reputations     *peerReputations
 	syncPeerService SyncPeerService
 	syncPeerClient  SyncPeerClient
 
+	snapService *snapService // serves the state tries, nil if there is no state storage
+	stateSyncer *stateSyncer // syncs the state of a new node, nil if the state sync is disabled
+
+	checkpoint CheckpointSyncConfig
+	headerSync HeaderSyncConfig
+
 	// Timeout for syncing a block
 	blockTimeout time.Duration
 
 	// Channel to notify Sync that a new status arrived
 	newStatusCh chan struct{}
+
+	closeCh chan struct{}
 }
 
 func NewSyncer(
@@ -45,17 +63,39 @@ func NewSyncer(
 	network Network,
 	blockchain Blockchain,
 	blockTimeout time.Duration,
+	stateSync StateSyncConfig,
+	checkpointSync CheckpointSyncConfig,
+	headerSync HeaderSyncConfig,
 ) Syncer {
-	return &syncer{
+	s := &syncer{
 		logger:          logger.Named(syncerName),
 		blockchain:      blockchain,
 		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
-		syncPeerService: NewSyncPeerService(network, blockchain),
+		syncPeerService: NewSyncPeerService(network, blockchain, checkpointSync.Verifier),
 		syncPeerClient:  NewSyncPeerClient(logger, network, blockchain),
+		checkpoint:      checkpointSync,
+		headerSync:      headerSync,
 		blockTimeout:    blockTimeout,
 		newStatusCh:     make(chan struct{}),
+		closeCh:         make(chan struct{}),
 		peerMap:         new(PeerMap),
+		reputations:     newPeerReputations(),
 	}
+
+	s.reputations.reportPeer = network.ReportPeer
+
+	if stateSync.Storage != nil {
+		s.snapService = newSnapService(network, stateSync.Storage)
+
+		// the state of the checkpoint is synced the same way as the state of a pivot block
+		if stateSync.Enabled || checkpointSync.Checkpoint != nil {
+			s.stateSyncer = newStateSyncer(s.logger, network, stateSync.Storage)
+		}
+	} else if checkpointSync.Checkpoint != nil {
+		s.logger.Warn("checkpoint sync is disabled without the state storage")
+	}
+
+	return s
 }
 
 // Start starts goroutine processes
@@ -66,22 +106,37 @@ func (s *syncer) Start() error {
 
 	s.syncPeerService.Start()
 
+	if s.snapService != nil {
+		s.snapService.Start()
+	}
+
 	s.initializePeerMap()
 
 	go s.startPeerStatusUpdateProcess()
 	go s.startPeerConnectionEventProcess()
 
+	if s.checkpoint.Checkpoint != nil && s.checkpoint.Backfill {
+		go s.runBackfill()
+	}
+
 	return nil
 }
 
 // Close terminates goroutine processes
 func (s *syncer) Close() error {
 	close(s.newStatusCh)
+	close(s.closeCh)
 
 	if err := s.syncPeerService.Close(); err != nil {
 		return err
 	}
 
+	if s.snapService != nil {
+		if err := s.snapService.Close(); err != nil {
+			return err
+		}
+	}
+
 	s.syncPeerClient.Close()
 
 	return nil
@@ -150,11 +205,16 @@ func (s *syncer) GetSyncProgression() *progress.Progression {
 	return s.syncProgression.GetProgression()
 }
 
+// PeerReputations returns the sync reputation of the peers the node synced with
+func (s *syncer) PeerReputations() []*PeerReputation {
+	return s.reputations.list()
+}
+
 // HasSyncPeer returns whether syncer has the peer to syncs blocks
 // return false if syncer has no peer whose latest block height doesn't exceed local height
 func (s *syncer) HasSyncPeer() bool {
-	bestPeer := s.peerMap.BestPeer(nil)
 	header := s.blockchain.Header()
+	bestPeer := s.peerMap.BestPeerFrom(header.Number+1, s.reputations.bannedPeers())
 
 	return bestPeer != nil && bestPeer.Number > header.Number
 }
@@ -162,22 +222,56 @@ func (s *syncer) HasSyncPeer() bool {
 // Sync syncs block with the best peer until callback returns true
 func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 	localLatest := s.blockchain.Header().Number
-	skipList := make(map[peer.ID]bool)
+	skipList := s.reputations.bannedPeers()
+
+	// rotate is set after a failed sync, the next peer is tried without waiting for a new status
+	rotate := false
 
 	for {
 		// Wait for a new event to arrive
-		<-s.newStatusCh
+		if !rotate {
+			<-s.newStatusCh
+		}
+
+		rotate = false
 
 		// fetch local latest block
 		if header := s.blockchain.Header(); header != nil {
 			localLatest = header.Number
 		}
 
-		// pick one best peer
-		bestPeer := s.peerMap.BestPeer(skipList)
+		// a new node starts from the trusted checkpoint instead of the genesis
+		if s.shouldCheckpointSync() {
+			checkpointPeer := s.peerMap.BestPeerFrom(s.checkpointAncestorsFrom(), skipList)
+			if checkpointPeer == nil {
+				skipList = s.reputations.bannedPeers()
+
+				continue
+			}
+
+			if checkpointPeer.Number < s.checkpoint.Checkpoint.Number {
+				continue
+			}
+
+			if err := s.checkpointSyncWithPeer(checkpointPeer); err != nil {
+				s.logger.Warn("failed to complete checkpoint sync with peer, try to next one",
+					"peer ID", checkpointPeer.ID, "error", err)
+
+				s.recordSyncFailure(checkpointPeer.ID, err, skipList)
+
+				rotate = true
+
+				continue
+			}
+
+			localLatest = s.blockchain.Header().Number
+		}
+
+		// pick one best peer, which still serves the blocks following the local latest one
+		bestPeer := s.peerMap.BestPeerFrom(localLatest+1, skipList)
 		if bestPeer == nil {
-			// Empty skipList map if there are no best peers
-			skipList = make(map[peer.ID]bool)
+			// Reset skipList map to the banned peers if there are no best peers
+			skipList = s.reputations.bannedPeers()
 
 			continue
 		}
@@ -187,27 +281,105 @@ func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 			continue
 		}
 
+		// a new node syncs the state of a recent block, instead of executing all the blocks from genesis
+		stateSync, err := s.shouldStateSync(bestPeer)
+		if err == nil && stateSync {
+			err = s.stateSyncWithPeer(bestPeer)
+		}
+
+		if err != nil {
+			s.logger.Warn("failed to complete state sync with peer, try to next one", "peer ID", bestPeer.ID, "error", err)
+
+			s.recordSyncFailure(bestPeer.ID, err, skipList)
+
+			rotate = true
+
+			continue
+		}
+
+		// verify the headers before the bodies of their blocks are downloaded
+		if s.shouldHeaderSync() {
+			shouldTerminate, err := s.headerSyncWithPeers(bestPeer, skipList, callback)
+			if err != nil {
+				s.logger.Warn("failed to complete header sync with peers", "peer ID", bestPeer.ID, "error", err)
+
+				rotate = true
+			} else if !shouldTerminate {
+				s.reputations.recordSuccess(bestPeer.ID)
+			}
+
+			if shouldTerminate {
+				break
+			}
+
+			continue
+		}
+
+		// fetch the blocks from several peers at once, if more than a chunk of blocks is missing
+		if peers := s.peerMap.PeersFrom(localLatest+1, skipList); len(peers) > 1 &&
+			bestPeer.Number-localLatest > downloadChunkSize {
+			_, shouldTerminate, dropped, err := s.parallelSyncWithPeers(peers, callback)
+			if err != nil {
+				s.logger.Warn("failed to complete parallel sync with peers", "error", err)
+
+				rotate = true
+			}
+
+			for _, peerID := range dropped {
+				skipList[peerID] = true
+			}
+
+			if shouldTerminate {
+				break
+			}
+
+			continue
+		}
+
 		// fetch block from the peer
 		lastNumber, shouldTerminate, err := s.bulkSyncWithPeer(bestPeer.ID, bestPeer.Number, callback)
 		if err != nil {
-			s.logger.Warn("failed to complete bulk sync with peer, try to next one", "peer ID", "error", bestPeer.ID, err)
+			s.logger.Warn("failed to complete bulk sync with peer, try to next one", "peer ID", bestPeer.ID, "error", err)
+		}
+
+		if shouldTerminate {
+			break
 		}
 
 		if lastNumber < bestPeer.Number {
-			skipList[bestPeer.ID] = true
+			if err == nil {
+				err = errIncompleteChunk
+			}
+
+			s.recordSyncFailure(bestPeer.ID, err, skipList)
 
 			// continue to next peer
+			rotate = true
+
 			continue
 		}
 
-		if shouldTerminate {
-			break
-		}
+		s.reputations.recordSuccess(bestPeer.ID)
 	}
 
 	return nil
 }
 
+// recordSyncFailure penalizes the peer according to the failure of the sync with it, and skips it
+// in the following attempts. The peer proven to serve invalid blocks is banned
+func (s *syncer) recordSyncFailure(peerID peer.ID, err error, skipList map[peer.ID]bool) {
+	switch {
+	case isPeerFault(err):
+		s.reputations.recordInvalidBlock(peerID)
+	case errors.Is(err, errStalled), errors.Is(err, errTimeout):
+		s.reputations.recordStall(peerID)
+	default:
+		s.reputations.recordFailure(peerID)
+	}
+
+	skipList[peerID] = true
+}
+
 // bulkSyncWithPeer syncs block with a given peer
 func (s *syncer) bulkSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
 	newBlockCallback func(*types.FullBlock) bool) (uint64, bool, error) {
@@ -235,7 +407,15 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
 		s.blockchain.UnsubscribeEvents(subscription)
 	}()
 
-	var lastReceivedNumber uint64
+	// the delivery rate of the peer is measured over the time spent waiting for its blocks,
+	// so a slow local verification and import of the blocks is not counted against it
+	var (
+		lastReceivedNumber uint64
+		windowWait         time.Duration // the time spent waiting in the current stall window
+		windowDelivered    uint64        // the blocks delivered in the current stall window
+		stallWindow        = stallWindowTimeouts * s.blockTimeout
+		waitStart          = time.Now()
+	)
 
 	for {
 		select {
@@ -244,8 +424,23 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
 				return lastReceivedNumber, shouldTerminate, nil
 			}
 
+			windowWait += time.Since(waitStart)
+			windowDelivered++
+
+			if windowWait >= stallWindow {
+				// the peer keeps streaming the blocks, but too slowly to catch up with it
+				if block.Number() < peerLatestBlock && windowDelivered < stallWindowTimeouts*minDeliveredBlocksPerTimeout {
+					return lastReceivedNumber, shouldTerminate,
+						fmt.Errorf("%w: delivered %d blocks in %s", errStalled, windowDelivered, windowWait)
+				}
+
+				windowWait, windowDelivered = 0, 0
+			}
+
 			// safe check
 			if block.Number() == 0 {
+				waitStart = time.Now()
+
 				continue
 			}
 
@@ -253,7 +448,7 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
 			if err != nil {
 				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
 
-				return lastReceivedNumber, false, fmt.Errorf("unable to verify block, %w", err)
+				return lastReceivedNumber, false, fmt.Errorf("%w, %w", errUnverifiableBlock, err)
 			}
 
 			if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
@@ -266,6 +461,7 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
 			shouldTerminate = newBlockCallback(fullBlock)
 
 			lastReceivedNumber = block.Number()
+			waitStart = time.Now()
 		case <-time.After(s.blockTimeout):
 			return lastReceivedNumber, shouldTerminate, errTimeout
 		}
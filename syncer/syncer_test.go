@@ -39,9 +39,16 @@ type mockBlockchain struct {
 	subscription                blockchain.Subscription
 	headerHandler               func() *types.Header
 	getBlockByNumberHandler     func(uint64, bool) (*types.Block, bool)
+	getHeaderByNumberHandler    func(uint64) (*types.Header, bool)
+	getBodyByHashHandler        func(types.Hash) (*types.Body, bool)
 	verifyFinalizedBlockHandler func(*types.Block) (*types.FullBlock, error)
 	writeBlockHandler           func(*types.Block) error
 	writeFullBlockHandler       func(*types.FullBlock) error
+	writeSyncedBlockHandler     func(*types.Block) error
+	writeCheckpointBlockHandler func(*types.Block) error
+	checkpointHandler           func() (*types.Header, bool)
+	backfillBlocksHandler       func([]*types.Block) error
+	historyTail                 uint64
 }
 
 func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
@@ -59,6 +66,18 @@ func (m *mockBlockchain) GetBlockByNumber(number uint64, full bool) (*types.Bloc
 	return m.getBlockByNumberHandler(number, full)
 }
 
+func (m *mockBlockchain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	return m.getHeaderByNumberHandler(number)
+}
+
+func (m *mockBlockchain) GetBodyByHash(hash types.Hash) (*types.Body, bool) {
+	return m.getBodyByHashHandler(hash)
+}
+
+func (m *mockBlockchain) HistoryTail() uint64 {
+	return m.historyTail
+}
+
 func (m *mockBlockchain) VerifyFinalizedBlock(b *types.Block) (*types.FullBlock, error) {
 	return m.verifyFinalizedBlockHandler(b)
 }
@@ -71,6 +90,22 @@ func (m *mockBlockchain) WriteFullBlock(b *types.FullBlock, s string) error {
 	return m.writeFullBlockHandler(b)
 }
 
+func (m *mockBlockchain) WriteSyncedBlock(b *types.Block, s string) error {
+	return m.writeSyncedBlockHandler(b)
+}
+
+func (m *mockBlockchain) WriteCheckpointBlock(b *types.Block, s string) error {
+	return m.writeCheckpointBlockHandler(b)
+}
+
+func (m *mockBlockchain) Checkpoint() (*types.Header, bool) {
+	return m.checkpointHandler()
+}
+
+func (m *mockBlockchain) BackfillBlocks(blocks []*types.Block) error {
+	return m.backfillBlocksHandler(blocks)
+}
+
 func newSimpleHeaderHandler(num uint64) func() *types.Header {
 	return func() *types.Header {
 		return &types.Header{
@@ -93,6 +128,10 @@ type mockSyncPeerClient struct {
 	getPeerStatusHandler                  func(peer.ID) (*NoForkPeer, error)
 	getConnectedPeerStatusesHandler       func() []*NoForkPeer
 	getBlocksHandler                      func(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	getBlockRangeHandler                  func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error)
+	getValidatorSnapshotHandler           func(peer.ID, uint64) ([]byte, error)
+	getHeadersHandler                     func(peer.ID, uint64, uint64) ([]*types.Header, error)
+	getBodiesHandler                      func(peer.ID, []types.Hash) ([]*types.Body, error)
 	getPeerStatusUpdateChHandler          func() <-chan *NoForkPeer
 	getPeerConnectionUpdateEventChHandler func() <-chan *event.PeerEvent
 }
@@ -123,6 +162,27 @@ func (m *mockSyncPeerClient) GetBlocks(
 	return m.getBlocksHandler(id, start, timeoutPerBlock)
 }
 
+func (m *mockSyncPeerClient) GetBlockRange(
+	id peer.ID,
+	from uint64,
+	to uint64,
+	timeoutPerBlock time.Duration,
+) (<-chan *types.Block, error) {
+	return m.getBlockRangeHandler(id, from, to, timeoutPerBlock)
+}
+
+func (m *mockSyncPeerClient) GetValidatorSnapshot(id peer.ID, number uint64) ([]byte, error) {
+	return m.getValidatorSnapshotHandler(id, number)
+}
+
+func (m *mockSyncPeerClient) GetHeaders(id peer.ID, from, to uint64) ([]*types.Header, error) {
+	return m.getHeadersHandler(id, from, to)
+}
+
+func (m *mockSyncPeerClient) GetBodies(id peer.ID, hashes []types.Hash) ([]*types.Body, error) {
+	return m.getBodiesHandler(id, hashes)
+}
+
 func (m *mockSyncPeerClient) GetPeerStatusUpdateCh() <-chan *NoForkPeer {
 	return m.getPeerStatusUpdateChHandler()
 }
@@ -176,6 +236,7 @@ func NewTestSyncer(
 		blockTimeout:    blockTimeout,
 		newStatusCh:     make(chan struct{}),
 		peerMap:         new(PeerMap),
+		reputations:     newPeerReputations(),
 	}
 }
 
@@ -584,7 +645,7 @@ func TestSync(t *testing.T) {
 						count++
 
 						if count == 1 {
-							return nil, errors.New("block verification failed")
+							return nil, fmt.Errorf("block verification failed: %w", blockchain.ErrInvalidSeal)
 						}
 					}
 
@@ -695,6 +756,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 		// handlers
 		verifyFinalizedBlockHandler func(*types.Block) (*types.FullBlock, error)
 		writeFullBlockHandler       func(*types.FullBlock) error
+		writeSyncedBlockHandler     func(*types.Block) error
 
 		// results
 		blocks                []*types.Block
@@ -858,3 +920,79 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 		})
 	}
 }
+
+func Test_bulkSyncWithPeer_Stalled(t *testing.T) {
+	t.Parallel()
+
+	const latest = 100
+
+	blocks := createMockBlocks(latest)
+
+	syncer := NewTestSyncer(
+		nil,
+		&mockBlockchain{
+			headerHandler: newSimpleHeaderHandler(0),
+			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+				return &types.FullBlock{Block: b}, nil
+			},
+			writeFullBlockHandler: func(b *types.FullBlock) error {
+				return nil
+			},
+		},
+		100*time.Millisecond,
+		&mockSyncPeerClient{
+			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
+				// the blocks arrive within the block timeout, but the import rate is too low to catch up
+				return blocksToCh(blocks, 60*time.Millisecond), nil
+			},
+		},
+		&mockProgression{},
+	)
+
+	lastSynced, shouldTerminate, err := syncer.bulkSyncWithPeer(peer.ID("X"), latest, func(*types.FullBlock) bool {
+		return false
+	})
+
+	assert.ErrorIs(t, err, errStalled)
+	assert.False(t, shouldTerminate)
+	assert.NotZero(t, lastSynced)
+	assert.Less(t, lastSynced, uint64(latest))
+}
+
+func Test_bulkSyncWithPeer_SlowImport(t *testing.T) {
+	t.Parallel()
+
+	const latest = 30
+
+	blocks := createMockBlocks(latest)
+
+	syncer := NewTestSyncer(
+		nil,
+		&mockBlockchain{
+			headerHandler: newSimpleHeaderHandler(0),
+			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+				return &types.FullBlock{Block: b}, nil
+			},
+			writeFullBlockHandler: func(b *types.FullBlock) error {
+				// the local import is slower than the stall rate, the peer is not blamed for it
+				time.Sleep(60 * time.Millisecond)
+
+				return nil
+			},
+		},
+		100*time.Millisecond,
+		&mockSyncPeerClient{
+			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
+				return blocksToCh(blocks, 0), nil
+			},
+		},
+		&mockProgression{},
+	)
+
+	lastSynced, _, err := syncer.bulkSyncWithPeer(peer.ID("X"), latest, func(*types.FullBlock) bool {
+		return false
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(latest), lastSynced)
+}
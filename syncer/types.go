@@ -10,6 +10,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -27,12 +28,26 @@ type Blockchain interface {
 	Header() *types.Header
 	// GetBlockByNumber returns block by number
 	GetBlockByNumber(uint64, bool) (*types.Block, bool)
+	// GetHeaderByNumber returns header by number
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+	// GetBodyByHash returns the body of the block of the given hash
+	GetBodyByHash(types.Hash) (*types.Body, bool)
+	// HistoryTail returns the lowest block number whose body is kept
+	HistoryTail() uint64
 	// VerifyFinalizedBlock verifies finalized block
 	VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error)
 	// WriteBlock writes a given block to chain
 	WriteBlock(*types.Block, string) error
 	// WriteFullBlock writes a given block to chain and saves its receipts to cache
 	WriteFullBlock(*types.FullBlock, string) error
+	// WriteSyncedBlock verifies and writes a block up to the state sync pivot without executing it
+	WriteSyncedBlock(*types.Block, string) error
+	// WriteCheckpointBlock writes a verified checkpoint block, whose state is synced, as the head of the chain
+	WriteCheckpointBlock(*types.Block, string) error
+	// Checkpoint returns the lowest block of a chain synced from a checkpoint
+	Checkpoint() (*types.Header, bool)
+	// BackfillBlocks writes the blocks preceding the checkpoint
+	BackfillBlocks([]*types.Block) error
 }
 
 type Network interface {
@@ -57,6 +72,8 @@ type Network interface {
 	SaveProtocolStream(protocol string, stream *rawGrpc.ClientConn, peerID peer.ID)
 	// CloseProtocolStream closes stream
 	CloseProtocolStream(protocol string, peerID peer.ID) error
+	// ReportPeer applies the penalty of a misbehavior to the reputation of the peer
+	ReportPeer(peerID peer.ID, penalty common.PeerPenalty, reason string)
 }
 
 type Syncer interface {
@@ -70,6 +87,8 @@ type Syncer interface {
 	HasSyncPeer() bool
 	// Sync starts routine to sync blocks
 	Sync(func(*types.FullBlock) bool) error
+	// PeerReputations returns the sync reputation of the peers
+	PeerReputations() []*PeerReputation
 }
 
 type Progression interface {
@@ -101,6 +120,15 @@ type SyncPeerClient interface {
 	GetConnectedPeerStatuses() []*NoForkPeer
 	// GetBlocks returns a stream of blocks from given height to peer's latest
 	GetBlocks(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	// GetBlockRange returns a stream of blocks from given height up to the given last block
+	GetBlockRange(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error)
+	// GetValidatorSnapshot fetches the encoded validator set which seals the given block
+	GetValidatorSnapshot(peer.ID, uint64) ([]byte, error)
+	// GetHeaders fetches the headers from given height up to the given last header,
+	// the peer may return fewer headers than requested
+	GetHeaders(peer.ID, uint64, uint64) ([]*types.Header, error)
+	// GetBodies fetches the bodies of the blocks of the given hashes, the peer may return a prefix of them
+	GetBodies(peer.ID, []types.Hash) ([]*types.Body, error)
 	// GetPeerStatusUpdateCh returns a channel of peer's status update
 	GetPeerStatusUpdateCh() <-chan *NoForkPeer
 	// GetPeerConnectionUpdateEventCh returns peer's connection change event
@@ -15,6 +15,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
@@ -152,6 +153,9 @@ type TxPool struct {
 	// networking stack
 	topic *network.Topic
 
+	// reportPeer reports the peers gossiping malformed transactions to the peer reputation
+	reportPeer func(peerID peer.ID, penalty common.PeerPenalty, reason string)
+
 	// gauge for measuring pool capacity
 	gauge slotGauge
 
@@ -233,6 +237,7 @@ func NewTxPool(
 		}
 
 		pool.topic = topic
+		pool.reportPeer = network.ReportPeer
 	}
 
 	if grpcServer != nil {
@@ -978,6 +983,7 @@ func (p *TxPool) addGossipTx(obj interface{}, peerID peer.ID) {
 	// decode tx
 	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
 		p.logger.Error("failed to decode broadcast tx", "err", err)
+		p.reportInvalidGossip(peerID, err)
 
 		return
 	}
@@ -993,6 +999,19 @@ func (p *TxPool) addGossipTx(obj interface{}, peerID peer.ID) {
 		}
 
 		p.logger.Error("failed to add broadcast tx", "err", err, "hash", tx.Hash().String())
+
+		// the sender of a transaction with an invalid signature can't be recovered by any node,
+		// so the transaction is malformed rather than outdated
+		if errors.Is(err, ErrExtractSignature) || errors.Is(err, ErrInvalidSender) {
+			p.reportInvalidGossip(peerID, err)
+		}
+	}
+}
+
+// reportInvalidGossip reports the peer which gossiped a malformed transaction
+func (p *TxPool) reportInvalidGossip(peerID peer.ID, err error) {
+	if p.reportPeer != nil {
+		p.reportPeer(peerID, common.PenaltyInvalidGossip, "malformed gossip transaction: "+err.Error())
 	}
 }
 